@@ -0,0 +1,18 @@
+package count_digits
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/count_digits"
+	registry.Register(registry.Algorithm{
+		Name:     "CountDigits",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Number of decimal digits of n",
+		Time:     "O(log n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return CountDigits(n) },
+	})
+}
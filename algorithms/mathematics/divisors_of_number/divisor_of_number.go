@@ -33,3 +33,10 @@ func allDivisorsOfNumber(n int) []int {
 
 	return divisors
 }
+
+// AllDivisorsOfNumber returns all divisors of n in O(sqrt(n)) time, in the
+// pair order of allDivisorsOfNumber: each divisor up to sqrt(n) followed by
+// its cofactor.
+func AllDivisorsOfNumber(n int) []int {
+	return allDivisorsOfNumber(n)
+}
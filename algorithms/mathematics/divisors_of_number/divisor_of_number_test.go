@@ -1,7 +1,10 @@
 package divisors_of_number
 
 import (
+	"github.com/ignoreAnt/go-dsa/analysis"
+	"github.com/ignoreAnt/go-dsa/stress"
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"testing"
 )
 
@@ -57,3 +60,47 @@ func TestAllDivisors(t *testing.T) {
 		})
 	}
 }
+
+// TestAllDivisorsStress compares allDivisorsOfNumber with divisorsOfNumber on
+// random inputs. allDivisorsOfNumber returns the divisors in pairs, so both
+// results are sorted before comparing.
+func TestAllDivisorsStress(t *testing.T) {
+	sorted := func(divisors func(int) []int) func(int) []int {
+		return func(n int) []int {
+			result := divisors(n)
+			slices.Sort(result)
+			return result
+		}
+	}
+
+	stress.Check(t, stress.Pair[int, []int]{
+		Name:      "allDivisorsOfNumber",
+		Reference: sorted(divisorsOfNumber),
+		Candidate: sorted(allDivisorsOfNumber),
+		Gen:       stress.SizedInts(-5, 1000),
+		Shrink:    stress.ShrinkInt(0),
+		Equal:     slices.Equal[[]int],
+	}, stress.Options{})
+}
+
+// TestAllDivisorsGrowth measures divisorsOfNumber and allDivisorsOfNumber with
+// the analysis package: the first tries every i <= n and the second only
+// i <= sqrt(n), so their running times fit O(n) and O(sqrt n).
+func TestAllDivisorsGrowth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+
+	brute := analysis.Measure("divisorsOfNumber", func(n int) func(*analysis.Counter) {
+		return func(*analysis.Counter) { divisorsOfNumber(n) }
+	}, analysis.Options{Start: 1 << 12, Steps: 7})
+	fast := analysis.Measure("allDivisorsOfNumber", func(n int) func(*analysis.Counter) {
+		return func(*analysis.Counter) { allDivisorsOfNumber(n) }
+	}, analysis.Options{Start: 1 << 16, Factor: 4, Steps: 7})
+	t.Log(brute)
+	t.Log(fast)
+
+	// neighbouring classes are allowed for: timings are noisy
+	assert.Contains(t, []analysis.Class{analysis.Linear, analysis.Linearithmic}, brute.Best(analysis.Time))
+	assert.Contains(t, []analysis.Class{analysis.Logarithmic, analysis.SquareRoot}, fast.Best(analysis.Time))
+}
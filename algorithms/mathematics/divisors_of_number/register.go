@@ -0,0 +1,26 @@
+package divisors_of_number
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/divisors_of_number"
+	registry.Register(registry.Algorithm{
+		Name:     "divisorsOfNumber",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "All divisors of n by testing every candidate up to n",
+		Time:     "O(n)",
+		Space:    "O(d(n))",
+		Run:      func(n int) any { return divisorsOfNumber(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "allDivisorsOfNumber",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "All divisors of n by testing candidates up to sqrt(n) in pairs",
+		Time:     "O(sqrt n)",
+		Space:    "O(d(n))",
+		Run:      func(n int) any { return allDivisorsOfNumber(n) },
+	})
+}
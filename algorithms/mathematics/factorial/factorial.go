@@ -50,3 +50,9 @@ func factorialRecursive(n int) int {
 
 	return n * factorialRecursive(n-1)
 }
+
+// Factorial returns n!, or -1 if n is negative, by the loop of
+// factorialIterative; n! overflows int for n > 20.
+func Factorial(n int) int {
+	return factorialIterative(n)
+}
@@ -1,6 +1,7 @@
 package factorial
 
 import (
+	"github.com/ignoreAnt/go-dsa/stress"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -53,3 +54,15 @@ func TestFactorial(t *testing.T) {
 		})
 	}
 }
+
+// TestFactorialStress compares factorialRecursive with factorialIterative on
+// random inputs, including negative ones and the range where int overflows.
+func TestFactorialStress(t *testing.T) {
+	stress.Check(t, stress.Pair[int, int]{
+		Name:      "factorialRecursive",
+		Reference: factorialIterative,
+		Candidate: factorialRecursive,
+		Gen:       stress.Ints(-10, 40),
+		Shrink:    stress.ShrinkInt(0),
+	}, stress.Options{})
+}
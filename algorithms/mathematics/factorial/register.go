@@ -0,0 +1,27 @@
+package factorial
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/factorial"
+	registry.Register(registry.Algorithm{
+		Name:     "factorialIterative",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "n! by a loop, overflowing int for n > 20",
+		Time:     "O(n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return factorialIterative(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "factorialRecursive",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "n! by recursion, overflowing int for n > 20",
+		Time:     "O(n)",
+		Space:    "O(n)",
+		Run:      func(n int) any { return factorialRecursive(n) },
+	})
+}
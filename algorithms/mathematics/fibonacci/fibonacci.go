@@ -0,0 +1,331 @@
+package fibonacci
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+)
+
+// ErrNegativeIndex is returned when a sequence is asked for a negative term.
+var ErrNegativeIndex = errors.New("fibonacci: negative index")
+
+// ErrOverflow is returned when the requested term does not fit in an int.
+var ErrOverflow = errors.New("fibonacci: result overflows int")
+
+// ErrInvalidModulus is returned when a modulus smaller than 1 is supplied.
+var ErrInvalidModulus = errors.New("fibonacci: modulus must be positive")
+
+// Fibonacci returns F(n), the n-th Fibonacci number, with F(0) = 0 and F(1) = 1.
+//
+// It uses the fast doubling identities
+//
+//	F(2k)   = F(k) * (2*F(k+1) - F(k))
+//	F(2k+1) = F(k)^2 + F(k+1)^2
+//
+// which need O(log n) arithmetic operations. Every addition and multiplication
+// is checked, so ErrOverflow is returned instead of a wrapped-around value.
+func Fibonacci(n int) (int, error) {
+	if n < 0 {
+		return 0, ErrNegativeIndex
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	// (F(n-1), F(n)) avoids computing F(n+1), which may overflow even
+	// when F(n) itself fits.
+	_, f, err := fibPair(n - 1)
+	return f, err
+}
+
+// Lucas returns L(n), the n-th Lucas number, with L(0) = 2 and L(1) = 1.
+//
+// Lucas numbers share the Fibonacci recurrence and are recovered from the same
+// doubling step through L(n) = F(n-1) + F(n+1) = 2*F(n+1) - F(n).
+func Lucas(n int) (int, error) {
+	if n < 0 {
+		return 0, ErrNegativeIndex
+	}
+
+	f, next, err := fibPair(n)
+	if err != nil {
+		return 0, err
+	}
+
+	// F(n+1) + (F(n+1) - F(n)) never leaves the int range before the result does
+	return addChecked(next, next-f)
+}
+
+// fibPair returns (F(k), F(k+1)) using checked fast doubling.
+func fibPair(k int) (int, int, error) {
+	a, b := 0, 1
+	for i := bits.Len(uint(k)) - 1; i >= 0; i-- {
+		// c = F(2m) = a * (2b - a)
+		twoB, err := addChecked(b, b)
+		if err != nil {
+			return 0, 0, err
+		}
+		c, err := mulChecked(a, twoB-a)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// d = F(2m+1) = a^2 + b^2
+		aa, err := mulChecked(a, a)
+		if err != nil {
+			return 0, 0, err
+		}
+		bb, err := mulChecked(b, b)
+		if err != nil {
+			return 0, 0, err
+		}
+		d, err := addChecked(aa, bb)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if (k>>i)&1 == 0 {
+			a, b = c, d
+		} else {
+			sum, err := addChecked(c, d)
+			if err != nil {
+				return 0, 0, err
+			}
+			a, b = d, sum
+		}
+	}
+	return a, b, nil
+}
+
+// addChecked returns a + b for non-negative operands or ErrOverflow.
+func addChecked(a, b int) (int, error) {
+	if a > math.MaxInt-b {
+		return 0, ErrOverflow
+	}
+	return a + b, nil
+}
+
+// mulChecked returns a * b for non-negative operands or ErrOverflow.
+func mulChecked(a, b int) (int, error) {
+	if a != 0 && b > math.MaxInt/a {
+		return 0, ErrOverflow
+	}
+	return a * b, nil
+}
+
+// FibonacciMod returns F(n) mod m using fast doubling in O(log n) steps.
+//
+// Products are formed with 128-bit intermediates, so any modulus that fits in
+// a uint64 is supported.
+func FibonacciMod(n int, m uint64) (uint64, error) {
+	if n < 0 {
+		return 0, ErrNegativeIndex
+	}
+	if m == 0 {
+		return 0, ErrInvalidModulus
+	}
+
+	f, _ := fibPairMod(uint64(n), m)
+	return f, nil
+}
+
+// LucasMod returns L(n) mod m using fast doubling in O(log n) steps.
+func LucasMod(n int, m uint64) (uint64, error) {
+	if n < 0 {
+		return 0, ErrNegativeIndex
+	}
+	if m == 0 {
+		return 0, ErrInvalidModulus
+	}
+
+	f, next := fibPairMod(uint64(n), m)
+	return subMod(addMod(next, next, m), f, m), nil
+}
+
+// fibPairMod returns (F(k) mod m, F(k+1) mod m).
+func fibPairMod(k, m uint64) (uint64, uint64) {
+	a, b := uint64(0), 1%m
+	for i := bits.Len64(k) - 1; i >= 0; i-- {
+		c := mulMod(a, subMod(addMod(b, b, m), a, m), m)
+		d := addMod(mulMod(a, a, m), mulMod(b, b, m), m)
+		if (k>>uint(i))&1 == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, addMod(c, d, m)
+		}
+	}
+	return a, b
+}
+
+// addMod returns (a + b) mod m for a, b < m without overflowing.
+func addMod(a, b, m uint64) uint64 {
+	if a >= m-b {
+		return a - (m - b)
+	}
+	return a + b
+}
+
+// subMod returns (a - b) mod m for a, b < m.
+func subMod(a, b, m uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + (m - b)
+}
+
+// mulMod returns (a * b) mod m using a 128-bit intermediate product.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// FibonacciBig returns F(n) as an arbitrary-precision integer.
+func FibonacciBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, ErrNegativeIndex
+	}
+
+	f, _ := fibPairBig(n)
+	return f, nil
+}
+
+// LucasBig returns L(n) as an arbitrary-precision integer.
+func LucasBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, ErrNegativeIndex
+	}
+
+	f, next := fibPairBig(n)
+	l := new(big.Int).Lsh(next, 1)
+	return l.Sub(l, f), nil
+}
+
+// fibPairBig returns (F(k), F(k+1)) as big integers.
+func fibPairBig(k int) (*big.Int, *big.Int) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	c, d, t := new(big.Int), new(big.Int), new(big.Int)
+	for i := bits.Len(uint(k)) - 1; i >= 0; i-- {
+		// c = a * (2b - a)
+		t.Lsh(b, 1)
+		t.Sub(t, a)
+		c.Mul(a, t)
+
+		// d = a^2 + b^2
+		d.Mul(a, a)
+		t.Mul(b, b)
+		d.Add(d, t)
+
+		if (k>>i)&1 == 0 {
+			a.Set(c)
+			b.Set(d)
+		} else {
+			a.Set(d)
+			b.Add(c, d)
+		}
+	}
+	return a, b
+}
+
+// PisanoPeriod returns π(m), the period of the Fibonacci sequence modulo m.
+//
+// The period is assembled from the prime factorisation of m:
+//
+//   - π(m) is the lcm of π(p^k) over the prime powers p^k dividing m.
+//   - π(p^k) = p^(k-1) * π(p) (Wall's conjecture, verified far beyond int range).
+//   - π(2) = 3 and π(5) = 20.
+//   - π(p) divides p-1 when p ≡ ±1 (mod 10) and 2(p+1) when p ≡ ±3 (mod 10).
+//
+// For the remaining primes the candidate period is reduced one prime factor at
+// a time while F(d) ≡ 0 and F(d+1) ≡ 1 (mod p) still hold.
+//
+// π(m) is at most 6m, so it can exceed the int range for m above
+// math.MaxInt/6; every product is checked, and ErrOverflow is returned
+// instead of a wrapped-around period.
+func PisanoPeriod(m int) (int, error) {
+	if m < 1 {
+		return 0, ErrInvalidModulus
+	}
+	if m == 1 {
+		return 1, nil
+	}
+
+	period := 1
+	factors := prime_factors.PrimeFactors(m)
+	for i := 0; i < len(factors); {
+		p, k := factors[i], 0
+		for i < len(factors) && factors[i] == p {
+			i++
+			k++
+		}
+
+		pp, err := pisanoPrime(p)
+		if err != nil {
+			return 0, err
+		}
+		for ; k > 1; k-- {
+			if pp, err = mulChecked(pp, p); err != nil {
+				return 0, err
+			}
+		}
+		// lcm(period, pp), dividing before multiplying
+		if period, err = mulChecked(period/gcd.GCD(period, pp), pp); err != nil {
+			return 0, err
+		}
+	}
+	return period, nil
+}
+
+// pisanoPrime returns π(p) for a prime p, or ErrOverflow if it does not fit
+// in an int.
+func pisanoPrime(p int) (int, error) {
+	switch p {
+	case 2:
+		return 3, nil
+	case 5:
+		return 20, nil
+	}
+
+	// The candidate 2(p+1) exceeds the int range for p above
+	// math.MaxInt/2 even when π(p) does not, so it is kept as a uint64
+	// and factored as 2 times p+1.
+	var candidate uint64
+	var factors []int
+	if r := p % 10; r == 3 || r == 7 {
+		candidate = 2 * uint64(p+1)
+		factors = append([]int{2}, prime_factors.PrimeFactors(p+1)...)
+	} else {
+		candidate = uint64(p - 1)
+		factors = prime_factors.PrimeFactors(p - 1)
+	}
+
+	for _, q := range distinct(factors) {
+		for candidate%uint64(q) == 0 && isPeriod(candidate/uint64(q), p) {
+			candidate /= uint64(q)
+		}
+	}
+	if candidate > math.MaxInt {
+		return 0, ErrOverflow
+	}
+	return int(candidate), nil
+}
+
+// isPeriod reports whether the Fibonacci sequence modulo m repeats after d terms.
+func isPeriod(d uint64, m int) bool {
+	f, next := fibPairMod(d, uint64(m))
+	return f == 0 && next == 1
+}
+
+// distinct returns the distinct values of a sorted slice.
+func distinct(sorted []int) []int {
+	var out []int
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,203 @@
+package fibonacci
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFibonacci tests Fibonacci, FibonacciMod and FibonacciBig for various cases.
+//
+// The test cases include the base cases, small terms, the largest term that
+// fits in a 64-bit int and the first term that overflows it. Every case that
+// fits is also checked against the modular and big.Int variants.
+func TestFibonacci(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    int
+		expected int
+		err      error
+	}{
+		// Edge cases
+		{"F(0)", 0, 0, nil},
+		{"F(1)", 1, 1, nil},
+		{"F(2)", 2, 1, nil},
+		{"Negative index", -1, 0, ErrNegativeIndex},
+
+		// Small terms
+		{"F(10)", 10, 55, nil},
+		{"F(20)", 20, 6765, nil},
+		{"F(50)", 50, 12586269025, nil},
+
+		// Largest terms that fit in int64
+		{"F(90)", 90, 2880067194370816120, nil},
+		{"F(91)", 91, 4660046610375530309, nil},
+		{"F(92)", 92, 7540113804746346429, nil},
+
+		// Overflow
+		{"F(93) overflows", 93, 0, ErrOverflow},
+		{"F(1000) overflows", 1000, 0, ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Fibonacci(tc.input)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+			if tc.err != nil {
+				return
+			}
+
+			b, err := FibonacciBig(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, int64(tc.expected), b.Int64())
+
+			m, err := FibonacciMod(tc.input, 1_000_000_007)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(tc.expected%1_000_000_007), m)
+		})
+	}
+}
+
+// TestLucas tests Lucas, LucasMod and LucasBig for various cases.
+func TestLucas(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    int
+		expected int
+		err      error
+	}{
+		{"L(0)", 0, 2, nil},
+		{"L(1)", 1, 1, nil},
+		{"L(2)", 2, 3, nil},
+		{"L(10)", 10, 123, nil},
+		{"L(89)", 89, 3980154972736918051, nil},
+		{"L(90)", 90, 6440026026380244498, nil},
+		{"L(91) overflows", 91, 0, ErrOverflow},
+		{"Negative index", -3, 0, ErrNegativeIndex},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Lucas(tc.input)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+			if tc.err != nil {
+				return
+			}
+
+			b, err := LucasBig(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, int64(tc.expected), b.Int64())
+
+			m, err := LucasMod(tc.input, 998_244_353)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(tc.expected%998_244_353), m)
+		})
+	}
+}
+
+// TestLargeIndices tests the modular and big.Int variants far beyond int range.
+func TestLargeIndices(t *testing.T) {
+	f, err := FibonacciMod(1_000_000_000_000_000_000, 1_000_000_007)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(209783453), f)
+
+	f, err = FibonacciMod(1_000_000_000_000_000_000, 18446744073709551557) // largest 64-bit prime
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7905894408451582888), f)
+
+	l, err := LucasMod(1_000_000_000_000_000_000, 1_000_000_007)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150331332), l)
+
+	expected, _ := new(big.Int).SetString("354224848179261915075", 10)
+	b, err := FibonacciBig(100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expected.Cmp(b), "Expected: %v, Got: %v", expected, b)
+
+	expected, _ = new(big.Int).SetString("792070839848372253127", 10)
+	b, err = LucasBig(100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expected.Cmp(b), "Expected: %v, Got: %v", expected, b)
+
+	f, err = FibonacciMod(1000, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), f)
+
+	_, err = FibonacciMod(10, 0)
+	assert.ErrorIs(t, err, ErrInvalidModulus)
+}
+
+// TestPisanoPeriod tests PisanoPeriod against periods found by direct iteration.
+func TestPisanoPeriod(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		// Edge cases
+		{"Modulus 1", 1, 1},
+		{"Modulus 2", 2, 3},
+		{"Modulus 5", 5, 20},
+
+		// Small primes with p ≡ ±1 and p ≡ ±3 (mod 10)
+		{"Modulus 3", 3, 8},
+		{"Modulus 7", 7, 16},
+		{"Modulus 11", 11, 10},
+		{"Modulus 997", 997, 1996},
+		{"Modulus 10007", 10007, 20016},
+
+		// Prime powers and composites
+		{"Modulus 4", 4, 6},
+		{"Modulus 8", 8, 12},
+		{"Modulus 9", 9, 24},
+		{"Modulus 6", 6, 24},
+		{"Modulus 10", 10, 60},
+		{"Modulus 12", 12, 24},
+		{"Modulus 100", 100, 300},
+		{"Modulus 144", 144, 24},
+		{"Modulus 1000", 1000, 1500},
+		{"Modulus 1013", 1013, 2028},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := PisanoPeriod(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+
+	_, err := PisanoPeriod(0)
+	assert.ErrorIs(t, err, ErrInvalidModulus)
+
+	// π(5^26) = 4 * 5^26 fits in an int, but not π(5^27) = 4 * 5^27, nor
+	// π(3 * 5^26) = lcm(8, 4 * 5^26) = 8 * 5^26.
+	pow5 := 1
+	for range 26 {
+		pow5 *= 5
+	}
+	actual, err := PisanoPeriod(pow5)
+	require.NoError(t, err)
+	assert.Equal(t, 4*pow5, actual)
+	_, err = PisanoPeriod(5 * pow5)
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = PisanoPeriod(3 * pow5)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+// TestPisanoPrime tests π(p) for primes p ≡ ±3 (mod 10) so large that the
+// candidate 2(p+1) does not fit in an int, though π(p) may.
+func TestPisanoPrime(t *testing.T) {
+	// p+1 = 2^3 * 3^34 * 7^2 and π(p) = 2(p+1)/3
+	actual, err := pisanoPrime(6537455226269295047)
+	require.NoError(t, err)
+	assert.Equal(t, 4358303484179530032, actual)
+
+	// p+1 = 2^14 * 3^11 * 7^11 and π(p) = 2(p+1)
+	_, err = pisanoPrime(5738946568883748863)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
@@ -0,0 +1,165 @@
+package fibonacci
+
+import "errors"
+
+// ErrInvalidRecurrence is returned when a LinearRecurrence has no coefficients
+// or a different number of initial terms than coefficients.
+var ErrInvalidRecurrence = errors.New("fibonacci: recurrence needs one initial term per coefficient")
+
+// LinearRecurrence describes a sequence over the integers modulo Modulus with
+//
+//	a(n) = Coefficients[0]*a(n-1) + Coefficients[1]*a(n-2) + ... + Coefficients[d-1]*a(n-d)
+//
+// and a(0), ..., a(d-1) given by Initial. The Fibonacci numbers, for example,
+// are LinearRecurrence{Coefficients: []uint64{1, 1}, Initial: []uint64{0, 1}}.
+type LinearRecurrence struct {
+	Coefficients []uint64
+	Initial      []uint64
+	Modulus      uint64
+}
+
+// Term returns a(n) mod Modulus using Kitamasa's method.
+//
+// a(n) is a linear combination of the initial terms whose weights are the
+// coefficients of x^n modulo the characteristic polynomial
+// x^d - c[0]x^(d-1) - ... - c[d-1]. That remainder is found by binary
+// exponentiation, giving O(d^2 log n) time for a recurrence of order d.
+func (r LinearRecurrence) Term(n int) (uint64, error) {
+	d := len(r.Coefficients)
+	if d == 0 || len(r.Initial) != d {
+		return 0, ErrInvalidRecurrence
+	}
+	if r.Modulus == 0 {
+		return 0, ErrInvalidModulus
+	}
+	if n < 0 {
+		return 0, ErrNegativeIndex
+	}
+
+	m := r.Modulus
+	if n < d {
+		return r.Initial[n] % m, nil
+	}
+
+	coefficients := make([]uint64, d)
+	for i, c := range r.Coefficients {
+		coefficients[i] = c % m
+	}
+
+	// result = 1 and base = x, both reduced modulo the characteristic polynomial
+	result := make([]uint64, d)
+	result[0] = 1 % m
+	base := make([]uint64, d)
+	if d == 1 {
+		base[0] = coefficients[0]
+	} else {
+		base[1] = 1 % m
+	}
+
+	for e := n; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulPolyMod(result, base, coefficients, m)
+		}
+		base = mulPolyMod(base, base, coefficients, m)
+	}
+
+	var term uint64
+	for i, w := range result {
+		term = addMod(term, mulMod(w, r.Initial[i]%m, m), m)
+	}
+	return term, nil
+}
+
+// mulPolyMod multiplies two polynomials of degree below d and reduces the
+// product using x^d = c[0]x^(d-1) + ... + c[d-1].
+func mulPolyMod(a, b, c []uint64, m uint64) []uint64 {
+	d := len(c)
+	product := make([]uint64, 2*d-1)
+	for i, x := range a {
+		if x == 0 {
+			continue
+		}
+		for j, y := range b {
+			product[i+j] = addMod(product[i+j], mulMod(x, y, m), m)
+		}
+	}
+
+	for k := 2*d - 2; k >= d; k-- {
+		t := product[k]
+		if t == 0 {
+			continue
+		}
+		for i := 1; i <= d; i++ {
+			product[k-i] = addMod(product[k-i], mulMod(t, c[i-1], m), m)
+		}
+	}
+	return product[:d]
+}
+
+// BerlekampMassey returns the coefficients of the shortest linear recurrence
+// that generates seq modulo the prime p, in the form used by LinearRecurrence.
+//
+// The algorithm runs in O(len(seq)^2). A recurrence of order d is recovered
+// reliably from 2d terms. An all-zero sequence yields an empty result.
+func BerlekampMassey(seq []uint64, p uint64) ([]uint64, error) {
+	if p < 2 {
+		return nil, ErrInvalidModulus
+	}
+
+	// current and previous connection polynomials, C(x) = 1 + C[1]x + ...
+	current, previous := []uint64{1}, []uint64{1}
+	length, shift := 0, 1
+	lastDiscrepancy := uint64(1)
+
+	for n := range seq {
+		discrepancy := seq[n] % p
+		for i := 1; i <= length; i++ {
+			discrepancy = addMod(discrepancy, mulMod(current[i], seq[n-i]%p, p), p)
+		}
+
+		if discrepancy == 0 {
+			shift++
+			continue
+		}
+
+		scale := mulMod(discrepancy, powMod(lastDiscrepancy, p-2, p), p)
+		next := append([]uint64(nil), current...)
+		for len(next) < len(previous)+shift {
+			next = append(next, 0)
+		}
+		for i, v := range previous {
+			next[i+shift] = subMod(next[i+shift], mulMod(scale, v, p), p)
+		}
+
+		if 2*length <= n {
+			previous = current
+			length = n + 1 - length
+			lastDiscrepancy = discrepancy
+			shift = 1
+		} else {
+			shift++
+		}
+		current = next
+	}
+
+	coefficients := make([]uint64, length)
+	for i := range coefficients {
+		if i+1 < len(current) {
+			coefficients[i] = subMod(0, current[i+1], p)
+		}
+	}
+	return coefficients, nil
+}
+
+// powMod returns base^exp mod m by binary exponentiation.
+func powMod(base, exp, m uint64) uint64 {
+	result := 1 % m
+	base %= m
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package fibonacci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLinearRecurrenceTerm tests Term against terms generated by direct iteration.
+//
+// The test cases cover first-order (geometric) sequences, the Fibonacci and
+// Tribonacci recurrences, and a recurrence with coefficients larger than the
+// modulus.
+func TestLinearRecurrenceTerm(t *testing.T) {
+	const mod = 1_000_000_007
+
+	testCases := []struct {
+		name       string
+		recurrence LinearRecurrence
+	}{
+		{"Powers of 3", LinearRecurrence{[]uint64{3}, []uint64{1}, mod}},
+		{"Fibonacci", LinearRecurrence{[]uint64{1, 1}, []uint64{0, 1}, mod}},
+		{"Lucas", LinearRecurrence{[]uint64{1, 1}, []uint64{2, 1}, mod}},
+		{"Tribonacci", LinearRecurrence{[]uint64{1, 1, 1}, []uint64{0, 0, 1}, mod}},
+		{"Large coefficients", LinearRecurrence{[]uint64{mod + 5, 2 * mod, 7, 1 << 40}, []uint64{4, 3, 2, 1}, mod}},
+		{"Small modulus", LinearRecurrence{[]uint64{2, 3}, []uint64{1, 1}, 10}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := iterate(tc.recurrence, 200)
+			for n, want := range expected {
+				actual, err := tc.recurrence.Term(n)
+				require.NoError(t, err)
+				assert.Equal(t, want, actual, "n = %d, Expected: %v, Got: %v", n, want, actual)
+			}
+		})
+	}
+
+	t.Run("Agrees with FibonacciMod for huge n", func(t *testing.T) {
+		fib := LinearRecurrence{[]uint64{1, 1}, []uint64{0, 1}, mod}
+		actual, err := fib.Term(1_000_000_000_000_000_000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(209783453), actual)
+	})
+
+	t.Run("Invalid recurrences", func(t *testing.T) {
+		_, err := LinearRecurrence{nil, nil, mod}.Term(5)
+		assert.ErrorIs(t, err, ErrInvalidRecurrence)
+		_, err = LinearRecurrence{[]uint64{1, 1}, []uint64{1}, mod}.Term(5)
+		assert.ErrorIs(t, err, ErrInvalidRecurrence)
+		_, err = LinearRecurrence{[]uint64{1}, []uint64{1}, 0}.Term(5)
+		assert.ErrorIs(t, err, ErrInvalidModulus)
+		_, err = LinearRecurrence{[]uint64{1}, []uint64{1}, mod}.Term(-1)
+		assert.ErrorIs(t, err, ErrNegativeIndex)
+	})
+}
+
+// TestBerlekampMassey tests that BerlekampMassey recovers the recurrence that
+// generated a sequence, and that the recovered recurrence reproduces it.
+func TestBerlekampMassey(t *testing.T) {
+	const prime = 998_244_353
+
+	testCases := []struct {
+		name     string
+		seq      []uint64
+		expected []uint64
+	}{
+		{"All zeros", []uint64{0, 0, 0, 0}, []uint64{}},
+		{"Constant", []uint64{5, 5, 5, 5}, []uint64{1}},
+		{"Powers of 2", []uint64{1, 2, 4, 8, 16, 32}, []uint64{2}},
+		{"Fibonacci", []uint64{0, 1, 1, 2, 3, 5, 8, 13}, []uint64{1, 1}},
+		{"Tribonacci", []uint64{0, 0, 1, 1, 2, 4, 7, 13, 24, 44}, []uint64{1, 1, 1}},
+		{"Squares", []uint64{0, 1, 4, 9, 16, 25, 36, 49}, []uint64{3, prime - 3, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := BerlekampMassey(tc.seq, prime)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+
+			if len(actual) == 0 {
+				return
+			}
+			recurrence := LinearRecurrence{actual, tc.seq[:len(actual)], prime}
+			for n, want := range tc.seq {
+				got, err := recurrence.Term(n)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, "n = %d", n)
+			}
+		})
+	}
+
+	_, err := BerlekampMassey([]uint64{1, 2}, 1)
+	assert.ErrorIs(t, err, ErrInvalidModulus)
+}
+
+// iterate returns the first n terms of r computed directly from its definition.
+func iterate(r LinearRecurrence, n int) []uint64 {
+	m := r.Modulus
+	terms := make([]uint64, 0, n)
+	for _, v := range r.Initial {
+		terms = append(terms, v%m)
+	}
+	for len(terms) < n {
+		var next uint64
+		for i, c := range r.Coefficients {
+			next = addMod(next, mulMod(c%m, terms[len(terms)-1-i], m), m)
+		}
+		terms = append(terms, next)
+	}
+	return terms[:n]
+}
@@ -0,0 +1,87 @@
+package fibonacci
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/fibonacci"
+	registry.Register(registry.Algorithm{
+		Name:     "Fibonacci",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "n-th Fibonacci number by fast doubling, failing on int overflow",
+		Time:     "O(log n)",
+		Space:    "O(log n)",
+		InPlace:  true,
+		Run: func(n int) any {
+			f, _ := Fibonacci(n % 93)
+			return f
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "FibonacciMod",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "n-th Fibonacci number modulo m by fast doubling",
+		Time:     "O(log n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run: func(n int) any {
+			f, _ := FibonacciMod(n, 1_000_000_007)
+			return f
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "FibonacciBig",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "n-th Fibonacci number of any size by fast doubling",
+		Time:     "O(M(n) log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			f, _ := FibonacciBig(n)
+			return f
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "PisanoPeriod",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Period of the Fibonacci numbers modulo m, from the factorisation of m",
+		Time:     "O(sqrt m + p log p) per prime p",
+		Space:    "O(log m)",
+		Run: func(n int) any {
+			p, _ := PisanoPeriod(n + 1)
+			return p
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "LinearRecurrence.Term",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "n-th term of a linear recurrence of order k by Kitamasa's method",
+		Time:     "O(k^2 log n)",
+		Space:    "O(k)",
+		Run: func(n int) any {
+			r := LinearRecurrence{Coefficients: []uint64{1, 1, 1}, Initial: []uint64{0, 0, 1}, Modulus: 1_000_000_007}
+			t, _ := r.Term(n)
+			return t
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "BerlekampMassey",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Shortest linear recurrence generating a sequence modulo a prime",
+		Time:     "O(n^2)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			seq := make([]uint64, n)
+			for i := range seq {
+				seq[i] = uint64(i*i%7 + i%5)
+			}
+			c, _ := BerlekampMassey(seq, 1_000_000_007)
+			return c
+		},
+	})
+}
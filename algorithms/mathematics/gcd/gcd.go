@@ -1,12 +1,27 @@
 package gcd
 
+import "github.com/ignoreAnt/go-dsa/trace"
+
 // gcdIterative returns the greatest common divisor of a and b using the Euclidean
 // algorithm in an iterative manner.
 func gcdIterative(a, b int) int {
+	return gcdIterativeTraced(a, b, nil)
+}
 
+// gcdIterativeTraced is gcdIterative reporting its steps to t, which may be
+// nil: the comparisons with zero, and for each round of the Euclidean
+// algorithm the division of a by b followed by the swap that moves the
+// remainder into b.
+func gcdIterativeTraced(a, b int, t trace.Tracer) int {
+	if t != nil {
+		t.Event(trace.Comparison(a, 0))
+	}
 	if a == 0 {
 		return b
 	}
+	if t != nil {
+		t.Event(trace.Comparison(b, 0))
+	}
 	if b == 0 {
 		return a
 	}
@@ -14,6 +29,10 @@ func gcdIterative(a, b int) int {
 	// Euclidean algorithm
 	//
 	for b != 0 {
+		if t != nil {
+			t.Event(trace.Event{Kind: trace.Divide, A: a, B: b, Result: a % b})
+			t.Event(trace.Event{Kind: trace.Swap, A: a % b, B: b})
+		}
 		a, b = b, a%b
 	}
 
@@ -29,3 +48,15 @@ func gcdRecursive(a, b int) int {
 
 	return gcdRecursive(b, a%b)
 }
+
+// GCD returns the greatest common divisor of a and b, computed by the
+// iterative Euclidean algorithm of gcdIterative.
+func GCD(a, b int) int {
+	return gcdIterative(a, b)
+}
+
+// GCDTraced is GCD reporting the steps of the Euclidean algorithm to t; see
+// package trace.
+func GCDTraced(a, b int, t trace.Tracer) int {
+	return gcdIterativeTraced(a, b, t)
+}
@@ -1,6 +1,8 @@
 package gcd
 
 import (
+	"github.com/ignoreAnt/go-dsa/stress"
+	"github.com/ignoreAnt/go-dsa/trace"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -65,3 +67,47 @@ func TestGCD(t *testing.T) {
 		})
 	}
 }
+
+// TestGCDStress compares gcdRecursive with gcdIterative on random pairs,
+// negative values included.
+func TestGCDStress(t *testing.T) {
+	operand := stress.Ints(-1_000_000_000, 1_000_000_000)
+	stress.Check(t, stress.Pair[stress.Tuple[int, int], int]{
+		Name:      "gcdRecursive",
+		Reference: func(p stress.Tuple[int, int]) int { return gcdIterative(p.First, p.Second) },
+		Candidate: func(p stress.Tuple[int, int]) int { return gcdRecursive(p.First, p.Second) },
+		Gen:       stress.Tuples(operand, operand),
+		Shrink:    stress.ShrinkTuple(stress.ShrinkInt(0), stress.ShrinkInt(0)),
+	}, stress.Options{})
+}
+
+// TestGCDTraced tests the steps reported by the iterative Euclidean algorithm.
+func TestGCDTraced(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     int
+		expected []trace.Event
+	}{
+		{"a is zero", 0, 7, []trace.Event{trace.Comparison(0, 0)}},
+		{"b is zero", 7, 0, []trace.Event{trace.Comparison(7, 0), trace.Comparison(0, 0)}},
+		{"48 and 18", 48, 18, []trace.Event{
+			trace.Comparison(48, 0),
+			trace.Comparison(18, 0),
+			{Kind: trace.Divide, A: 48, B: 18, Result: 12},
+			{Kind: trace.Swap, A: 12, B: 18},
+			{Kind: trace.Divide, A: 18, B: 12, Result: 6},
+			{Kind: trace.Swap, A: 6, B: 12},
+			{Kind: trace.Divide, A: 12, B: 6, Result: 0},
+			{Kind: trace.Swap, A: 0, B: 6},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rec trace.Recorder
+			actual := GCDTraced(tc.a, tc.b, &rec)
+			assert.Equal(t, gcdIterative(tc.a, tc.b), actual)
+			assert.Equal(t, tc.expected, rec.Events, "Expected: %v, Got: %v", tc.expected, rec.Events)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package gcd
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/gcd"
+	registry.Register(registry.Algorithm{
+		Name:     "gcdIterative",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Greatest common divisor by the iterative Euclidean algorithm",
+		Time:     "O(log min(a, b))",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return gcdIterative(n, n*2/3+1) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "gcdRecursive",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Greatest common divisor by the recursive Euclidean algorithm",
+		Time:     "O(log min(a, b))",
+		Space:    "O(log min(a, b))",
+		InPlace:  true,
+		Run:      func(n int) any { return gcdRecursive(n, n*2/3+1) },
+	})
+}
@@ -31,3 +31,9 @@ func lcm(a, b int) int {
 
 	return (a * b) / gcd(a, b)
 }
+
+// LCM returns the lowest common multiple of a and b, or 0 if either is 0,
+// dividing their product by the greatest common divisor as lcm does.
+func LCM(a, b int) int {
+	return lcm(a, b)
+}
@@ -0,0 +1,18 @@
+package lcm
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/lcm"
+	registry.Register(registry.Algorithm{
+		Name:     "lcm",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Lowest common multiple as a*b / gcd(a, b)",
+		Time:     "O(log min(a, b))",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return lcm(n, n*2/3+1) },
+	})
+}
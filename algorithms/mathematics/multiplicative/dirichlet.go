@@ -0,0 +1,70 @@
+package multiplicative
+
+import "errors"
+
+// ErrNotInvertible is returned by DirichletInverse when f(1) is not ±1, so the
+// inverse would not have integer values.
+var ErrNotInvertible = errors.New("multiplicative: f(1) must be 1 or -1")
+
+// Convolve returns the Dirichlet convolution (f * g)(n) = Σ_{d|n} f(d) g(n/d)
+// of two tables indexed from 1, as produced by Function.Table. The result has
+// the length of the shorter table.
+//
+// Every pair (d, m) with d*m <= n is visited once, which is
+// Σ_{d<=n} n/d = O(n log n) work.
+func Convolve(f, g []int64) []int64 {
+	n := min(len(f), len(g)) - 1
+	if n < 1 {
+		return make([]int64, max(n+1, 0))
+	}
+
+	h := make([]int64, n+1)
+	for d := 1; d <= n; d++ {
+		if f[d] == 0 {
+			continue
+		}
+		for m := 1; d*m <= n; m++ {
+			h[d*m] += f[d] * g[m]
+		}
+	}
+	return h
+}
+
+// MobiusInvert returns f given its divisor sums g(n) = Σ_{d|n} f(d).
+//
+// By Möbius inversion f = g * μ. Rather than convolving with a μ table, the
+// relation f(n) = g(n) - Σ_{d|n, d<n} f(d) is unwound in place: once f(d) is
+// known it is subtracted from every proper multiple of d.
+func MobiusInvert(g []int64) []int64 {
+	f := append([]int64(nil), g...)
+	for d := 1; d < len(f); d++ {
+		for m := 2 * d; m < len(f); m += d {
+			f[m] -= f[d]
+		}
+	}
+	return f
+}
+
+// DirichletInverse returns the table of the function f⁻¹ with f * f⁻¹ = ε.
+//
+// f⁻¹(1) = 1/f(1), and for n > 1, f⁻¹(n) = -f⁻¹(1) Σ_{d|n, d>1} f(d) f⁻¹(n/d),
+// which is accumulated by pushing each finished f⁻¹(m) to its multiples.
+func DirichletInverse(f []int64) ([]int64, error) {
+	if len(f) < 2 || (f[1] != 1 && f[1] != -1) {
+		return nil, ErrNotInvertible
+	}
+
+	n := len(f) - 1
+	inverse := make([]int64, n+1) // holds Σ f(d) f⁻¹(m/d) over d > 1 until finished
+	for m := 1; m <= n; m++ {
+		if m == 1 {
+			inverse[1] = f[1]
+		} else {
+			inverse[m] = -f[1] * inverse[m]
+		}
+		for d := 2; d*m <= n; d++ {
+			inverse[d*m] += f[d] * inverse[m]
+		}
+	}
+	return inverse, nil
+}
@@ -0,0 +1,71 @@
+package multiplicative
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConvolve tests the classical Dirichlet convolution identities.
+func TestConvolve(t *testing.T) {
+	const n = 1000
+	one, id := One.Table(n), Identity.Table(n)
+
+	testCases := []struct {
+		name     string
+		actual   []int64
+		expected []int64
+	}{
+		{"φ * 1 = id", Convolve(Phi.Table(n), one), id},
+		{"μ * 1 = ε", Convolve(Mobius.Table(n), one), Unit.Table(n)},
+		{"1 * 1 = τ", Convolve(one, one), DivisorCount.Table(n)},
+		{"id * 1 = σ", Convolve(id, one), DivisorSum.Table(n)},
+		{"μ * id = φ", Convolve(Mobius.Table(n), id), Phi.Table(n)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.actual)
+		})
+	}
+
+	assert.Len(t, Convolve(one[:11], id), 11, "result has the length of the shorter table")
+}
+
+// TestMobiusInvert tests that Möbius inversion undoes divisor sums.
+func TestMobiusInvert(t *testing.T) {
+	const n = 1000
+	assert.Equal(t, Phi.Table(n), MobiusInvert(Identity.Table(n)))
+	assert.Equal(t, One.Table(n), MobiusInvert(DivisorCount.Table(n)))
+	assert.Equal(t, Identity.Table(n), MobiusInvert(DivisorSum.Table(n)))
+}
+
+// TestDirichletInverse tests that f * f⁻¹ = ε and that 1⁻¹ = μ.
+func TestDirichletInverse(t *testing.T) {
+	const n = 500
+
+	inverse, err := DirichletInverse(One.Table(n))
+	require.NoError(t, err)
+	assert.Equal(t, Mobius.Table(n), inverse)
+
+	for name, f := range map[string]Function{"Phi": Phi, "DivisorSum": DivisorSum, "Mobius": Mobius} {
+		t.Run(name, func(t *testing.T) {
+			table := f.Table(n)
+			inverse, err := DirichletInverse(table)
+			require.NoError(t, err)
+			assert.Equal(t, Unit.Table(n), Convolve(table, inverse))
+		})
+	}
+
+	negated := One.Table(10)
+	for i := range negated {
+		negated[i] = -negated[i]
+	}
+	inverse, err = DirichletInverse(negated)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, -1, 1, 1, 0, 1, -1, 1, 0, 0, -1}, inverse)
+
+	_, err = DirichletInverse([]int64{0, 2, 1})
+	assert.ErrorIs(t, err, ErrNotInvertible)
+}
@@ -0,0 +1,117 @@
+package multiplicative
+
+import (
+	"errors"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/sieve_of_eratosthenes"
+)
+
+// ErrPrimePolyDegree is returned by Min25.Sum when the polynomial of f at
+// primes has a degree above 2.
+var ErrPrimePolyDegree = errors.New("multiplicative: Min25 supports prime polynomials of degree at most 2")
+
+// Min25 evaluates Σ_{i<=n} f(i) for a multiplicative f whose values at primes
+// are a polynomial in p, using the min_25 sieve in roughly O(n^(3/4) / log n).
+//
+// The first phase finds, for every distinct quotient w = n/i, the sums
+// Σ_{p<=w} p^j over primes by sieving out composites one prime at a time
+// (Lucy_Hedgehog's method):
+//
+//	g(w) ← g(w) - p^j (g(w/p) - g(p-1))   for every w >= p^2
+//
+// The second phase adds the composites back through
+//
+//	S(x, k) = Σ_{p>p_k, p<=x} f(p) + Σ_{i>k, p_i^2<=x} Σ_{e>=1, p_i^(e+1)<=x} (f(p_i^e) S(x/p_i^e, i) + f(p_i^(e+1)))
+//
+// where S(x, k) sums f(m) over 2 <= m <= x with smallest prime factor above p_k.
+type Min25 struct {
+	Mod uint64 // modulus, 0 for 2^64
+
+	// PrimePoly holds the coefficients of f at primes, lowest degree first:
+	// f(p) = PrimePoly[0] + PrimePoly[1]*p + PrimePoly[2]*p^2. Degrees up to
+	// 2 are supported; Sum returns ErrPrimePolyDegree for longer slices.
+	PrimePoly []int64
+
+	// PrimePower returns f(p^k) for k >= 1. It is called only for primes
+	// p <= sqrt(n), so p^k fits in an int.
+	PrimePower Function
+}
+
+// Sum returns Σ_{i<=n} f(i) modulo Mod, or ErrPrimePolyDegree if PrimePoly
+// has more than three coefficients.
+func (m Min25) Sum(n int) (uint64, error) {
+	if len(m.PrimePoly) > 3 {
+		return 0, ErrPrimePolyDegree
+	}
+	if n < 1 {
+		return 0, nil
+	}
+
+	s := prime_numbers.IntSqrt(n)
+	primes := sieve_of_eratosthenes.SieveOfEratosthenes(s)
+
+	// the distinct values of n/i, in decreasing order, and their indices
+	var quotients []int
+	for l := 1; l <= n; l = n/(n/l) + 1 {
+		quotients = append(quotients, n/l)
+	}
+	index := func(w int) int {
+		if w <= s {
+			return len(quotients) - w
+		}
+		return n/w - 1
+	}
+
+	// primeSum[w] = Σ_{p<=w} f(p), combined from one power-sum table per degree
+	primeSum := make([]uint64, len(quotients))
+	for j, coefficient := range m.PrimePoly {
+		if coefficient == 0 {
+			continue
+		}
+		g := make([]uint64, len(quotients))
+		for i, w := range quotients {
+			g[i] = subMod(powerSum(w, j, m.Mod), 1, m.Mod) // Σ_{2<=i<=w} i^j
+		}
+
+		for _, p := range primes {
+			pj := reduce(pow(p, j), m.Mod)
+			below := g[index(p-1)]
+			for i, w := range quotients {
+				if w < p*p {
+					break
+				}
+				g[i] = subMod(g[i], mulMod(pj, subMod(g[index(w/p)], below, m.Mod), m.Mod), m.Mod)
+			}
+		}
+
+		c := reduce(coefficient, m.Mod)
+		for i := range primeSum {
+			primeSum[i] = addMod(primeSum[i], mulMod(c, g[i], m.Mod), m.Mod)
+		}
+	}
+
+	var rec func(x, k int) uint64
+	rec = func(x, k int) uint64 {
+		// primes in (p_k, x]; p_k is the k-th prime and p_0 stands for 1
+		result := primeSum[index(x)]
+		if k > 0 {
+			result = subMod(result, primeSum[index(primes[k-1])], m.Mod)
+		}
+
+		for i := k; i < len(primes) && primes[i] <= x/primes[i]; i++ {
+			p := primes[i]
+			power := p
+			for e := 1; power <= x/p; e++ {
+				fe := reduce(m.PrimePower(p, e), m.Mod)
+				fe1 := reduce(m.PrimePower(p, e+1), m.Mod)
+				result = addMod(result, mulMod(fe, rec(x/power, i+1), m.Mod), m.Mod)
+				result = addMod(result, fe1, m.Mod)
+				power *= p
+			}
+		}
+		return result
+	}
+
+	return addMod(rec(n, 0), reduce(1, m.Mod), m.Mod), nil
+}
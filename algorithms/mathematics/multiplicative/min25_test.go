@@ -0,0 +1,64 @@
+package multiplicative
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMin25 tests the min_25 sieve against tables and Du's sieve.
+func TestMin25(t *testing.T) {
+	testCases := []struct {
+		name string
+		f    Function
+		sum  Min25
+	}{
+		{"Phi", Phi, Min25{PrimePoly: []int64{-1, 1}, PrimePower: Phi}},
+		{"Mobius", Mobius, Min25{PrimePoly: []int64{-1}, PrimePower: Mobius}},
+		{"DivisorCount", DivisorCount, Min25{PrimePoly: []int64{2}, PrimePower: DivisorCount}},
+		{"DivisorSum", DivisorSum, Min25{PrimePoly: []int64{1, 1}, PrimePower: DivisorSum}},
+		{"Identity", Identity, Min25{PrimePoly: []int64{0, 1}, PrimePower: Identity}},
+		{"Sum of squares of divisors", sigma2, Min25{PrimePoly: []int64{1, 0, 1}, PrimePower: sigma2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := tc.f.Table(1500)
+			var sum int64
+			for n := 1; n <= 1500; n++ {
+				sum += table[n]
+				actual, err := tc.sum.Sum(n)
+				require.NoError(t, err)
+				assert.Equal(t, uint64(sum), actual, "n = %d", n)
+			}
+		})
+	}
+
+	t.Run("Agrees with Du's sieve", func(t *testing.T) {
+		phi := Min25{Mod: testModulus, PrimePoly: []int64{-1, 1}, PrimePower: Phi}
+		actual, err := phi.Sum(1_000_000_000)
+		require.NoError(t, err)
+		assert.Equal(t, SumPhi(1_000_000_000, testModulus), actual)
+
+		mobius := Min25{PrimePoly: []int64{-1}, PrimePower: Mobius}
+		actual, err = mobius.Sum(10_000_000)
+		require.NoError(t, err)
+		assert.Equal(t, SumMobius(10_000_000, 0), actual)
+	})
+
+	actual, err := Min25{PrimePoly: []int64{1}, PrimePower: One}.Sum(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), actual)
+
+	cubic := Min25{PrimePoly: []int64{0, 0, 0, 1}, PrimePower: One}
+	for _, n := range []int{0, 10} {
+		_, err := cubic.Sum(n)
+		assert.ErrorIs(t, err, ErrPrimePolyDegree, "n = %d", n)
+	}
+}
+
+// sigma2 is σ₂(n), the sum of the squares of the divisors of n.
+var sigma2 Function = func(p, k int) int64 {
+	return (pow(p, 2*k+2) - 1) / int64(p*p-1)
+}
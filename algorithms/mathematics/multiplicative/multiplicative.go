@@ -0,0 +1,108 @@
+package multiplicative
+
+import "github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+
+// Function is a multiplicative arithmetic function, i.e. one with f(1) = 1 and
+// f(ab) = f(a)f(b) whenever gcd(a, b) = 1. Such a function is determined by
+// its values on prime powers, so it is described by f(p^k) for a prime p and
+// k >= 1.
+type Function func(p, k int) int64
+
+// The standard multiplicative functions.
+var (
+	// Unit is the identity of Dirichlet convolution: ε(1) = 1, ε(n) = 0 otherwise.
+	Unit Function = func(p, k int) int64 { return 0 }
+
+	// One is the constant function 1(n) = 1.
+	One Function = func(p, k int) int64 { return 1 }
+
+	// Identity is id(n) = n.
+	Identity Function = func(p, k int) int64 { return pow(p, k) }
+
+	// Phi is Euler's totient φ(n), the count of 1 <= i <= n coprime to n.
+	Phi Function = func(p, k int) int64 { return pow(p, k-1) * int64(p-1) }
+
+	// Mobius is the Möbius function μ(n): (-1)^r for a product of r distinct
+	// primes and 0 when n has a square factor.
+	Mobius Function = func(p, k int) int64 {
+		if k == 1 {
+			return -1
+		}
+		return 0
+	}
+
+	// DivisorCount is τ(n), the number of divisors of n.
+	DivisorCount Function = func(p, k int) int64 { return int64(k + 1) }
+
+	// DivisorSum is σ(n), the sum of the divisors of n.
+	DivisorSum Function = func(p, k int) int64 { return (pow(p, k+1) - 1) / int64(p-1) }
+)
+
+// At returns f(n) for n >= 1 by grouping the prime factors of n into prime
+// powers and multiplying their values.
+func (f Function) At(n int) int64 {
+	value := int64(1)
+	factors := prime_factors.PrimeFactors(n)
+	for i := 0; i < len(factors); {
+		p, k := factors[i], 0
+		for i < len(factors) && factors[i] == p {
+			i++
+			k++
+		}
+		value *= f(p, k)
+	}
+	return value
+}
+
+// Table returns f(0), f(1), ..., f(n), with f(0) = 0 by convention.
+//
+// It is a linear sieve: every composite i*p is reached exactly once from its
+// smallest prime factor p. If p does not divide i, f(i*p) = f(i)f(p);
+// otherwise the full power p^e is split off and f(i*p) = f(rest)f(p^e).
+func (f Function) Table(n int) []int64 {
+	values := make([]int64, max(n+1, 1))
+	if n < 1 {
+		return values
+	}
+	values[1] = 1
+
+	smallest := make([]int32, n+1) // smallest prime factor, 0 if not yet seen
+	var primes []int
+	for i := 2; i <= n; i++ {
+		if smallest[i] == 0 {
+			smallest[i] = int32(i)
+			primes = append(primes, i)
+			values[i] = f(i, 1)
+		}
+
+		for _, p := range primes {
+			if p > int(smallest[i]) || i > n/p {
+				break
+			}
+			m := i * p
+			smallest[m] = int32(p)
+
+			if p < int(smallest[i]) {
+				values[m] = values[i] * f(p, 1)
+				continue
+			}
+
+			rest, e := m, 0
+			for rest%p == 0 {
+				rest /= p
+				e++
+			}
+			values[m] = values[rest] * f(p, e)
+		}
+	}
+	return values
+}
+
+// pow returns p^k for k >= 0.
+func pow(p, k int) int64 {
+	result := int64(1)
+	for ; k > 0; k-- {
+		result *= int64(p)
+	}
+	return result
+}
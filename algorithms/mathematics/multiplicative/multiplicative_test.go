@@ -0,0 +1,69 @@
+package multiplicative
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFunctionAt tests At for the standard functions on small inputs.
+func TestFunctionAt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		f        Function
+		input    int
+		expected int64
+	}{
+		// Values at 1
+		{"φ(1)", Phi, 1, 1},
+		{"μ(1)", Mobius, 1, 1},
+		{"ε(1)", Unit, 1, 1},
+
+		// Euler's totient
+		{"φ(9)", Phi, 9, 6},
+		{"φ(36)", Phi, 36, 12},
+		{"φ(97)", Phi, 97, 96},
+
+		// Möbius function
+		{"μ(6)", Mobius, 6, 1},
+		{"μ(30)", Mobius, 30, -1},
+		{"μ(12)", Mobius, 12, 0},
+
+		// Divisor functions
+		{"τ(12)", DivisorCount, 12, 6},
+		{"σ(12)", DivisorSum, 12, 28},
+		{"σ(28)", DivisorSum, 28, 56}, // 28 is perfect
+
+		// Identity, One and Unit
+		{"id(360)", Identity, 360, 360},
+		{"1(360)", One, 360, 1},
+		{"ε(360)", Unit, 360, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.f.At(tc.input)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+}
+
+// TestFunctionTable tests that the linear sieve agrees with At.
+func TestFunctionTable(t *testing.T) {
+	for name, f := range map[string]Function{
+		"Phi": Phi, "Mobius": Mobius, "DivisorCount": DivisorCount,
+		"DivisorSum": DivisorSum, "Identity": Identity, "Unit": Unit,
+	} {
+		t.Run(name, func(t *testing.T) {
+			table := f.Table(2000)
+			assert.Len(t, table, 2001)
+			assert.Equal(t, int64(0), table[0])
+			for n := 1; n <= 2000; n++ {
+				assert.Equal(t, f.At(n), table[n], "n = %d", n)
+			}
+		})
+	}
+
+	assert.Equal(t, []int64{0}, Phi.Table(0))
+	assert.Equal(t, []int64{0, 1}, Phi.Table(1))
+}
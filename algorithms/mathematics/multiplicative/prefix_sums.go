@@ -0,0 +1,242 @@
+package multiplicative
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+)
+
+// Prefix sums of arithmetic functions grow far beyond int64 (Σφ(i) for
+// i <= 10^10 is about 3 * 10^19), so they are computed modulo mod. A modulus
+// of 0 stands for 2^64: the natural wrap-around of uint64 arithmetic, which
+// gives exact results whenever they are below 2^64.
+
+// Summatory returns Σ_{1<=i<=n} f(i) modulo the modulus in use.
+type Summatory func(n int) uint64
+
+// DuSieve evaluates the prefix sums S(n) = Σ_{i<=n} f(i) of a function f by
+// Du's sieve (the Dirichlet-convolution sieve).
+//
+// It needs a helper function g such that the prefix sums G of g and H of
+// h = f * g are cheap. Splitting h(k) = Σ_{d|k} g(d) f(k/d) over d gives
+//
+//	H(n) = Σ_{d<=n} g(d) S(n/d)   =>   S(n) = H(n) - Σ_{2<=d<=n} g(d) S(n/d)
+//
+// for g(1) = 1. The quotients n/d take O(sqrt(n)) distinct values, so the sum
+// is evaluated in blocks of equal n/d. With S precomputed up to about
+// n^(2/3) and the remaining values memoised, the total cost is O(n^(2/3)).
+type DuSieve struct {
+	Mod   uint64    // modulus, 0 for 2^64
+	G     Summatory // prefix sums of g, with g(1) = 1
+	H     Summatory // prefix sums of h = f * g
+	Small []uint64  // Small[i] = S(i) for i < len(Small), may be empty
+
+	memo map[int]uint64
+}
+
+// Sum returns S(n) modulo Mod, which is 0 for n < 1.
+func (d *DuSieve) Sum(n int) uint64 {
+	if n < 1 {
+		return 0
+	}
+	if n < len(d.Small) {
+		return d.Small[n]
+	}
+	if v, ok := d.memo[n]; ok {
+		return v
+	}
+
+	result := d.H(n)
+	for l := 2; l <= n; {
+		q := n / l
+		r := n / q
+		weight := subMod(d.G(r), d.G(l-1), d.Mod)
+		result = subMod(result, mulMod(weight, d.Sum(q), d.Mod), d.Mod)
+		l = r + 1
+	}
+
+	if d.memo == nil {
+		d.memo = make(map[int]uint64)
+	}
+	d.memo[n] = result
+	return result
+}
+
+// smallTableLimit returns the size of the precomputed table used by the
+// DuSieve helpers: about n^(2/3), capped to keep memory bounded, and at
+// least 1.
+func smallTableLimit(n int) int {
+	limit := int(math.Cbrt(float64(max(n, 0))))
+	return max(min(limit*limit+1, n+1, 1<<22), 1)
+}
+
+// prefixTable returns the running sums of f(0..limit-1) modulo mod.
+func prefixTable(f Function, limit int, mod uint64) []uint64 {
+	values := f.Table(limit - 1)
+	sums := make([]uint64, limit)
+	for i := 1; i < limit; i++ {
+		sums[i] = addMod(sums[i-1], reduce(values[i], mod), mod)
+	}
+	return sums
+}
+
+// SumPhi returns Σ_{i<=n} φ(i) modulo mod using Du's sieve with g = 1, since
+// φ * 1 = id and the prefix sums of id are n(n+1)/2. It returns 0 for n < 1.
+func SumPhi(n int, mod uint64) uint64 {
+	if n < 1 {
+		return 0
+	}
+	sieve := &DuSieve{
+		Mod:   mod,
+		G:     func(k int) uint64 { return reduce(int64(k), mod) },
+		H:     func(k int) uint64 { return powerSum(k, 1, mod) },
+		Small: prefixTable(Phi, smallTableLimit(n), mod),
+	}
+	return sieve.Sum(n)
+}
+
+// SumMobius returns the Mertens function Σ_{i<=n} μ(i) modulo mod using Du's
+// sieve with g = 1, since μ * 1 = ε. It returns 0 for n < 1.
+func SumMobius(n int, mod uint64) uint64 {
+	if n < 1 {
+		return 0
+	}
+	sieve := &DuSieve{
+		Mod:   mod,
+		G:     func(k int) uint64 { return reduce(int64(k), mod) },
+		H:     func(k int) uint64 { return reduce(int64(min(k, 1)), mod) },
+		Small: prefixTable(Mobius, smallTableLimit(n), mod),
+	}
+	return sieve.Sum(n)
+}
+
+// Hyperbola returns Σ_{k<=n} (f * g)(k) modulo mod by the Dirichlet
+// hyperbola method.
+//
+// The pairs (a, b) with ab <= n lie under the hyperbola; splitting at
+// s = floor(sqrt(n)) counts those with a <= s and those with b <= s, and
+// subtracts the square counted twice:
+//
+//	Σ_{a<=s} f(a) G(n/a) + Σ_{b<=s} g(b) F(n/b) - F(s) G(s)
+//
+// which needs only O(sqrt(n)) values of f, g, F and G.
+func Hyperbola(n int, f, g func(int) uint64, F, G Summatory, mod uint64) uint64 {
+	if n < 1 {
+		return 0
+	}
+
+	s := prime_numbers.IntSqrt(n)
+	var result uint64
+	for k := 1; k <= s; k++ {
+		result = addMod(result, mulMod(f(k), G(n/k), mod), mod)
+		result = addMod(result, mulMod(g(k), F(n/k), mod), mod)
+	}
+	return subMod(result, mulMod(F(s), G(s), mod), mod)
+}
+
+// SumDivisorCount returns Σ_{k<=n} τ(k) modulo mod. As τ = 1 * 1 this is the
+// hyperbola method with f = g = 1.
+func SumDivisorCount(n int, mod uint64) uint64 {
+	one := func(int) uint64 { return reduce(1, mod) }
+	count := func(k int) uint64 { return reduce(int64(k), mod) }
+	return Hyperbola(n, one, one, count, count, mod)
+}
+
+// SumDivisorSum returns Σ_{k<=n} σ(k) modulo mod. As σ = id * 1 this is the
+// hyperbola method with f = id and g = 1.
+func SumDivisorSum(n int, mod uint64) uint64 {
+	id := func(k int) uint64 { return reduce(int64(k), mod) }
+	one := func(int) uint64 { return reduce(1, mod) }
+	idSum := func(k int) uint64 { return powerSum(k, 1, mod) }
+	return Hyperbola(n, id, one, idSum, id, mod)
+}
+
+// powerSum returns Σ_{1<=i<=n} i^j modulo mod for j in {0, 1, 2}.
+//
+// The closed forms n, n(n+1)/2 and n(n+1)(2n+1)/6 are evaluated by dividing
+// the factors before reducing, so no modular inverse of 2 or 3 is needed and
+// the result is correct for even moduli and for 2^64 too.
+func powerSum(n, j int, mod uint64) uint64 {
+	if n < 1 {
+		return 0
+	}
+
+	a, b, c := uint64(n), uint64(n)+1, 2*uint64(n)+1
+	switch j {
+	case 0:
+		return reduce64(a, mod)
+	case 1:
+		if a%2 == 0 {
+			a /= 2
+		} else {
+			b /= 2
+		}
+		return mulMod(reduce64(a, mod), reduce64(b, mod), mod)
+	case 2:
+		if a%2 == 0 {
+			a /= 2
+		} else {
+			b /= 2
+		}
+		switch {
+		case a%3 == 0:
+			a /= 3
+		case b%3 == 0:
+			b /= 3
+		default:
+			c /= 3
+		}
+		return mulMod(mulMod(reduce64(a, mod), reduce64(b, mod), mod), reduce64(c, mod), mod)
+	}
+	panic("multiplicative: powerSum supports exponents 0, 1 and 2")
+}
+
+// reduce returns v modulo mod as an element of [0, mod), or of uint64 for
+// mod = 0. Negative values wrap around.
+func reduce(v int64, mod uint64) uint64 {
+	if v >= 0 {
+		return reduce64(uint64(v), mod)
+	}
+	if mod == 0 {
+		return uint64(v)
+	}
+	// -v computed as uint64 so that MinInt64 does not overflow
+	return subMod(0, reduce64(uint64(-(v+1))+1, mod), mod)
+}
+
+// reduce64 returns v modulo mod.
+func reduce64(v, mod uint64) uint64 {
+	if mod == 0 {
+		return v
+	}
+	return v % mod
+}
+
+// addMod returns (a + b) modulo mod for reduced a and b.
+func addMod(a, b, mod uint64) uint64 {
+	if mod == 0 {
+		return a + b
+	}
+	if a >= mod-b {
+		return a - (mod - b)
+	}
+	return a + b
+}
+
+// subMod returns (a - b) modulo mod for reduced a and b.
+func subMod(a, b, mod uint64) uint64 {
+	if mod == 0 || a >= b {
+		return a - b
+	}
+	return a + (mod - b)
+}
+
+// mulMod returns (a * b) modulo mod using a 128-bit product.
+func mulMod(a, b, mod uint64) uint64 {
+	if mod == 0 {
+		return a * b
+	}
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, mod)
+}
@@ -0,0 +1,97 @@
+package multiplicative
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testModulus = 1_000_000_007
+
+// TestSumPhi tests Du's sieve for Σφ against brute-force sums and known values.
+func TestSumPhi(t *testing.T) {
+	table := Phi.Table(3000)
+	var sum int64
+	for n := 1; n <= 3000; n++ {
+		sum += table[n]
+		assert.Equal(t, uint64(sum), SumPhi(n, 0), "n = %d", n)
+	}
+
+	assert.Equal(t, uint64(303963552392), SumPhi(1_000_000, 0))
+	assert.Equal(t, uint64(963550271), SumPhi(1_000_000, testModulus))
+
+	assert.Equal(t, uint64(303963551173008414), SumPhi(1_000_000_000, 0))
+
+	// Σφ(i) for i <= 10^10 is 30396355092886216366, which exceeds 2^64
+	assert.Equal(t, uint64(111732206), SumPhi(10_000_000_000, testModulus))
+	assert.Equal(t, uint64(30396355092886216366-1<<64), SumPhi(10_000_000_000, 0))
+
+	for _, n := range []int{0, -1, -5, math.MinInt} {
+		assert.Zero(t, SumPhi(n, 0), "n = %d", n)
+		assert.Zero(t, SumPhi(n, testModulus), "n = %d", n)
+	}
+}
+
+// TestSumMobius tests Du's sieve for the Mertens function.
+func TestSumMobius(t *testing.T) {
+	table := Mobius.Table(3000)
+	var sum int64
+	for n := 1; n <= 3000; n++ {
+		sum += table[n]
+		assert.Equal(t, reduce(sum, testModulus), SumMobius(n, testModulus), "n = %d", n)
+	}
+
+	assert.Equal(t, uint64(212), SumMobius(1_000_000, 0))
+	assert.Equal(t, uint64(0), SumMobius(0, 0))
+	assert.Equal(t, uint64(0), SumMobius(-5, 0))
+	assert.Equal(t, uint64(0), SumMobius(math.MinInt, testModulus))
+}
+
+// TestDuSieveNegative tests that a sieve with or without a small table sums
+// nothing below 1, without calling its helpers.
+func TestDuSieveNegative(t *testing.T) {
+	fail := func(k int) uint64 {
+		t.Errorf("helper called with %d", k)
+		return 0
+	}
+	for _, small := range [][]uint64{nil, {0, 1, 2}} {
+		d := &DuSieve{G: fail, H: fail, Small: small}
+		for _, n := range []int{0, -1, -3, math.MinInt} {
+			assert.Zero(t, d.Sum(n), "n = %d, %d small sums", n, len(small))
+		}
+	}
+	assert.Equal(t, 1, smallTableLimit(-5))
+	assert.Equal(t, 1, smallTableLimit(0))
+}
+
+// TestHyperbola tests the hyperbola method through Στ and Σσ.
+func TestHyperbola(t *testing.T) {
+	tau, sigma := DivisorCount.Table(2000), DivisorSum.Table(2000)
+	var tauSum, sigmaSum int64
+	for n := 1; n <= 2000; n++ {
+		tauSum += tau[n]
+		sigmaSum += sigma[n]
+		assert.Equal(t, uint64(tauSum), SumDivisorCount(n, 0), "n = %d", n)
+		assert.Equal(t, uint64(sigmaSum), SumDivisorSum(n, 0), "n = %d", n)
+	}
+
+	assert.Equal(t, uint64(13970034), SumDivisorCount(1_000_000, 0))
+	assert.Equal(t, uint64(822468118437%testModulus), SumDivisorSum(1_000_000, testModulus))
+}
+
+// TestPowerSum tests the closed forms for sums of powers, including moduli
+// where 2 and 3 are not invertible.
+func TestPowerSum(t *testing.T) {
+	for _, mod := range []uint64{0, 6, 12, testModulus} {
+		var s0, s1, s2 uint64
+		for n := 1; n <= 100; n++ {
+			k := uint64(n)
+			s0, s1, s2 = s0+1, s1+k, s2+k*k
+			assert.Equal(t, reduce64(s0, mod), powerSum(n, 0, mod), "n = %d, mod = %d", n, mod)
+			assert.Equal(t, reduce64(s1, mod), powerSum(n, 1, mod), "n = %d, mod = %d", n, mod)
+			assert.Equal(t, reduce64(s2, mod), powerSum(n, 2, mod), "n = %d, mod = %d", n, mod)
+		}
+	}
+	assert.Panics(t, func() { powerSum(5, 3, 0) })
+}
@@ -0,0 +1,58 @@
+package multiplicative
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/multiplicative"
+	registry.Register(registry.Algorithm{
+		Name:     "Function.Table",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Values of a multiplicative function up to n by a linear sieve",
+		Time:     "O(n)",
+		Space:    "O(n)",
+		Run:      func(n int) any { return Phi.Table(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Convolve",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Dirichlet convolution of two arithmetic functions up to n",
+		Time:     "O(n log n)",
+		Space:    "O(n)",
+		Run:      func(n int) any { return Convolve(Mobius.Table(n), One.Table(n)) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "SumPhi",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Prefix sum of Euler's totient by Du's sieve",
+		Time:     "O(n^(2/3))",
+		Space:    "O(n^(2/3))",
+		Run:      func(n int) any { return SumPhi(n, 0) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "SumDivisorCount",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Prefix sum of the divisor count by the hyperbola method",
+		Time:     "O(sqrt n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return SumDivisorCount(n, 0) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Min25.Sum",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Prefix sum of a multiplicative function by the min_25 sieve",
+		Time:     "O(n^(3/4) / log n)",
+		Space:    "O(sqrt n)",
+		Run: func(n int) any {
+			phi := Min25{PrimePoly: []int64{-1, 1}, PrimePower: Phi}
+			s, _ := phi.Sum(n)
+			return s
+		},
+	})
+}
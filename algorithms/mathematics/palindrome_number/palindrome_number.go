@@ -45,3 +45,10 @@ func isPalindromeStringMethod(n int) bool {
 	}
 	return true
 }
+
+// IsPalindromeNumber reports whether the decimal digits of n read the same
+// forward and backward, comparing n with its digits reversed as
+// isPalindromeNumber does. Negative numbers are not palindromes.
+func IsPalindromeNumber(n int) bool {
+	return isPalindromeNumber(n)
+}
@@ -1,7 +1,9 @@
 package palindrome_number
 
 import (
+	"github.com/ignoreAnt/go-dsa/stress"
 	"github.com/stretchr/testify/assert"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -67,3 +69,33 @@ func TestIsPalindrome(t *testing.T) {
 		})
 	}
 }
+
+// TestIsPalindromeStress compares isPalindromeStringMethod with
+// isPalindromeNumber on random inputs. Half of them are built as palindromes,
+// which random integers almost never are.
+func TestIsPalindromeStress(t *testing.T) {
+	anyInt := stress.Ints(-1_000_000, 1_000_000_000_000_000_000)
+	gen := func(rng *rand.Rand, size int) int {
+		if rng.IntN(2) == 0 {
+			return anyInt(rng, size)
+		}
+		digits := make([]int, 1+rng.IntN(18))
+		for i := range (len(digits) + 1) / 2 {
+			d := rng.IntN(10)
+			digits[i], digits[len(digits)-1-i] = d, d
+		}
+		n := 0
+		for _, d := range digits {
+			n = n*10 + d
+		}
+		return n
+	}
+
+	stress.Check(t, stress.Pair[int, bool]{
+		Name:      "isPalindromeStringMethod",
+		Reference: isPalindromeNumber,
+		Candidate: isPalindromeStringMethod,
+		Gen:       gen,
+		Shrink:    stress.ShrinkInt(0),
+	}, stress.Options{})
+}
@@ -0,0 +1,27 @@
+package palindrome_number
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/palindrome_number"
+	registry.Register(registry.Algorithm{
+		Name:     "isPalindromeNumber",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Whether n reads the same backwards, by reversing its digits arithmetically",
+		Time:     "O(log n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return isPalindromeNumber(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "isPalindromeStringMethod",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Whether n reads the same backwards, by comparing the ends of its decimal string",
+		Time:     "O(log n)",
+		Space:    "O(log n)",
+		Run:      func(n int) any { return isPalindromeStringMethod(n) },
+	})
+}
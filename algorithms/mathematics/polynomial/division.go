@@ -0,0 +1,103 @@
+package polynomial
+
+// divisionThreshold is the quotient length below which DivMod uses long
+// division instead of Newton iteration.
+const divisionThreshold = 64
+
+// DivMod returns the quotient q and remainder rem with a = q*b + rem and
+// deg rem < deg b.
+//
+// Short quotients are found by long division in O(deg q * deg b). Longer ones
+// use the reversal trick: with n = deg a - deg b + 1, reversing the
+// coefficients turns the quotient into rev(a) * rev(b)^-1 mod x^n, a power
+// series product computed with Inverse and Mul in O(n log n).
+func (r *Ring) DivMod(a, b Poly) (q, rem Poly, err error) {
+	a, b = r.reduce(a), r.reduce(b)
+	if len(b) == 0 {
+		return nil, nil, ErrDivisionByZero
+	}
+	if len(a) < len(b) {
+		return Poly{}, a, nil
+	}
+
+	n := len(a) - len(b) + 1
+	if n <= divisionThreshold || len(b) <= divisionThreshold {
+		q, rem = r.longDivision(a, b)
+		return q, rem, nil
+	}
+
+	revInverse, err := r.Inverse(reverse(b), n)
+	if err != nil {
+		return nil, nil, err
+	}
+	product := r.Mul(reverse(a)[:n], revInverse)
+	q = trim(reverse(truncate(product, n)))
+	return q, r.Sub(a, r.Mul(b, q)), nil
+}
+
+// longDivision divides trimmed polynomials with len(a) >= len(b) > 0.
+func (r *Ring) longDivision(a, b Poly) (Poly, Poly) {
+	rem := append(Poly(nil), a...)
+	q := make(Poly, len(a)-len(b)+1)
+	leadInverse := r.inverse(b[len(b)-1])
+
+	for i := len(q) - 1; i >= 0; i-- {
+		c := rem[i+len(b)-1] * leadInverse % r.mod
+		q[i] = c
+		if c == 0 {
+			continue
+		}
+		for j, d := range b {
+			rem[i+j] = r.sub(rem[i+j], c*d%r.mod)
+		}
+	}
+	return trim(q), trim(rem[:len(b)-1])
+}
+
+// Inverse returns the first n coefficients of the power series 1/a, i.e. the
+// g with a*g ≡ 1 (mod x^n).
+//
+// Newton iteration doubles the number of correct coefficients each round with
+// g ← g(2 - a*g) mod x^(2k), so the total cost is a constant number of
+// multiplications of size n.
+func (r *Ring) Inverse(a Poly, n int) (Poly, error) {
+	a = r.reduce(a)
+	if len(a) == 0 || a[0] == 0 {
+		return nil, ErrNotInvertible
+	}
+	if n <= 0 {
+		return Poly{}, nil
+	}
+
+	g := Poly{r.inverse(a[0])}
+	for k := 1; k < n; k *= 2 {
+		size := min(2*k, n)
+		prefix := a[:min(len(a), size)]
+
+		// e = 2 - a*g mod x^size
+		e := truncate(r.Mul(prefix, g), size)
+		for i := range e {
+			e[i] = r.sub(0, e[i])
+		}
+		e[0] = r.add(e[0], 2)
+
+		g = truncate(r.Mul(g, e), size)
+	}
+	return trim(g), nil
+}
+
+// truncate returns the first n coefficients of p, padded with zeros.
+func truncate(p Poly, n int) Poly {
+	out := make(Poly, n)
+	copy(out, p)
+	return out
+}
+
+// reverse returns the coefficients of p in reverse order.
+func reverse(p Poly) Poly {
+	out := make(Poly, len(p))
+	for i, c := range p {
+		out[len(p)-1-i] = c
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package polynomial
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDivMod tests DivMod on hand-checked cases and checks a = q*b + rem with
+// deg rem < deg b on random inputs that exercise both long division and the
+// Newton path.
+func TestDivMod(t *testing.T) {
+	r := mustDefaultRing(t)
+
+	testCases := []struct {
+		name        string
+		a, b        Poly
+		expectedQ   Poly
+		expectedRem Poly
+	}{
+		{"Exact division", Poly{DefaultModulus - 1, 0, 1}, Poly{DefaultModulus - 1, 1}, Poly{1, 1}, Poly{}}, // (x^2-1)/(x-1)
+		{"With remainder", Poly{1, 0, 1}, Poly{1, 1}, Poly{DefaultModulus - 1, 1}, Poly{2}},                 // (x^2+1)/(x+1)
+		{"Dividend of lower degree", Poly{1, 2}, Poly{1, 2, 3}, Poly{}, Poly{1, 2}},
+		{"Constant divisor", Poly{2, 4, 6}, Poly{2}, Poly{1, 2, 3}, Poly{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, rem, err := r.DivMod(tc.a, tc.b)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQ, q, "Expected: %v, Got: %v", tc.expectedQ, q)
+			assert.Equal(t, tc.expectedRem, rem, "Expected: %v, Got: %v", tc.expectedRem, rem)
+		})
+	}
+
+	_, _, err := r.DivMod(Poly{1, 2}, Poly{0})
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	rng := rand.New(rand.NewPCG(28, 2))
+	for _, size := range [][2]int{{10, 3}, {200, 100}, {500, 70}, {1000, 300}, {1500, 1499}} {
+		a := randomPoly(rng, size[0], DefaultModulus)
+		b := randomPoly(rng, size[1], DefaultModulus)
+		q, rem, err := r.DivMod(a, b)
+		require.NoError(t, err)
+		assert.Less(t, rem.Degree(), b.Degree(), "size %v", size)
+		assert.Equal(t, a, r.Add(r.Mul(q, b), rem), "size %v", size)
+	}
+}
+
+// TestInverse tests that a * Inverse(a, n) ≡ 1 (mod x^n).
+func TestInverse(t *testing.T) {
+	r := mustDefaultRing(t)
+
+	// 1/(1-x) = 1 + x + x^2 + ...
+	inv, err := r.Inverse(Poly{1, DefaultModulus - 1}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, Poly{1, 1, 1, 1, 1}, inv)
+
+	rng := rand.New(rand.NewPCG(28, 3))
+	for _, n := range []int{1, 2, 7, 64, 100, 1000} {
+		a := randomPoly(rng, n+3, DefaultModulus)
+		a[0] = 1 + a[0]%(DefaultModulus-1)
+		inv, err := r.Inverse(a, n)
+		require.NoError(t, err)
+		assert.Equal(t, Poly{1}, trim(truncate(r.Mul(a, inv), n)), "n = %d", n)
+	}
+
+	_, err = r.Inverse(Poly{0, 1}, 4)
+	assert.ErrorIs(t, err, ErrNotInvertible)
+}
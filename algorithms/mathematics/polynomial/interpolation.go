@@ -0,0 +1,74 @@
+package polynomial
+
+// EvalMany returns p(x) for every x in xs.
+func (r *Ring) EvalMany(p Poly, xs []uint64) []uint64 {
+	ys := make([]uint64, len(xs))
+	for i, x := range xs {
+		ys[i] = r.Eval(p, x)
+	}
+	return ys
+}
+
+// Interpolate returns the unique polynomial of degree below len(xs) that
+// passes through every point (xs[i], ys[i]). It returns ErrPointCount if xs
+// and ys differ in length and ErrDuplicatePoint if two x values are equal
+// modulo the ring's modulus.
+//
+// It uses Lagrange's formula in O(n^2): the master polynomial
+// M(x) = (x - x0)(x - x1)...(x - x(n-1)) is built once, each basis polynomial
+// M(x) / (x - xi) is recovered from it by synthetic division, and its weight
+// is yi / Π(xi - xj) for j != i.
+func (r *Ring) Interpolate(xs, ys []uint64) (Poly, error) {
+	if len(xs) != len(ys) {
+		return nil, ErrPointCount
+	}
+
+	points := make([]uint64, len(xs))
+	seen := make(map[uint64]bool, len(xs))
+	for i, x := range xs {
+		points[i] = x % r.mod
+		if seen[points[i]] {
+			return nil, ErrDuplicatePoint
+		}
+		seen[points[i]] = true
+	}
+
+	master := Poly{1}
+	for _, x := range points {
+		master = r.mulLinear(master, x)
+	}
+
+	result := make(Poly, len(points))
+	for i, x := range points {
+		basis := r.divLinear(master, x)
+
+		denominator := r.Eval(basis, x)
+		weight := ys[i] % r.mod * r.inverse(denominator) % r.mod
+		for j, c := range basis {
+			result[j] = r.add(result[j], c*weight%r.mod)
+		}
+	}
+	return trim(result), nil
+}
+
+// mulLinear returns p * (x - root) without trimming.
+func (r *Ring) mulLinear(p Poly, root uint64) Poly {
+	out := make(Poly, len(p)+1)
+	for i, c := range p {
+		out[i+1] = r.add(out[i+1], c)
+		out[i] = r.sub(out[i], c*root%r.mod)
+	}
+	return out
+}
+
+// divLinear returns p / (x - root) by synthetic division, assuming root is a
+// root of p so that the remainder is zero.
+func (r *Ring) divLinear(p Poly, root uint64) Poly {
+	out := make(Poly, len(p)-1)
+	var carry uint64
+	for i := len(p) - 1; i >= 1; i-- {
+		carry = r.add(p[i], carry*root%r.mod)
+		out[i-1] = carry
+	}
+	return out
+}
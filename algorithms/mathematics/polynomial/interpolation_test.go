@@ -0,0 +1,44 @@
+package polynomial
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInterpolate tests that Interpolate recovers a polynomial from its values
+// and that EvalMany reproduces them.
+func TestInterpolate(t *testing.T) {
+	r := mustDefaultRing(t)
+
+	// 1 + 2x + 3x^2 through x = 0, 1, 2
+	p, err := r.Interpolate([]uint64{0, 1, 2}, []uint64{1, 6, 17})
+	require.NoError(t, err)
+	assert.Equal(t, Poly{1, 2, 3}, p)
+
+	// a single point gives a constant
+	p, err = r.Interpolate([]uint64{5}, []uint64{9})
+	require.NoError(t, err)
+	assert.Equal(t, Poly{9}, p)
+
+	rng := rand.New(rand.NewPCG(28, 4))
+	for _, n := range []int{2, 10, 100} {
+		expected := randomPoly(rng, n, DefaultModulus)
+		xs := make([]uint64, n)
+		for i := range xs {
+			xs[i] = uint64(i*i + 7)
+		}
+		ys := r.EvalMany(expected, xs)
+
+		actual, err := r.Interpolate(xs, ys)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "n = %d", n)
+	}
+
+	_, err = r.Interpolate([]uint64{1, DefaultModulus + 1}, []uint64{1, 2})
+	assert.ErrorIs(t, err, ErrDuplicatePoint)
+	_, err = r.Interpolate([]uint64{1, 2}, []uint64{1})
+	assert.ErrorIs(t, err, ErrPointCount)
+}
@@ -0,0 +1,325 @@
+package polynomial
+
+import "math/bits"
+
+// Size thresholds, in coefficients of the shorter factor, used by Mul to pick
+// a multiplication method. Below karatsubaThreshold the O(n^2) schoolbook
+// method has the smallest constant; NTT takes over from nttThreshold.
+const (
+	karatsubaThreshold = 32
+	nttThreshold       = 64
+)
+
+// The three NTT primes used by MulArbitrary. Each has a 2^23-th root of unity
+// or better, and their product is about 7.8 * 10^25.
+var crtRings = [3]*Ring{
+	mustRing(998244353), // 119 * 2^23 + 1
+	mustRing(167772161), // 5 * 2^25 + 1
+	mustRing(469762049), // 7 * 2^26 + 1
+}
+
+// Mul returns a * b, choosing the method by size:
+//
+//   - schoolbook, O(nm), when the shorter factor is small;
+//   - NTT, O(n log n), when the modulus has a root of unity of the needed order;
+//   - CRT over three NTT primes, O(n log n), for other moduli when exact;
+//   - Karatsuba, O(n^1.585), otherwise.
+func (r *Ring) Mul(a, b Poly) Poly {
+	a, b = r.reduce(a), r.reduce(b)
+	if len(a) == 0 || len(b) == 0 {
+		return Poly{}
+	}
+
+	short := min(len(a), len(b))
+	switch {
+	case short <= karatsubaThreshold:
+		return trim(r.schoolbook(a, b))
+	case short >= nttThreshold && r.canNTT(len(a)+len(b)-1):
+		return trim(r.ntt(a, b))
+	case short >= nttThreshold && crtExact(short, r.mod):
+		return trim(crtMul(a, b, r.mod))
+	}
+	return trim(r.karatsuba(a, b))
+}
+
+// MulSchoolbook returns a * b using the O(nm) schoolbook method.
+func (r *Ring) MulSchoolbook(a, b Poly) Poly {
+	a, b = r.reduce(a), r.reduce(b)
+	if len(a) == 0 || len(b) == 0 {
+		return Poly{}
+	}
+	return trim(r.schoolbook(a, b))
+}
+
+// MulKaratsuba returns a * b using Karatsuba's O(n^1.585) method.
+func (r *Ring) MulKaratsuba(a, b Poly) Poly {
+	a, b = r.reduce(a), r.reduce(b)
+	if len(a) == 0 || len(b) == 0 {
+		return Poly{}
+	}
+	return trim(r.karatsuba(a, b))
+}
+
+// MulNTT returns a * b using the number-theoretic transform. It reports false
+// if the modulus has no root of unity of the order the product needs.
+func (r *Ring) MulNTT(a, b Poly) (Poly, bool) {
+	a, b = r.reduce(a), r.reduce(b)
+	if len(a) == 0 || len(b) == 0 {
+		return Poly{}, true
+	}
+	if !r.canNTT(len(a) + len(b) - 1) {
+		return nil, false
+	}
+	return trim(r.ntt(a, b)), true
+}
+
+// MulArbitrary returns a * b with coefficients modulo any m in [1, 2^32), or
+// ErrModulusRange for another m.
+//
+// The product is computed exactly modulo each of three NTT primes and the
+// coefficients are recovered by the Chinese remainder theorem. This is exact
+// while min(len(a), len(b)) * (m-1)^2 stays below the product of the primes,
+// which covers m near 10^9 while the shorter factor has fewer than about
+// 7.8 * 10^7 coefficients; larger inputs are multiplied with Karatsuba
+// directly modulo m. A product longer than the 2^23 coefficients one
+// transform supports is computed in pieces.
+func MulArbitrary(a, b Poly, m uint64) (Poly, error) {
+	if m == 0 || m >= 1<<32 {
+		return nil, ErrModulusRange
+	}
+
+	ra, rb := make(Poly, len(a)), make(Poly, len(b))
+	for i, c := range a {
+		ra[i] = c % m
+	}
+	for i, c := range b {
+		rb[i] = c % m
+	}
+	ra, rb = trim(ra), trim(rb)
+	if len(ra) == 0 || len(rb) == 0 {
+		return Poly{}, nil
+	}
+
+	if !crtExact(min(len(ra), len(rb)), m) {
+		// a Ring's arithmetic only relies on the modulus being below 2^32
+		return trim((&Ring{mod: m}).karatsuba(ra, rb)), nil
+	}
+	return trim(crtMul(ra, rb, m)), nil
+}
+
+// canNTT reports whether a product with n coefficients fits the largest
+// power-of-two transform the modulus supports.
+func (r *Ring) canNTT(n int) bool {
+	return r.root != 0 && bits.Len(uint(n-1)) <= r.twoAdicity
+}
+
+// schoolbook returns the full product of two non-empty polynomials.
+func (r *Ring) schoolbook(a, b Poly) Poly {
+	out := make(Poly, len(a)+len(b)-1)
+	for i, x := range a {
+		if x == 0 {
+			continue
+		}
+		for j, y := range b {
+			out[i+j] = (out[i+j] + x*y) % r.mod
+		}
+	}
+	return out
+}
+
+// karatsuba returns the full product of two non-empty polynomials.
+//
+// With a = a0 + x^h a1 and b = b0 + x^h b1 the product is
+// z0 + x^h (z1 - z0 - z2) + x^(2h) z2, where z0 = a0 b0, z2 = a1 b1 and
+// z1 = (a0 + a1)(b0 + b1), so three half-size products replace four.
+func (r *Ring) karatsuba(a, b Poly) Poly {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(b) <= karatsubaThreshold {
+		return r.schoolbook(a, b)
+	}
+
+	out := make(Poly, len(a)+len(b)-1)
+	h := (len(a) + 1) / 2
+	if len(b) <= h {
+		// b is too short to split: multiply it with each half of a
+		for i, c := range r.karatsuba(a[:h], b) {
+			out[i] = r.add(out[i], c)
+		}
+		for i, c := range r.karatsuba(a[h:], b) {
+			out[i+h] = r.add(out[i+h], c)
+		}
+		return out
+	}
+
+	a0, a1, b0, b1 := a[:h], a[h:], b[:h], b[h:]
+	z0 := r.karatsuba(a0, b0)
+	z2 := r.karatsuba(a1, b1)
+	z1 := r.karatsuba(r.addCoefficients(a0, a1), r.addCoefficients(b0, b1))
+
+	for i, c := range z0 {
+		out[i] = r.add(out[i], c)
+		z1[i] = r.sub(z1[i], c)
+	}
+	for i, c := range z2 {
+		out[i+2*h] = r.add(out[i+2*h], c)
+		z1[i] = r.sub(z1[i], c)
+	}
+	for i, c := range z1 {
+		if i+h < len(out) {
+			out[i+h] = r.add(out[i+h], c)
+		}
+	}
+	return out
+}
+
+// addCoefficients returns a + b without trimming, so the result is at least
+// as long as the longer operand.
+func (r *Ring) addCoefficients(a, b Poly) Poly {
+	out := make(Poly, max(len(a), len(b)))
+	copy(out, a)
+	for i, c := range b {
+		out[i] = r.add(out[i], c)
+	}
+	return out
+}
+
+// ntt returns the full product of two non-empty polynomials by transforming
+// both, multiplying pointwise and transforming back.
+func (r *Ring) ntt(a, b Poly) Poly {
+	n := len(a) + len(b) - 1
+	size := 1 << bits.Len(uint(n-1))
+
+	fa, fb := make(Poly, size), make(Poly, size)
+	copy(fa, a)
+	copy(fb, b)
+	r.transform(fa, false)
+	r.transform(fb, false)
+	for i := range fa {
+		fa[i] = fa[i] * fb[i] % r.mod
+	}
+	r.transform(fa, true)
+	return fa[:n]
+}
+
+// transform replaces a, whose length is a power of two, with its
+// number-theoretic transform (or the inverse transform).
+//
+// It is the iterative Cooley-Tukey butterfly: the input is permuted into
+// bit-reversed order and merged in rounds of doubling length, using
+// root^((mod-1)/length) as the principal length-th root of unity.
+func (r *Ring) transform(a Poly, invert bool) {
+	n := len(a)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	for length := 2; length <= n; length <<= 1 {
+		w := r.pow(r.root, (r.mod-1)/uint64(length))
+		if invert {
+			w = r.inverse(w)
+		}
+		half := length / 2
+		for i := 0; i < n; i += length {
+			wn := uint64(1)
+			for j := 0; j < half; j++ {
+				u, v := a[i+j], a[i+j+half]*wn%r.mod
+				a[i+j] = r.add(u, v)
+				a[i+j+half] = r.sub(u, v)
+				wn = wn * w % r.mod
+			}
+		}
+	}
+
+	if invert {
+		nInv := r.inverse(uint64(n))
+		for i := range a {
+			a[i] = a[i] * nInv % r.mod
+		}
+	}
+}
+
+// crtExact reports whether every coefficient of a product whose shorter
+// factor has short coefficients, each below m, is below the product of the
+// CRT primes and so can be recovered exactly.
+func crtExact(short int, m uint64) bool {
+	// (m-1)^2 fits in 64 bits for m < 2^32; its product with short needs 128
+	hi, _ := bits.Mul64((m-1)*(m-1), uint64(short))
+
+	// 998244353 * 167772161 * 469762049 = 0x411400 * 2^64 + 0x09ed000061800001,
+	// so any value whose high word is below 0x411400 is below the product
+	const crtBoundHigh = 0x411400
+	return hi < crtBoundHigh
+}
+
+// residue returns a copy of p with every coefficient reduced modulo mod. Unlike
+// Ring.reduce it keeps trailing zeros, so the length of a product is preserved.
+func residue(p Poly, mod uint64) Poly {
+	out := make(Poly, len(p))
+	for i, c := range p {
+		out[i] = c % mod
+	}
+	return out
+}
+
+// crtMaxLength is the longest product one transform over every CRT prime
+// supports: 998244353 - 1 is divisible by 2^23 and no higher power of two.
+const crtMaxLength = 1 << 23
+
+// crtMul multiplies two non-empty polynomials modulo m through the CRT
+// primes. A product longer than crtMaxLength is summed from the products of
+// pieces of half that length, each of which fits one transform.
+func crtMul(a, b Poly, m uint64) Poly {
+	if len(a)+len(b)-1 <= crtMaxLength {
+		return crtMulPiece(a, b, m)
+	}
+	const piece = crtMaxLength / 2
+	out := make(Poly, len(a)+len(b)-1)
+	for i := 0; i < len(a); i += piece {
+		for j := 0; j < len(b); j += piece {
+			p := crtMulPiece(a[i:min(i+piece, len(a))], b[j:min(j+piece, len(b))], m)
+			for k, c := range p {
+				out[i+j+k] = (out[i+j+k] + c) % m
+			}
+		}
+	}
+	return out
+}
+
+// crtMulPiece multiplies two non-empty polynomials whose product has at most
+// crtMaxLength coefficients modulo each CRT prime and combines the results
+// modulo m.
+func crtMulPiece(a, b Poly, m uint64) Poly {
+	var residues [3]Poly
+	for i, ring := range crtRings {
+		residues[i] = ring.ntt(residue(a, ring.mod), residue(b, ring.mod))
+	}
+
+	p1, p2, p3 := crtRings[0].mod, crtRings[1].mod, crtRings[2].mod
+	inv1 := crtRings[1].inverse(p1 % p2)            // p1^-1 mod p2
+	inv12 := crtRings[2].inverse(p1 % p3 * p2 % p3) // (p1 p2)^-1 mod p3
+	p12 := p1 * p2                                  // below 2^58
+	p12ModM := p12 % m
+
+	out := make(Poly, len(a)+len(b)-1)
+	for i := range out {
+		r1, r2, r3 := residues[0][i], residues[1][i], residues[2][i]
+
+		// x12 = r1 + p1 * k1 is the residue modulo p1 p2
+		k1 := (r2 + p2 - r1%p2) % p2 * inv1 % p2
+		x12 := r1 + p1*k1
+
+		// x = x12 + p1 p2 * k2 is the residue modulo p1 p2 p3
+		k2 := (r3 + p3 - x12%p3) % p3 * inv12 % p3
+		out[i] = (x12%m + p12ModM*k2%m) % m
+	}
+	return out
+}
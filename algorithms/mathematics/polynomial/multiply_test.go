@@ -0,0 +1,180 @@
+package polynomial
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/stress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomPoly returns a polynomial with n coefficients below mod whose leading
+// coefficient is non-zero.
+func randomPoly(rng *rand.Rand, n int, mod uint64) Poly {
+	p := make(Poly, n)
+	for i := range p {
+		p[i] = rng.Uint64N(mod)
+	}
+	if n > 0 && p[n-1] == 0 {
+		p[n-1] = 1
+	}
+	return p
+}
+
+// TestMul tests that every multiplication method agrees with the schoolbook
+// product on hand-checked and random inputs of many sizes, including sizes on
+// both sides of each threshold.
+func TestMul(t *testing.T) {
+	r := mustDefaultRing(t)
+
+	t.Run("Small products", func(t *testing.T) {
+		assert.Equal(t, Poly{3, 10, 8}, r.Mul(Poly{1, 2}, Poly{3, 4}))                                  // (1+2x)(3+4x)
+		assert.Equal(t, Poly{1, 0, DefaultModulus - 1}, r.Mul(Poly{1, 1}, Poly{1, DefaultModulus - 1})) // (1+x)(1-x)
+		assert.Equal(t, Poly{}, r.Mul(Poly{}, Poly{1, 2}))
+		assert.Equal(t, Poly{}, r.Mul(Poly{0, 0}, Poly{1, 2}))
+	})
+
+	rng := rand.New(rand.NewPCG(28, 998244353))
+	for _, size := range [][2]int{{1, 1}, {5, 40}, {33, 33}, {63, 200}, {64, 64}, {100, 37}, {257, 300}, {1000, 999}} {
+		a := randomPoly(rng, size[0], DefaultModulus)
+		b := randomPoly(rng, size[1], DefaultModulus)
+		expected := r.MulSchoolbook(a, b)
+
+		assert.Equal(t, expected, r.Mul(a, b), "Mul %v", size)
+		assert.Equal(t, expected, r.MulKaratsuba(a, b), "MulKaratsuba %v", size)
+
+		actual, ok := r.MulNTT(a, b)
+		require.True(t, ok)
+		assert.Equal(t, expected, actual, "MulNTT %v", size)
+
+		actual, err := MulArbitrary(a, b, DefaultModulus)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "MulArbitrary %v", size)
+	}
+}
+
+// TestMulStress compares MulKaratsuba and MulNTT with MulSchoolbook on random
+// pairs of polynomials, shrinking any disagreement to the shortest inputs.
+func TestMulStress(t *testing.T) {
+	r := mustDefaultRing(t)
+	coefficient := func(rng *rand.Rand, size int) uint64 { return rng.Uint64N(DefaultModulus) }
+	poly := stress.Slices(coefficient)
+	gen := stress.Tuples(poly, poly)
+	shrink := stress.ShrinkTuple(stress.ShrinkSlice[uint64](nil), stress.ShrinkSlice[uint64](nil))
+	schoolbook := func(p stress.Tuple[[]uint64, []uint64]) []uint64 { return r.MulSchoolbook(p.First, p.Second) }
+
+	stress.Check(t, stress.Pair[stress.Tuple[[]uint64, []uint64], []uint64]{
+		Name:      "MulKaratsuba",
+		Reference: schoolbook,
+		Candidate: func(p stress.Tuple[[]uint64, []uint64]) []uint64 { return r.MulKaratsuba(p.First, p.Second) },
+		Gen:       gen,
+		Shrink:    shrink,
+		Equal:     slices.Equal[[]uint64],
+	}, stress.Options{Iterations: 200, MaxSize: 300})
+
+	stress.Check(t, stress.Pair[stress.Tuple[[]uint64, []uint64], []uint64]{
+		Name:      "MulNTT",
+		Reference: schoolbook,
+		Candidate: func(p stress.Tuple[[]uint64, []uint64]) []uint64 {
+			product, _ := r.MulNTT(p.First, p.Second)
+			return product
+		},
+		Gen:    gen,
+		Shrink: shrink,
+		Equal:  slices.Equal[[]uint64],
+	}, stress.Options{Iterations: 200, MaxSize: 300})
+}
+
+// TestMulOtherModuli tests Mul over a prime without large roots of unity and
+// MulArbitrary over composite moduli, including the Karatsuba fallback.
+func TestMulOtherModuli(t *testing.T) {
+	rng := rand.New(rand.NewPCG(28, 1_000_000_007))
+
+	r, err := NewRing(1_000_000_007)
+	require.NoError(t, err)
+	a := randomPoly(rng, 500, r.Modulus())
+	b := randomPoly(rng, 300, r.Modulus())
+	_, ok := r.MulNTT(a, b)
+	assert.False(t, ok, "1e9+7 supports only a transform of length 2")
+	assert.Equal(t, r.MulSchoolbook(a, b), r.Mul(a, b))
+
+	for _, m := range []uint64{1, 2, 10, 1 << 20, 1_000_000_000, 1<<32 - 1} {
+		a := randomPoly(rng, 400, m)
+		b := randomPoly(rng, 150, m)
+		expected := naiveMul(a, b, m)
+		actual, err := MulArbitrary(a, b, m)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "modulus %d", m)
+	}
+
+	for _, m := range []uint64{0, 1 << 32} {
+		_, err := MulArbitrary(Poly{1}, Poly{1}, m)
+		assert.ErrorIs(t, err, ErrModulusRange, "modulus %d", m)
+	}
+}
+
+// TestMulArbitraryLong tests MulArbitrary on a product longer than one
+// transform over the CRT primes supports, which it computes in pieces: the
+// square of 1 + x + ... + x^(n-1) has the coefficients 1, 2, ..., n, ..., 2, 1.
+func TestMulArbitraryLong(t *testing.T) {
+	if testing.Short() {
+		t.Skip("multiplies polynomials of 2^22 + 1 coefficients")
+	}
+	const n = 1<<22 + 1
+	const m = 1_000_000_007
+	ones := make(Poly, n)
+	for i := range ones {
+		ones[i] = 1
+	}
+	actual, err := MulArbitrary(ones, ones, m)
+	require.NoError(t, err)
+	require.Len(t, actual, 2*n-1)
+	for i, c := range actual {
+		expected := uint64(min(i+1, 2*n-1-i))
+		if c != expected {
+			t.Fatalf("coefficient %d: Expected: %d, Got: %d", i, expected, c)
+		}
+	}
+}
+
+// naiveMul returns a * b modulo m using 128-bit-safe arithmetic.
+func naiveMul(a, b Poly, m uint64) Poly {
+	out := make(Poly, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			out[i+j] = (out[i+j] + x%m*(y%m)%m) % m
+		}
+	}
+	return trim(out)
+}
+
+// BenchmarkMul compares the multiplication methods on 2048-coefficient inputs.
+func BenchmarkMul(b *testing.B) {
+	r, _ := NewRing(DefaultModulus)
+	rng := rand.New(rand.NewPCG(1, 2))
+	x := randomPoly(rng, 2048, DefaultModulus)
+	y := randomPoly(rng, 2048, DefaultModulus)
+
+	b.Run("Schoolbook", func(b *testing.B) {
+		for range b.N {
+			r.MulSchoolbook(x, y)
+		}
+	})
+	b.Run("Karatsuba", func(b *testing.B) {
+		for range b.N {
+			r.MulKaratsuba(x, y)
+		}
+	})
+	b.Run("NTT", func(b *testing.B) {
+		for range b.N {
+			r.MulNTT(x, y)
+		}
+	})
+	b.Run("CRT", func(b *testing.B) {
+		for range b.N {
+			MulArbitrary(x, y, 1_000_000_007)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package polynomial
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+)
+
+// ErrInvalidModulus is returned when a Ring is built over a number that is
+// not a prime below 2^32.
+var ErrInvalidModulus = errors.New("polynomial: modulus must be a prime below 2^32")
+
+// ErrDivisionByZero is returned when dividing by the zero polynomial.
+var ErrDivisionByZero = errors.New("polynomial: division by zero polynomial")
+
+// ErrNotInvertible is returned when a power series has no inverse, i.e. its
+// constant term is zero.
+var ErrNotInvertible = errors.New("polynomial: constant term is not invertible")
+
+// ErrDuplicatePoint is returned by Interpolate when two points share an
+// x-coordinate.
+var ErrDuplicatePoint = errors.New("polynomial: duplicate interpolation point")
+
+// ErrPointCount is returned by Interpolate when it is given different numbers
+// of x and y values.
+var ErrPointCount = errors.New("polynomial: different numbers of x and y values")
+
+// ErrModulusRange is returned by MulArbitrary for a modulus outside [1, 2^32).
+var ErrModulusRange = errors.New("polynomial: modulus must be in [1, 2^32)")
+
+// DefaultModulus is the NTT-friendly prime 998244353 = 119 * 2^23 + 1.
+const DefaultModulus = 998244353
+
+// Poly is a polynomial with coefficients listed from the constant term up,
+// so Poly{1, 2, 3} is 1 + 2x + 3x^2. Results returned by a Ring have no
+// trailing zero coefficients; the zero polynomial is the empty Poly.
+type Poly []uint64
+
+// Degree returns the degree of p, or -1 for the zero polynomial.
+func (p Poly) Degree() int {
+	return len(trim(p)) - 1
+}
+
+// trim returns p without its trailing zero coefficients.
+func trim(p Poly) Poly {
+	n := len(p)
+	for n > 0 && p[n-1] == 0 {
+		n--
+	}
+	return p[:n]
+}
+
+// Ring performs polynomial arithmetic with coefficients modulo a prime.
+//
+// Primes of the form c * 2^k + 1 have a 2^k-th root of unity, which lets Mul
+// use the number-theoretic transform for products of up to 2^k coefficients.
+// Other primes fall back to Karatsuba or to CRT-based multiplication.
+type Ring struct {
+	mod        uint64
+	twoAdicity int    // largest k with 2^k dividing mod - 1
+	root       uint64 // primitive root modulo mod, 0 if NTT is unused
+}
+
+// NewRing returns a Ring over the integers modulo the prime mod.
+//
+// Primality is checked with prime_numbers.IsPrime. If mod - 1 has a large
+// enough power of two for NTT to pay off, a primitive root is found by testing
+// g^((mod-1)/q) != 1 for every prime factor q of mod - 1.
+func NewRing(mod uint64) (*Ring, error) {
+	if mod >= 1<<32 || !prime_numbers.IsPrime(int(mod)) {
+		return nil, ErrInvalidModulus
+	}
+
+	r := &Ring{mod: mod, twoAdicity: bits.TrailingZeros64(mod - 1)}
+	if 1<<r.twoAdicity >= nttThreshold {
+		r.root = r.primitiveRoot()
+	}
+	return r, nil
+}
+
+// mustRing returns NewRing(mod) and panics on error. It is used for the fixed
+// primes of this package.
+func mustRing(mod uint64) *Ring {
+	r, err := NewRing(mod)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// primitiveRoot returns the smallest generator of the multiplicative group.
+func (r *Ring) primitiveRoot() uint64 {
+	factors := prime_factors.PrimeFactors(int(r.mod - 1))
+	for g := uint64(2); ; g++ {
+		isRoot := true
+		for _, q := range factors {
+			if r.pow(g, (r.mod-1)/uint64(q)) == 1 {
+				isRoot = false
+				break
+			}
+		}
+		if isRoot {
+			return g
+		}
+	}
+}
+
+// Modulus returns the prime the Ring works modulo.
+func (r *Ring) Modulus() uint64 {
+	return r.mod
+}
+
+// reduce returns a trimmed copy of p with every coefficient reduced.
+func (r *Ring) reduce(p Poly) Poly {
+	out := make(Poly, len(p))
+	for i, c := range p {
+		out[i] = c % r.mod
+	}
+	return trim(out)
+}
+
+// Add returns a + b.
+func (r *Ring) Add(a, b Poly) Poly {
+	a, b = r.reduce(a), r.reduce(b)
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for i, c := range b {
+		a[i] = r.add(a[i], c)
+	}
+	return trim(a)
+}
+
+// Sub returns a - b.
+func (r *Ring) Sub(a, b Poly) Poly {
+	a, b = r.reduce(a), r.reduce(b)
+	for len(a) < len(b) {
+		a = append(a, 0)
+	}
+	for i, c := range b {
+		a[i] = r.sub(a[i], c)
+	}
+	return trim(a)
+}
+
+// Scale returns k * p.
+func (r *Ring) Scale(p Poly, k uint64) Poly {
+	p, k = r.reduce(p), k%r.mod
+	for i := range p {
+		p[i] = p[i] * k % r.mod
+	}
+	return trim(p)
+}
+
+// Eval returns p(x) by Horner's rule in O(deg p) time.
+func (r *Ring) Eval(p Poly, x uint64) uint64 {
+	x %= r.mod
+	var y uint64
+	for i := len(p) - 1; i >= 0; i-- {
+		y = r.add(y*x%r.mod, p[i]%r.mod)
+	}
+	return y
+}
+
+// add returns (a + b) mod r.mod for reduced a and b.
+func (r *Ring) add(a, b uint64) uint64 {
+	s := a + b
+	if s >= r.mod {
+		s -= r.mod
+	}
+	return s
+}
+
+// sub returns (a - b) mod r.mod for reduced a and b.
+func (r *Ring) sub(a, b uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + r.mod - b
+}
+
+// pow returns base^exp mod r.mod by binary exponentiation. Because the
+// modulus is below 2^32, every product of reduced values fits in a uint64.
+func (r *Ring) pow(base, exp uint64) uint64 {
+	result := uint64(1)
+	base %= r.mod
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = result * base % r.mod
+		}
+		base = base * base % r.mod
+	}
+	return result
+}
+
+// inverse returns a^-1 mod r.mod by Fermat's little theorem.
+func (r *Ring) inverse(a uint64) uint64 {
+	return r.pow(a, r.mod-2)
+}
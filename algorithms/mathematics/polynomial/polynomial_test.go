@@ -0,0 +1,78 @@
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mustDefaultRing returns the Ring over DefaultModulus.
+func mustDefaultRing(t *testing.T) *Ring {
+	t.Helper()
+	r, err := NewRing(DefaultModulus)
+	require.NoError(t, err)
+	return r
+}
+
+// TestNewRing tests that NewRing accepts primes below 2^32, rejects other
+// moduli and finds primitive roots for NTT-friendly primes.
+func TestNewRing(t *testing.T) {
+	testCases := []struct {
+		name         string
+		mod          uint64
+		expectedRoot uint64
+		err          error
+	}{
+		{"998244353", 998244353, 3, nil},
+		{"167772161", 167772161, 3, nil},
+		{"469762049", 469762049, 3, nil},
+		{"1e9+7 has no large NTT", 1_000_000_007, 0, nil},
+		{"Small prime", 7, 0, nil},
+		{"Composite", 1_000_000, 0, ErrInvalidModulus},
+		{"One", 1, 0, ErrInvalidModulus},
+		{"Too large", 1<<32 + 15, 0, ErrInvalidModulus},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRing(tc.mod)
+			assert.ErrorIs(t, err, tc.err)
+			if err == nil {
+				assert.Equal(t, tc.expectedRoot, r.root)
+				assert.Equal(t, tc.mod, r.Modulus())
+			}
+		})
+	}
+}
+
+// TestBasicOperations tests Add, Sub, Scale, Eval and Degree.
+func TestBasicOperations(t *testing.T) {
+	r := mustDefaultRing(t)
+
+	testCases := []struct {
+		name     string
+		actual   Poly
+		expected Poly
+	}{
+		{"Add", r.Add(Poly{1, 2, 3}, Poly{4, 5}), Poly{5, 7, 3}},
+		{"Add cancels leading terms", r.Add(Poly{1, 2, 3}, Poly{0, 0, DefaultModulus - 3}), Poly{1, 2}},
+		{"Add reduces coefficients", r.Add(Poly{DefaultModulus + 1}, Poly{1}), Poly{2}},
+		{"Sub", r.Sub(Poly{5, 7, 3}, Poly{4, 5}), Poly{1, 2, 3}},
+		{"Sub wraps around", r.Sub(Poly{1}, Poly{2, 1}), Poly{DefaultModulus - 1, DefaultModulus - 1}},
+		{"Sub to zero", r.Sub(Poly{1, 2}, Poly{1, 2}), Poly{}},
+		{"Scale", r.Scale(Poly{1, 2, 3}, 2), Poly{2, 4, 6}},
+		{"Scale by zero", r.Scale(Poly{1, 2, 3}, DefaultModulus), Poly{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.actual, "Expected: %v, Got: %v", tc.expected, tc.actual)
+		})
+	}
+
+	assert.Equal(t, uint64(1+2*10+3*100), r.Eval(Poly{1, 2, 3}, 10))
+	assert.Equal(t, uint64(0), r.Eval(Poly{}, 10))
+	assert.Equal(t, 2, Poly{1, 2, 3, 0, 0}.Degree())
+	assert.Equal(t, -1, Poly{0, 0}.Degree())
+}
@@ -0,0 +1,83 @@
+package polynomial
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/polynomial"
+	registry.Register(registry.Algorithm{
+		Name:     "Ring.MulSchoolbook",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Polynomial product by multiplying every pair of coefficients",
+		Time:     "O(n^2)",
+		Space:    "O(n)",
+		Run:      func(n int) any { return mul(n, (*Ring).MulSchoolbook) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Ring.MulKaratsuba",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Polynomial product by Karatsuba's three-multiplication split",
+		Time:     "O(n^1.585)",
+		Space:    "O(n)",
+		Run:      func(n int) any { return mul(n, (*Ring).MulKaratsuba) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Ring.MulNTT",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Polynomial product by the number-theoretic transform",
+		Time:     "O(n log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			return mul(n, func(r *Ring, a, b Poly) Poly {
+				p, _ := r.MulNTT(a, b)
+				return p
+			})
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Ring.DivMod",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Polynomial division with remainder, by Newton iteration for large inputs",
+		Time:     "O(n log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			r := mustRing(DefaultModulus)
+			q, _, _ := r.DivMod(sample(2*n+1), sample(n+1))
+			return q
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Ring.Interpolate",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Polynomial through n points by Lagrange interpolation",
+		Time:     "O(n^2)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			xs := make([]uint64, n)
+			for i := range xs {
+				xs[i] = uint64(i)
+			}
+			p, _ := mustRing(DefaultModulus).Interpolate(xs, sample(n))
+			return p
+		},
+	})
+}
+
+// sample returns a deterministic polynomial with n non-zero coefficients.
+func sample(n int) Poly {
+	p := make(Poly, n)
+	for i := range p {
+		p[i] = uint64(i*i+1) % DefaultModulus
+	}
+	return p
+}
+
+// mul multiplies two sample polynomials of n coefficients with method.
+func mul(n int, method func(*Ring, Poly, Poly) Poly) Poly {
+	return method(mustRing(DefaultModulus), sample(n), sample(n))
+}
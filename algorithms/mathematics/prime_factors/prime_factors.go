@@ -1,5 +1,7 @@
 package prime_factors
 
+import "github.com/ignoreAnt/go-dsa/trace"
+
 // primeFactorsBrute returns a slice of all prime factors of n,
 // using a brute-force method with a time complexity of O(n).
 func primeFactorsBrute(n int) []int {
@@ -20,17 +22,36 @@ func primeFactorsBrute(n int) []int {
 // then checks the odd factors from 3 up to sqrt(n). If n is a prime number
 // greater than 2, it is added to the slice of factors.
 func primeFactors(n int) []int {
+	return primeFactorsTraced(n, nil)
+}
+
+// primeFactorsTraced is primeFactors reporting its steps to t, which may be
+// nil: each trial division as a trace.Divide event and each test of the
+// loop bound i*i <= n as a trace.Compare event.
+func primeFactorsTraced(n int, t trace.Tracer) []int {
 	var factors []int
 
 	// Divide out the factor of 2
-	for n%2 == 0 {
+	for {
+		if t != nil {
+			traceDivision(n, 2, t)
+		}
+		if n%2 != 0 {
+			break
+		}
 		factors = append(factors, 2)
 		n = n / 2
 	}
 
 	// Check for the odd factors from 3 up to sqrt(n)
-	for i := 3; i*i <= n; i = i + 2 {
-		for n%i == 0 {
+	for i := 3; atMost(i*i, n, t); i = i + 2 {
+		for {
+			if t != nil {
+				traceDivision(n, i, t)
+			}
+			if n%i != 0 {
+				break
+			}
 			factors = append(factors, i)
 			n = n / i
 		}
@@ -44,3 +65,36 @@ func primeFactors(n int) []int {
 
 	return factors
 }
+
+// atMost reports whether a <= b, tracing the comparison to t. The event is
+// built out of line, in traceComparison, so that atMost is small enough to
+// inline and an untraced call costs only the nil check.
+func atMost(a, b int, t trace.Tracer) bool {
+	if t != nil {
+		traceComparison(a, b, t)
+	}
+	return a <= b
+}
+
+// traceDivision sends the trial division of n by d to t.
+func traceDivision(n, d int, t trace.Tracer) {
+	t.Event(trace.Event{Kind: trace.Divide, A: n, B: d, Result: n % d})
+}
+
+// traceComparison sends the comparison of a with b to t.
+func traceComparison(a, b int, t trace.Tracer) {
+	t.Event(trace.Comparison(a, b))
+}
+
+// PrimeFactors returns the prime factors of n in non-decreasing order,
+// repeated according to multiplicity, by the O(sqrt(n)) trial division of
+// primeFactors.
+func PrimeFactors(n int) []int {
+	return primeFactors(n)
+}
+
+// PrimeFactorsTraced is PrimeFactors reporting its trial divisions to t; see
+// package trace.
+func PrimeFactorsTraced(n int, t trace.Tracer) []int {
+	return primeFactorsTraced(n, t)
+}
@@ -0,0 +1,128 @@
+package prime_factors
+
+import (
+	"math/big"
+	"sort"
+)
+
+// TrialDivisionLimit is the largest n that PrimeFactorsBig factors with the
+// O(sqrt(n)) primeFactors method; about 10^6 trial divisions at most. It is
+// the bound up to which any O(sqrt(n)) trial division over an int is cheap.
+const TrialDivisionLimit = 1 << 40
+
+// smallPrimeLimit bounds the primes divided out before Pollard's rho starts.
+const smallPrimeLimit = 1000
+
+// PrimeFactorsBig returns the prime factors of n > 1 in non-decreasing order,
+// repeated according to multiplicity. It returns nil for n <= 1.
+//
+// Values up to TrialDivisionLimit are handed to primeFactors. Larger values
+// lose their small prime factors to trial division; what is left is split
+// recursively by Pollard's rho (Brent's variant) until every part passes the
+// Miller-Rabin/Baillie-PSW test of big.Int.ProbablyPrime.
+func PrimeFactorsBig(n *big.Int) []*big.Int {
+	if n.Cmp(big.NewInt(1)) <= 0 {
+		return nil
+	}
+	if n.IsInt64() && n.Int64() <= TrialDivisionLimit {
+		var factors []*big.Int
+		for _, f := range primeFactors(int(n.Int64())) {
+			factors = append(factors, big.NewInt(int64(f)))
+		}
+		return factors
+	}
+
+	var factors []*big.Int
+	rest := new(big.Int).Set(n)
+	quotient, remainder := new(big.Int), new(big.Int)
+	for p := int64(2); p < smallPrimeLimit; p++ {
+		divisor := big.NewInt(p)
+		for {
+			quotient.QuoRem(rest, divisor, remainder)
+			if remainder.Sign() != 0 {
+				break
+			}
+			factors = append(factors, new(big.Int).Set(divisor))
+			rest.Set(quotient)
+		}
+	}
+
+	factors = splitFactors(rest, factors)
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Cmp(factors[j]) < 0 })
+	return factors
+}
+
+// splitFactors appends the prime factors of n to factors. n has no prime
+// factor below smallPrimeLimit.
+func splitFactors(n *big.Int, factors []*big.Int) []*big.Int {
+	if n.Cmp(big.NewInt(1)) == 0 {
+		return factors
+	}
+	if n.ProbablyPrime(20) {
+		return append(factors, new(big.Int).Set(n))
+	}
+
+	d := pollardRho(n)
+	factors = splitFactors(d, factors)
+	return splitFactors(new(big.Int).Quo(n, d), factors)
+}
+
+// pollardRho returns a non-trivial divisor of the odd composite n.
+//
+// The sequence x ← x^2 + c (mod n) eventually cycles modulo each prime factor
+// p of n after about sqrt(p) steps; gcd(|x - y|, n) exposes p when the
+// tortoise y and hare x meet modulo p. Brent's variant moves the tortoise
+// only at powers of two and batches the differences into one product per
+// gcd. A failed run (gcd = n) is retried with the next constant c.
+func pollardRho(n *big.Int) *big.Int {
+	const batch = 128
+
+	one := big.NewInt(1)
+	x, y, ys := new(big.Int), new(big.Int), new(big.Int)
+	q, g, diff := new(big.Int), new(big.Int), new(big.Int)
+
+	step := func(v, c *big.Int) {
+		v.Mul(v, v)
+		v.Add(v, c)
+		v.Mod(v, n)
+	}
+
+	for c := int64(1); ; c++ {
+		constant := big.NewInt(c)
+		y.SetInt64(2)
+		q.SetInt64(1)
+		g.SetInt64(1)
+
+		for r := 1; g.Cmp(one) == 0; r *= 2 {
+			x.Set(y)
+			for i := 0; i < r; i++ {
+				step(y, constant)
+			}
+			for k := 0; k < r && g.Cmp(one) == 0; k += batch {
+				ys.Set(y)
+				for i := 0; i < min(batch, r-k); i++ {
+					step(y, constant)
+					diff.Sub(x, y)
+					q.Mul(q, diff.Abs(diff))
+					q.Mod(q, n)
+				}
+				g.GCD(nil, nil, q, n)
+			}
+		}
+
+		if g.Cmp(n) == 0 {
+			// the batch overshot: redo it one step at a time
+			for {
+				step(ys, constant)
+				diff.Sub(x, ys)
+				g.GCD(nil, nil, diff.Abs(diff), n)
+				if g.Cmp(one) != 0 {
+					break
+				}
+			}
+		}
+		if g.Cmp(n) != 0 {
+			return new(big.Int).Set(g)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package prime_factors
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/stress"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPrimeFactorsBig tests PrimeFactorsBig on values handled by trial
+// division and on values beyond int64 that need Pollard's rho.
+func TestPrimeFactorsBig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		// Edge cases
+		{"Prime factors of 0", "0", nil},
+		{"Prime factors of 1", "1", nil},
+		{"Prime factors of 2", "2", []string{"2"}},
+
+		// Values handled by primeFactors
+		{"Prime factors of 360", "360", []string{"2", "2", "2", "3", "3", "5"}},
+		{"Prime factors of 2^40", "1099511627776", repeat("2", 40)},
+
+		// Large primes and semiprimes
+		{"Largest 64-bit prime", "18446744073709551557", []string{"18446744073709551557"}},
+		{"Product of two 10-digit primes", "998244359987710471", []string{"998244353", "1000000007"}},
+		{"Square of the largest 32-bit prime", "18446744030759878681", []string{"4294967291", "4294967291"}},
+		{"Product beyond 2^64", "1000000007039000000273", []string{"1000000007", "1000000000039"}},
+		{"F6 = 2^64 + 1", "18446744073709551617", []string{"274177", "67280421310721"}},
+
+		// Mixed small and large factors
+		{"Factorial of 25", "15511210043330985984000000",
+			[]string{"2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2",
+				"3", "3", "3", "3", "3", "3", "3", "3", "3", "3", "5", "5", "5", "5", "5", "5", "7", "7", "7",
+				"11", "11", "13", "17", "19", "23"}},
+		{"Power of two times small and large primes", "6442450944251255586816",
+			append(repeat("2", 31), "3", "1000000000039")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, _ := new(big.Int).SetString(tc.input, 10)
+			var actual []string
+			for _, f := range PrimeFactorsBig(n) {
+				actual = append(actual, f.String())
+			}
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+
+			// the product of the factors is the input
+			if len(tc.expected) > 0 {
+				product := big.NewInt(1)
+				for _, f := range PrimeFactorsBig(n) {
+					product.Mul(product, f)
+				}
+				assert.Equal(t, 0, product.Cmp(n))
+			}
+		})
+	}
+}
+
+// TestPrimeFactorsBigDistinct tests that repeated factors are distinct
+// values, so that changing one leaves the others alone.
+func TestPrimeFactorsBigDistinct(t *testing.T) {
+	for _, input := range []string{"18446744073709551616", "6442450944251255586816"} {
+		n, _ := new(big.Int).SetString(input, 10)
+		factors := PrimeFactorsBig(n)
+		for i := range factors {
+			for j := range i {
+				assert.NotSame(t, factors[j], factors[i], "%s: factors %d and %d", input, j, i)
+			}
+		}
+		factors[0].Mul(factors[0], big.NewInt(5))
+		assert.Equal(t, "2", factors[1].String())
+	}
+}
+
+// repeat returns a slice holding s n times.
+func repeat(s string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = s
+	}
+	return out
+}
+
+// TestPrimeFactorsBigStress compares the Pollard's rho path of PrimeFactorsBig
+// with primeFactors on random inputs just above TrialDivisionLimit.
+func TestPrimeFactorsBigStress(t *testing.T) {
+	stress.Check(t, stress.Pair[int, []string]{
+		Name:      "PrimeFactorsBig",
+		Reference: func(n int) []string { return decimalStrings(primeFactors(n)) },
+		Candidate: func(n int) []string {
+			var factors []string
+			for _, f := range PrimeFactorsBig(big.NewInt(int64(n))) {
+				factors = append(factors, f.String())
+			}
+			return factors
+		},
+		Gen:    stress.Ints(TrialDivisionLimit+1, 4*TrialDivisionLimit),
+		Shrink: stress.ShrinkInt(TrialDivisionLimit + 1),
+		Equal:  slices.Equal[[]string],
+	}, stress.Options{Iterations: 50})
+}
+
+// decimalStrings formats each of values in decimal.
+func decimalStrings(values []int) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = big.NewInt(int64(v)).String()
+	}
+	return result
+}
@@ -1,7 +1,11 @@
 package prime_factors
 
 import (
+	"github.com/ignoreAnt/go-dsa/gen"
+	"github.com/ignoreAnt/go-dsa/stress"
+	"github.com/ignoreAnt/go-dsa/trace"
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"testing"
 )
 
@@ -70,3 +74,70 @@ func TestPrimeFactors(t *testing.T) {
 		})
 	}
 }
+
+// TestPrimeFactorsStress compares primeFactors with primeFactorsBrute on
+// random inputs.
+func TestPrimeFactorsStress(t *testing.T) {
+	stress.Check(t, stress.Pair[int, []int]{
+		Name:      "primeFactors",
+		Reference: primeFactorsBrute,
+		Candidate: primeFactors,
+		Gen:       stress.SizedInts(1, 1000),
+		Shrink:    stress.ShrinkInt(1),
+		Equal:     slices.Equal[[]int],
+	}, stress.Options{})
+}
+
+// TestPrimeFactorsSemiprimes tests primeFactors on random products of two
+// primes, the hardest inputs for trial division, which must split into two
+// primes whose product is the input.
+func TestPrimeFactorsSemiprimes(t *testing.T) {
+	g := gen.New(1)
+	for range 50 {
+		n, err := g.Semiprime(1e9, 1e12)
+		assert.NoError(t, err)
+		factors := primeFactors(n)
+		assert.Len(t, factors, 2, "Expected: two factors of %d, Got: %v", n, factors)
+		if len(factors) == 2 {
+			assert.Equal(t, n, factors[0]*factors[1])
+		}
+		for _, f := range factors {
+			assert.Equal(t, []int{f}, primeFactors(f), "Expected: %d to be prime", f)
+		}
+	}
+}
+
+// TestPrimeFactorsTraced tests that tracing leaves the result unchanged, that
+// the trial divisions with remainder 0 find every factor but possibly a last
+// one above sqrt(n), and that only the final test of the loop bound fails.
+func TestPrimeFactorsTraced(t *testing.T) {
+	for _, n := range []int{1, 2, 12, 97, 360, 1001, 2 * 3 * 3 * 1009} {
+		var rec trace.Recorder
+		actual := PrimeFactorsTraced(n, &rec)
+		assert.Equal(t, primeFactors(n), actual, "n = %d", n)
+
+		var divisors, bounds []int
+		for _, e := range rec.Events {
+			switch e.Kind {
+			case trace.Divide:
+				assert.Equal(t, e.A%e.B, e.Result, "%v", e)
+				if e.Result == 0 {
+					divisors = append(divisors, e.B)
+				}
+			case trace.Compare:
+				bounds = append(bounds, e.Result)
+			default:
+				t.Errorf("unexpected event %v", e)
+			}
+		}
+
+		if len(divisors) < len(actual) {
+			divisors = append(divisors, actual[len(actual)-1])
+		}
+		assert.Equal(t, actual, divisors, "n = %d", n)
+		if assert.NotEmpty(t, bounds, "n = %d", n) {
+			assert.Equal(t, 1, bounds[len(bounds)-1], "n = %d", n)
+			assert.NotContains(t, bounds[:len(bounds)-1], 1, "n = %d", n)
+		}
+	}
+}
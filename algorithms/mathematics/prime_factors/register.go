@@ -0,0 +1,39 @@
+package prime_factors
+
+import (
+	"math/big"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+)
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/prime_factors"
+	registry.Register(registry.Algorithm{
+		Name:     "primeFactorsBrute",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Prime factorisation by dividing by every candidate up to n",
+		Time:     "O(n)",
+		Space:    "O(log n)",
+		Run:      func(n int) any { return primeFactorsBrute(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "primeFactors",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Prime factorisation by trial division up to sqrt(n)",
+		Time:     "O(sqrt n)",
+		Space:    "O(log n)",
+		Run:      func(n int) any { return primeFactors(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "PrimeFactorsBig",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Prime factorisation of big integers by trial division and Pollard's rho (Brent)",
+		Time:     "O(n^(1/4)) expected multiplications",
+		Space:    "O(log n)",
+		Run:      func(n int) any { return PrimeFactorsBig(big.NewInt(int64(n))) },
+	})
+}
@@ -1,5 +1,7 @@
 package prime_numbers
 
+import "math"
+
 // isPrime checks if a given integer is a prime number.
 //
 // A prime number is a natural number greater than 1 that has no positive divisors other than 1 and itself.
@@ -44,3 +46,23 @@ func isPrime(n int) bool {
 
 	return true
 }
+
+// IsPrime reports whether n is a prime number, by the 6k ± 1 trial division
+// of isPrime.
+func IsPrime(n int) bool {
+	return isPrime(n)
+}
+
+// IntSqrt returns floor(sqrt(n)) for n >= 0, the largest divisor trial
+// division has to try. It corrects the floating-point square root, which may
+// be one off either way for n beyond 2^52.
+func IntSqrt(n int) int {
+	r := int(math.Sqrt(float64(n)))
+	for r > 0 && r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
@@ -1,7 +1,9 @@
 package prime_numbers
 
 import (
+	"github.com/ignoreAnt/go-dsa/gen"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -66,3 +68,51 @@ func TestIsPrime(t *testing.T) {
 		})
 	}
 }
+
+// TestIsPrimeGenerated tests isPrime on random primes and composites of up to
+// twelve digits, beyond the hand-picked cases above.
+func TestIsPrimeGenerated(t *testing.T) {
+	g := gen.New(1)
+	for range 50 {
+		p, err := g.Prime(2, 1e12)
+		assert.NoError(t, err)
+		assert.True(t, isPrime(p), "Expected: %d to be prime", p)
+
+		c, err := g.Composite(4, 1e12)
+		assert.NoError(t, err)
+		assert.False(t, isPrime(c), "Expected: %d to be composite", c)
+
+		s, err := g.Semiprime(4, 1e12)
+		assert.NoError(t, err)
+		assert.False(t, isPrime(s), "Expected: %d to be composite", s)
+	}
+}
+
+// TestIntSqrt tests IntSqrt on squares and their neighbours, including those
+// near math.MaxInt where the floating-point square root is inexact.
+func TestIntSqrt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"Zero", 0, 0},
+		{"One", 1, 1},
+		{"Three", 3, 1},
+		{"Four", 4, 2},
+		{"Below a square", 99, 9},
+		{"Square", 100, 10},
+		{"Below a large square", 999999999999999999, 999999999},
+		{"Large square", 1e18, 1e9},
+		{"Below the square of 2^31.5", 3037000499*3037000499 - 1, 3037000498},
+		{"Square of 3037000499", 3037000499 * 3037000499, 3037000499},
+		{"MaxInt", math.MaxInt, 3037000499},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := IntSqrt(tc.input)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package prime_numbers
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/prime_numbers"
+	registry.Register(registry.Algorithm{
+		Name:     "isPrime",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Primality by 6k ± 1 trial division",
+		Time:     "O(sqrt n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return isPrime(n) },
+	})
+}
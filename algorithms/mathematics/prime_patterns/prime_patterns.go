@@ -0,0 +1,258 @@
+package prime_patterns
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/big"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/palindrome_number"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/sieve_of_eratosthenes"
+)
+
+// ErrInvalidGoldbachInput is returned when a Goldbach query is made for a
+// number that is not even or not greater than 2.
+var ErrInvalidGoldbachInput = errors.New("prime_patterns: n must be even and greater than 2")
+
+// segmentSize is the number of integers sieved at a time by Primes. It keeps
+// the working set in cache regardless of the size of the range.
+const segmentSize = 1 << 16
+
+// narrowRatio bounds the ranges that Primes tests number by number: those
+// narrower than sqrt(to) / narrowRatio, for which sieving the base primes up
+// to sqrt(to) costs more than a probable-prime test of each number.
+const narrowRatio = 1 << 10
+
+// Pair is a pair of primes P < Q.
+type Pair struct {
+	P, Q int
+}
+
+// Gap is the distance between two consecutive primes Start and End.
+type Gap struct {
+	Start, End int
+}
+
+// Length returns End - Start.
+func (g Gap) Length() int {
+	return g.End - g.Start
+}
+
+// Primes returns an iterator over the primes in [from, to] in increasing
+// order.
+//
+// It is a segmented Sieve of Eratosthenes: the primes up to sqrt(to) come
+// from sieveOfEratosthenes and are used to cross off composites in blocks of
+// segmentSize numbers, and the iteration stops as soon as the consumer
+// breaks. Memory is O(sqrt(to) + segmentSize) however wide the range is: the
+// base sieve takes a byte per number up to sqrt(to), some 3 GB for to near
+// math.MaxInt, and the base primes about 2 sqrt(to) / ln(to) ints. A range
+// narrower than sqrt(to) / narrowRatio is not sieved; each of its numbers is
+// tested with big.Int.ProbablyPrime, which is exact below 2^64, in O(1)
+// memory.
+func Primes(from, to int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		from = max(from, 2)
+		if from > to {
+			return
+		}
+
+		root := prime_numbers.IntSqrt(to)
+		if to-from < root/narrowRatio {
+			var b big.Int
+			for n := from; ; n++ {
+				if b.SetInt64(int64(n)).ProbablyPrime(0) && !yield(n) {
+					return
+				}
+				if n == to {
+					return
+				}
+			}
+		}
+
+		base := sieve_of_eratosthenes.SieveOfEratosthenes(root)
+		composite := make([]bool, segmentSize)
+		for low := from; ; low += segmentSize {
+			high := to
+			if to-low >= segmentSize {
+				high = low + segmentSize - 1
+			}
+
+			clear(composite)
+			for _, p := range base {
+				if p > high/p {
+					break
+				}
+				// first multiple of p in the segment, never below p*p; the
+				// offset is checked before it is added, so that low + offset
+				// cannot overflow past math.MaxInt
+				offset := (p - low%p) % p
+				if offset > high-low {
+					continue
+				}
+				start := max(p*p, low+offset)
+				for m := start; m <= high && m >= start; m += p {
+					composite[m-low] = true
+				}
+			}
+
+			// n stops at high rather than passing it, which for high ==
+			// math.MaxInt would wrap around
+			for n := low; ; n++ {
+				if !composite[n-low] && !yield(n) {
+					return
+				}
+				if n == high {
+					break
+				}
+			}
+			if high == to {
+				return
+			}
+		}
+	}
+}
+
+// PrimePairs returns an iterator over the pairs (p, p+gap) of primes with
+// from <= p and p+gap <= to, ordered by p. The primes need not be consecutive:
+// (5, 11) is a pair for gap 6 although 7 lies between them.
+//
+// A window of the primes seen in the last gap numbers is kept while
+// streaming, so each prime q is matched against q - gap in O(1) amortised.
+func PrimePairs(from, to, gap int) iter.Seq[Pair] {
+	return func(yield func(Pair) bool) {
+		if gap <= 0 {
+			return
+		}
+
+		var window []int
+		for q := range Primes(from, to) {
+			for len(window) > 0 && window[0] < q-gap {
+				window = window[1:]
+			}
+			if len(window) > 0 && window[0] == q-gap {
+				if !yield(Pair{window[0], q}) {
+					return
+				}
+			}
+			window = append(window, q)
+		}
+	}
+}
+
+// TwinPrimes returns an iterator over the prime pairs (p, p+2) in [from, to].
+func TwinPrimes(from, to int) iter.Seq[Pair] {
+	return PrimePairs(from, to, 2)
+}
+
+// CousinPrimes returns an iterator over the prime pairs (p, p+4) in [from, to].
+func CousinPrimes(from, to int) iter.Seq[Pair] {
+	return PrimePairs(from, to, 4)
+}
+
+// SexyPrimes returns an iterator over the prime pairs (p, p+6) in [from, to].
+func SexyPrimes(from, to int) iter.Seq[Pair] {
+	return PrimePairs(from, to, 6)
+}
+
+// MaximalPrimeGaps returns an iterator over the record gaps between
+// consecutive primes in [from, to]: each gap yielded is strictly longer than
+// every earlier gap in the range.
+func MaximalPrimeGaps(from, to int) iter.Seq[Gap] {
+	return func(yield func(Gap) bool) {
+		previous, record := 0, 0
+		for p := range Primes(from, to) {
+			if previous != 0 && p-previous > record {
+				record = p - previous
+				if !yield(Gap{previous, p}) {
+					return
+				}
+			}
+			previous = p
+		}
+	}
+}
+
+// GoldbachPartitions returns an iterator over the ways of writing the even
+// number n > 2 as p + q with primes p <= q, ordered by p.
+//
+// The primes up to n are sieved once, so each candidate p is checked in O(1).
+func GoldbachPartitions(n int) (iter.Seq[Pair], error) {
+	if n <= 2 || n%2 != 0 {
+		return nil, ErrInvalidGoldbachInput
+	}
+
+	return func(yield func(Pair) bool) {
+		primes := sieve_of_eratosthenes.SieveOfEratosthenes(n)
+		isPrime := make([]bool, n+1)
+		for _, p := range primes {
+			isPrime[p] = true
+		}
+
+		for _, p := range primes {
+			if p > n-p {
+				return
+			}
+			if isPrime[n-p] && !yield(Pair{p, n - p}) {
+				return
+			}
+		}
+	}, nil
+}
+
+// GoldbachCount returns the number of Goldbach partitions of the even number
+// n > 2.
+func GoldbachCount(n int) (int, error) {
+	partitions, err := GoldbachPartitions(n)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for range partitions {
+		count++
+	}
+	return count, nil
+}
+
+// Emirps returns an iterator over the emirps in [from, to]: primes whose
+// decimal reversal is a different prime, such as 13 and 31. The reversal may
+// fall outside the range and is checked with big.Int.ProbablyPrime, which is
+// exact below 2^64 and, unlike trial division, fast for large reversals.
+func Emirps(from, to int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var b big.Int
+		for p := range Primes(from, to) {
+			reversed, ok := reverseDigits(p)
+			if ok && reversed != p && b.SetInt64(int64(reversed)).ProbablyPrime(0) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// PalindromicPrimes returns an iterator over the primes in [from, to] that
+// read the same forward and backward, such as 131.
+func PalindromicPrimes(from, to int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for p := range Primes(from, to) {
+			if palindrome_number.IsPalindromeNumber(p) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// reverseDigits returns the decimal reversal of n >= 0 and whether it fits in
+// an int.
+func reverseDigits(n int) (int, bool) {
+	reversed := 0
+	for ; n > 0; n /= 10 {
+		if reversed > (math.MaxInt-n%10)/10 {
+			return 0, false
+		}
+		reversed = reversed*10 + n%10
+	}
+	return reversed, true
+}
@@ -0,0 +1,178 @@
+package prime_patterns
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrimes tests the segmented sieve on small ranges, on ranges that span
+// several segments and against trial division.
+func TestPrimes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to int
+		expected []int
+	}{
+		// Edge cases
+		{"Empty range", 10, 5, nil},
+		{"Negative range", -10, 1, nil},
+		{"Only 2", 0, 2, []int{2}},
+
+		// Small ranges
+		{"Primes up to 30", 1, 30, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+		{"Primes from 90 to 110", 90, 110, []int{97, 101, 103, 107, 109}},
+		{"Range without primes", 114, 126, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := slices.Collect(Primes(tc.from, tc.to))
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+
+	t.Run("Across segments agrees with isPrime", func(t *testing.T) {
+		from, to := 1_000_000-3*segmentSize/2, 1_000_000+segmentSize
+		var expected []int
+		for n := from; n <= to; n++ {
+			if prime_numbers.IsPrime(n) {
+				expected = append(expected, n)
+			}
+		}
+		assert.Equal(t, expected, slices.Collect(Primes(from, to)))
+	})
+
+	t.Run("Large offset", func(t *testing.T) {
+		actual := slices.Collect(Primes(1_000_000_000_000, 1_000_000_000_100))
+		assert.Equal(t, []int{1_000_000_000_039, 1_000_000_000_061, 1_000_000_000_063, 1_000_000_000_091}, actual)
+	})
+
+	t.Run("Up to MaxInt", func(t *testing.T) {
+		var expected []int
+		for _, d := range []int{471, 457, 409, 391, 387, 375, 301, 259, 165, 25} {
+			expected = append(expected, math.MaxInt-d+1) // the primes 2^63 - d
+		}
+		assert.Equal(t, expected, slices.Collect(Primes(math.MaxInt-500, math.MaxInt)))
+		assert.Equal(t, []int(nil), slices.Collect(Primes(math.MaxInt, math.MaxInt)))
+	})
+
+	t.Run("Narrow and sieved ranges agree with isPrime", func(t *testing.T) {
+		// sqrt(10^10) / narrowRatio is 97: the first range is tested number
+		// by number and the second sieved.
+		for _, width := range []int{90, 200} {
+			from, to := 10_000_000_000-width, 10_000_000_000
+			var expected []int
+			for n := from; n <= to; n++ {
+				if prime_numbers.IsPrime(n) {
+					expected = append(expected, n)
+				}
+			}
+			assert.Equal(t, expected, slices.Collect(Primes(from, to)), "width %d", width)
+		}
+	})
+
+	t.Run("Early break", func(t *testing.T) {
+		var first []int
+		for p := range Primes(2, 1<<40) {
+			if len(first) == 3 {
+				break
+			}
+			first = append(first, p)
+		}
+		assert.Equal(t, []int{2, 3, 5}, first)
+	})
+}
+
+// TestPrimePairs tests twin, cousin and sexy prime pairs.
+func TestPrimePairs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pairs    []Pair
+		expected []Pair
+	}{
+		{"Twin primes up to 100", slices.Collect(TwinPrimes(1, 100)),
+			[]Pair{{3, 5}, {5, 7}, {11, 13}, {17, 19}, {29, 31}, {41, 43}, {59, 61}, {71, 73}}},
+		{"Cousin primes up to 50", slices.Collect(CousinPrimes(1, 50)),
+			[]Pair{{3, 7}, {7, 11}, {13, 17}, {19, 23}, {37, 41}, {43, 47}}},
+		{"Sexy primes up to 50", slices.Collect(SexyPrimes(1, 50)),
+			[]Pair{{5, 11}, {7, 13}, {11, 17}, {13, 19}, {17, 23}, {23, 29}, {31, 37}, {37, 43}, {41, 47}}},
+		{"Both members must be in range", slices.Collect(TwinPrimes(4, 12)),
+			[]Pair{{5, 7}}},
+		{"Non-positive gap", slices.Collect(PrimePairs(1, 100, 0)), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.pairs, "Expected: %v, Got: %v", tc.expected, tc.pairs)
+		})
+	}
+}
+
+// TestMaximalPrimeGaps tests the record gaps between consecutive primes.
+func TestMaximalPrimeGaps(t *testing.T) {
+	expected := []Gap{{2, 3}, {3, 5}, {7, 11}, {23, 29}, {89, 97}, {113, 127}, {523, 541}, {887, 907}}
+	actual := slices.Collect(MaximalPrimeGaps(1, 1000))
+	assert.Equal(t, expected, actual, "Expected: %v, Got: %v", expected, actual)
+	assert.Equal(t, 20, actual[len(actual)-1].Length())
+
+	// records are relative to the range, so a range starting at 1000 begins afresh
+	assert.Equal(t, []Gap{{1009, 1013}, {1013, 1019}, {1021, 1031}, {1069, 1087}}, slices.Collect(MaximalPrimeGaps(1000, 1100)))
+}
+
+// TestGoldbach tests GoldbachPartitions and GoldbachCount.
+func TestGoldbach(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"4", 4, 1},
+		{"10", 10, 2},
+		{"28", 28, 2},
+		{"100", 100, 6},
+		{"1000", 1000, 28},
+		{"10000", 10000, 127},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := GoldbachCount(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+
+	partitions, err := GoldbachPartitions(10)
+	require.NoError(t, err)
+	assert.Equal(t, []Pair{{3, 7}, {5, 5}}, slices.Collect(partitions))
+
+	for _, n := range []int{-4, 0, 2, 7} {
+		_, err := GoldbachCount(n)
+		assert.ErrorIs(t, err, ErrInvalidGoldbachInput, "n = %d", n)
+	}
+}
+
+// TestEmirpsAndPalindromicPrimes tests Emirps and PalindromicPrimes.
+func TestEmirpsAndPalindromicPrimes(t *testing.T) {
+	assert.Equal(t,
+		[]int{13, 17, 31, 37, 71, 73, 79, 97, 107, 113, 149, 157, 167, 179, 199},
+		slices.Collect(Emirps(1, 200)))
+
+	// A narrow range of large numbers, whose reversals are as large
+	const from = 123456789012345678
+	assert.Equal(t,
+		[]int{123456789012346171, 123456789012346591, 123456789012346931, 123456789012348299, 123456789012348497},
+		slices.Collect(Emirps(from, from+3000)))
+
+	assert.Equal(t,
+		[]int{2, 3, 5, 7, 11, 101, 131, 151, 181, 191, 313, 353, 373, 383, 727, 757, 787, 797, 919, 929},
+		slices.Collect(PalindromicPrimes(1, 1000)))
+
+	_, ok := reverseDigits(1_999_999_999_999_999_999)
+	assert.False(t, ok, "reversal overflows int")
+}
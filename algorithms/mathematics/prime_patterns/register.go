@@ -0,0 +1,60 @@
+package prime_patterns
+
+import (
+	"iter"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+)
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/prime_patterns"
+	registry.Register(registry.Algorithm{
+		Name:     "Primes",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Primes in a range by a segmented Sieve of Eratosthenes",
+		Time:     "O(n log log n)",
+		Space:    "O(sqrt n)",
+		Run:      func(n int) any { return count(Primes(0, n)) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "TwinPrimes",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Twin prime pairs (p, p+2) in a range",
+		Time:     "O(n log log n)",
+		Space:    "O(sqrt n)",
+		Run:      func(n int) any { return count(TwinPrimes(0, n)) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "MaximalPrimeGaps",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Record gaps between consecutive primes in a range",
+		Time:     "O(n log log n)",
+		Space:    "O(sqrt n)",
+		Run:      func(n int) any { return count(MaximalPrimeGaps(0, n)) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "GoldbachCount",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Ways to write an even n as a sum of two primes",
+		Time:     "O(n log log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			c, _ := GoldbachCount(2*n + 4)
+			return c
+		},
+	})
+}
+
+// count returns the number of values in seq.
+func count[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+package rational
+
+import "math/big"
+
+// BigRational is an exact fraction of arbitrary-precision integers.
+//
+// Like Rational it is always kept in lowest terms with a positive
+// denominator. Operations return new values and never modify their operands.
+// The zero value is 0/1 and ready to use.
+type BigRational struct {
+	num *big.Int // nil in the zero value, read through numerator()
+	den *big.Int // nil in the zero value, read through denominator()
+}
+
+// bigZero and bigOne are 0 and 1, to be read and never modified.
+var bigZero, bigOne = new(big.Int), big.NewInt(1)
+
+// numerator returns num, treating the zero value as 0/1.
+func (r *BigRational) numerator() *big.Int {
+	if r.num == nil {
+		return bigZero
+	}
+	return r.num
+}
+
+// denominator returns den, treating the zero value as 0/1.
+func (r *BigRational) denominator() *big.Int {
+	if r.den == nil {
+		return bigOne
+	}
+	return r.den
+}
+
+// NewBig returns num/den in lowest terms. The arguments are copied.
+func NewBig(num, den *big.Int) (*BigRational, error) {
+	if den.Sign() == 0 {
+		return nil, ErrZeroDenominator
+	}
+	return normaliseBig(new(big.Int).Set(num), new(big.Int).Set(den)), nil
+}
+
+// normaliseBig reduces num/den in place and returns it as a BigRational.
+func normaliseBig(num, den *big.Int) *BigRational {
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+
+	g := new(big.Int).GCD(nil, nil, new(big.Int).Abs(num), den)
+	if g.Sign() != 0 && g.Cmp(bigOne) != 0 {
+		num.Quo(num, g)
+		den.Quo(den, g)
+	}
+	return &BigRational{num: num, den: den}
+}
+
+// Num returns a copy of the numerator in lowest terms.
+func (r *BigRational) Num() *big.Int {
+	return new(big.Int).Set(r.numerator())
+}
+
+// Den returns a copy of the denominator in lowest terms.
+func (r *BigRational) Den() *big.Int {
+	return new(big.Int).Set(r.denominator())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of r.
+func (r *BigRational) Sign() int {
+	return r.numerator().Sign()
+}
+
+// Add returns r + s.
+func (r *BigRational) Add(s *BigRational) *BigRational {
+	num := new(big.Int).Mul(r.numerator(), s.denominator())
+	num.Add(num, new(big.Int).Mul(s.numerator(), r.denominator()))
+	return normaliseBig(num, new(big.Int).Mul(r.denominator(), s.denominator()))
+}
+
+// Sub returns r - s.
+func (r *BigRational) Sub(s *BigRational) *BigRational {
+	num := new(big.Int).Mul(r.numerator(), s.denominator())
+	num.Sub(num, new(big.Int).Mul(s.numerator(), r.denominator()))
+	return normaliseBig(num, new(big.Int).Mul(r.denominator(), s.denominator()))
+}
+
+// Mul returns r * s.
+func (r *BigRational) Mul(s *BigRational) *BigRational {
+	return normaliseBig(new(big.Int).Mul(r.numerator(), s.numerator()), new(big.Int).Mul(r.denominator(), s.denominator()))
+}
+
+// Div returns r / s.
+func (r *BigRational) Div(s *BigRational) (*BigRational, error) {
+	if s.numerator().Sign() == 0 {
+		return nil, ErrZeroDenominator
+	}
+	return normaliseBig(new(big.Int).Mul(r.numerator(), s.denominator()), new(big.Int).Mul(r.denominator(), s.numerator())), nil
+}
+
+// Cmp compares r and s and returns -1 if r < s, 0 if r == s and +1 if r > s.
+func (r *BigRational) Cmp(s *BigRational) int {
+	left := new(big.Int).Mul(r.numerator(), s.denominator())
+	return left.Cmp(new(big.Int).Mul(s.numerator(), r.denominator()))
+}
+
+// Rational converts r to a Rational, or returns ErrOverflow if the numerator
+// or denominator does not fit in int64.
+func (r *BigRational) Rational() (Rational, error) {
+	if !r.numerator().IsInt64() || !r.denominator().IsInt64() {
+		return Rational{}, ErrOverflow
+	}
+	return Rational{num: r.numerator().Int64(), den: r.denominator().Int64()}, nil
+}
+
+// floor returns the largest integer not greater than r.
+func (r *BigRational) floor() *big.Int {
+	// Div rounds towards negative infinity for a positive divisor
+	return new(big.Int).Div(r.numerator(), r.denominator())
+}
@@ -0,0 +1,118 @@
+package rational
+
+import "errors"
+
+// ErrEmptyContinuedFraction is returned by FromContinuedFraction for an empty
+// list of terms.
+var ErrEmptyContinuedFraction = errors.New("rational: empty continued fraction")
+
+// ContinuedFraction returns the terms [a0; a1, a2, ...] of the regular
+// continued fraction of r, where a0 may be negative and every later term is
+// positive. The expansion is the sequence of quotients of the Euclidean
+// algorithm on num and den, so it has O(log den) terms.
+//
+// The last term is never 1 unless r is itself 1, which makes the expansion
+// unique.
+func (r Rational) ContinuedFraction() []int64 {
+	num, den := r.num, r.denominator()
+
+	var terms []int64
+	for {
+		a := floorDiv(num, den)
+		terms = append(terms, a)
+
+		// num - a*den lies in [0, den), so any wrap-around in a*den cancels out
+		num, den = den, num-a*den
+		if den == 0 {
+			return terms
+		}
+	}
+}
+
+// FromContinuedFraction evaluates the continued fraction [a0; a1, a2, ...].
+//
+// The convergents h(n)/k(n) follow h(n) = a(n)*h(n-1) + h(n-2) and
+// k(n) = a(n)*k(n-1) + k(n-2), starting from h(-1)/k(-1) = 1/0 and
+// h(-2)/k(-2) = 0/1.
+func FromContinuedFraction(terms []int64) (Rational, error) {
+	if len(terms) == 0 {
+		return Rational{}, ErrEmptyContinuedFraction
+	}
+
+	h, hPrev := int64(1), int64(0)
+	k, kPrev := int64(0), int64(1)
+	for _, a := range terms {
+		ah, ok1 := mulChecked(a, h)
+		nextH, ok2 := addChecked(ah, hPrev)
+		ak, ok3 := mulChecked(a, k)
+		nextK, ok4 := addChecked(ak, kPrev)
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			return Rational{}, ErrOverflow
+		}
+		h, hPrev = nextH, h
+		k, kPrev = nextK, k
+	}
+
+	if k == 0 {
+		return Rational{}, ErrZeroDenominator
+	}
+	return normalise(h, k)
+}
+
+// BestApproximation returns the fraction p/q closest to r with
+// 1 <= q <= maxDen. Ties are broken in favour of the smaller denominator.
+//
+// Every best approximation is a convergent or a semiconvergent of the
+// continued fraction of r. The convergents are followed until the next one
+// would exceed maxDen; the answer is then either the last convergent or the
+// largest semiconvergent (h(n-2) + t*h(n-1)) / (k(n-2) + t*k(n-1)) that still
+// fits.
+func (r Rational) BestApproximation(maxDen int64) (Rational, error) {
+	if maxDen < 1 {
+		return Rational{}, ErrZeroDenominator
+	}
+	if r.denominator() <= maxDen {
+		return r, nil
+	}
+
+	h, hPrev := int64(1), int64(0)
+	k, kPrev := int64(0), int64(1)
+	for _, a := range r.ContinuedFraction() {
+		ak, ok := mulChecked(a, k)
+		nextK, ok2 := addChecked(ak, kPrev)
+		if !ok || !ok2 || nextK > maxDen {
+			break
+		}
+		ah, ok3 := mulChecked(a, h)
+		nextH, ok4 := addChecked(ah, hPrev)
+		if !ok3 || !ok4 {
+			return Rational{}, ErrOverflow
+		}
+		h, hPrev = nextH, h
+		k, kPrev = nextK, k
+	}
+
+	convergent := Rational{num: h, den: k}
+	t := (maxDen - kPrev) / k
+	if t == 0 {
+		return convergent, nil
+	}
+
+	th, ok1 := mulChecked(t, h)
+	semiH, ok2 := addChecked(th, hPrev)
+	if !ok1 || !ok2 {
+		return Rational{}, ErrOverflow
+	}
+	semi := Rational{num: semiH, den: kPrev + t*k}
+
+	// the distances are compared exactly, as r - p/q may not fit in int64
+	x := r.Big()
+	semiDistance := x.Sub(semi.Big())
+	semiDistance.num.Abs(semiDistance.num)
+	convergentDistance := x.Sub(convergent.Big())
+	convergentDistance.num.Abs(convergentDistance.num)
+	if semiDistance.Cmp(convergentDistance) < 0 {
+		return semi, nil
+	}
+	return convergent, nil
+}
@@ -0,0 +1,84 @@
+package rational
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContinuedFraction tests ContinuedFraction and that FromContinuedFraction
+// inverts it.
+func TestContinuedFraction(t *testing.T) {
+	testCases := []struct {
+		name     string
+		num, den int64
+		expected []int64
+	}{
+		{"Zero", 0, 1, []int64{0}},
+		{"Integer", 5, 1, []int64{5}},
+		{"One half", 1, 2, []int64{0, 2}},
+		{"415/93", 415, 93, []int64{4, 2, 6, 7}},
+		{"355/113", 355, 113, []int64{3, 7, 16}},
+		{"Negative", -415, 93, []int64{-5, 1, 1, 6, 7}},
+		{"Consecutive Fibonacci numbers", 89, 55, []int64{1, 1, 1, 1, 1, 1, 1, 1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mustNew(t, tc.num, tc.den)
+			actual := r.ContinuedFraction()
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+
+			back, err := FromContinuedFraction(actual)
+			require.NoError(t, err)
+			assert.Equal(t, r, back)
+		})
+	}
+
+	_, err := FromContinuedFraction(nil)
+	assert.ErrorIs(t, err, ErrEmptyContinuedFraction)
+
+	// a non-canonical expansion still evaluates to the same value
+	r, err := FromContinuedFraction([]int64{0, 1, 1})
+	require.NoError(t, err)
+	assert.Equal(t, mustNew(t, 1, 2), r)
+}
+
+// TestBestApproximation tests BestApproximation against values found by a
+// brute-force search over every denominator up to the bound.
+func TestBestApproximation(t *testing.T) {
+	pi := mustNew(t, 314159265, 100000000)
+
+	testCases := []struct {
+		name     string
+		input    Rational
+		maxDen   int64
+		expected Rational
+	}{
+		// Convergents of pi
+		{"Pi, denominator 1", pi, 1, FromInt(3)},
+		{"Pi, denominator 7", pi, 7, mustNew(t, 22, 7)},
+		{"Pi, denominator 1000", pi, 1000, mustNew(t, 355, 113)},
+
+		// Semiconvergents
+		{"Pi, denominator 100", pi, 100, mustNew(t, 311, 99)},
+		{"Negative pi, denominator 57", mustNew(t, -314159265, 100000000), 57, mustNew(t, -179, 57)},
+		{"3/8, denominator 5", mustNew(t, 3, 8), 5, mustNew(t, 2, 5)},
+
+		// Ties and exact values
+		{"1/3, denominator 2 (tie)", mustNew(t, 1, 3), 2, mustNew(t, 1, 2)},
+		{"Already within bound", mustNew(t, 5, 7), 10, mustNew(t, 5, 7)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.input.BestApproximation(tc.maxDen)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+
+	_, err := pi.BestApproximation(0)
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+}
@@ -0,0 +1,256 @@
+package rational
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// ErrSyntax is returned by Parse and ParseBig for malformed input.
+var ErrSyntax = errors.New("rational: invalid syntax")
+
+// String formats r as "num/den", or as "num" when r is an integer.
+func (r Rational) String() string {
+	return r.Big().String()
+}
+
+// MixedString formats r as a mixed number such as "1 1/2" or "-2 3/4".
+// Proper fractions and integers are formatted as by String.
+func (r Rational) MixedString() string {
+	return r.Big().MixedString()
+}
+
+// DecimalString formats r in decimal notation with the repeating part of the
+// expansion in parentheses, e.g. "0.75", "0.(3)" or "-1.1(6)".
+//
+// At most maxDigits digits are produced after the point. If the expansion
+// has not terminated or started repeating by then it is cut off with "...".
+// A maxDigits of 0 or less gives the integer part alone, followed by "..."
+// unless r is an integer.
+func (r Rational) DecimalString(maxDigits int) string {
+	return r.Big().DecimalString(maxDigits)
+}
+
+// String formats r as "num/den", or as "num" when r is an integer.
+func (r *BigRational) String() string {
+	if r.denominator().IsInt64() && r.denominator().Int64() == 1 {
+		return r.numerator().String()
+	}
+	return r.numerator().String() + "/" + r.denominator().String()
+}
+
+// MixedString formats r as a mixed number such as "1 1/2" or "-2 3/4".
+// Proper fractions and integers are formatted as by String.
+func (r *BigRational) MixedString() string {
+	whole, rem := new(big.Int).QuoRem(new(big.Int).Abs(r.numerator()), r.denominator(), new(big.Int))
+	if whole.Sign() == 0 || rem.Sign() == 0 {
+		return r.String()
+	}
+
+	var sb strings.Builder
+	if r.numerator().Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(whole.String())
+	sb.WriteByte(' ')
+	sb.WriteString(rem.String())
+	sb.WriteByte('/')
+	sb.WriteString(r.denominator().String())
+	return sb.String()
+}
+
+// DecimalString formats r in decimal notation with the repeating part of the
+// expansion in parentheses. See Rational.DecimalString.
+//
+// The digits come from long division. The first remainder to reappear marks
+// the start of the repeating block, so each remainder is remembered together
+// with the position of the digit it produced.
+func (r *BigRational) DecimalString(maxDigits int) string {
+	whole, rem := new(big.Int).QuoRem(new(big.Int).Abs(r.numerator()), r.denominator(), new(big.Int))
+
+	var sb strings.Builder
+	if r.numerator().Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(whole.String())
+	if rem.Sign() == 0 {
+		return sb.String()
+	}
+
+	if maxDigits <= 0 {
+		sb.WriteString("...")
+		return sb.String()
+	}
+
+	var digits []byte
+	seen := make(map[string]int)
+	ten, digit := big.NewInt(10), new(big.Int)
+	for rem.Sign() != 0 {
+		// A remainder seen before closes the repetend even when the
+		// digits have just reached maxDigits.
+		key := rem.String()
+		if start, ok := seen[key]; ok {
+			sb.WriteByte('.')
+			sb.Write(digits[:start])
+			sb.WriteByte('(')
+			sb.Write(digits[start:])
+			sb.WriteByte(')')
+			return sb.String()
+		}
+		if len(digits) == maxDigits {
+			break
+		}
+		seen[key] = len(digits)
+
+		rem.Mul(rem, ten)
+		digit.QuoRem(rem, r.denominator(), rem)
+		digits = append(digits, byte('0'+digit.Int64()))
+	}
+
+	sb.WriteByte('.')
+	sb.Write(digits)
+	if rem.Sign() != 0 {
+		sb.WriteString("...")
+	}
+	return sb.String()
+}
+
+// Parse parses s as a Rational. It accepts the forms produced by String,
+// MixedString and DecimalString:
+//
+//	"3", "-3/4", "1 1/2", "-2 3/4", "0.75", "0.(3)", "-1.1(6)"
+//
+// ErrSyntax is returned for malformed input and ErrOverflow when the value
+// does not fit in int64.
+func Parse(s string) (Rational, error) {
+	r, err := ParseBig(s)
+	if err != nil {
+		return Rational{}, err
+	}
+	return r.Rational()
+}
+
+// ParseBig parses s as a BigRational. See Parse for the accepted forms.
+func ParseBig(s string) (*BigRational, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	var r *BigRational
+	var err error
+	switch {
+	case strings.ContainsAny(s, ".("):
+		r, err = parseDecimal(s)
+	case strings.Contains(s, " "):
+		r, err = parseMixed(s)
+	default:
+		r, err = parseFraction(s)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if negative {
+		r.num.Neg(r.num)
+	}
+	return r, nil
+}
+
+// parseFraction parses an unsigned "num/den" or "num".
+func parseFraction(s string) (*BigRational, error) {
+	numText, denText, isFraction := strings.Cut(s, "/")
+	num, ok := parseDigits(numText)
+	if !ok {
+		return nil, ErrSyntax
+	}
+
+	den := big.NewInt(1)
+	if isFraction {
+		if den, ok = parseDigits(denText); !ok {
+			return nil, ErrSyntax
+		}
+		if den.Sign() == 0 {
+			return nil, ErrZeroDenominator
+		}
+	}
+	return normaliseBig(num, den), nil
+}
+
+// parseMixed parses an unsigned "whole num/den".
+func parseMixed(s string) (*BigRational, error) {
+	wholeText, fractionText, _ := strings.Cut(s, " ")
+	whole, ok := parseDigits(wholeText)
+	if !ok || !strings.Contains(fractionText, "/") {
+		return nil, ErrSyntax
+	}
+
+	fraction, err := parseFraction(strings.TrimSpace(fractionText))
+	if err != nil {
+		return nil, err
+	}
+	return fraction.Add(&BigRational{num: whole, den: big.NewInt(1)}), nil
+}
+
+// parseDecimal parses an unsigned "int.frac(repeat)" where frac and the
+// repeating block are optional.
+//
+// With f fractional digits and a repeating block of k digits the value is
+// int + frac/10^f + repeat/(10^f * (10^k - 1)).
+func parseDecimal(s string) (*BigRational, error) {
+	intText, rest, _ := strings.Cut(s, ".")
+	fracText, repeatText, hasRepeat := strings.Cut(rest, "(")
+	if hasRepeat {
+		var closed bool
+		repeatText, closed = strings.CutSuffix(repeatText, ")")
+		if !closed || repeatText == "" {
+			return nil, ErrSyntax
+		}
+	}
+	if intText == "" && fracText == "" && repeatText == "" {
+		return nil, ErrSyntax
+	}
+
+	value := &BigRational{num: big.NewInt(0), den: big.NewInt(1)}
+	if intText != "" {
+		whole, ok := parseDigits(intText)
+		if !ok {
+			return nil, ErrSyntax
+		}
+		value.num = whole
+	}
+
+	scale := pow10(len(fracText))
+	if fracText != "" {
+		frac, ok := parseDigits(fracText)
+		if !ok {
+			return nil, ErrSyntax
+		}
+		value = value.Add(normaliseBig(frac, new(big.Int).Set(scale)))
+	}
+
+	if repeatText != "" {
+		repeat, ok := parseDigits(repeatText)
+		if !ok {
+			return nil, ErrSyntax
+		}
+		nines := pow10(len(repeatText))
+		nines.Sub(nines, big.NewInt(1))
+		value = value.Add(normaliseBig(repeat, nines.Mul(nines, scale)))
+	}
+	return value, nil
+}
+
+// parseDigits parses a non-empty string of decimal digits.
+func parseDigits(s string) (*big.Int, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return nil, false
+	}
+	return new(big.Int).SetString(s, 10)
+}
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
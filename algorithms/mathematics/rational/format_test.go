@@ -0,0 +1,137 @@
+package rational
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFormat tests String, MixedString and DecimalString.
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		num, den int64
+		plain    string
+		mixed    string
+		decimal  string
+	}{
+		// Integers and proper fractions
+		{"Zero", 0, 1, "0", "0", "0"},
+		{"Integer", -12, 1, "-12", "-12", "-12"},
+		{"Three quarters", 3, 4, "3/4", "3/4", "0.75"},
+
+		// Improper fractions
+		{"Three halves", 3, 2, "3/2", "1 1/2", "1.5"},
+		{"Negative eleven quarters", -11, 4, "-11/4", "-2 3/4", "-2.75"},
+
+		// Repeating decimals
+		{"One third", 1, 3, "1/3", "1/3", "0.(3)"},
+		{"Seven sixths", -7, 6, "-7/6", "-1 1/6", "-1.1(6)"},
+		{"One seventh", 1, 7, "1/7", "1/7", "0.(142857)"},
+		{"Mixed period", 1, 12, "1/12", "1/12", "0.08(3)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mustNew(t, tc.num, tc.den)
+			assert.Equal(t, tc.plain, r.String())
+			assert.Equal(t, tc.mixed, r.MixedString())
+			assert.Equal(t, tc.decimal, r.DecimalString(50))
+		})
+	}
+
+	t.Run("Truncated expansion", func(t *testing.T) {
+		assert.Equal(t, "0.01020408...", mustNew(t, 1, 98).DecimalString(8))
+	})
+
+	limits := []struct {
+		name      string
+		num, den  int64
+		maxDigits int
+		expected  string
+	}{
+		// The repetend closes exactly at the cut-off
+		{"One third in one digit", 1, 3, 1, "0.(3)"},
+		{"One seventh in six digits", 1, 7, 6, "0.(142857)"},
+		{"Mixed period in three digits", 1, 12, 3, "0.08(3)"},
+		{"Mixed period in two digits", 1, 12, 2, "0.08..."},
+		{"Terminating in its digits", 3, 4, 2, "0.75"},
+		{"Terminating in one digit fewer", 3, 4, 1, "0.7..."},
+
+		// No digits after the point
+		{"One third in no digits", 1, 3, 0, "0..."},
+		{"Seven sixths in no digits", -7, 6, 0, "-1..."},
+		{"Three quarters in negative digits", 3, 4, -2, "0..."},
+		{"Integer in no digits", -12, 1, 0, "-12"},
+	}
+	for _, tc := range limits {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mustNew(t, tc.num, tc.den).DecimalString(tc.maxDigits)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+}
+
+// TestParse tests Parse on every supported form and on malformed input.
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		err      error
+	}{
+		// Fractions and integers
+		{"Integer", "42", "42", nil},
+		{"Fraction", "6/8", "3/4", nil},
+		{"Negative fraction", "-6/8", "-3/4", nil},
+		{"Plus sign and spaces", "  +1/2 ", "1/2", nil},
+
+		// Mixed numbers
+		{"Mixed", "1 1/2", "3/2", nil},
+		{"Negative mixed", "-2 3/4", "-11/4", nil},
+
+		// Decimals
+		{"Terminating", "0.75", "3/4", nil},
+		{"No integer part", ".5", "1/2", nil},
+		{"Repeating", "0.(3)", "1/3", nil},
+		{"Repeating after prefix", "-1.1(6)", "-7/6", nil},
+		{"Same integer and fraction digits", "1.1", "11/10", nil},
+		{"Repeating nines", "0.(9)", "1", nil},
+		{"Long period", "0.(142857)", "1/7", nil},
+
+		// Errors
+		{"Empty", "", "0", ErrSyntax},
+		{"Letters", "abc", "0", ErrSyntax},
+		{"Zero denominator", "1/0", "0", ErrZeroDenominator},
+		{"Unclosed period", "0.(3", "0", ErrSyntax},
+		{"Empty period", "0.()", "0", ErrSyntax},
+		{"Mixed without fraction", "1 2", "0", ErrSyntax},
+		{"Two signs", "-+3", "0", ErrSyntax},
+		{"Two signs before a decimal", "+-0.5", "0", ErrSyntax},
+		{"Overflow", "99999999999999999999/2", "0", ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Parse(tc.input)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, actual.String(), "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		for _, r := range []Rational{mustNew(t, -7, 6), mustNew(t, 355, 113), mustNew(t, 1, 12)} {
+			for _, s := range []string{r.String(), r.MixedString(), r.DecimalString(200)} {
+				parsed, err := Parse(s)
+				assert.NoError(t, err)
+				assert.Equal(t, r, parsed, "round trip of %q", s)
+			}
+		}
+	})
+
+	t.Run("Big values", func(t *testing.T) {
+		r, err := ParseBig("-123456789012345678901234567890/10")
+		assert.NoError(t, err)
+		assert.Equal(t, "-12345678901234567890123456789", r.String())
+	})
+}
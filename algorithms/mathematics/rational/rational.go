@@ -0,0 +1,271 @@
+package rational
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+)
+
+// ErrZeroDenominator is returned when a fraction with denominator 0 is built
+// or when dividing by zero.
+var ErrZeroDenominator = errors.New("rational: zero denominator")
+
+// ErrOverflow is returned when the exact result does not fit in int64.
+var ErrOverflow = errors.New("rational: int64 overflow")
+
+// Rational is an exact fraction num/den over int64.
+//
+// Every constructor and operation keeps the value normalised: the denominator
+// is positive and gcd(num, den) = 1, so two equal values always have equal
+// fields. The zero value is 0/1 and ready to use.
+type Rational struct {
+	num int64
+	den int64 // 0 in the zero value, read through denominator()
+}
+
+// New returns num/den in lowest terms.
+func New(num, den int64) (Rational, error) {
+	if den == 0 {
+		return Rational{}, ErrZeroDenominator
+	}
+	return normalise(num, den)
+}
+
+// FromInt returns the integer n as a Rational.
+func FromInt(n int64) Rational {
+	return Rational{num: n, den: 1}
+}
+
+// Num returns the numerator in lowest terms. It carries the sign of the value.
+func (r Rational) Num() int64 {
+	return r.num
+}
+
+// Den returns the denominator in lowest terms. It is always positive.
+func (r Rational) Den() int64 {
+	return r.denominator()
+}
+
+// denominator returns den, treating the zero value as 0/1.
+func (r Rational) denominator() int64 {
+	if r.den == 0 {
+		return 1
+	}
+	return r.den
+}
+
+// normalise divides num and den by their gcd and moves the sign to num.
+func normalise(num, den int64) (Rational, error) {
+	// gcd works on magnitudes, which cannot represent -MinInt64; a factor
+	// of 2 shared with the other operand is removed first.
+	for (num == math.MinInt64 || den == math.MinInt64) && num%2 == 0 && den%2 == 0 {
+		num, den = num/2, den/2
+	}
+
+	if den < 0 {
+		if num == math.MinInt64 || den == math.MinInt64 {
+			return Rational{}, ErrOverflow
+		}
+		num, den = -num, -den
+	}
+	if num == math.MinInt64 {
+		// den is odd here, so the fraction is already in lowest terms
+		return Rational{num: num, den: den}, nil
+	}
+
+	g := int64(gcd.GCD(int(abs(num)), int(den)))
+	return Rational{num: num / g, den: den / g}, nil
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of r.
+func (r Rational) Sign() int {
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsInt reports whether r is an integer.
+func (r Rational) IsInt() bool {
+	return r.denominator() == 1
+}
+
+// Neg returns -r.
+func (r Rational) Neg() (Rational, error) {
+	if r.num == math.MinInt64 {
+		return Rational{}, ErrOverflow
+	}
+	return Rational{num: -r.num, den: r.denominator()}, nil
+}
+
+// Abs returns |r|.
+func (r Rational) Abs() (Rational, error) {
+	if r.num < 0 {
+		return r.Neg()
+	}
+	return Rational{num: r.num, den: r.denominator()}, nil
+}
+
+// Inv returns 1/r.
+func (r Rational) Inv() (Rational, error) {
+	if r.num == 0 {
+		return Rational{}, ErrZeroDenominator
+	}
+	return normalise(r.denominator(), r.num)
+}
+
+// Add returns r + s.
+//
+// The denominators are first divided by their gcd g, so the intermediate
+// values are a*(d/g) + c*(b/g) over (b/g)*d rather than the naive a*d + c*b
+// over b*d, which postpones overflow as far as possible.
+func (r Rational) Add(s Rational) (Rational, error) {
+	return r.combine(s, addChecked)
+}
+
+// Sub returns r - s.
+func (r Rational) Sub(s Rational) (Rational, error) {
+	return r.combine(s, subChecked)
+}
+
+// combine returns (a*(d/g) op c*(b/g)) / ((b/g)*d) for r = a/b and s = c/d.
+func (r Rational) combine(s Rational, op func(x, y int64) (int64, bool)) (Rational, error) {
+	a, b := r.num, r.denominator()
+	c, d := s.num, s.denominator()
+
+	g := int64(gcd.GCD(int(b), int(d)))
+	left, ok1 := mulChecked(a, d/g)
+	right, ok2 := mulChecked(c, b/g)
+	num, ok3 := op(left, right)
+	den, ok4 := mulChecked(b/g, d)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return Rational{}, ErrOverflow
+	}
+	return normalise(num, den)
+}
+
+// Mul returns r * s.
+//
+// Cross-cancelling gcd(a, d) and gcd(c, b) before multiplying keeps the
+// result in lowest terms without a final reduction.
+func (r Rational) Mul(s Rational) (Rational, error) {
+	a, b := r.num, r.denominator()
+	c, d := s.num, s.denominator()
+	if a == 0 || c == 0 {
+		return Rational{}, nil
+	}
+	if a == math.MinInt64 || c == math.MinInt64 {
+		return mulViaBig(r, s)
+	}
+
+	g1 := int64(gcd.GCD(int(abs(a)), int(d)))
+	g2 := int64(gcd.GCD(int(abs(c)), int(b)))
+	num, ok1 := mulChecked(a/g1, c/g2)
+	den, ok2 := mulChecked(b/g2, d/g1)
+	if !ok1 || !ok2 {
+		return Rational{}, ErrOverflow
+	}
+	return Rational{num: num, den: den}, nil
+}
+
+// mulViaBig handles products involving MinInt64, whose magnitude has no
+// int64 representation.
+func mulViaBig(r, s Rational) (Rational, error) {
+	return r.Big().Mul(s.Big()).Rational()
+}
+
+// Div returns r / s.
+func (r Rational) Div(s Rational) (Rational, error) {
+	inv, err := s.Inv()
+	if err != nil {
+		return Rational{}, err
+	}
+	return r.Mul(inv)
+}
+
+// Cmp compares r and s and returns -1 if r < s, 0 if r == s and +1 if r > s.
+func (r Rational) Cmp(s Rational) int {
+	left, ok1 := mulChecked(r.num, s.denominator())
+	right, ok2 := mulChecked(s.num, r.denominator())
+	if ok1 && ok2 {
+		switch {
+		case left < right:
+			return -1
+		case left > right:
+			return 1
+		}
+		return 0
+	}
+	return r.Big().Cmp(s.Big())
+}
+
+// Equal reports whether r and s represent the same value.
+func (r Rational) Equal(s Rational) bool {
+	return r.num == s.num && r.denominator() == s.denominator()
+}
+
+// Floor returns the largest integer not greater than r.
+func (r Rational) Floor() int64 {
+	return floorDiv(r.num, r.denominator())
+}
+
+// Float64 returns the nearest float64 to r.
+func (r Rational) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(big.NewInt(r.num), big.NewInt(r.denominator())).Float64()
+	return f
+}
+
+// Big returns r as a BigRational.
+func (r Rational) Big() *BigRational {
+	return &BigRational{num: big.NewInt(r.num), den: big.NewInt(r.denominator())}
+}
+
+// floorDiv returns floor(a / b) for b > 0.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// abs returns |n| for n > MinInt64.
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// addChecked returns a + b and whether the sum fits in int64.
+func addChecked(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// subChecked returns a - b and whether the difference fits in int64.
+func subChecked(a, b int64) (int64, bool) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// mulChecked returns a * b and whether the product fits in int64.
+func mulChecked(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (c < 0) != ((a < 0) != (b < 0)) || c/b != a {
+		return 0, false
+	}
+	return c, true
+}
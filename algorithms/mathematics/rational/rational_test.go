@@ -0,0 +1,171 @@
+package rational
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mustNew returns num/den and fails the test if it cannot be built.
+func mustNew(t *testing.T, num, den int64) Rational {
+	t.Helper()
+	r, err := New(num, den)
+	require.NoError(t, err)
+	return r
+}
+
+// TestNew tests that New normalises fractions to lowest terms with a positive
+// denominator.
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name        string
+		num, den    int64
+		expectedNum int64
+		expectedDen int64
+		err         error
+	}{
+		// Edge cases
+		{"Zero", 0, 5, 0, 1, nil},
+		{"Zero denominator", 1, 0, 0, 1, ErrZeroDenominator},
+		{"Integer", 7, 1, 7, 1, nil},
+
+		// Reduction and sign handling
+		{"Reduces 6/8", 6, 8, 3, 4, nil},
+		{"Negative numerator", -6, 8, -3, 4, nil},
+		{"Negative denominator", 6, -8, -3, 4, nil},
+		{"Both negative", -6, -8, 3, 4, nil},
+		{"Already reduced", 13, 17, 13, 17, nil},
+
+		// Extreme values
+		{"MinInt64 over 2", math.MinInt64, 2, math.MinInt64 / 2, 1, nil},
+		{"MinInt64 over odd", math.MinInt64, 3, math.MinInt64, 3, nil},
+		{"MinInt64 over -1 overflows", math.MinInt64, -1, 0, 1, ErrOverflow},
+		{"1 over MinInt64 overflows", 1, math.MinInt64, 0, 1, ErrOverflow},
+		{"2 over MinInt64", 2, math.MinInt64, -1, -(math.MinInt64 / 2), nil},
+		{"MaxInt64 over MaxInt64", math.MaxInt64, math.MaxInt64, 1, 1, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := New(tc.num, tc.den)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expectedNum, actual.Num(), "Expected: %v, Got: %v", tc.expectedNum, actual.Num())
+			assert.Equal(t, tc.expectedDen, actual.Den(), "Expected: %v, Got: %v", tc.expectedDen, actual.Den())
+		})
+	}
+}
+
+// TestArithmetic tests Add, Sub, Mul and Div, including results that must be
+// reduced and results that overflow int64.
+func TestArithmetic(t *testing.T) {
+	half := mustNew(t, 1, 2)
+	third := mustNew(t, 1, 3)
+	big1 := mustNew(t, math.MaxInt64, 2)
+
+	testCases := []struct {
+		name     string
+		op       func() (Rational, error)
+		expected string
+		err      error
+	}{
+		{"1/2 + 1/3", func() (Rational, error) { return half.Add(third) }, "5/6", nil},
+		{"1/2 - 1/3", func() (Rational, error) { return half.Sub(third) }, "1/6", nil},
+		{"1/3 - 1/2", func() (Rational, error) { return third.Sub(half) }, "-1/6", nil},
+		{"1/2 * 1/3", func() (Rational, error) { return half.Mul(third) }, "1/6", nil},
+		{"1/2 / 1/3", func() (Rational, error) { return half.Div(third) }, "3/2", nil},
+		{"1/2 + 1/2", func() (Rational, error) { return half.Add(half) }, "1", nil},
+		{"Zero value + 1/2", func() (Rational, error) { return Rational{}.Add(half) }, "1/2", nil},
+		{"Cross cancellation", func() (Rational, error) { return mustNew(t, 4, 9).Mul(mustNew(t, 3, 8)) }, "1/6", nil},
+		{"Product of large values", func() (Rational, error) {
+			return mustNew(t, math.MaxInt64, 3).Mul(mustNew(t, 3, math.MaxInt64))
+		}, "1", nil},
+		{"MinInt64 times 1/2", func() (Rational, error) { return FromInt(math.MinInt64).Mul(half) }, "-4611686018427387904", nil},
+
+		// Errors
+		{"Divide by zero", func() (Rational, error) { return half.Div(Rational{}) }, "0", ErrZeroDenominator},
+		{"Sum overflows", func() (Rational, error) { return big1.Add(big1) }, "0", ErrOverflow},
+		{"Product overflows", func() (Rational, error) { return big1.Mul(big1) }, "0", ErrOverflow},
+		{"Difference overflows", func() (Rational, error) { return FromInt(math.MinInt64).Sub(FromInt(1)) }, "0", ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.op()
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, actual.String(), "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+}
+
+// TestCompare tests Cmp, Equal, Sign, Floor and Float64.
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     Rational
+		expected int
+	}{
+		{"Less", mustNew(t, 1, 3), mustNew(t, 1, 2), -1},
+		{"Greater", mustNew(t, 2, 3), mustNew(t, 1, 2), 1},
+		{"Equal", mustNew(t, 2, 4), mustNew(t, 1, 2), 0},
+		{"Negative", mustNew(t, -1, 2), mustNew(t, 1, 3), -1},
+		{"Zero value equals 0/7", Rational{}, mustNew(t, 0, 7), 0},
+		{"Needs more than 64 bits", mustNew(t, math.MaxInt64, math.MaxInt64-1), mustNew(t, math.MaxInt64-1, math.MaxInt64-2), -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.a.Cmp(tc.b))
+			assert.Equal(t, -tc.expected, tc.b.Cmp(tc.a))
+			assert.Equal(t, tc.expected == 0, tc.a.Equal(tc.b))
+		})
+	}
+
+	assert.Equal(t, -1, mustNew(t, -7, 2).Sign())
+	assert.Equal(t, 0, Rational{}.Sign())
+	assert.Equal(t, int64(-4), mustNew(t, -7, 2).Floor())
+	assert.Equal(t, int64(3), mustNew(t, 7, 2).Floor())
+	assert.Equal(t, 0.75, mustNew(t, 3, 4).Float64())
+	assert.True(t, FromInt(5).IsInt())
+}
+
+// TestBigRational tests the arbitrary-precision variant and its conversion to
+// Rational.
+func TestBigRational(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	r, err := NewBig(huge, new(big.Int).Mul(huge, big.NewInt(-3)))
+	require.NoError(t, err)
+	assert.Equal(t, "-1/3", r.String())
+
+	sum := r.Add(mustNew(t, 1, 3).Big())
+	assert.Equal(t, 0, sum.Sign())
+
+	product := mustNew(t, math.MaxInt64, 2).Big().Mul(mustNew(t, math.MaxInt64, 2).Big())
+	assert.Equal(t, "85070591730234615847396907784232501249/4", product.String())
+	_, err = product.Rational()
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	quotient, err := product.Div(product.Sub(mustNew(t, 1, 4).Big()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, quotient.Cmp(FromInt(1).Big()))
+
+	_, err = NewBig(big.NewInt(1), big.NewInt(0))
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+	_, err = product.Div(Rational{}.Big())
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+
+	back, err := mustNew(t, -22, 7).Big().Rational()
+	require.NoError(t, err)
+	assert.Equal(t, mustNew(t, -22, 7), back)
+
+	var zero BigRational
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, 0, zero.Sign())
+	assert.Equal(t, "1/2", zero.Add(mustNew(t, 1, 2).Big()).String())
+	assert.Equal(t, 0, zero.Cmp(Rational{}.Big()))
+	assert.Equal(t, "1", zero.Den().String())
+	_, err = product.Div(&zero)
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+}
@@ -0,0 +1,49 @@
+package rational
+
+import (
+	"strconv"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+)
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/rational"
+	registry.Register(registry.Algorithm{
+		Name:     "Rational.ContinuedFraction",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Continued fraction expansion of a rational by the Euclidean algorithm",
+		Time:     "O(log den)",
+		Space:    "O(log den)",
+		Run: func(n int) any {
+			r, _ := New(int64(n)*7+3, int64(n)+1)
+			return r.ContinuedFraction()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Rational.BestApproximation",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Closest fraction with a bounded denominator, from the convergents and semiconvergents",
+		Time:     "O(log den)",
+		Space:    "O(log den)",
+		Run: func(n int) any {
+			r, _ := New(314159265358979, 100000000000000)
+			b, _ := r.BestApproximation(int64(n) + 1)
+			return b
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Parse",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Parsing of fractions, mixed numbers and repeating decimals",
+		Time:     "O(len s)",
+		Space:    "O(len s)",
+		Run: func(n int) any {
+			r, _ := Parse("1.2(" + strconv.Itoa(n) + ")")
+			return r
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package sieve_of_eratosthenes
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/sieve_of_eratosthenes"
+	registry.Register(registry.Algorithm{
+		Name:     "primesBrute",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Primes up to n by trial division of every candidate",
+		Time:     "O(n sqrt n)",
+		Space:    "O(n / log n)",
+		Run:      func(n int) any { return primesBrute(n) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "sieveOfEratosthenes",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Primes up to n by crossing off multiples of each prime",
+		Time:     "O(n log log n)",
+		Space:    "O(n)",
+		Run:      func(n int) any { return sieveOfEratosthenes(n) },
+	})
+}
@@ -1,5 +1,7 @@
 package sieve_of_eratosthenes
 
+import "github.com/ignoreAnt/go-dsa/trace"
+
 // primesBrute returns a slice of all prime numbers from 2 up to n inclusive.
 // This function uses a brute-force method with a time complexity of O(n).
 func primesBrute(n int) []int {
@@ -33,6 +35,12 @@ func isPrime(num int) bool {
 // It iterates over the numbers from 2 to n and marks the multiples of each prime as non-prime.
 // Finally, it returns the list of all remaining prime numbers.
 func sieveOfEratosthenes(n int) []int {
+	return sieveOfEratosthenesTraced(n, nil)
+}
+
+// sieveOfEratosthenesTraced is sieveOfEratosthenes reporting each number it
+// crosses off to t as a trace.MarkComposite event; t may be nil.
+func sieveOfEratosthenesTraced(n int, t trace.Tracer) []int {
 	if n < 2 {
 		return []int{}
 	}
@@ -45,6 +53,9 @@ func sieveOfEratosthenes(n int) []int {
 	for p := 2; p*p <= n; p++ {
 		if isPrime[p] {
 			for i := p * p; i <= n; i += p {
+				if t != nil {
+					t.Event(trace.Event{Kind: trace.MarkComposite, A: i, B: p})
+				}
 				isPrime[i] = false
 			}
 		}
@@ -58,3 +69,15 @@ func sieveOfEratosthenes(n int) []int {
 	}
 	return primes
 }
+
+// SieveOfEratosthenes returns all prime numbers from 2 up to n inclusive in
+// increasing order, crossing out multiples as sieveOfEratosthenes does.
+func SieveOfEratosthenes(n int) []int {
+	return sieveOfEratosthenes(n)
+}
+
+// SieveOfEratosthenesTraced is SieveOfEratosthenes reporting each number it
+// crosses off to t; see package trace.
+func SieveOfEratosthenesTraced(n int, t trace.Tracer) []int {
+	return sieveOfEratosthenesTraced(n, t)
+}
@@ -1,7 +1,12 @@
 package sieve_of_eratosthenes
 
 import (
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+	"github.com/ignoreAnt/go-dsa/analysis"
+	"github.com/ignoreAnt/go-dsa/stress"
+	"github.com/ignoreAnt/go-dsa/trace"
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"testing"
 )
 
@@ -52,3 +57,70 @@ func TestSieveOfEratosthenes(t *testing.T) {
 		})
 	}
 }
+
+// TestSieveOfEratosthenesStress compares sieveOfEratosthenes with primesBrute,
+// and the trial division of isPrime with that of prime_numbers, on random
+// inputs.
+func TestSieveOfEratosthenesStress(t *testing.T) {
+	stress.Check(t, stress.Pair[int, []int]{
+		Name:      "sieveOfEratosthenes",
+		Reference: primesBrute,
+		Candidate: sieveOfEratosthenes,
+		Gen:       stress.SizedInts(-2, 100),
+		Shrink:    stress.ShrinkInt(0),
+		Equal:     slices.Equal[[]int],
+	}, stress.Options{})
+
+	stress.Check(t, stress.Pair[int, bool]{
+		Name:      "prime_numbers.IsPrime",
+		Reference: isPrime,
+		Candidate: prime_numbers.IsPrime,
+		Gen:       stress.Ints(-10, 1_000_000),
+		Shrink:    stress.ShrinkInt(0),
+	}, stress.Options{Iterations: 10000})
+}
+
+// TestSieveOfEratosthenesGrowth measures primesBrute and sieveOfEratosthenes
+// with the analysis package. Trial division up to sqrt(i) for every i <= n
+// costs up to O(n sqrt n / log n), the sieve O(n log log n), so the running
+// time of the brute-force version must grow markedly faster.
+func TestSieveOfEratosthenesGrowth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+
+	opts := analysis.Options{Start: 1 << 12, Steps: 7}
+	brute := analysis.Measure("primesBrute", func(n int) func(*analysis.Counter) {
+		return func(*analysis.Counter) { primesBrute(n) }
+	}, opts)
+	sieve := analysis.Measure("sieveOfEratosthenes", func(n int) func(*analysis.Counter) {
+		return func(*analysis.Counter) { sieveOfEratosthenes(n) }
+	}, opts)
+	t.Log(brute)
+	t.Log(sieve)
+
+	bruteExponent, sieveExponent := brute.Exponent(analysis.Time), sieve.Exponent(analysis.Time)
+	assert.Greater(t, bruteExponent, sieveExponent+0.15, "Expected: %.2f > %.2f + 0.15", bruteExponent, sieveExponent)
+	assert.Equal(t, analysis.Linear, sieve.Best(analysis.Bytes))
+}
+
+// TestSieveOfEratosthenesTraced tests that tracing leaves the result unchanged
+// and that every composite up to n, and nothing else, is marked by one of its
+// prime factors.
+func TestSieveOfEratosthenesTraced(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 30, 100} {
+		var rec trace.Recorder
+		actual := SieveOfEratosthenesTraced(n, &rec)
+		assert.Equal(t, sieveOfEratosthenes(n), actual, "n = %d", n)
+
+		marked := make(map[int]bool)
+		for _, e := range rec.Events {
+			assert.Equal(t, trace.MarkComposite, e.Kind)
+			assert.True(t, isPrime(e.B) && e.A%e.B == 0 && e.A > e.B, "%v", e)
+			marked[e.A] = true
+		}
+		for i := 2; i <= n; i++ {
+			assert.Equal(t, !isPrime(i), marked[i], "n = %d, i = %d", n, i)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package trailing_zeroes_factorial
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "algorithms/mathematics/trailing_zeroes_factorial"
+	registry.Register(registry.Algorithm{
+		Name:     "TrailingZeroesInFactorial",
+		Package:  pkg,
+		Category: "mathematics",
+		Summary:  "Trailing zeros of n! by counting factors of 5 (Legendre's formula)",
+		Time:     "O(log n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run:      func(n int) any { return TrailingZeroesInFactorial(n) },
+	})
+}
@@ -0,0 +1,151 @@
+// Package analysis estimates the order of growth of a function empirically.
+//
+// Measure runs a function on geometrically growing input sizes and records,
+// for each size, the time per run, the allocations per run and an optional
+// operation count. Each series is then fitted to the classes of the usual
+// complexity hierarchy, O(1) through O(2^n), and to a power law n^k whose
+// exponent k is a class-free summary of the growth:
+//
+//	report := analysis.Measure("primesBrute", func(n int) func(*analysis.Counter) {
+//		return func(*analysis.Counter) { primesBrute(n) }
+//	}, analysis.Options{Start: 1 << 10, Steps: 8})
+//	fmt.Println(report)
+//
+// Timings are noisy and include constant overheads, so fits are evidence, not
+// proof: two classes that differ by a log factor are hard to tell apart over a
+// few doublings of n. Operation counts are exact and fit far more reliably.
+package analysis
+
+import (
+	"runtime"
+	"time"
+)
+
+// Func prepares an input of size n and returns the code to measure on it.
+// Preparation is not timed. The returned function may be run many times, so
+// it must not consume its input; it reports the operations it performs to
+// ops, which it may also ignore.
+type Func func(n int) func(ops *Counter)
+
+// Counter counts the basic operations of a run, such as comparisons or loop
+// iterations. A nil *Counter discards the counts.
+type Counter struct {
+	count int64
+}
+
+// Inc counts one operation.
+func (c *Counter) Inc() {
+	if c != nil {
+		c.count++
+	}
+}
+
+// Add counts k operations.
+func (c *Counter) Add(k int) {
+	if c != nil {
+		c.count += int64(k)
+	}
+}
+
+// Count returns the number of operations counted so far.
+func (c *Counter) Count() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.count
+}
+
+// Options control the input sizes and the time spent on each. Zero fields
+// take the defaults listed with them.
+type Options struct {
+	// Sizes lists the input sizes to measure. If nil, the sizes are Start,
+	// Start*Factor, Start*Factor^2, ... for Steps sizes in all.
+	Sizes  []int
+	Start  int     // 16
+	Factor float64 // 2
+	Steps  int     // 10
+
+	// MinTime is the least time spent on each size; short runs are repeated
+	// until it is reached. 10ms by default.
+	MinTime time.Duration
+}
+
+// sizes returns the input sizes described by o.
+func (o Options) sizes() []int {
+	if o.Sizes != nil {
+		return o.Sizes
+	}
+	start, factor, steps := o.Start, o.Factor, o.Steps
+	if start <= 0 {
+		start = 16
+	}
+	if factor <= 1 {
+		factor = 2
+	}
+	if steps <= 0 {
+		steps = 10
+	}
+
+	sizes := make([]int, 0, steps)
+	for size := float64(start); len(sizes) < steps; size *= factor {
+		n := int(size)
+		if len(sizes) > 0 && n <= sizes[len(sizes)-1] {
+			n = sizes[len(sizes)-1] + 1
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes
+}
+
+// Sample holds the measurements for one input size, averaged over Runs runs.
+type Sample struct {
+	N      int
+	Runs   int
+	Time   time.Duration // per run
+	Allocs float64       // heap allocations per run
+	Bytes  float64       // bytes allocated per run
+	Ops    float64       // operations counted per run
+}
+
+// Measure runs f on each input size of opts and returns the measurements.
+func Measure(name string, f Func, opts Options) *Report {
+	minTime := opts.MinTime
+	if minTime <= 0 {
+		minTime = 10 * time.Millisecond
+	}
+
+	report := &Report{Name: name}
+	for _, n := range opts.sizes() {
+		run := f(n)
+		report.Samples = append(report.Samples, sample(n, run, minTime))
+	}
+	return report
+}
+
+// sample times run, doubling the number of runs until they take minTime.
+func sample(n int, run func(*Counter), minTime time.Duration) Sample {
+	runtime.GC()
+
+	var before, after runtime.MemStats
+	for runs := 1; ; runs *= 2 {
+		var ops Counter
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for range runs {
+			run(&ops)
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if elapsed >= minTime || runs >= 1<<30 {
+			return Sample{
+				N:      n,
+				Runs:   runs,
+				Time:   elapsed / time.Duration(runs),
+				Allocs: float64(after.Mallocs-before.Mallocs) / float64(runs),
+				Bytes:  float64(after.TotalAlloc-before.TotalAlloc) / float64(runs),
+				Ops:    float64(ops.Count()) / float64(runs),
+			}
+		}
+	}
+}
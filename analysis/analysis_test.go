@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCounter tests counting, including on a nil Counter.
+func TestCounter(t *testing.T) {
+	var c Counter
+	c.Inc()
+	c.Add(41)
+	assert.Equal(t, int64(42), c.Count())
+
+	var none *Counter
+	none.Inc()
+	none.Add(5)
+	assert.Equal(t, int64(0), none.Count())
+}
+
+// TestSizes tests the default and custom size sequences.
+func TestSizes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     Options
+		expected []int
+	}{
+		{"defaults", Options{}, []int{16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192}},
+		{"start and steps", Options{Start: 1000, Steps: 3}, []int{1000, 2000, 4000}},
+		{"slow factor stays increasing", Options{Start: 1, Factor: 1.2, Steps: 4}, []int{1, 2, 3, 4}},
+		{"explicit sizes", Options{Sizes: []int{3, 1, 4}}, []int{3, 1, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.opts.sizes()
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+}
+
+// TestMeasure tests that exact operation counts and allocation sizes are
+// fitted to the class of the code that produced them.
+func TestMeasure(t *testing.T) {
+	opts := Options{Start: 64, Steps: 6, MinTime: time.Microsecond}
+
+	testCases := []struct {
+		name     string
+		f        Func
+		metric   Metric
+		expected Class
+	}{
+		{"constant ops", func(n int) func(*Counter) {
+			return func(ops *Counter) { ops.Add(3) }
+		}, Ops, Constant},
+		{"logarithmic ops", func(n int) func(*Counter) {
+			return func(ops *Counter) {
+				for k := n; k > 1; k /= 2 {
+					ops.Inc()
+				}
+			}
+		}, Ops, Logarithmic},
+		{"linear ops", func(n int) func(*Counter) {
+			return func(ops *Counter) { ops.Add(n) }
+		}, Ops, Linear},
+		{"quadratic ops", func(n int) func(*Counter) {
+			return func(ops *Counter) {
+				for i := 0; i < n; i++ {
+					ops.Add(i)
+				}
+			}
+		}, Ops, Quadratic},
+		{"linear bytes", func(n int) func(*Counter) {
+			return func(*Counter) { sink = make([]byte, 100*n) }
+		}, Bytes, Linear},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := Measure(tc.name, tc.f, opts)
+			require.Len(t, report.Samples, 6)
+			assert.Equal(t, 64, report.Samples[0].N)
+			actual := report.Best(tc.metric)
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v\n%v", tc.expected, actual, report)
+		})
+	}
+}
+
+// sink keeps allocations in TestMeasure from being optimised away.
+var sink []byte
+
+// TestReportString tests the layout of a report.
+func TestReportString(t *testing.T) {
+	report := &Report{Name: "demo", Samples: []Sample{
+		{N: 10, Runs: 1, Time: 100, Ops: 10},
+		{N: 20, Runs: 1, Time: 200, Ops: 20},
+		{N: 40, Runs: 1, Time: 400, Ops: 40},
+	}}
+
+	expected := "demo\n" +
+		"           n        time/op    allocs/op       bytes/op            ops\n" +
+		"          10          100ns          0.0            0.0           10.0\n" +
+		"          20          200ns          0.0            0.0           20.0\n" +
+		"          40          400ns          0.0            0.0           40.0\n" +
+		"time:   best fit O(n)        (error 0.000), exponent 1.00\n" +
+		"ops:    best fit O(n)        (error 0.000), exponent 1.00\n"
+	assert.Equal(t, expected, report.String())
+}
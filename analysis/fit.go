@@ -0,0 +1,256 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Class is a complexity class: a function of n that a series is fitted to.
+type Class int
+
+// The candidate classes, in increasing order of growth.
+const (
+	Constant     Class = iota // 1
+	Logarithmic               // log n
+	SquareRoot                // sqrt n
+	Linear                    // n
+	Linearithmic              // n log n
+	Quadratic                 // n^2
+	Exponential               // 2^n
+)
+
+// Classes lists every Class in increasing order of growth.
+var Classes = []Class{Constant, Logarithmic, SquareRoot, Linear, Linearithmic, Quadratic, Exponential}
+
+// String returns the class in big O notation.
+func (c Class) String() string {
+	switch c {
+	case Constant:
+		return "O(1)"
+	case Logarithmic:
+		return "O(log n)"
+	case SquareRoot:
+		return "O(sqrt n)"
+	case Linear:
+		return "O(n)"
+	case Linearithmic:
+		return "O(n log n)"
+	case Quadratic:
+		return "O(n^2)"
+	case Exponential:
+		return "O(2^n)"
+	}
+	return fmt.Sprintf("Class(%d)", int(c))
+}
+
+// Eval returns the value of the class function at n. Logarithms are taken of
+// max(n, 2) so that they stay positive.
+func (c Class) Eval(n float64) float64 {
+	switch c {
+	case Constant:
+		return 1
+	case Logarithmic:
+		return math.Log2(max(n, 2))
+	case SquareRoot:
+		return math.Sqrt(n)
+	case Linear:
+		return n
+	case Linearithmic:
+		return n * math.Log2(max(n, 2))
+	case Quadratic:
+		return n * n
+	case Exponential:
+		return math.Exp2(n)
+	}
+	panic(fmt.Sprintf("analysis: unknown class %d", int(c)))
+}
+
+// Fit is the best approximation y ≈ Coefficient * Class(n) of a series.
+// Error is the root mean square of the relative residuals
+// (y - Coefficient*Class(n)) / y; 0 is a perfect fit.
+type Fit struct {
+	Class       Class
+	Coefficient float64
+	Error       float64
+}
+
+// FitClasses fits the points (ns[i], ys[i]) to every class and returns the
+// fits from best to worst. Points with ys[i] <= 0 carry no information about
+// growth and are ignored; if no point is left, the series is taken as
+// constant. A class that overflows at the given sizes, as 2^n does for large
+// n, gets an infinite Error.
+//
+// The coefficient minimises the relative rather than the absolute error, so
+// that the small sizes count as much as the large ones:
+//
+//	c = Σ g_i/y_i / Σ (g_i/y_i)^2   where g_i = Class(n_i)
+func FitClasses(ns []int, ys []float64) []Fit {
+	fits := make([]Fit, len(Classes))
+	for i, class := range Classes {
+		fits[i] = fitClass(class, ns, ys)
+	}
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].Error < fits[j].Error })
+	return fits
+}
+
+// fitClass fits the points to one class.
+func fitClass(class Class, ns []int, ys []float64) Fit {
+	var num, den float64
+	points := 0
+	for i, n := range ns {
+		if ys[i] <= 0 {
+			continue
+		}
+		r := class.Eval(float64(n)) / ys[i]
+		num += r
+		den += r * r
+		points++
+	}
+	if points == 0 {
+		if class == Constant {
+			return Fit{Class: class}
+		}
+		return Fit{Class: class, Error: math.Inf(1)}
+	}
+	if math.IsInf(den, 0) || math.IsNaN(den) {
+		return Fit{Class: class, Error: math.Inf(1)}
+	}
+
+	c := num / den
+	var squares float64
+	for i, n := range ns {
+		if ys[i] <= 0 {
+			continue
+		}
+		residual := 1 - c*class.Eval(float64(n))/ys[i]
+		squares += residual * residual
+	}
+	return Fit{Class: class, Coefficient: c, Error: math.Sqrt(squares / float64(points))}
+}
+
+// Exponent returns the exponent k of the power law y ≈ a * n^k that fits the
+// points best, by least squares on log y against log n. It is about 0 for
+// O(1), 0.5 for O(sqrt n), 1 for O(n) and 2 for O(n^2), with log factors
+// adding a little; it returns NaN with fewer than two usable points.
+func Exponent(ns []int, ys []float64) float64 {
+	var sx, sy, sxx, sxy float64
+	points := 0.0
+	for i, n := range ns {
+		if ys[i] <= 0 || n <= 0 {
+			continue
+		}
+		x, y := math.Log(float64(n)), math.Log(ys[i])
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+		points++
+	}
+	den := points*sxx - sx*sx
+	if points < 2 || den == 0 {
+		return math.NaN()
+	}
+	return (points*sxy - sx*sy) / den
+}
+
+// Metric selects one series of a Report.
+type Metric int
+
+// The measured series.
+const (
+	Time   Metric = iota // time per run
+	Allocs               // heap allocations per run
+	Bytes                // bytes allocated per run
+	Ops                  // counted operations per run
+)
+
+// String returns the name of the metric.
+func (m Metric) String() string {
+	switch m {
+	case Time:
+		return "time"
+	case Allocs:
+		return "allocs"
+	case Bytes:
+		return "bytes"
+	case Ops:
+		return "ops"
+	}
+	return fmt.Sprintf("Metric(%d)", int(m))
+}
+
+// Report is the result of Measure.
+type Report struct {
+	Name    string
+	Samples []Sample
+}
+
+// Series returns the sizes and the values of metric m.
+func (r *Report) Series(m Metric) ([]int, []float64) {
+	ns := make([]int, len(r.Samples))
+	ys := make([]float64, len(r.Samples))
+	for i, s := range r.Samples {
+		ns[i] = s.N
+		switch m {
+		case Time:
+			ys[i] = float64(s.Time)
+		case Allocs:
+			ys[i] = s.Allocs
+		case Bytes:
+			ys[i] = s.Bytes
+		case Ops:
+			ys[i] = s.Ops
+		}
+	}
+	return ns, ys
+}
+
+// Fits returns the fits of metric m from best to worst.
+func (r *Report) Fits(m Metric) []Fit {
+	return FitClasses(r.Series(m))
+}
+
+// Best returns the class that fits metric m best.
+func (r *Report) Best(m Metric) Class {
+	return r.Fits(m)[0].Class
+}
+
+// Exponent returns the power-law exponent of metric m.
+func (r *Report) Exponent(m Metric) float64 {
+	return Exponent(r.Series(m))
+}
+
+// String formats the samples as a table followed by the best fit of each
+// metric that was measured.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", r.Name)
+	fmt.Fprintf(&b, "%12s %14s %12s %14s %14s\n", "n", "time/op", "allocs/op", "bytes/op", "ops")
+	for _, s := range r.Samples {
+		fmt.Fprintf(&b, "%12d %14s %12.1f %14.1f %14.1f\n", s.N, s.Time.Round(time.Nanosecond), s.Allocs, s.Bytes, s.Ops)
+	}
+
+	for _, m := range []Metric{Time, Allocs, Bytes, Ops} {
+		_, ys := r.Series(m)
+		if !hasData(ys) {
+			continue
+		}
+		fit := r.Fits(m)[0]
+		fmt.Fprintf(&b, "%-7s best fit %-11s (error %.3f), exponent %.2f\n",
+			m.String()+":", fit.Class, fit.Error, r.Exponent(m))
+	}
+	return b.String()
+}
+
+// hasData reports whether any value of the series is positive.
+func hasData(ys []float64) bool {
+	for _, y := range ys {
+		if y > 0 {
+			return true
+		}
+	}
+	return false
+}
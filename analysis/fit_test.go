@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFitClasses tests that exact series of each class, scaled and with a
+// little noise, are fitted to the right class.
+func TestFitClasses(t *testing.T) {
+	var ns []int
+	for n := 16; n <= 1<<16; n *= 2 {
+		ns = append(ns, n)
+	}
+
+	for _, class := range Classes {
+		t.Run(class.String(), func(t *testing.T) {
+			sizes := ns
+			if class == Exponential {
+				sizes = []int{10, 12, 14, 16, 18, 20, 22, 24}
+			}
+			ys := make([]float64, len(sizes))
+			for i, n := range sizes {
+				noise := 1 + 0.02*float64(i%3-1)
+				ys[i] = 3.5 * class.Eval(float64(n)) * noise
+			}
+
+			fits := FitClasses(sizes, ys)
+			assert.Len(t, fits, len(Classes))
+			assert.Equal(t, class, fits[0].Class, "Expected: %v, Got: %v", class, fits[0].Class)
+			assert.InDelta(t, 3.5, fits[0].Coefficient, 0.1)
+			assert.Less(t, fits[0].Error, 0.05)
+		})
+	}
+}
+
+// TestFitClassesEdgeCases tests empty and overflowing series.
+func TestFitClassesEdgeCases(t *testing.T) {
+	fits := FitClasses([]int{1, 2, 3}, []float64{0, 0, 0})
+	assert.Equal(t, Fit{Class: Constant}, fits[0])
+	assert.True(t, math.IsInf(fits[1].Error, 1))
+
+	fits = FitClasses([]int{1 << 20, 1 << 21}, []float64{1e6, 2e6})
+	assert.Equal(t, Linear, fits[0].Class)
+	assert.Equal(t, Exponential, fits[len(fits)-1].Class)
+	assert.True(t, math.IsInf(fits[len(fits)-1].Error, 1))
+}
+
+// TestExponent tests the power-law exponent of exact series.
+func TestExponent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		f        func(float64) float64
+		expected float64
+	}{
+		{"constant", func(float64) float64 { return 7 }, 0},
+		{"square root", math.Sqrt, 0.5},
+		{"linear", func(n float64) float64 { return 2 * n }, 1},
+		{"cubic", func(n float64) float64 { return n * n * n }, 3},
+	}
+
+	ns := []int{10, 100, 1000, 10000}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ys := make([]float64, len(ns))
+			for i, n := range ns {
+				ys[i] = tc.f(float64(n))
+			}
+			actual := Exponent(ns, ys)
+			assert.InDelta(t, tc.expected, actual, 1e-9, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+
+	assert.True(t, math.IsNaN(Exponent([]int{5}, []float64{1})))
+}
+
+// TestClassString tests the names of the classes.
+func TestClassString(t *testing.T) {
+	assert.Equal(t, "O(n log n)", Linearithmic.String())
+	assert.Equal(t, "O(2^n)", Exponential.String())
+	assert.Equal(t, "Class(42)", Class(42).String())
+	assert.Equal(t, "ops", Ops.String())
+}
@@ -0,0 +1,18 @@
+// Package all registers every lesson under basics with package lesson.
+// Import it for its side effect:
+//
+//	import _ "github.com/ignoreAnt/go-dsa/basics/all"
+package all
+
+import (
+	_ "github.com/ignoreAnt/go-dsa/basics/concurrency"
+	_ "github.com/ignoreAnt/go-dsa/basics/constants"
+	_ "github.com/ignoreAnt/go-dsa/basics/control_flow"
+	_ "github.com/ignoreAnt/go-dsa/basics/error_handling"
+	_ "github.com/ignoreAnt/go-dsa/basics/functions"
+	_ "github.com/ignoreAnt/go-dsa/basics/hello"
+	_ "github.com/ignoreAnt/go-dsa/basics/interfaces"
+	_ "github.com/ignoreAnt/go-dsa/basics/pointers"
+	_ "github.com/ignoreAnt/go-dsa/basics/structs_and_methods"
+	_ "github.com/ignoreAnt/go-dsa/basics/variables"
+)
@@ -0,0 +1,57 @@
+package all
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/basics/lesson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files from the current output of the lessons:
+// go test ./basics/all -update.
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// address matches the pointers printed by the pointers lesson, which differ
+// from run to run.
+var address = regexp.MustCompile(`0x[0-9a-f]+`)
+
+// TestGolden tests the output of every lesson against testdata/<name>.golden.
+func TestGolden(t *testing.T) {
+	lessons := lesson.All()
+	require.Len(t, lessons, 10)
+
+	for _, l := range lessons {
+		t.Run(l.Name(), func(t *testing.T) {
+			var out strings.Builder
+			l.Run(&out)
+			actual := address.ReplaceAllString(out.String(), "0xADDRESS")
+
+			golden := filepath.Join("testdata", l.Name()+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, []byte(actual), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), actual, "output of %s differs from %s; run go test ./basics/all -update if the change is intended", l.Name(), golden)
+		})
+	}
+}
+
+// TestDeterministic tests that running a lesson twice gives the same output,
+// which the golden files rely on.
+func TestDeterministic(t *testing.T) {
+	for _, l := range lesson.All() {
+		t.Run(l.Name(), func(t *testing.T) {
+			var first, second strings.Builder
+			l.Run(&first)
+			l.Run(&second)
+			assert.Equal(t, address.ReplaceAllString(first.String(), "0xADDRESS"), address.ReplaceAllString(second.String(), "0xADDRESS"))
+		})
+	}
+}
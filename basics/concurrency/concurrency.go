@@ -1,7 +1,8 @@
-package main
+package concurrency
 
 import (
 	"fmt"
+	"io"
 	"sync"
 	"time"
 )
@@ -109,8 +110,9 @@ Notes:
 
 */
 
-// main demonstrates basic concurrency concepts using goroutines, channels, mutexes, and
-// select statements.
+// run demonstrates basic concurrency concepts using goroutines, channels, mutexes, and
+// select statements, writing to w. Every section waits for its goroutines before
+// printing, so the output is the same on every run.
 //
 // It covers five main topics:
 //
@@ -119,26 +121,39 @@ Notes:
 // 3. Buffered Channel: Creating a buffered channel with capacity 2.
 // 4. Select Statements: Using select to wait on multiple channel operations.
 // 5. Mutex and sync Package: Using mutexes to protect shared data in concurrent data structures.
-func main() {
+func run(w io.Writer) {
 	// 1. Goroutines
-	go printMessage("Hello from goroutine!")
+	// Waiting on done makes the goroutine's message appear before the next
+	// section instead of whenever the scheduler gets to it.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		printMessage(w, "Hello from goroutine!")
+	}()
+	<-done
 
 	// Using WaitGroup to wait for goroutines to complete
 	// 2. WaitGroup
+	// The goroutines may finish in either order, so each stores its message in
+	// its own slot and the messages are printed in order once both are done.
 	var wg sync.WaitGroup
+	messages := make([]string, 2)
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		printMessage("Hello from goroutine 1!")
+		messages[0] = "Hello from goroutine 1!"
 	}()
 
 	go func() {
 		defer wg.Done()
-		printMessage("Hello from goroutine 2!")
+		messages[1] = "Hello from goroutine 2!"
 	}()
 
 	wg.Wait()
+	for _, message := range messages {
+		printMessage(w, message)
+	}
 
 	//3. Using UnBuffered Channel
 	msgChan := make(chan string)
@@ -150,7 +165,7 @@ func main() {
 
 	msg := <-msgChan
 	if msg != "" {
-		fmt.Println(msg)
+		fmt.Fprintln(w, msg)
 	}
 
 	// 3. Buffered Channel
@@ -158,50 +173,52 @@ func main() {
 	bufferedChan <- 1
 	bufferedChan <- 2
 
-	fmt.Println(<-bufferedChan)
-	fmt.Println(<-bufferedChan)
+	fmt.Fprintln(w, <-bufferedChan)
+	fmt.Fprintln(w, <-bufferedChan)
 
 	// 4. Select Statements
-	ch1 := make(chan string)
+	// ch1 already holds a value when the first select runs, so that case is
+	// chosen; nothing is ever sent on ch2, so the second select times out.
+	ch1 := make(chan string, 1)
 	ch2 := make(chan string)
-
-	go func() {
-		time.Sleep(time.Second)
-		ch1 <- "One"
-	}()
-
-	go func() {
-		time.Sleep(2 * time.Second)
-		ch2 <- "Two"
-	}()
+	ch1 <- "One"
 
 	select {
 	case msg1 := <-ch1:
-		fmt.Println(msg1)
+		fmt.Fprintln(w, msg1)
 	case msg2 := <-ch2:
-		fmt.Println(msg2)
-	case <-time.After(3 * time.Second):
-		fmt.Println("Timeout!")
+		fmt.Fprintln(w, msg2)
+	case <-time.After(time.Second):
+		fmt.Fprintln(w, "Timeout!")
+	}
+
+	select {
+	case msg2 := <-ch2:
+		fmt.Fprintln(w, msg2)
+	case <-time.After(10 * time.Millisecond):
+		fmt.Fprintln(w, "Timeout!")
 	}
 
 	// 5. Mutex and sync Package
+	// The increments happen in any order, but the total after wg.Wait is
+	// always 5.
 	var counter int = 0
 	var mutex sync.Mutex
 	for i := 0; i < 5; i++ {
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			mutex.Lock()
 			defer mutex.Unlock()
 			counter++
-			fmt.Println("Counter:", counter)
 		}()
 	}
 
 	wg.Wait()
-	time.Sleep(2 * time.Second)
-
+	fmt.Fprintln(w, "Counter:", counter)
 }
 
-// printMessage Prints a given string to the console.
-func printMessage(s string) {
-	fmt.Println(s)
+// printMessage writes a given string to w.
+func printMessage(w io.Writer, s string) {
+	fmt.Fprintln(w, s)
 }
@@ -0,0 +1,7 @@
+package concurrency
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("concurrency", "goroutines, WaitGroup, channels, select and mutexes", run))
+}
@@ -1,6 +1,9 @@
-package main
+package constants
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 /**
 Overview of Constants
@@ -148,27 +151,27 @@ const (
 	Eight             // 1 << 3 = 8
 )
 
-// Prints examples of different types of constants:
+// run prints examples of different types of constants to w:
 // Basic constants, typed constants, grouped constants,
 // derived constants, and complex enumeration patterns using iota.
-func main() {
+func run(w io.Writer) {
 	// Print basic and typed constants
-	fmt.Println("Pi:", Pi)
-	fmt.Println("Threshold:", Threshold)
+	fmt.Fprintln(w, "Pi:", Pi)
+	fmt.Fprintln(w, "Threshold:", Threshold)
 
 	// Print grouped constants
-	fmt.Println("Screen Width:", Width)
-	fmt.Println("Screen Height:", Height)
+	fmt.Fprintln(w, "Screen Width:", Width)
+	fmt.Fprintln(w, "Screen Height:", Height)
 
 	// Print derived constants
-	fmt.Println("Area:", Area)
-	fmt.Println("HalfHeight:", HalfHeight)
-	fmt.Println("Greeting:", Greeting)
+	fmt.Fprintln(w, "Area:", Area)
+	fmt.Fprintln(w, "HalfHeight:", HalfHeight)
+	fmt.Fprintln(w, "Greeting:", Greeting)
 
 	// Print enumerated constants using iota
-	fmt.Println("Colors:", Red, Green, Blue)
+	fmt.Fprintln(w, "Colors:", Red, Green, Blue)
 
 	// Print complex enumeration patterns with iota
-	fmt.Println("Power Constants:", One, Two, Four, Eight)
+	fmt.Fprintln(w, "Power Constants:", One, Two, Four, Eight)
 
 }
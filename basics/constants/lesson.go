@@ -0,0 +1,7 @@
+package constants
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("constants", "typed, untyped, grouped and derived constants and iota", run))
+}
@@ -1,6 +1,9 @@
-package main
+package control_flow
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 /**
  * @author: Aakash B
@@ -115,44 +118,44 @@ Use Cases in Data Structures and Algorithms
 
 */
 
-func main() {
+func run(w io.Writer) {
 	// 1. If Statements
-	fmt.Println("If Statement Example:")
+	fmt.Fprintln(w, "If Statement Example:")
 	age := 20
 	if age >= 18 {
-		fmt.Println("You are eligible to vote.")
+		fmt.Fprintln(w, "You are eligible to vote.")
 	} else {
-		fmt.Println("You are not eligible to vote.")
+		fmt.Fprintln(w, "You are not eligible to vote.")
 	}
 
 	// 2. Short If Statement with initialization
 	if score := 90; score > 80 {
-		fmt.Println("Grade A")
+		fmt.Fprintln(w, "Grade A")
 	} else if score >= 75 {
-		fmt.Println("Grade B")
+		fmt.Fprintln(w, "Grade B")
 	} else {
-		fmt.Println("Grade C")
+		fmt.Fprintln(w, "Grade C")
 	}
 
 	// 3. For Loop
-	fmt.Println("For Loop Example:")
+	fmt.Fprintln(w, "For Loop Example:")
 
 	// Basic For Loop
 	for i := 0; i < 5; i++ {
-		fmt.Println("i : ", i)
+		fmt.Fprintln(w, "i : ", i)
 	}
 
 	// For loop as a while loop
 	j := 0
 	for j < 3 {
-		fmt.Println("j: ", j)
+		fmt.Fprintln(w, "j: ", j)
 		j++
 	}
 
 	// Infinite loop for with break
 	k := 0
 	for {
-		fmt.Println("Infinite loop, k: ", k)
+		fmt.Fprintln(w, "Infinite loop, k: ", k)
 		k++
 		if k == 2 {
 			break
@@ -160,34 +163,34 @@ func main() {
 	}
 
 	// 3.Switch Statements
-	fmt.Println("\nSwitch Case examples: ")
+	fmt.Fprintln(w, "\nSwitch Case examples: ")
 	day := 3
 
 	switch day {
 	case 1:
-		fmt.Println("Monday")
+		fmt.Fprintln(w, "Monday")
 	case 2:
-		fmt.Println("Tuesday")
+		fmt.Fprintln(w, "Tuesday")
 	case 3:
-		fmt.Println("Wednesday")
+		fmt.Fprintln(w, "Wednesday")
 	case 4:
-		fmt.Println("Thursday")
+		fmt.Fprintln(w, "Thursday")
 	case 5:
-		fmt.Println("Friday")
+		fmt.Fprintln(w, "Friday")
 	case 6:
-		fmt.Println("Saturday")
+		fmt.Fprintln(w, "Saturday")
 	case 7:
-		fmt.Println("Sunday")
+		fmt.Fprintln(w, "Sunday")
 	default:
-		fmt.Println("Unknown day")
+		fmt.Fprintln(w, "Unknown day")
 	}
 
 	// Switch with multiple values in a case
 	letter := "a"
 	switch letter {
 	case "a", "e", "i", "o", "u":
-		fmt.Println("Vowel")
+		fmt.Fprintln(w, "Vowel")
 	default:
-		fmt.Println("Consonant")
+		fmt.Fprintln(w, "Consonant")
 	}
 }
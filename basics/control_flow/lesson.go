@@ -0,0 +1,7 @@
+package control_flow
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("control_flow", "if, for and switch statements", run))
+}
@@ -1,8 +1,9 @@
-package main
+package error_handling
 
 import (
 	"errors"
 	"fmt"
+	"io"
 )
 
 /**
@@ -124,21 +125,21 @@ func divideWithCustomError(a, b int) (int, error) {
 	return a / b, nil
 }
 
-// main demonstrates idiomatic error handling with both the built-in error type and a custom error type.
-func main() {
+// run demonstrates idiomatic error handling with both the built-in error type and a custom error type, writing to w.
+func run(w io.Writer) {
 	// 1. Using the Built-in error Type
 	result, err := divide(10, 0)
 	if err != nil {
-		fmt.Println("Error:", err)
+		fmt.Fprintln(w, "Error:", err)
 	} else {
-		fmt.Println("Result:", result)
+		fmt.Fprintln(w, "Result:", result)
 	}
 
 	// 2. Custom Error Handling
 	result, err = divideWithCustomError(10, 0)
 	if err != nil {
-		fmt.Println("Error:", err)
+		fmt.Fprintln(w, "Error:", err)
 	} else {
-		fmt.Println("Result:", result)
+		fmt.Fprintln(w, "Result:", result)
 	}
 }
@@ -0,0 +1,7 @@
+package error_handling
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("error_handling", "the error type and custom error types", run))
+}
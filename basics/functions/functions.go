@@ -1,6 +1,9 @@
-package main
+package functions
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 /**
 Functions in Go
@@ -169,16 +172,16 @@ Use Cases in Data Structures and Algorithms:
 
 	Code Example: anonymousFunctionExample
 
-		func anonymousFunctionExample() {
+		func anonymousFunctionExample(w io.Writer) {
 			// Assigned to a variable
 			greet := func(name string) {
-				fmt.Println("Hello,", name)
+				fmt.Fprintln(w, "Hello,", name)
 			}
 			greet("Alice")
 
 			// Immediately invoked
 			func(msg string) {
-				fmt.Println(msg)
+				fmt.Fprintln(w, msg)
 			}("This is an immediately invoked function!")
 		}
 
@@ -249,40 +252,41 @@ Use Cases in Data Structures and Algorithms:
 
 */
 
-func main() {
+func run(w io.Writer) {
 
 	// 1. Basic Function Definition and Calling
-	fmt.Println(add(1, 2))
+	fmt.Fprintln(w, add(1, 2))
 
 	// 2. Parameters and Return Values
-	fmt.Println(subtract(5, 3))
+	fmt.Fprintln(w, subtract(5, 3))
 
 	// 3. Multiple Return Values
-	fmt.Println(divide(10, 5))
+	quotient, remainder := divide(10, 5)
+	fmt.Fprintln(w, quotient, remainder)
 
 	// 4. Named Return Values
 	rectangleProperties(5, 3)
 
 	// 5. Variadic Functions
-	fmt.Println(sum(1, 2, 3, 4, 5))
+	fmt.Fprintln(w, sum(1, 2, 3, 4, 5))
 
 	// 6. Passing Functions as Parameters
-	fmt.Println(applyOperation(2, 3, multiply))
+	fmt.Fprintln(w, applyOperation(2, 3, multiply))
 
 	// 7. Anonymous Functions
-	fmt.Println("\nAnonymous Functions Example:")
-	anonymousFunctionExample()
+	fmt.Fprintln(w, "\nAnonymous Functions Example:")
+	anonymousFunctionExample(w)
 
 	// 8. Closures
-	fmt.Println("\nClosures Example:")
+	fmt.Fprintln(w, "\nClosures Example:")
 	counter := closureExample()
-	fmt.Println("counter : ", counter())
-	fmt.Println("counter : ", counter())
-	fmt.Println("counter : ", counter())
+	fmt.Fprintln(w, "counter : ", counter())
+	fmt.Fprintln(w, "counter : ", counter())
+	fmt.Fprintln(w, "counter : ", counter())
 
 	// 9. Recursion
-	fmt.Println("\nRecursion Example:")
-	fmt.Println(factorial(5))
+	fmt.Fprintln(w, "\nRecursion Example:")
+	fmt.Fprintln(w, factorial(5))
 
 }
 
@@ -338,15 +342,15 @@ func multiply(a, b int) int {
 
 // 7. Anonymous Functions
 // anonymousFunctionExample demonstrates the use of an anonymous function.
-func anonymousFunctionExample() {
+func anonymousFunctionExample(w io.Writer) {
 	// Assigned to a variable
 	greet := func(name string) {
-		fmt.Println("Hello,", name)
+		fmt.Fprintln(w, "Hello,", name)
 	}
 	greet("Alice")
 
 	func(msg string) {
-		fmt.Println(msg)
+		fmt.Fprintln(w, msg)
 	}("This is an immediately invoked function!")
 }
 
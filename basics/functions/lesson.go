@@ -0,0 +1,7 @@
+package functions
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("functions", "multiple results, variadic functions, closures and recursion", run))
+}
@@ -1,4 +1,9 @@
-package main
+package hello
+
+import (
+	"fmt"
+	"io"
+)
 
 /**
 Explanation
@@ -34,7 +39,7 @@ For example, we’ll use fmt.Println to print out the structure of arrays,
 linked lists, and other data types to verify their state.
 */
 
-// Main function is the entry point of the program
-func main() {
-	println("Hello, Go language!")
+// run prints the greeting to w; it is the body of the hello lesson.
+func run(w io.Writer) {
+	fmt.Fprintln(w, "Hello, Go language!")
 }
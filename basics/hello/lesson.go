@@ -0,0 +1,7 @@
+package hello
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("hello", "the smallest Go program", run))
+}
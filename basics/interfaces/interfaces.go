@@ -1,7 +1,8 @@
-package main
+package interfaces
 
 import (
 	"fmt"
+	"io"
 	"math"
 )
 
@@ -113,48 +114,48 @@ func (r Rectangle) Area() float64 {
 }
 
 // printArea Prints the area of any Shape interface.
-func printArea(s Shape) {
-	fmt.Println("Area:", s.Area())
+func printArea(w io.Writer, s Shape) {
+	fmt.Fprintln(w, "Area:", s.Area())
 }
 
 // typeSwitchExample Demonstrates a type switch to determine the underlying type of a Shape interface.
 // It prints out the shape and its dimensions.
-func typeSwitchExample(s Shape) {
+func typeSwitchExample(w io.Writer, s Shape) {
 	switch t := s.(type) {
 	case Circle:
-		fmt.Println("Circle with radius:", t.Radius)
+		fmt.Fprintln(w, "Circle with radius:", t.Radius)
 	case Rectangle:
-		fmt.Println("Rectangle with width and height:", t.Width, t.Height)
+		fmt.Fprintln(w, "Rectangle with width and height:", t.Width, t.Height)
 	default:
-		fmt.Println("Unknown shape")
+		fmt.Fprintln(w, "Unknown shape")
 	}
 }
 
 // typeAssertion Demonstrates type assertion in Go.
 // It takes any Shape and uses type assertion to access the underlying Circle type.
 // If the type doesn't match Circle, it prints "Not a Circle!".
-func typeAssertion(s Shape) {
+func typeAssertion(w io.Writer, s Shape) {
 	if circle, ok := s.(Circle); ok {
-		fmt.Printf("Circle Radius: %.2f\n", circle.Radius)
+		fmt.Fprintf(w, "Circle Radius: %.2f\n", circle.Radius)
 	} else {
-		fmt.Println("Not a Circle!")
+		fmt.Fprintln(w, "Not a Circle!")
 	}
 }
-func main() {
+func run(w io.Writer) {
 	// Creating instances of Circle and Rectangle
 	circle := Circle{Radius: 5}
 	rectangle := Rectangle{Width: 4, Height: 6}
 
 	// Using printArea to print the areas of the shapes
-	printArea(circle)
-	printArea(rectangle)
+	printArea(w, circle)
+	printArea(w, rectangle)
 
 	// Using typeAssertion to access the Circle type
-	typeAssertion(circle)
-	typeAssertion(rectangle)
+	typeAssertion(w, circle)
+	typeAssertion(w, rectangle)
 
 	// Using typeSwitchExample to print the shape and its dimensions
-	typeSwitchExample(circle)
-	typeSwitchExample(rectangle)
+	typeSwitchExample(w, circle)
+	typeSwitchExample(w, rectangle)
 
 }
@@ -0,0 +1,7 @@
+package interfaces
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("interfaces", "interfaces, type assertions and type switches", run))
+}
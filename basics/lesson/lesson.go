@@ -0,0 +1,89 @@
+// Package lesson is a registry of the runnable lessons under basics, so that
+// one command can list and run them and tests can compare their output with
+// golden files.
+//
+// Each lesson package registers itself from an init function in a file named
+// lesson.go next to the code. Importing package basics/all links in and
+// registers every lesson:
+//
+//	import _ "github.com/ignoreAnt/go-dsa/basics/all"
+//
+//	for _, l := range lesson.All() {
+//		l.Run(os.Stdout)
+//	}
+package lesson
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Lesson is a runnable example program.
+type Lesson interface {
+	// Name is the identifier of the lesson, the name of its package.
+	Name() string
+	// Description is a one-line summary of what the lesson shows.
+	Description() string
+	// Run executes the lesson, writing its output to w. The output must be
+	// the same on every run.
+	Run(w io.Writer)
+}
+
+// New returns a Lesson with the given name and description that calls run.
+func New(name, description string, run func(w io.Writer)) Lesson {
+	return funcLesson{name: name, description: description, run: run}
+}
+
+// funcLesson is the Lesson returned by New.
+type funcLesson struct {
+	name        string
+	description string
+	run         func(w io.Writer)
+}
+
+func (l funcLesson) Name() string        { return l.name }
+func (l funcLesson) Description() string { return l.description }
+func (l funcLesson) Run(w io.Writer)     { l.run(w) }
+
+var (
+	mu      sync.RWMutex
+	lessons = make(map[string]Lesson)
+)
+
+// Register adds l to the registry. It panics if l has no name or if a lesson
+// with the same name is already registered; both are programming errors.
+func Register(l Lesson) {
+	if l.Name() == "" {
+		panic("lesson: registration without a name")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := lessons[l.Name()]; ok {
+		panic(fmt.Sprintf("lesson: %s registered twice", l.Name()))
+	}
+	lessons[l.Name()] = l
+}
+
+// All returns every registered lesson ordered by name.
+func All() []Lesson {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Lesson, 0, len(lessons))
+	for _, l := range lessons {
+		all = append(all, l)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
+
+// Lookup returns the lesson with the given name.
+func Lookup(name string) (Lesson, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	l, ok := lessons[name]
+	return l, ok
+}
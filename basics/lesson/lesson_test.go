@@ -0,0 +1,43 @@
+package lesson
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistry tests registering, listing and looking up lessons.
+func TestRegistry(t *testing.T) {
+	t.Cleanup(func() { lessons = make(map[string]Lesson) })
+
+	Register(New("zeta", "last", func(w io.Writer) { io.WriteString(w, "z\n") }))
+	Register(New("alpha", "first", func(w io.Writer) { io.WriteString(w, "a\n") }))
+
+	all := All()
+	require.Len(t, all, 2)
+	assert.Equal(t, "alpha", all[0].Name())
+	assert.Equal(t, "zeta", all[1].Name())
+
+	l, ok := Lookup("zeta")
+	require.True(t, ok)
+	assert.Equal(t, "last", l.Description())
+	var out strings.Builder
+	l.Run(&out)
+	assert.Equal(t, "z\n", out.String())
+
+	_, ok = Lookup("missing")
+	assert.False(t, ok)
+}
+
+// TestRegisterPanics tests that invalid registrations are rejected.
+func TestRegisterPanics(t *testing.T) {
+	t.Cleanup(func() { lessons = make(map[string]Lesson) })
+
+	run := func(io.Writer) {}
+	assert.Panics(t, func() { Register(New("", "no name", run)) })
+	Register(New("twice", "", run))
+	assert.Panics(t, func() { Register(New("twice", "", run)) })
+}
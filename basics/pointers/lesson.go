@@ -0,0 +1,7 @@
+package pointers
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("pointers", "taking addresses, dereferencing and nil pointers", run))
+}
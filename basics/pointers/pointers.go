@@ -1,6 +1,9 @@
-package main
+package pointers
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 /**
  * @author: Aakash B
@@ -89,24 +92,24 @@ Notes:
 
 */
 
-func main() {
+func run(w io.Writer) {
 	//1. Pointer Declaration and Initialization
 	var x int = 10
 	var p *int = &x
 	*p = 50
-	fmt.Println("Value of x:", x)
-	fmt.Println("Pointer p points to x:", p)
-	fmt.Println("Value at pointer p (dereferenced):", *p)
+	fmt.Fprintln(w, "Value of x:", x)
+	fmt.Fprintln(w, "Pointer p points to x:", p)
+	fmt.Fprintln(w, "Value at pointer p (dereferenced):", *p)
 
 	//2. Passing Pointers to Functions
 	*p = 100
-	fmt.Println("Updating value of x through pointer", x)
+	fmt.Fprintln(w, "Updating value of x through pointer", x)
 
 	//3. Nil Pointers
 	var nilPointer *int
-	fmt.Println("Nil pointer:", nilPointer)
+	fmt.Fprintln(w, "Nil pointer:", nilPointer)
 	if nilPointer == nil {
-		fmt.Println("nilPointer is nil")
+		fmt.Fprintln(w, "nilPointer is nil")
 	}
 
 	//4. Pointer Arithmetic
@@ -115,15 +118,15 @@ func main() {
 	var r *int = &y
 	*q = 30
 	*r = 40
-	fmt.Println("Value of y:", y)
-	fmt.Println("Pointer q points to y:", q)
-	fmt.Println("Pointer r points to y:", r)
-	fmt.Println("Value at pointer q (dereferenced):", *q)
-	fmt.Println("Value at pointer r (dereferenced):", *r)
+	fmt.Fprintln(w, "Value of y:", y)
+	fmt.Fprintln(w, "Pointer q points to y:", q)
+	fmt.Fprintln(w, "Pointer r points to y:", r)
+	fmt.Fprintln(w, "Value at pointer q (dereferenced):", *q)
+	fmt.Fprintln(w, "Value at pointer r (dereferenced):", *r)
 
 	//5. Passing Pointers to Functions
 	modifyValue(&x)
-	fmt.Println("Value of x after calling modifyValue:", x)
+	fmt.Fprintln(w, "Value of x after calling modifyValue:", x)
 }
 
 func modifyValue(i *int) {
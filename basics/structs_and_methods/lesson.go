@@ -0,0 +1,7 @@
+package structs_and_methods
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("structs_and_methods", "structs, methods with receivers and embedding", run))
+}
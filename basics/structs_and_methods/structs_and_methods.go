@@ -1,6 +1,9 @@
-package main
+package structs_and_methods
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 /**
  * @author: Aakash B
@@ -111,7 +114,7 @@ type Employee struct {
 	JobTitle string
 }
 
-func main() {
+func run(w io.Writer) {
 
 	//Initialize a Person struct
 	person := Person{
@@ -121,7 +124,7 @@ func main() {
 	}
 
 	//Print the full name of the person
-	fmt.Println("Full Name:", person.FullName())
+	fmt.Fprintln(w, "Full Name:", person.FullName())
 
 	//Initialize an Employee struct
 	employee := Employee{
@@ -134,9 +137,9 @@ func main() {
 	}
 
 	//Print the full name of the employee
-	fmt.Println("Full Name:", employee.FullName())
+	fmt.Fprintln(w, "Full Name:", employee.FullName())
 
 	//Print the job title of the employee
-	fmt.Println("Job Title:", employee.JobTitle)
+	fmt.Fprintln(w, "Job Title:", employee.JobTitle)
 
 }
@@ -0,0 +1,7 @@
+package variables
+
+import "github.com/ignoreAnt/go-dsa/basics/lesson"
+
+func init() {
+	lesson.Register(lesson.New("variables", "declarations, type inference and the basic types", run))
+}
@@ -1,6 +1,9 @@
-package main
+package variables
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 /**
 Variable Declaration:
@@ -73,35 +76,35 @@ It also covers the basic data types in Go, including booleans, integers,
 floating-point numbers, and strings.
 Finally, it touches on the different integer types available in Go.
 */
-func main() {
+func run(w io.Writer) {
 	// Explicit variable declaration with type
 	var x int = 10
-	fmt.Println("Explicitly declared variable:", x)
+	fmt.Fprintln(w, "Explicitly declared variable:", x)
 
 	// Type inference: Go will infer the type based on the assigned value
 	y := 20.5
-	fmt.Println("Type inferred variable:", y)
+	fmt.Fprintln(w, "Type inferred variable:", y)
 
 	// Short variable declaration (works only within functions)
 	z := "Hello, Go!"
-	fmt.Println("Short variable declaration:", z)
+	fmt.Fprintln(w, "Short variable declaration:", z)
 
 	// Multiple variable declaration
 	a, b, c := 1, 2, 3
-	fmt.Println("Multiple variable declaration:", a, b, c)
+	fmt.Fprintln(w, "Multiple variable declaration:", a, b, c)
 
 	// Basic data types
 	var boolVar bool = true                // boolean
 	var intVar int = 42                    // integer resolves automatically to architecture-dependent type integer
 	var floatVar float64 = 3.14            // floating-point
 	var stringVar string = "Hello, World!" // string
-	fmt.Println("Basic data types:", boolVar, intVar, floatVar, stringVar)
+	fmt.Fprintln(w, "Basic data types:", boolVar, intVar, floatVar, stringVar)
 
 	// Integer types
 	var int8Var int8 = 127                   // 8-bit integer
 	var int16Var int16 = 32767               // 16-bit integer
 	var int32Var int32 = 2147483647          // 32-bit integer
 	var int64Var int64 = 9223372036854775807 // 64-bit integer
-	fmt.Println("Integer types:", int8Var, int16Var, int32Var, int64Var)
+	fmt.Fprintln(w, "Integer types:", int8Var, int16Var, int32Var, int64Var)
 
 }
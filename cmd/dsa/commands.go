@@ -0,0 +1,356 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/divisors_of_number"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/factorial"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/lcm"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/palindrome_number"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_patterns"
+)
+
+// maxFactorial bounds the factorial command; 100000! has 456574 digits.
+const maxFactorial = 100_000
+
+// command is a dsa subcommand.
+type command struct {
+	summary string
+	run     func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// commandOrder lists the commands in the order shown by "dsa help".
+var commandOrder = []string{"factor", "primes", "gcd", "lcm", "divisors", "factorial", "palindrome", "list", "trace"}
+
+// commands maps each command name to its implementation.
+var commands = map[string]command{
+	"factor": {
+		summary: "prime factorisation of each number",
+		run:     numericCommand("factor", "factors", false, factorize),
+	},
+	"primes": {
+		summary: "primes in the range --from..--to",
+		run:     runPrimes,
+	},
+	"gcd": {
+		summary: "greatest common divisor of the numbers in each query",
+		run:     numericCommand("gcd", "gcd", true, greatestCommonDivisor),
+	},
+	"lcm": {
+		summary: "lowest common multiple of the numbers in each query",
+		run:     numericCommand("lcm", "lcm", true, lowestCommonMultiple),
+	},
+	"divisors": {
+		summary: "all divisors of each number, in increasing order",
+		run:     numericCommand("divisors", "divisors", false, divisors),
+	},
+	"factorial": {
+		summary: fmt.Sprintf("n! for each n up to %d", maxFactorial),
+		run:     numericCommand("factorial", "factorial", false, factorialOf),
+	},
+	"palindrome": {
+		summary: "whether each number reads the same backwards",
+		run:     numericCommand("palindrome", "palindrome", false, palindrome),
+	},
+	"list": {
+		summary: "catalogue of the algorithms, with --search, --category and --format",
+		run:     runList,
+	},
+	"trace": {
+		summary: "steps of sieve, factor or gcd, as text, --animate or --html",
+		run:     runTrace,
+	},
+}
+
+// errNeedsPositive is returned for inputs below 1 where they have no meaning.
+var errNeedsPositive = errors.New("needs a positive integer")
+
+// numericCommand returns the run function of a command that evaluates eval
+// once per query. A query is a single number, or every number on the command
+// line (or on one input line) when variadic is set.
+func numericCommand(name, field string, variadic bool, eval func([]*big.Int) (any, error)) func([]string, io.Reader, io.Writer, io.Writer) int {
+	return func(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+		flags := flag.NewFlagSet("dsa "+name, flag.ContinueOnError)
+		flags.SetOutput(stderr)
+		format := flags.String("format", "plain", "output format: plain, json or csv")
+		if err := flags.Parse(args); err != nil {
+			return exitUsage
+		}
+
+		out, err := newWriter(*format, stdout, "input", field)
+		if err != nil {
+			fmt.Fprintf(stderr, "dsa %s: %v\n", name, err)
+			return exitUsage
+		}
+
+		queries := readQueries(flags.Args(), stdin, variadic)
+		status := exitOK
+		for query, err := range queries {
+			if err == nil {
+				var numbers []*big.Int
+				if numbers, err = parseNumbers(query); err == nil {
+					var value any
+					if value, err = eval(numbers); err == nil {
+						err = out.write(strings.Join(query, " "), value)
+					}
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(stderr, "dsa %s: %s: %v\n", name, strings.Join(query, " "), err)
+				status = exitError
+			}
+		}
+		if err := out.close(); err != nil {
+			fmt.Fprintf(stderr, "dsa %s: %v\n", name, err)
+			return exitError
+		}
+		return status
+	}
+}
+
+// readQueries returns the queries given on the command line or, when args is
+// empty, read from stdin. Single-number commands get one query per token;
+// variadic commands one per argument list or non-empty input line.
+func readQueries(args []string, stdin io.Reader, variadic bool) func(yield func([]string, error) bool) {
+	return func(yield func([]string, error) bool) {
+		if len(args) > 0 {
+			if variadic {
+				yield(args, nil)
+				return
+			}
+			for _, arg := range args {
+				if !yield([]string{arg}, nil) {
+					return
+				}
+			}
+			return
+		}
+
+		scanner := bufio.NewScanner(stdin)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 0 {
+				continue
+			}
+			if variadic {
+				if !yield(fields, nil) {
+					return
+				}
+				continue
+			}
+			for _, field := range fields {
+				if !yield([]string{field}, nil) {
+					return
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// parseNumbers parses decimal integers of any size.
+func parseNumbers(texts []string) ([]*big.Int, error) {
+	numbers := make([]*big.Int, len(texts))
+	for i, text := range texts {
+		n, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", text)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// asSmall returns n as an int if 0 <= n <= prime_factors.TrialDivisionLimit,
+// the largest input handed to the O(sqrt(n)) int-based algorithms.
+func asSmall(n *big.Int) (int, bool) {
+	if n.Sign() < 0 || !n.IsInt64() || n.Int64() > prime_factors.TrialDivisionLimit {
+		return 0, false
+	}
+	return int(n.Int64()), true
+}
+
+// factorize returns the prime factors of n >= 1 using prime_factors.
+func factorize(numbers []*big.Int) (any, error) {
+	n := numbers[0]
+	if n.Sign() <= 0 {
+		return nil, errNeedsPositive
+	}
+	factors := prime_factors.PrimeFactorsBig(n)
+	if factors == nil {
+		factors = []*big.Int{}
+	}
+	return factors, nil
+}
+
+// divisors returns the divisors of n >= 1 in increasing order. Small values
+// use divisors_of_number; larger ones are expanded from the factorisation.
+func divisors(numbers []*big.Int) (any, error) {
+	n := numbers[0]
+	if n.Sign() <= 0 {
+		return nil, errNeedsPositive
+	}
+
+	if small, ok := asSmall(n); ok {
+		ds := divisors_of_number.AllDivisorsOfNumber(small)
+		sort.Ints(ds)
+		out := make([]*big.Int, len(ds))
+		for i, d := range ds {
+			out[i] = big.NewInt(int64(d))
+		}
+		return out, nil
+	}
+
+	out := []*big.Int{big.NewInt(1)}
+	factors := prime_factors.PrimeFactorsBig(n)
+	for i := 0; i < len(factors); {
+		p := factors[i]
+		count := len(out)
+		power := big.NewInt(1)
+		for ; i < len(factors) && factors[i].Cmp(p) == 0; i++ {
+			power = new(big.Int).Mul(power, p)
+			for _, d := range out[:count] {
+				out = append(out, new(big.Int).Mul(d, power))
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Cmp(out[j]) < 0 })
+	return out, nil
+}
+
+// greatestCommonDivisor folds gcd over the numbers. int-sized values use the
+// gcd package; anything larger falls back to big.Int.GCD.
+func greatestCommonDivisor(numbers []*big.Int) (any, error) {
+	result := new(big.Int)
+	for _, n := range numbers {
+		a, okA := fitsInt(result)
+		b, okB := fitsInt(n)
+		if okA && okB {
+			result.SetInt64(int64(gcd.GCD(a, b)))
+			continue
+		}
+		result.GCD(nil, nil, result, new(big.Int).Abs(n))
+	}
+	return result, nil
+}
+
+// lowestCommonMultiple folds lcm over the numbers. The lcm package is used
+// while the product a*b fits in an int, big.Int arithmetic otherwise.
+func lowestCommonMultiple(numbers []*big.Int) (any, error) {
+	result := big.NewInt(1)
+	for _, n := range numbers {
+		a, okA := fitsInt(result)
+		b, okB := fitsInt(n)
+		if okA && okB && (b == 0 || a <= math.MaxInt/b) {
+			result.SetInt64(int64(lcm.LCM(a, b)))
+			continue
+		}
+		if n.Sign() == 0 {
+			result.SetInt64(0)
+			continue
+		}
+		g := new(big.Int).GCD(nil, nil, result, new(big.Int).Abs(n))
+		result.Mul(result, new(big.Int).Quo(new(big.Int).Abs(n), g))
+	}
+	return result, nil
+}
+
+// fitsInt returns |n| as an int if it fits.
+func fitsInt(n *big.Int) (int, bool) {
+	abs := new(big.Int).Abs(n)
+	if !abs.IsInt64() {
+		return 0, false
+	}
+	return int(abs.Int64()), true
+}
+
+// factorialOf returns n! for 0 <= n <= maxFactorial. Values up to 20! come
+// from the factorial package; beyond that int overflows and big.Int is used.
+func factorialOf(numbers []*big.Int) (any, error) {
+	n := numbers[0]
+	if n.Sign() < 0 {
+		return nil, errors.New("factorial is not defined for negative numbers")
+	}
+	if !n.IsInt64() || n.Int64() > maxFactorial {
+		return nil, fmt.Errorf("too large, the limit is %d", maxFactorial)
+	}
+
+	k := n.Int64()
+	if k <= 20 {
+		return big.NewInt(int64(factorial.Factorial(int(k)))), nil
+	}
+	return new(big.Int).MulRange(1, k), nil
+}
+
+// palindrome reports whether n reads the same forward and backward, using
+// palindrome_number for int-sized values and the decimal digits otherwise.
+func palindrome(numbers []*big.Int) (any, error) {
+	n := numbers[0]
+	if n.IsInt64() {
+		return palindrome_number.IsPalindromeNumber(int(n.Int64())), nil
+	}
+	if n.Sign() < 0 {
+		return false, nil
+	}
+
+	digits := n.String()
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		if digits[i] != digits[j] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// runPrimes implements "dsa primes --from a --to b", streaming the primes of
+// the range from prime_patterns.Primes.
+func runPrimes(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("dsa primes", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	from := flags.Int("from", 2, "smallest number of the range")
+	to := flags.Int("to", -1, "largest number of the range (required)")
+	format := flags.String("format", "plain", "output format: plain, json or csv")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *to < 0 || flags.NArg() > 0 {
+		fmt.Fprintln(stderr, "dsa primes: usage: dsa primes [--from a] --to b [--format f]")
+		return exitUsage
+	}
+
+	bw := bufio.NewWriter(stdout)
+	out, err := newWriter(*format, bw, "", "prime")
+	if err != nil {
+		fmt.Fprintf(stderr, "dsa primes: %v\n", err)
+		return exitUsage
+	}
+
+	for p := range prime_patterns.Primes(*from, *to) {
+		if err := out.write("", big.NewInt(int64(p))); err != nil {
+			fmt.Fprintf(stderr, "dsa primes: %v\n", err)
+			return exitError
+		}
+	}
+	if err := out.close(); err != nil {
+		fmt.Fprintf(stderr, "dsa primes: %v\n", err)
+		return exitError
+	}
+	if err := bw.Flush(); err != nil {
+		fmt.Fprintf(stderr, "dsa primes: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+	_ "github.com/ignoreAnt/go-dsa/registry/all"
+)
+
+// runList implements "dsa list", which prints the algorithm registry, and
+// generates the Markdown catalogue with --format markdown.
+func runList(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("dsa list", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	category := flags.String("category", "", "only list algorithms of this category")
+	search := flags.String("search", "", "only list algorithms matching every word of this query")
+	format := flags.String("format", "plain", "output format: plain, json, csv or markdown")
+	output := flags.String("output", "", "write to this file instead of standard output")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(stderr, "dsa list: usage: dsa list [--category c] [--search query] [--format f] [--output file]")
+		return exitUsage
+	}
+
+	var algorithms []registry.Algorithm
+	for _, a := range registry.Search(*search) {
+		if *category == "" || a.Category == *category {
+			algorithms = append(algorithms, a)
+		}
+	}
+
+	write, ok := listFormats[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "dsa list: unknown format %q, want plain, json, csv or markdown\n", *format)
+		return exitUsage
+	}
+
+	var err error
+	if *output == "" {
+		err = write(stdout, algorithms)
+	} else {
+		var file *os.File
+		file, err = os.Create(*output)
+		if err == nil {
+			err = write(file, algorithms)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "dsa list: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// listFormats maps each --format of "dsa list" to its writer.
+var listFormats = map[string]func(io.Writer, []registry.Algorithm) error{
+	"plain":    writeListPlain,
+	"json":     writeListJSON,
+	"csv":      writeListCSV,
+	"markdown": registry.WriteCatalogue,
+}
+
+// writeListPlain writes an aligned table of the algorithms.
+func writeListPlain(w io.Writer, algorithms []registry.Algorithm) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tCATEGORY\tTIME\tSPACE\tSUMMARY")
+	for _, a := range algorithms {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", a.Qualified(), a.Category, a.Time, a.Space, a.Summary)
+	}
+	return tw.Flush()
+}
+
+// writeListJSON writes the algorithms as a JSON array.
+func writeListJSON(w io.Writer, algorithms []registry.Algorithm) error {
+	if algorithms == nil {
+		algorithms = []registry.Algorithm{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(algorithms)
+}
+
+// writeListCSV writes the algorithms as CSV with a header row.
+func writeListCSV(w io.Writer, algorithms []registry.Algorithm) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"name", "package", "category", "time", "space", "stable", "in_place", "summary"})
+	for _, a := range algorithms {
+		cw.Write([]string{a.Name, a.Package, a.Category, a.Time, a.Space,
+			strconv.FormatBool(a.Stable), strconv.FormatBool(a.InPlace), a.Summary})
+	}
+	cw.Flush()
+	return cw.Error()
+}
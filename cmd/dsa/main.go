@@ -0,0 +1,67 @@
+// Command dsa exposes the algorithms/mathematics packages on the command line.
+//
+// Usage:
+//
+//	dsa <command> [--format plain|json|csv] [numbers...]
+//
+// Numbers may be arbitrarily large. When none are given on the command line
+// they are read from standard input: one query per whitespace-separated token,
+// or one query per line for commands that take several numbers (gcd, lcm).
+// Flags come before the numbers; use "--" to end them when the first number
+// is negative.
+//
+// Run "dsa help" for the list of commands.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes returned by run.
+const (
+	exitOK    = 0
+	exitError = 1 // a query failed
+	exitUsage = 2 // the command line was malformed
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit code. It is
+// separate from main so that tests can drive the whole program.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "dsa: unknown command %q\n", name)
+		printUsage(stderr)
+		return exitUsage
+	}
+	return cmd.run(args[1:], stdin, stdout, stderr)
+}
+
+// printUsage writes the list of commands to w.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: dsa <command> [--format plain|json|csv] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range commandOrder {
+		cmd := commands[name]
+		fmt.Fprintf(w, "  %-11s %s\n", name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Numbers are read from standard input when none are given.")
+}
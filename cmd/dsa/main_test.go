@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRun drives the whole program through run, checking the exit code and
+// the text written to stdout and stderr.
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "factor small and big",
+			args:       []string{"factor", "1", "12", "998244359987710471"},
+			wantStdout: "1: \n12: 2 2 3\n998244359987710471: 998244353 1000000007\n",
+		},
+		{
+			name:       "factor json",
+			args:       []string{"factor", "--format", "json", "12", "97"},
+			wantStdout: `[{"input":"12","factors":[2,2,3]},{"input":"97","factors":[97]}]` + "\n",
+		},
+		{
+			name:       "factor rejects zero",
+			args:       []string{"factor", "0", "6"},
+			wantCode:   exitError,
+			wantStdout: "6: 2 3\n",
+			wantStderr: "dsa factor: 0: needs a positive integer\n",
+		},
+		{
+			name:       "factor reads stdin tokens",
+			args:       []string{"factor"},
+			stdin:      "10 21\n\n35\n",
+			wantStdout: "10: 2 5\n21: 3 7\n35: 5 7\n",
+		},
+		{
+			name:       "invalid number",
+			args:       []string{"divisors", "12", "abc"},
+			wantCode:   exitError,
+			wantStdout: "12: 1 2 3 4 6 12\n",
+			wantStderr: "dsa divisors: abc: invalid number \"abc\"\n",
+		},
+		{
+			name:       "divisors of a large number",
+			args:       []string{"divisors", "2000000000078"},
+			wantStdout: "2000000000078: 1 2 1000000000039 2000000000078\n",
+		},
+		{
+			name:       "gcd of arguments",
+			args:       []string{"gcd", "12", "-18", "30"},
+			wantStdout: "12 -18 30: 6\n",
+		},
+		{
+			name:       "gcd of big numbers",
+			args:       []string{"gcd", "100000000000000000000", "250000000000000000000"},
+			wantStdout: "100000000000000000000 250000000000000000000: 50000000000000000000\n",
+		},
+		{
+			name:       "lcm csv from stdin lines",
+			args:       []string{"lcm", "--format", "csv"},
+			stdin:      "4 6\n1000000000000 999999999999\n",
+			wantStdout: "input,lcm\n4 6,12\n1000000000000 999999999999,999999999999000000000000\n",
+		},
+		{
+			name:       "factorial beyond int",
+			args:       []string{"factorial", "20", "25"},
+			wantStdout: "20: 2432902008176640000\n25: 15511210043330985984000000\n",
+		},
+		{
+			name:       "factorial limit",
+			args:       []string{"factorial", "100001"},
+			wantCode:   exitError,
+			wantStderr: "dsa factorial: 100001: too large, the limit is 100000\n",
+		},
+		{
+			name:       "palindrome",
+			args:       []string{"palindrome", "--format", "csv", "--", "-121", "1221", "123456789987654321123456789987654321"},
+			wantStdout: "input,palindrome\n-121,false\n1221,true\n123456789987654321123456789987654321,true\n",
+		},
+		{
+			name:       "primes plain",
+			args:       []string{"primes", "--from", "10", "--to", "30"},
+			wantStdout: "11\n13\n17\n19\n23\n29\n",
+		},
+		{
+			name:       "primes json",
+			args:       []string{"primes", "--to", "10", "--format", "json"},
+			wantStdout: "[2,3,5,7]\n",
+		},
+		{
+			name:       "primes empty json",
+			args:       []string{"primes", "--from", "24", "--to", "28", "--format", "json"},
+			wantStdout: "[]\n",
+		},
+		{
+			name:       "primes needs --to",
+			args:       []string{"primes"},
+			wantCode:   exitUsage,
+			wantStderr: "dsa primes: usage: dsa primes [--from a] --to b [--format f]\n",
+		},
+		{
+			name:       "list search",
+			args:       []string{"list", "--search", "euclidean iterative"},
+			wantStdout: "NAME              CATEGORY     TIME              SPACE  SUMMARY\ngcd.gcdIterative  mathematics  O(log min(a, b))  O(1)   Greatest common divisor by the iterative Euclidean algorithm\n",
+		},
+		{
+			name:       "list csv",
+			args:       []string{"list", "--format", "csv", "--search", "sieveOfEratosthenes"},
+			wantStdout: "name,package,category,time,space,stable,in_place,summary\nsieveOfEratosthenes,algorithms/mathematics/sieve_of_eratosthenes,mathematics,O(n log log n),O(n),false,false,Primes up to n by crossing off multiples of each prime\n",
+		},
+		{
+			name:       "list json with no match",
+			args:       []string{"list", "--format", "json", "--category", "sorting"},
+			wantStdout: "[]\n",
+		},
+		{
+			name:       "list unknown format",
+			args:       []string{"list", "--format", "xml"},
+			wantCode:   exitUsage,
+			wantStderr: "dsa list: unknown format \"xml\", want plain, json, csv or markdown\n",
+		},
+		{
+			name: "trace gcd",
+			args: []string{"trace", "gcd", "12", "8"},
+			wantStdout: "gcd(12, 8) = 4\n" +
+				"1  compare 12 with 0: greater than\n" +
+				"2  compare 8 with 0: greater than\n" +
+				"3  divide 12 by 8: remainder 4\n" +
+				"4  swap 4 and 8\n" +
+				"5  divide 8 by 4: remainder 0\n" +
+				"6  swap 0 and 4\n",
+		},
+		{
+			name:       "trace factor",
+			args:       []string{"trace", "factor", "18"},
+			wantStdout: "prime factors of 18: 2 3 3\n1  divide 18 by 2: remainder 0\n2  divide 9 by 2: remainder 1\n3  compare 9 with 9: equal to\n4  divide 9 by 3: remainder 0\n5  divide 3 by 3: remainder 0\n6  divide 1 by 3: remainder 1\n7  compare 25 with 1: greater than\n",
+		},
+		{
+			name:       "trace sieve too large",
+			args:       []string{"trace", "sieve", "5000"},
+			wantCode:   exitError,
+			wantStderr: "dsa trace sieve: n must be between 2 and 1000\n",
+		},
+		{
+			name:       "trace wrong argument count",
+			args:       []string{"trace", "gcd", "12"},
+			wantCode:   exitUsage,
+			wantStderr: "dsa trace: usage: dsa trace [--animate [--delay d] | --html file] sieve n | factor n | gcd a b\n",
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"gcd", "--format", "xml", "4", "6"},
+			wantCode:   exitUsage,
+			wantStderr: "dsa gcd: unknown format \"xml\", want plain, json or csv\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+			assert.Equal(t, tc.wantCode, code, "Expected: %v, Got: %v", tc.wantCode, code)
+			assert.Equal(t, tc.wantStdout, stdout.String())
+			assert.Equal(t, tc.wantStderr, stderr.String())
+		})
+	}
+}
+
+// TestRunUsage checks the help output and the handling of unknown commands.
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, run([]string{"help"}, nil, &stdout, &stderr))
+	for _, name := range commandOrder {
+		assert.Contains(t, stdout.String(), name)
+	}
+
+	stdout.Reset()
+	assert.Equal(t, exitUsage, run([]string{"frobnicate"}, nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown command "frobnicate"`)
+	assert.Empty(t, stdout.String())
+
+	stderr.Reset()
+	assert.Equal(t, exitUsage, run(nil, nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: dsa")
+}
+
+// TestRunTraceOutputs tests the animation and the HTML replay of "dsa trace".
+func TestRunTraceOutputs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, run([]string{"trace", "--animate", "--delay", "0", "sieve", "10"}, nil, &stdout, &stderr))
+	assert.Equal(t, 6, strings.Count(stdout.String(), "\x1b[H\x1b[2J"), "five marks and the initial frame")
+	assert.Contains(t, stdout.String(), "> mark 9 composite, a multiple of 3\n")
+	assert.Empty(t, stderr.String())
+
+	path := filepath.Join(t.TempDir(), "sieve.html")
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"trace", "--html", path, "sieve", "10"}, nil, &stdout, &stderr))
+	page, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "<title>sieve of Eratosthenes up to 10</title>")
+	assert.Empty(t, stdout.String())
+}
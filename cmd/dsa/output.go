@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// writer streams results in one of the output formats. Each result pairs the
+// query text with a value; an empty inputKey means results have no query and
+// only the value is written.
+//
+//	plain  "12: 2 2 3", or "2" without a query
+//	csv    a header row "input,factors" then "12,2 2 3"
+//	json   an array of {"input":"12","factors":[2,2,3]}, or of bare values
+type writer struct {
+	w        io.Writer
+	format   string
+	inputKey string
+	field    string
+	csv      *csv.Writer
+	count    int
+}
+
+// newWriter returns a writer for format, which must be plain, json or csv.
+func newWriter(format string, w io.Writer, inputKey, field string) (*writer, error) {
+	out := &writer{w: w, format: format, inputKey: inputKey, field: field}
+	switch format {
+	case "plain", "json":
+	case "csv":
+		out.csv = csv.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown format %q, want plain, json or csv", format)
+	}
+	return out, nil
+}
+
+// write emits one result.
+func (out *writer) write(input string, value any) error {
+	defer func() { out.count++ }()
+
+	switch out.format {
+	case "csv":
+		if out.count == 0 {
+			header := []string{out.field}
+			if out.inputKey != "" {
+				header = []string{out.inputKey, out.field}
+			}
+			if err := out.csv.Write(header); err != nil {
+				return err
+			}
+		}
+		record := []string{text(value)}
+		if out.inputKey != "" {
+			record = []string{input, text(value)}
+		}
+		if err := out.csv.Write(record); err != nil {
+			return err
+		}
+		out.csv.Flush()
+		return out.csv.Error()
+
+	case "json":
+		separator := ","
+		if out.count == 0 {
+			separator = "["
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if out.inputKey == "" {
+			_, err = fmt.Fprintf(out.w, "%s%s", separator, encoded)
+			return err
+		}
+		key, _ := json.Marshal(out.inputKey)
+		quoted, _ := json.Marshal(input)
+		field, _ := json.Marshal(out.field)
+		_, err = fmt.Fprintf(out.w, "%s{%s:%s,%s:%s}", separator, key, quoted, field, encoded)
+		return err
+	}
+
+	if out.inputKey == "" {
+		_, err := fmt.Fprintln(out.w, text(value))
+		return err
+	}
+	_, err := fmt.Fprintf(out.w, "%s: %s\n", input, text(value))
+	return err
+}
+
+// close finishes the output; for json it closes the array, which is empty if
+// nothing was written.
+func (out *writer) close() error {
+	if out.format != "json" {
+		return nil
+	}
+	if out.count == 0 {
+		_, err := io.WriteString(out.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(out.w, "]\n")
+	return err
+}
+
+// text formats a result value for the plain and csv formats. Lists are
+// separated by spaces.
+func text(value any) string {
+	switch v := value.(type) {
+	case []*big.Int:
+		parts := make([]string, len(v))
+		for i, n := range v {
+			parts[i] = n.String()
+		}
+		return strings.Join(parts, " ")
+	case *big.Int:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
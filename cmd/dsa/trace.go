@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/sieve_of_eratosthenes"
+	"github.com/ignoreAnt/go-dsa/trace"
+)
+
+// maxTraceSieve bounds "dsa trace sieve", whose grid has a cell per number.
+const maxTraceSieve = 1000
+
+// tracedAlgorithm is an algorithm that "dsa trace" can record.
+type tracedAlgorithm struct {
+	args int // number of integer arguments
+	run  func(ns []int) (trace.Trace, error)
+}
+
+// tracedAlgorithms maps the algorithm names of "dsa trace" to their recorders.
+var tracedAlgorithms = map[string]tracedAlgorithm{
+	"sieve": {1, func(ns []int) (trace.Trace, error) {
+		n := ns[0]
+		if n < 2 || n > maxTraceSieve {
+			return trace.Trace{}, fmt.Errorf("n must be between 2 and %d", maxTraceSieve)
+		}
+		var rec trace.Recorder
+		sieve_of_eratosthenes.SieveOfEratosthenesTraced(n, &rec)
+		return trace.Trace{Title: fmt.Sprintf("sieve of Eratosthenes up to %d", n), Cells: trace.Range(2, n), Events: rec.Events}, nil
+	}},
+	"factor": {1, func(ns []int) (trace.Trace, error) {
+		n := ns[0]
+		if n < 1 || n > prime_factors.TrialDivisionLimit {
+			return trace.Trace{}, fmt.Errorf("n must be between 1 and %d", prime_factors.TrialDivisionLimit)
+		}
+		var rec trace.Recorder
+		factors := prime_factors.PrimeFactorsTraced(n, &rec)
+		return trace.Trace{Title: fmt.Sprintf("prime factors of %d: %s", n, joinInts(factors)), Events: rec.Events}, nil
+	}},
+	"gcd": {2, func(ns []int) (trace.Trace, error) {
+		a, b := ns[0], ns[1]
+		if a < 0 || b < 0 {
+			return trace.Trace{}, errNeedsPositive
+		}
+		var rec trace.Recorder
+		g := gcd.GCDTraced(a, b, &rec)
+		return trace.Trace{Title: fmt.Sprintf("gcd(%d, %d) = %d", a, b, g), Events: rec.Events}, nil
+	}},
+}
+
+// runTrace implements "dsa trace", which records the steps of an algorithm
+// and prints them, plays them as a terminal animation or writes an HTML
+// replay.
+func runTrace(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("dsa trace", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	animate := flags.Bool("animate", false, "play the trace as an animation on the terminal")
+	delay := flags.Duration("delay", 200*time.Millisecond, "pause between the frames of --animate")
+	html := flags.String("html", "", "write a self-contained HTML replay to this file")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	usage := "dsa trace: usage: dsa trace [--animate [--delay d] | --html file] sieve n | factor n | gcd a b"
+	if flags.NArg() == 0 {
+		fmt.Fprintln(stderr, usage)
+		return exitUsage
+	}
+	name := flags.Arg(0)
+	algorithm, ok := tracedAlgorithms[name]
+	if !ok || flags.NArg()-1 != algorithm.args || (*animate && *html != "") {
+		fmt.Fprintln(stderr, usage)
+		return exitUsage
+	}
+
+	ns := make([]int, algorithm.args)
+	for i, arg := range flags.Args()[1:] {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			fmt.Fprintf(stderr, "dsa trace: invalid number %q\n", arg)
+			return exitUsage
+		}
+		ns[i] = n
+	}
+	t, err := algorithm.run(ns)
+	if err != nil {
+		fmt.Fprintf(stderr, "dsa trace %s: %v\n", name, err)
+		return exitError
+	}
+
+	switch {
+	case *html != "":
+		file, err := os.Create(*html)
+		if err == nil {
+			err = trace.WriteHTML(file, t)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "dsa trace: %v\n", err)
+			return exitError
+		}
+	case *animate:
+		if err := trace.Animate(stdout, t, *delay); err != nil {
+			fmt.Fprintf(stderr, "dsa trace: %v\n", err)
+			return exitError
+		}
+	default:
+		if err := trace.WriteLog(stdout, t); err != nil {
+			fmt.Fprintf(stderr, "dsa trace: %v\n", err)
+			return exitError
+		}
+	}
+	return exitOK
+}
+
+// joinInts formats ns separated by spaces.
+func joinInts(ns []int) string {
+	texts := make([]string, len(ns))
+	for i, n := range ns {
+		texts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(texts, " ")
+}
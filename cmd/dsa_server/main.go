@@ -0,0 +1,61 @@
+// Command dsa_server serves the number-theory API of package server over
+// HTTP.
+//
+// Usage:
+//
+//	dsa_server [--addr localhost:8080] [--timeout 5s] [--max-body 1048576] ...
+//
+// It shuts down gracefully on SIGINT or SIGTERM.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ignoreAnt/go-dsa/server"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	var config server.Config
+	flag.DurationVar(&config.Timeout, "timeout", 5*time.Second, "time allowed per request")
+	flag.Int64Var(&config.MaxBodyBytes, "max-body", 1<<20, "largest request body in bytes")
+	flag.IntVar(&config.MaxBatch, "max-batch", 1000, "most requests in a batch or numbers in a list")
+	flag.IntVar(&config.MaxDigits, "max-digits", 1000, "most decimal digits of an input number")
+	flag.IntVar(&config.MaxFactorDigits, "max-factor-digits", 30, "most decimal digits of a number to factor")
+	flag.IntVar(&config.MaxRange, "max-range", 1_000_000, "widest range of a primes query")
+	flag.IntVar(&config.MaxSieve, "max-sieve", 1_000_000_000_000, "largest upper end of a primes query")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(config),
+		ReadHeaderTimeout: 5 * time.Second,
+		// the handler answers within config.Timeout; leave time to send it
+		WriteTimeout: config.Timeout + 10*time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), config.Timeout+time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdown); err != nil {
+			log.Printf("dsa_server: shutdown: %v", err)
+		}
+	}()
+
+	log.Printf("dsa_server: listening on %s", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("dsa_server: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Command judge runs a Go solution against a directory of tests and prints a
+// verdict table.
+//
+// Usage:
+//
+//	judge [flags] solution testdir
+//
+// The solution is a Go package directory or file, which is compiled first, or
+// with --exec any executable. testdir holds the tests as NN.in and NN.out
+// files. Each test runs in its own process under the time and memory limits;
+// the output is compared by the --checker:
+//
+//	exact   byte for byte
+//	tokens  token by token, ignoring how whitespace is laid out
+//	float   token by token, numbers within --tolerance
+//
+// The exit status is 0 when every test is accepted, 1 otherwise and 2 for a
+// malformed command line.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ignoreAnt/go-dsa/judge"
+)
+
+// Exit codes of the judge. A run is a failure as soon as one test gets a
+// verdict other than AC, whether WA, TLE, MLE or RE; the table on stdout
+// says which.
+const (
+	exitOK    = 0 // every test was accepted
+	exitError = 1 // a test was not accepted, or the solution did not build
+	exitUsage = 2 // bad flags or arguments, or testdir holds no tests
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run parses the flags and the solution and testdir arguments in args,
+// builds the solution unless --exec is given, runs it on every test of
+// testdir under the limits and checker chosen, and writes the verdict table
+// to stdout and any error to stderr. It returns the exit code: exitOK only
+// if every test was accepted. Cancelling ctx stops the build or the test
+// in progress.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("judge", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	timeLimit := flags.Duration("time", 2*time.Second, "time limit per test")
+	memory := flags.Int64("memory", 256, "memory limit per test in MiB")
+	checker := flags.String("checker", "tokens", "output comparison: exact, tokens or float")
+	tolerance := flags.Float64("tolerance", 1e-6, "absolute or relative error allowed by --checker float")
+	execute := flags.Bool("exec", false, "run the solution as an executable instead of building it")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: judge [flags] solution testdir")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	opts := judge.Options{TimeLimit: *timeLimit, MemoryLimit: *memory << 20}
+	switch *checker {
+	case "exact":
+		opts.Checker = judge.Exact
+	case "tokens":
+		opts.Checker = judge.Tokens
+	case "float":
+		opts.Checker = judge.Floats(*tolerance)
+	default:
+		fmt.Fprintf(stderr, "judge: unknown checker %q, want exact, tokens or float\n", *checker)
+		return exitUsage
+	}
+
+	tests, err := judge.LoadTests(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	solution := judge.Command{Path: flags.Arg(0)}
+	if !*execute {
+		var cleanup func()
+		solution, cleanup, err = judge.Build(ctx, flags.Arg(0))
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		defer cleanup()
+	}
+
+	results := judge.Run(ctx, tests, solution, opts)
+	if err := judge.WriteTable(stdout, results); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	if !judge.AllAccepted(results) {
+		return exitError
+	}
+	return exitOK
+}
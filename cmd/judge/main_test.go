@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRun tests the verdict table and exit code of a judged solution, and
+// the usage errors for bad flags, a missing test directory and a solution
+// that does not build. Cases that build the solution are skipped with
+// -short.
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		args       []string
+		build      bool // the case compiles the solution
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "no arguments",
+			wantCode:   exitUsage,
+			wantStderr: "Usage: judge [flags] solution testdir\n",
+		},
+		{
+			name:       "unknown checker",
+			args:       []string{"--checker", "fuzzy", "sol", "../../judge/testdata/sum"},
+			wantCode:   exitUsage,
+			wantStderr: "judge: unknown checker \"fuzzy\", want exact, tokens or float\n",
+		},
+		{
+			name:       "missing tests",
+			args:       []string{"sol", "testdata/none"},
+			wantCode:   exitUsage,
+			wantStderr: "judge: no .in files",
+		},
+		{
+			name:       "accepted",
+			args:       []string{"--checker", "exact", "../../judge/testdata/solution", "../../judge/testdata/sum"},
+			build:      true,
+			wantStdout: "3/3 accepted\n",
+		},
+		{
+			name:       "build error",
+			args:       []string{"testdata/none", "../../judge/testdata/sum"},
+			build:      true,
+			wantCode:   exitError,
+			wantStderr: "judge: building testdata/none",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.build && testing.Short() {
+				t.Skip("builds a program")
+			}
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tc.args, &stdout, &stderr)
+			assert.Equal(t, tc.wantCode, code, "Expected: %v, Got: %v", tc.wantCode, code)
+			assert.Contains(t, stdout.String(), tc.wantStdout)
+			assert.Contains(t, stderr.String(), tc.wantStderr)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Command lessons lists and runs the Go lessons under basics.
+//
+// Usage:
+//
+//	lessons list          print the name and description of every lesson
+//	lessons run [name...] run the named lessons, or all of them in order
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	_ "github.com/ignoreAnt/go-dsa/basics/all"
+	"github.com/ignoreAnt/go-dsa/basics/lesson"
+)
+
+// Exit codes of lessons. A lesson cannot fail, so the only error is asking
+// for a command or lesson that does not exist.
+const (
+	exitOK    = 0 // the lessons ran, or the list or help was printed
+	exitUsage = 2 // an unknown command or lesson name, or a stray argument
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run dispatches on the command in args[0]: list prints every lesson with
+// its description, run runs the lessons named in the rest of args, and help
+// prints the usage to stdout. Anything else prints the usage to stderr and
+// returns exitUsage.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "help", "-h", "--help":
+		printUsage(stdout)
+		return exitOK
+	case "list":
+		if len(args) > 1 {
+			printUsage(stderr)
+			return exitUsage
+		}
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, l := range lesson.All() {
+			fmt.Fprintf(tw, "%s\t%s\n", l.Name(), l.Description())
+		}
+		tw.Flush()
+		return exitOK
+	case "run":
+		return runLessons(args[1:], stdout, stderr)
+	}
+
+	fmt.Fprintf(stderr, "lessons: unknown command %q\n", args[0])
+	printUsage(stderr)
+	return exitUsage
+}
+
+// runLessons runs the named lessons, or every lesson when names is empty. A
+// header line precedes each lesson when more than one is run.
+func runLessons(names []string, stdout, stderr io.Writer) int {
+	var selected []lesson.Lesson
+	if len(names) == 0 {
+		selected = lesson.All()
+	}
+	for _, name := range names {
+		l, ok := lesson.Lookup(name)
+		if !ok {
+			fmt.Fprintf(stderr, "lessons: unknown lesson %q; run \"lessons list\" to see them\n", name)
+			return exitUsage
+		}
+		selected = append(selected, l)
+	}
+
+	for i, l := range selected {
+		if len(selected) > 1 {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			fmt.Fprintf(stdout, "== %s: %s ==\n", l.Name(), l.Description())
+		}
+		l.Run(stdout)
+	}
+	return exitOK
+}
+
+// printUsage writes the list of commands to w.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: lessons <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  list           the lessons with a one-line description")
+	fmt.Fprintln(w, "  run [name...]  run the named lessons, or all of them")
+}
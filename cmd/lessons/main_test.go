@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRun tests the exact output of running one lesson, the headers
+// between several, and the error for a lesson name that is not registered.
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "run one lesson",
+			args:       []string{"run", "hello"},
+			wantStdout: "Hello, Go language!\n",
+		},
+		{
+			name: "run several lessons",
+			args: []string{"run", "hello", "structs_and_methods"},
+			wantStdout: "== hello: the smallest Go program ==\n" +
+				"Hello, Go language!\n" +
+				"\n" +
+				"== structs_and_methods: structs, methods with receivers and embedding ==\n" +
+				"Full Name: John Doe\nFull Name: Jane Smith\nJob Title: Marketing Manager\n",
+		},
+		{
+			name:       "unknown lesson",
+			args:       []string{"run", "hello", "generics"},
+			wantCode:   exitUsage,
+			wantStderr: "lessons: unknown lesson \"generics\"; run \"lessons list\" to see them\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := run(tc.args, &stdout, &stderr)
+			assert.Equal(t, tc.wantCode, code, "Expected: %v, Got: %v", tc.wantCode, code)
+			assert.Equal(t, tc.wantStdout, stdout.String())
+			assert.Equal(t, tc.wantStderr, stderr.String())
+		})
+	}
+}
+
+// TestRunList tests that list names every lesson, in order.
+func TestRunList(t *testing.T) {
+	var stdout, stderr strings.Builder
+	assert.Equal(t, exitOK, run([]string{"list"}, &stdout, &stderr))
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Len(t, lines, 10)
+	assert.True(t, strings.HasPrefix(lines[0], "concurrency "), lines[0])
+	assert.Contains(t, stdout.String(), "hello                the smallest Go program\n")
+	assert.Empty(t, stderr.String())
+}
+
+// TestRunAll tests that run without names runs every lesson.
+func TestRunAll(t *testing.T) {
+	var stdout, stderr strings.Builder
+	assert.Equal(t, exitOK, run([]string{"run"}, &stdout, &stderr))
+	assert.Equal(t, 10, strings.Count(stdout.String(), "\n== ")+1)
+	assert.Contains(t, stdout.String(), "Counter: 5\n")
+}
+
+// TestRunUsage tests that a missing or unknown command prints the usage.
+func TestRunUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"frobnicate"}, {"list", "extra"}} {
+		var stdout, stderr strings.Builder
+		assert.Equal(t, exitUsage, run(args, &stdout, &stderr), "args %q", args)
+		assert.Contains(t, stderr.String(), "Usage: lessons")
+	}
+}
@@ -0,0 +1,40 @@
+package arrays
+
+import "fmt"
+
+// Growth is the policy a Vector uses to grow: given its current capacity and
+// the number of elements it needs room for, it returns the new capacity. A
+// result below needed is raised to needed.
+type Growth func(capacity, needed int) int
+
+// Factor returns the policy of multiplying the capacity by f, which must be
+// greater than 1. Any factor makes appending amortised O(1): the copies made
+// by all resizes add up to a geometric series, at most f/(f-1) times the
+// final length. Smaller factors waste less memory and copy more often.
+func Factor(f float64) Growth {
+	if !(f > 1) {
+		panic(fmt.Sprintf("arrays: growth factor %v is not greater than 1", f))
+	}
+	return func(capacity, needed int) int {
+		return max(needed, int(float64(capacity)*f), capacity+1)
+	}
+}
+
+// The usual growth factors: doubling, as in many standard libraries, and 1.5,
+// which lets a freed block be reused by a later resize.
+var (
+	Doubling    = Factor(2)
+	OneAndAHalf = Factor(1.5)
+)
+
+// Increment returns the policy of adding k slots at a time. Appending n
+// elements then copies about n²/2k of them, so each append costs O(n/k):
+// amortised O(n), not O(1). It panics if k < 1.
+func Increment(k int) Growth {
+	if k < 1 {
+		panic(fmt.Sprintf("arrays: growth increment %d is less than 1", k))
+	}
+	return func(capacity, needed int) int {
+		return max(needed, capacity+k)
+	}
+}
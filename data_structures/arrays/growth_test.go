@@ -0,0 +1,81 @@
+package arrays
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ignoreAnt/go-dsa/analysis"
+)
+
+// TestGrowthCapacities tests the capacities each policy goes through while
+// appending one element at a time.
+func TestGrowthCapacities(t *testing.T) {
+	testCases := []struct {
+		name     string
+		growth   Growth
+		expected []int
+	}{
+		{"doubling", Doubling, []int{1, 2, 4, 8, 16, 32}},
+		{"one and a half", OneAndAHalf, []int{1, 2, 3, 4, 6, 9, 13, 19, 28}},
+		{"increment", Increment(8), []int{8, 16, 24}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New[int](tc.growth)
+			var capacities []int
+			for i := range tc.expected[len(tc.expected)-1] {
+				v.Append(i)
+				if c := v.Cap(); len(capacities) == 0 || capacities[len(capacities)-1] != c {
+					capacities = append(capacities, c)
+				}
+			}
+			assert.Equal(t, tc.expected, capacities, "Expected: %v, Got: %v", tc.expected, capacities)
+			assert.Equal(t, len(tc.expected), v.Stats().Resizes)
+		})
+	}
+
+	// A large insert grows straight to what it needs.
+	v := New[int](Doubling)
+	v.Append(make([]int, 100)...)
+	assert.Equal(t, 100, v.Cap())
+}
+
+// TestGrowthAmortised measures the copies made by appending n elements one at
+// a time: O(n) in all, so O(1) per append, when growing by a factor, but O(n²)
+// when growing by a fixed increment.
+func TestGrowthAmortised(t *testing.T) {
+	testCases := []struct {
+		name     string
+		growth   Growth
+		expected analysis.Class
+		bound    float64 // most copies per element, if growing by a factor
+	}{
+		{"doubling", Doubling, analysis.Linear, 2},
+		{"one and a half", OneAndAHalf, analysis.Linear, 3},
+		{"increment", Increment(16), analysis.Quadratic, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := analysis.Measure(tc.name, func(n int) func(*analysis.Counter) {
+				return func(ops *analysis.Counter) {
+					v := New[int](tc.growth)
+					for i := range n {
+						v.Append(i)
+					}
+					ops.Add(v.Stats().Copies)
+				}
+			}, analysis.Options{Start: 1 << 10, Steps: 6, MinTime: time.Millisecond})
+
+			assert.Equal(t, tc.expected, report.Best(analysis.Ops), "Expected: %v, Got: %v\n%v", tc.expected, report.Best(analysis.Ops), report)
+			if tc.bound > 0 {
+				for _, s := range report.Samples {
+					assert.LessOrEqual(t, s.Ops/float64(s.N), tc.bound, "n = %d", s.N)
+				}
+			}
+		})
+	}
+}
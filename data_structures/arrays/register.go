@@ -0,0 +1,40 @@
+package arrays
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "data_structures/arrays"
+	registry.Register(registry.Algorithm{
+		Name:     "Vector.Append",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Appending to a dynamic array that doubles when full, amortised over n appends",
+		Time:     "O(1) amortised",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			v := New[int](Doubling)
+			for i := range n {
+				v.Append(i)
+			}
+			return v.Stats()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Vector.Rotate",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Rotation of a dynamic array by three reversals",
+		Time:     "O(n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run: func(n int) any {
+			v := New[int](nil)
+			for i := range n {
+				v.Append(i)
+			}
+			v.Rotate(n / 3)
+			return v.At(0)
+		},
+	})
+}
@@ -0,0 +1,235 @@
+// Package arrays implements a generic dynamic array, Vector, whose growth
+// policy is a pluggable strategy and whose work is counted, to show
+// amortised analysis in numbers.
+package arrays
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Stats counts the work a Vector has done on its buffer.
+type Stats struct {
+	Resizes int // buffers allocated to grow
+	Copies  int // elements copied into new buffers
+	Moves   int // elements shifted within the buffer to insert or delete
+}
+
+// Vector is a dynamic array: a sequence that grows as elements are added,
+// reallocating its buffer by its Growth policy when it is full.
+//
+// Its buffer is a gap buffer: the elements sit at both ends, around an unused
+// gap. Normally the gap is kept after the last element, so the elements are
+// contiguous as in a plain array and inserting or deleting at index i shifts
+// the n-i elements after it. In gap mode, set by SetGapMode, the gap instead
+// stays where the last edit was, and the next edit moves only the elements
+// between it and the gap. Edits near a cursor, such as typing in a text
+// editor, then cost O(1) each however long the sequence is.
+//
+// The zero value is an empty vector growing by Doubling, ready to use.
+type Vector[T any] struct {
+	buf      []T // len(buf) is the capacity
+	gapStart int // the gap is buf[gapStart:gapEnd]
+	gapEnd   int
+	gapMode  bool
+	growth   Growth
+	stats    Stats
+}
+
+// New returns an empty vector growing by growth, or by Doubling if growth is
+// nil.
+func New[T any](growth Growth) *Vector[T] {
+	return &Vector[T]{growth: growth}
+}
+
+// Len returns the number of elements.
+func (v *Vector[T]) Len() int {
+	return len(v.buf) - (v.gapEnd - v.gapStart)
+}
+
+// Cap returns the number of elements the vector holds before it grows.
+func (v *Vector[T]) Cap() int {
+	return len(v.buf)
+}
+
+// Stats returns the work counted since the vector was made or ResetStats was
+// last called.
+func (v *Vector[T]) Stats() Stats {
+	return v.stats
+}
+
+// ResetStats sets the counts of Stats to zero.
+func (v *Vector[T]) ResetStats() {
+	v.stats = Stats{}
+}
+
+// SetGapMode turns gap mode on or off; see Vector. Turning it off moves the
+// gap back after the last element.
+func (v *Vector[T]) SetGapMode(on bool) {
+	v.gapMode = on
+	if !on {
+		v.moveGap(v.Len())
+	}
+}
+
+// index returns the index in buf of element i. It panics if i is out of
+// range.
+func (v *Vector[T]) index(i int) int {
+	if i < 0 || i >= v.Len() {
+		panic(fmt.Sprintf("arrays: index %d out of range with length %d", i, v.Len()))
+	}
+	if i < v.gapStart {
+		return i
+	}
+	return i + v.gapEnd - v.gapStart
+}
+
+// At returns element i. It panics if i is out of range.
+func (v *Vector[T]) At(i int) T {
+	return v.buf[v.index(i)]
+}
+
+// Set replaces element i with x. It panics if i is out of range.
+func (v *Vector[T]) Set(i int, x T) {
+	v.buf[v.index(i)] = x
+}
+
+// Append adds xs after the last element. Without gap mode it takes amortised
+// O(1) time per element with any Factor policy.
+func (v *Vector[T]) Append(xs ...T) {
+	v.Insert(v.Len(), xs...)
+}
+
+// Insert inserts xs before element i, or after the last if i is Len(). It
+// panics if i is out of range.
+func (v *Vector[T]) Insert(i int, xs ...T) {
+	n := v.Len()
+	if i < 0 || i > n {
+		panic(fmt.Sprintf("arrays: insert at %d out of range with length %d", i, n))
+	}
+	if len(xs) > v.gapEnd-v.gapStart {
+		v.grow(n + len(xs))
+	}
+	if v.gapMode {
+		v.moveGap(i)
+	} else {
+		// The gap is at the end: shift the elements after i into it.
+		copy(v.buf[i+len(xs):], v.buf[i:n])
+		v.stats.Moves += n - i
+	}
+	copy(v.buf[i:], xs)
+	v.gapStart += len(xs)
+}
+
+// Delete removes the elements i to j-1. It panics if they are out of range.
+func (v *Vector[T]) Delete(i, j int) {
+	n := v.Len()
+	if i < 0 || j > n || i > j {
+		panic(fmt.Sprintf("arrays: delete of [%d:%d] out of range with length %d", i, j, n))
+	}
+	if v.gapMode {
+		// Open the gap at i and widen it over the deleted elements.
+		v.moveGap(i)
+		clear(v.buf[v.gapEnd : v.gapEnd+j-i])
+		v.gapEnd += j - i
+		return
+	}
+	copy(v.buf[i:], v.buf[j:n])
+	clear(v.buf[n-(j-i) : n])
+	v.stats.Moves += n - j
+	v.gapStart -= j - i
+}
+
+// Reverse reverses the order of the elements in place.
+func (v *Vector[T]) Reverse() {
+	v.moveGap(v.Len())
+	slices.Reverse(v.buf[:v.gapStart])
+}
+
+// Rotate moves every element k places towards the front, those pushed off
+// the front going round to the back; a negative k rotates towards the back.
+// It reverses the first k elements, the rest and then the whole, which takes
+// O(n) time and O(1) space.
+func (v *Vector[T]) Rotate(k int) {
+	n := v.Len()
+	if n == 0 {
+		return
+	}
+	if k %= n; k < 0 {
+		k += n
+	}
+	v.moveGap(n)
+	a := v.buf[:n]
+	slices.Reverse(a[:k])
+	slices.Reverse(a[k:])
+	slices.Reverse(a)
+}
+
+// moveGap moves the gap to start before element i, shifting the elements
+// between it and i across it.
+func (v *Vector[T]) moveGap(i int) {
+	width := v.gapEnd - v.gapStart
+	switch {
+	case i < v.gapStart:
+		// Shift buf[i:gapStart] to the end of the gap.
+		k := v.gapStart - i
+		copy(v.buf[v.gapEnd-k:], v.buf[i:v.gapStart])
+		clear(v.buf[i:min(i+width, v.gapStart)])
+		v.stats.Moves += k
+	case i > v.gapStart:
+		// Shift the k elements after the gap to its start.
+		k := i - v.gapStart
+		copy(v.buf[v.gapStart:], v.buf[v.gapEnd:v.gapEnd+k])
+		clear(v.buf[max(i, v.gapEnd) : v.gapEnd+k])
+		v.stats.Moves += k
+	}
+	v.gapStart, v.gapEnd = i, i+width
+}
+
+// grow reallocates the buffer with room for at least needed elements, as
+// decided by the growth policy, keeping the gap where it is.
+func (v *Vector[T]) grow(needed int) {
+	growth := v.growth
+	if growth == nil {
+		growth = Doubling
+	}
+	capacity := max(growth(len(v.buf), needed), needed)
+
+	buf := make([]T, capacity)
+	tail := len(v.buf) - v.gapEnd
+	copy(buf, v.buf[:v.gapStart])
+	copy(buf[capacity-tail:], v.buf[v.gapEnd:])
+	v.stats.Resizes++
+	v.stats.Copies += v.gapStart + tail
+	v.buf, v.gapEnd = buf, capacity-tail
+}
+
+// All returns an iterator over the indices and elements of the vector, in
+// order. The vector must not change during the iteration.
+func (v *Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, x := range v.buf[:v.gapStart] {
+			if !yield(i, x) {
+				return
+			}
+		}
+		for i, x := range v.buf[v.gapEnd:] {
+			if !yield(v.gapStart+i, x) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the elements of the vector, in order. The
+// vector must not change during the iteration.
+func (v *Vector[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range v.All() {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package arrays
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVectorEdits tests inserting and deleting at the ends and in the middle,
+// with and without gap mode.
+func TestVectorEdits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		edit     func(v *Vector[int])
+		expected []int
+	}{
+		{"append", func(v *Vector[int]) { v.Append(1, 2, 3) }, []int{1, 2, 3}},
+		{"insert at front", func(v *Vector[int]) {
+			v.Append(2, 3)
+			v.Insert(0, 0, 1)
+		}, []int{0, 1, 2, 3}},
+		{"insert in the middle", func(v *Vector[int]) {
+			v.Append(1, 4)
+			v.Insert(1, 2, 3)
+		}, []int{1, 2, 3, 4}},
+		{"delete a range", func(v *Vector[int]) {
+			v.Append(1, 2, 3, 4, 5)
+			v.Delete(1, 3)
+		}, []int{1, 4, 5}},
+		{"delete everything", func(v *Vector[int]) {
+			v.Append(1, 2, 3)
+			v.Delete(0, 3)
+		}, nil},
+		{"empty delete", func(v *Vector[int]) {
+			v.Append(1, 2)
+			v.Delete(1, 1)
+		}, []int{1, 2}},
+		{"set", func(v *Vector[int]) {
+			v.Append(1, 2, 3)
+			v.Set(2, 9)
+		}, []int{1, 2, 9}},
+	}
+
+	for _, gap := range []bool{false, true} {
+		for _, tc := range testCases {
+			name := tc.name
+			if gap {
+				name += " in gap mode"
+			}
+			t.Run(name, func(t *testing.T) {
+				v := New[int](nil)
+				v.SetGapMode(gap)
+				tc.edit(v)
+				values := slices.Collect(v.Values())
+				assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+				assert.Equal(t, len(tc.expected), v.Len())
+			})
+		}
+	}
+}
+
+// TestVectorPanics tests the messages of out-of-range accesses.
+func TestVectorPanics(t *testing.T) {
+	var v Vector[string]
+	v.Append("a", "b")
+	assert.PanicsWithValue(t, "arrays: index 2 out of range with length 2", func() { v.At(2) })
+	assert.PanicsWithValue(t, "arrays: index -1 out of range with length 2", func() { v.Set(-1, "x") })
+	assert.PanicsWithValue(t, "arrays: insert at 3 out of range with length 2", func() { v.Insert(3, "x") })
+	assert.PanicsWithValue(t, "arrays: delete of [1:0] out of range with length 2", func() { v.Delete(1, 0) })
+	assert.PanicsWithValue(t, "arrays: growth factor 1 is not greater than 1", func() { Factor(1) })
+	assert.PanicsWithValue(t, "arrays: growth increment 0 is less than 1", func() { Increment(0) })
+}
+
+// TestVectorRotateReverse tests rotations by any amount and reversal.
+func TestVectorRotateReverse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		k        int
+		expected []int
+	}{
+		{"zero", 0, []int{1, 2, 3, 4, 5}},
+		{"left", 2, []int{3, 4, 5, 1, 2}},
+		{"right", -1, []int{5, 1, 2, 3, 4}},
+		{"full turn", 5, []int{1, 2, 3, 4, 5}},
+		{"more than a turn", 7, []int{3, 4, 5, 1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New[int](nil)
+			v.SetGapMode(true)
+			v.Append(1, 2, 4, 5)
+			v.Insert(2, 3) // leaves the gap in the middle
+			v.Rotate(tc.k)
+			values := slices.Collect(v.Values())
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+
+			v.Reverse()
+			slices.Reverse(values)
+			assert.Equal(t, values, slices.Collect(v.Values()))
+		})
+	}
+
+	var empty Vector[int]
+	empty.Rotate(3)
+	empty.Reverse()
+	assert.Zero(t, empty.Len())
+}
+
+// TestVectorModel compares a vector with a slice under random edits,
+// switching gap mode on and off along the way.
+func TestVectorModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, growth := range []Growth{Doubling, OneAndAHalf, Increment(3)} {
+		v := New[int](growth)
+		var model []int
+		for step := range 5000 {
+			if step%500 == 0 {
+				v.SetGapMode(rng.IntN(2) == 0)
+			}
+			n := len(model)
+			switch op := rng.IntN(10); {
+			case op < 5:
+				i := rng.IntN(n + 1)
+				xs := []int{step, -step}[:1+rng.IntN(2)]
+				v.Insert(i, xs...)
+				model = slices.Insert(model, i, xs...)
+			case op < 8 && n > 0:
+				i := rng.IntN(n)
+				j := i + rng.IntN(min(3, n-i)+1)
+				v.Delete(i, j)
+				model = slices.Delete(model, i, j)
+			case op < 9 && n > 0:
+				i := rng.IntN(n)
+				v.Set(i, step)
+				model[i] = step
+			case n > 0:
+				k := rng.IntN(2*n) - n
+				v.Rotate(k)
+				if k < 0 {
+					k += n
+				}
+				model = append(model[k:], model[:k]...)
+			}
+
+			require.Equal(t, len(model), v.Len())
+			require.LessOrEqual(t, v.Len(), v.Cap())
+			if n := len(model); n > 0 {
+				i := rng.IntN(n)
+				require.Equal(t, model[i], v.At(i), "step %d, index %d", step, i)
+			}
+		}
+		require.Equal(t, model, slices.Collect(v.Values()))
+	}
+}
+
+// TestVectorGapMode tests that edits at a moving cursor shift a few elements
+// each in gap mode, but the whole tail without it.
+func TestVectorGapMode(t *testing.T) {
+	typing := func(gap bool) Stats {
+		v := New[byte](nil)
+		v.Append(make([]byte, 1000)...)
+		v.SetGapMode(gap)
+		v.ResetStats()
+		// Type 100 characters in the middle, correcting every tenth.
+		cursor := 500
+		for i := range 100 {
+			v.Insert(cursor, 'a'+byte(i%26))
+			cursor++
+			if i%10 == 9 {
+				cursor--
+				v.Delete(cursor, cursor+1)
+			}
+		}
+		return v.Stats()
+	}
+
+	plain, gap := typing(false), typing(true)
+	// Without a gap, each of the 110 edits shifts the 500 elements after the
+	// cursor. With one, the first insert moves the gap across those 500 and
+	// each deletion moves it back over one character.
+	assert.Equal(t, 110*500, plain.Moves)
+	assert.Equal(t, 500+10, gap.Moves)
+}
+
+// TestVectorAll tests iteration across the gap, stopping early.
+func TestVectorAll(t *testing.T) {
+	v := New[string](nil)
+	v.SetGapMode(true)
+	v.Append("a", "c", "d")
+	v.Insert(1, "b")
+
+	var indices []int
+	for i, s := range v.All() {
+		indices = append(indices, i)
+		assert.Equal(t, v.At(i), s)
+		if s == "c" {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2}, indices)
+}
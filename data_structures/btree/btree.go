@@ -0,0 +1,752 @@
+// Package btree implements a B-tree: an ordered map whose nodes hold many
+// keys each, so that the tree stays shallow and every lookup touches few
+// nodes. That makes it the index of choice when nodes live on disk.
+//
+// A Tree keeps its nodes either in memory (New) or in fixed-size pages of a
+// file (Open), which is read through a small buffer pool of decoded nodes,
+// so the tree may be larger than memory and reopened after the process
+// exits. Both run the same algorithms.
+//
+// The order m of a tree is the largest number of children of a node. Every
+// node but the root holds between ⌈m/2⌉-1 and m-1 keys, and all leaves are
+// at the same depth, so a tree of n keys has height O(log_m n) and lookups,
+// insertions and deletions touch O(log_m n) nodes.
+package btree
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+)
+
+var (
+	// ErrNotEmpty is returned by Load on a tree that already has keys.
+	ErrNotEmpty = errors.New("btree: tree is not empty")
+	// ErrUnsorted is returned by Load for keys that are not strictly
+	// increasing.
+	ErrUnsorted = errors.New("btree: keys are not strictly increasing")
+	// ErrClosed is the error of a tree after Close.
+	ErrClosed = errors.New("btree: tree is closed")
+)
+
+// Tree is a B-tree mapping keys of type K to values of type V.
+//
+// Operations on a tree stored in a file can fail to read or write a page.
+// The first such error stops the tree: it is returned by Err, Put, Delete,
+// Load, Flush and Close, and every later operation does nothing and returns
+// zero values. A Put, Delete or Load that would make a node too large for its
+// page is refused before it changes anything and does not stop the tree. A
+// tree in memory never fails until it is closed.
+type Tree[K, V any] struct {
+	pages pager[K, V]
+	cmp   func(a, b K) int
+	order int
+	root  pageID // 0 when the tree is empty
+	len   int
+	err   error
+	dry   *rehearsal[K, V] // set while an update is rehearsed
+}
+
+// New returns an empty in-memory tree of the given order, with keys in
+// their natural order. It panics if order is less than 3.
+func New[K cmp.Ordered, V any](order int) *Tree[K, V] {
+	return NewFunc[K, V](order, cmp.Compare[K])
+}
+
+// NewFunc returns an empty in-memory tree of the given order, with keys
+// ordered by cmp, which returns a negative number, zero or a positive number
+// as a is less than, equal to or greater than b. It panics if order is less
+// than 3.
+func NewFunc[K, V any](order int, cmp func(a, b K) int) *Tree[K, V] {
+	checkOrder(order)
+	return &Tree[K, V]{pages: &memoryPager[K, V]{nodes: []*node[K, V]{nil}}, cmp: cmp, order: order}
+}
+
+// checkOrder panics if order is too small for a B-tree.
+func checkOrder(order int) {
+	if order < 3 {
+		panic("btree: order must be at least 3")
+	}
+}
+
+// node is a node of a tree. An internal node has one more child than keys,
+// and the keys of child i lie between keys i-1 and i.
+type node[K, V any] struct {
+	id       pageID
+	keys     []K
+	values   []V
+	children []pageID // nil in a leaf
+}
+
+// leaf reports whether n is a leaf.
+func (n *node[K, V]) leaf() bool {
+	return n.children == nil
+}
+
+// failure carries a storage error from deep in the recursion of an operation
+// up to the method that started it; see catch.
+type failure struct{ err error }
+
+// catch recovers a failure and makes its error the error of the tree. Every
+// exported method that touches nodes defers it.
+func (t *Tree[K, V]) catch() {
+	if r := recover(); r != nil {
+		f, ok := r.(failure)
+		if !ok {
+			panic(r)
+		}
+		t.err = f.err
+	}
+}
+
+// check panics with a failure if err is not nil.
+func check(err error) {
+	if err != nil {
+		panic(failure{err})
+	}
+}
+
+// node returns the node with the given id, or its copy during a rehearsal.
+func (t *Tree[K, V]) node(id pageID) *node[K, V] {
+	n, err := t.pages.read(id)
+	check(err)
+	if t.dry != nil {
+		return t.dry.copy(n)
+	}
+	return n
+}
+
+// write records that node n has changed.
+func (t *Tree[K, V]) write(n *node[K, V]) {
+	if t.dry != nil {
+		t.dry.written[n.id] = true
+		return
+	}
+	t.pages.write(n)
+}
+
+// alloc returns a new empty node, a leaf or an internal node.
+func (t *Tree[K, V]) alloc(leaf bool) *node[K, V] {
+	n, err := t.pages.alloc()
+	check(err)
+	if !leaf {
+		n.children = []pageID{}
+	}
+	return n
+}
+
+// free releases node n.
+func (t *Tree[K, V]) free(n *node[K, V]) {
+	if t.dry != nil {
+		delete(t.dry.written, n.id)
+		return
+	}
+	check(t.pages.free(n))
+}
+
+// rehearsal holds the copies of the nodes that an update reads while it is
+// rehearsed, and the ids of those it changes and keeps.
+type rehearsal[K, V any] struct {
+	copies  map[pageID]*node[K, V]
+	written map[pageID]bool
+}
+
+// copy returns the copy of node n, making it on first use.
+func (r *rehearsal[K, V]) copy(n *node[K, V]) *node[K, V] {
+	c, ok := r.copies[n.id]
+	if !ok {
+		c = &node[K, V]{id: n.id, keys: slices.Clone(n.keys), values: slices.Clone(n.values), children: slices.Clone(n.children)}
+		r.copies[n.id] = c
+	}
+	return c
+}
+
+// rehearse runs update on copies of the nodes it reads, leaving the tree as
+// it was, and returns the error of fits for the first node it would leave
+// too large. update must not allocate nodes.
+func (t *Tree[K, V]) rehearse(fits func(n *node[K, V]) error, update func()) error {
+	root, count := t.root, t.len
+	t.dry = &rehearsal[K, V]{copies: make(map[pageID]*node[K, V]), written: make(map[pageID]bool)}
+	defer func() { t.root, t.len, t.dry = root, count, nil }()
+	update()
+	for id := range t.dry.written {
+		if err := fits(t.dry.copies[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hold keeps every node read from now on in memory until the returned
+// function is called, so that an update can change them through the
+// pointers it holds.
+func (t *Tree[K, V]) hold() func() {
+	t.pages.hold()
+	return func() { check(t.pages.release()) }
+}
+
+// Len returns the number of keys.
+func (t *Tree[K, V]) Len() int {
+	return t.len
+}
+
+// Order returns the largest number of children of a node.
+func (t *Tree[K, V]) Order() int {
+	return t.order
+}
+
+// maxKeys and minKeys return the bounds on the number of keys of a node
+// other than the root.
+func (t *Tree[K, V]) maxKeys() int { return t.order - 1 }
+func (t *Tree[K, V]) minKeys() int { return (t.order+1)/2 - 1 }
+
+// Height returns the number of levels of nodes, 0 for an empty tree.
+func (t *Tree[K, V]) Height() (h int) {
+	if t.err != nil {
+		return 0
+	}
+	defer t.catch()
+	for id := t.root; id != 0; h++ {
+		n := t.node(id)
+		if n.leaf() {
+			id = 0
+		} else {
+			id = n.children[0]
+		}
+	}
+	return h
+}
+
+// Err returns the error that stopped the tree, or nil.
+func (t *Tree[K, V]) Err() error {
+	return t.err
+}
+
+// Flush writes the modified nodes of a tree stored in a file, and its
+// header, to the file. It does nothing to a tree in memory.
+func (t *Tree[K, V]) Flush() error {
+	if t.err == nil {
+		t.err = t.pages.sync(t.root, t.len)
+	}
+	return t.err
+}
+
+// Close flushes the tree and closes its file. The tree cannot be used
+// afterwards.
+func (t *Tree[K, V]) Close() error {
+	err := t.Flush()
+	if cerr := t.pages.close(); err == nil {
+		err = cerr
+	}
+	if t.err == nil {
+		t.err = ErrClosed
+	}
+	return err
+}
+
+// search returns the position of k among the keys of n and whether it is
+// there.
+func (t *Tree[K, V]) search(n *node[K, V], k K) (int, bool) {
+	return slices.BinarySearchFunc(n.keys, k, t.cmp)
+}
+
+// Get returns the value of key k, or false if k is absent.
+func (t *Tree[K, V]) Get(k K) (v V, ok bool) {
+	if t.err != nil {
+		return v, false
+	}
+	defer t.catch()
+	for id := t.root; id != 0; {
+		n := t.node(id)
+		i, found := t.search(n, k)
+		if found {
+			return n.values[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		id = n.children[i]
+	}
+	return v, false
+}
+
+// Min returns the smallest key with its value, or false if the tree is
+// empty.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	return t.edge(func(n *node[K, V]) int { return 0 }, func(n *node[K, V]) int { return 0 })
+}
+
+// Max returns the largest key with its value, or false if the tree is
+// empty.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	return t.edge(func(n *node[K, V]) int { return len(n.children) - 1 }, func(n *node[K, V]) int { return len(n.keys) - 1 })
+}
+
+// edge follows the child chosen by child from the root down to a leaf and
+// returns the key chosen by key there.
+func (t *Tree[K, V]) edge(child, key func(n *node[K, V]) int) (k K, v V, ok bool) {
+	if t.err != nil || t.root == 0 {
+		return k, v, false
+	}
+	defer t.catch()
+	n := t.node(t.root)
+	for !n.leaf() {
+		n = t.node(n.children[child(n)])
+	}
+	i := key(n)
+	return n.keys[i], n.values[i], true
+}
+
+// Put sets the value of key k to v, adding k if it is absent. For a tree
+// stored in a file, it returns an error wrapping ErrNodeTooLarge, and leaves
+// the tree unchanged and usable, if a node it would write does not fit in a
+// page. It also returns the error that stops the tree, if any.
+func (t *Tree[K, V]) Put(k K, v V) (err error) {
+	if t.err != nil {
+		return t.err
+	}
+	defer func() {
+		t.catch()
+		if t.err != nil {
+			err = t.err
+		}
+	}()
+	defer t.hold()()
+
+	if fits := t.pages.fits(); fits != nil {
+		if err := t.checkPut(fits, k, v); err != nil {
+			return err
+		}
+	}
+	if t.root == 0 {
+		n := t.alloc(true)
+		n.keys, n.values = []K{k}, []V{v}
+		t.write(n)
+		t.root, t.len = n.id, 1
+		return nil
+	}
+	old := t.node(t.root)
+	if sk, sv, right, split := t.insert(old, k, v); split {
+		root := t.alloc(false)
+		root.keys, root.values = []K{sk}, []V{sv}
+		root.children = []pageID{old.id, right}
+		t.write(root)
+		t.root = root.id
+	}
+	return nil
+}
+
+// checkPut returns the error of fits for the first node that putting k and
+// v would leave too large, without changing the tree. It follows the path
+// of k down to the node where it belongs and then the splits that inserting
+// it there would cause back up, the way insert does.
+func (t *Tree[K, V]) checkPut(fits func(n *node[K, V]) error, k K, v V) error {
+	if t.root == 0 {
+		return fits(&node[K, V]{keys: []K{k}, values: []V{v}})
+	}
+	var path []*node[K, V]
+	var at []int
+	for id := t.root; id != 0; {
+		n := t.node(id)
+		i, found := t.search(n, k)
+		if found {
+			values := slices.Clone(n.values)
+			values[i] = v
+			return fits(&node[K, V]{keys: n.keys, values: values, children: n.children})
+		}
+		path, at = append(path, n), append(at, i)
+		id = 0
+		if !n.leaf() {
+			id = n.children[i]
+		}
+	}
+	for d := len(path) - 1; d >= 0; d-- {
+		n, i := path[d], at[d]
+		// Page ids all take the same space, so 0 stands in for the
+		// split child.
+		next := &node[K, V]{
+			keys:   slices.Insert(slices.Clone(n.keys), i, k),
+			values: slices.Insert(slices.Clone(n.values), i, v),
+		}
+		if !n.leaf() {
+			next.children = slices.Insert(slices.Clone(n.children), i+1, 0)
+		}
+		if len(next.keys) <= t.maxKeys() {
+			return fits(next)
+		}
+		mid := len(next.keys) / 2
+		left := &node[K, V]{keys: next.keys[:mid], values: next.values[:mid]}
+		right := &node[K, V]{keys: next.keys[mid+1:], values: next.values[mid+1:]}
+		if !n.leaf() {
+			left.children, right.children = next.children[:mid+1], next.children[mid+1:]
+		}
+		if err := fits(left); err != nil {
+			return err
+		}
+		if err := fits(right); err != nil {
+			return err
+		}
+		k, v = next.keys[mid], next.values[mid]
+	}
+	return fits(&node[K, V]{keys: []K{k}, values: []V{v}, children: []pageID{0, 0}})
+}
+
+// insert puts k and v into the subtree rooted at n. If n overflows, it is
+// split in two: insert returns the middle key and value, to go up into the
+// parent, and the id of the new right half.
+func (t *Tree[K, V]) insert(n *node[K, V], k K, v V) (sk K, sv V, right pageID, split bool) {
+	i, found := t.search(n, k)
+	switch {
+	case found:
+		n.values[i] = v
+	case n.leaf():
+		n.keys = slices.Insert(n.keys, i, k)
+		n.values = slices.Insert(n.values, i, v)
+		t.len++
+	default:
+		ck, cv, cr, csplit := t.insert(t.node(n.children[i]), k, v)
+		if !csplit {
+			return sk, sv, 0, false
+		}
+		n.keys = slices.Insert(n.keys, i, ck)
+		n.values = slices.Insert(n.values, i, cv)
+		n.children = slices.Insert(n.children, i+1, cr)
+	}
+	t.write(n)
+	if len(n.keys) <= t.maxKeys() {
+		return sk, sv, 0, false
+	}
+	sk, sv, right = t.split(n)
+	return sk, sv, right, true
+}
+
+// split moves the keys of n after the middle one to a new node and returns
+// the middle key and value, which are removed from n, and the id of the new
+// node.
+func (t *Tree[K, V]) split(n *node[K, V]) (K, V, pageID) {
+	mid := len(n.keys) / 2
+	right := t.alloc(n.leaf())
+	right.keys = slices.Clone(n.keys[mid+1:])
+	right.values = slices.Clone(n.values[mid+1:])
+	if !n.leaf() {
+		right.children = slices.Clone(n.children[mid+1:])
+		n.children = truncate(n.children, mid+1)
+	}
+	k, v := n.keys[mid], n.values[mid]
+	n.keys, n.values = truncate(n.keys, mid), truncate(n.values, mid)
+	t.write(n)
+	t.write(right)
+	return k, v, right.id
+}
+
+// truncate shortens s to n elements, clearing the rest so that they can be
+// garbage collected.
+func truncate[S ~[]E, E any](s S, n int) S {
+	clear(s[n:])
+	return s[:n]
+}
+
+// Delete removes key k and reports whether it was present. Merging two
+// nodes or moving a key between them can make a node larger in bytes
+// though it holds no more keys than allowed: for a tree stored in a file,
+// Delete returns an error wrapping ErrNodeTooLarge, and leaves the tree
+// unchanged and usable, if a node it would write does not fit in a page.
+// It also returns the error that stops the tree, if any.
+func (t *Tree[K, V]) Delete(k K) (ok bool, err error) {
+	if t.err != nil {
+		return false, t.err
+	}
+	if t.root == 0 {
+		return false, nil
+	}
+	defer func() {
+		t.catch()
+		if t.err != nil {
+			ok, err = false, t.err
+		}
+	}()
+	defer t.hold()()
+
+	if fits := t.pages.fits(); fits != nil {
+		if err := t.rehearse(fits, func() { t.remove(k) }); err != nil {
+			return false, err
+		}
+	}
+	return t.remove(k), nil
+}
+
+// remove removes key k, as Delete does, and reports whether it was present.
+func (t *Tree[K, V]) remove(k K) bool {
+	root := t.node(t.root)
+	if !t.delete(root, k) {
+		return false
+	}
+	if len(root.keys) == 0 {
+		// The root lost its last key: the tree is empty or one level
+		// lower.
+		t.root = 0
+		if !root.leaf() {
+			t.root = root.children[0]
+		}
+		t.free(root)
+	}
+	return true
+}
+
+// delete removes k from the subtree rooted at n and reports whether it was
+// there. n may be left with too few keys, for its parent to fix.
+func (t *Tree[K, V]) delete(n *node[K, V], k K) bool {
+	i, found := t.search(n, k)
+	switch {
+	case n.leaf():
+		if !found {
+			return false
+		}
+		n.keys = slices.Delete(n.keys, i, i+1)
+		n.values = slices.Delete(n.values, i, i+1)
+		t.write(n)
+		t.len--
+	case found:
+		// Replace k by its predecessor, the largest key of the subtree
+		// before it.
+		n.keys[i], n.values[i] = t.deleteMax(t.node(n.children[i]))
+		t.write(n)
+		t.fix(n, i)
+		t.len--
+	default:
+		if !t.delete(t.node(n.children[i]), k) {
+			return false
+		}
+		t.fix(n, i)
+	}
+	return true
+}
+
+// deleteMax removes the largest key of the subtree rooted at n and returns
+// it with its value.
+func (t *Tree[K, V]) deleteMax(n *node[K, V]) (K, V) {
+	if !n.leaf() {
+		last := len(n.children) - 1
+		k, v := t.deleteMax(t.node(n.children[last]))
+		t.fix(n, last)
+		return k, v
+	}
+	last := len(n.keys) - 1
+	k, v := n.keys[last], n.values[last]
+	n.keys, n.values = truncate(n.keys, last), truncate(n.values, last)
+	t.write(n)
+	return k, v
+}
+
+// fix restores the minimum number of keys of child i of n, if it lost one,
+// by borrowing a key from a sibling that has one to spare or else by
+// merging the child with a sibling.
+func (t *Tree[K, V]) fix(n *node[K, V], i int) {
+	c := t.node(n.children[i])
+	if len(c.keys) >= t.minKeys() {
+		return
+	}
+	if i > 0 {
+		if left := t.node(n.children[i-1]); len(left.keys) > t.minKeys() {
+			t.rotateRight(n, i-1, left, c)
+			return
+		}
+	}
+	if i < len(n.children)-1 {
+		if right := t.node(n.children[i+1]); len(right.keys) > t.minKeys() {
+			t.rotateLeft(n, i, c, right)
+			return
+		}
+	}
+	if i > 0 {
+		t.merge(n, i-1, t.node(n.children[i-1]), c)
+	} else {
+		t.merge(n, i, c, t.node(n.children[i+1]))
+	}
+}
+
+// rotateRight moves the last key of left up into key i of n, and key i of
+// n down to the front of right, where left and right are children i and
+// i+1 of n.
+func (t *Tree[K, V]) rotateRight(n *node[K, V], i int, left, right *node[K, V]) {
+	last := len(left.keys) - 1
+	right.keys = slices.Insert(right.keys, 0, n.keys[i])
+	right.values = slices.Insert(right.values, 0, n.values[i])
+	n.keys[i], n.values[i] = left.keys[last], left.values[last]
+	left.keys, left.values = truncate(left.keys, last), truncate(left.values, last)
+	if !left.leaf() {
+		right.children = slices.Insert(right.children, 0, left.children[last+1])
+		left.children = truncate(left.children, last+1)
+	}
+	t.write(n)
+	t.write(left)
+	t.write(right)
+}
+
+// rotateLeft moves the first key of right up into key i of n, and key i of
+// n down to the end of left, where left and right are children i and i+1
+// of n.
+func (t *Tree[K, V]) rotateLeft(n *node[K, V], i int, left, right *node[K, V]) {
+	left.keys = append(left.keys, n.keys[i])
+	left.values = append(left.values, n.values[i])
+	n.keys[i], n.values[i] = right.keys[0], right.values[0]
+	right.keys = slices.Delete(right.keys, 0, 1)
+	right.values = slices.Delete(right.values, 0, 1)
+	if !right.leaf() {
+		left.children = append(left.children, right.children[0])
+		right.children = slices.Delete(right.children, 0, 1)
+	}
+	t.write(n)
+	t.write(left)
+	t.write(right)
+}
+
+// merge moves key i of n and all of right into left, where left and right
+// are children i and i+1 of n, and frees right.
+func (t *Tree[K, V]) merge(n *node[K, V], i int, left, right *node[K, V]) {
+	left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+	left.values = append(append(left.values, n.values[i]), right.values...)
+	if !left.leaf() {
+		left.children = append(left.children, right.children...)
+	}
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.values = slices.Delete(n.values, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+	t.write(n)
+	t.write(left)
+	t.free(right)
+}
+
+// All returns an iterator over the keys and values in increasing order of
+// keys. The tree must not change during the iteration.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return t.scan(nil, nil)
+}
+
+// Range returns an iterator over the keys from lo up to but not including
+// hi, with their values, in increasing order. The tree must not change
+// during the iteration.
+func (t *Tree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return t.scan(&lo, &hi)
+}
+
+// scan returns an iterator over the keys from lo up to but not including
+// hi, either of which may be nil for no bound.
+func (t *Tree[K, V]) scan(lo, hi *K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.err != nil || t.root == 0 {
+			return
+		}
+		defer t.catch()
+		t.ascend(t.root, lo, hi, yield)
+	}
+}
+
+// ascend yields the keys of the subtree with the given id from lo up to but
+// not including hi, and reports whether to go on.
+func (t *Tree[K, V]) ascend(id pageID, lo, hi *K, yield func(K, V) bool) bool {
+	n := t.node(id)
+	i := 0
+	if lo != nil {
+		i, _ = t.search(n, *lo)
+	}
+	for ; i < len(n.keys); i++ {
+		if !n.leaf() && !t.ascend(n.children[i], lo, hi, yield) {
+			return false
+		}
+		if hi != nil && t.cmp(n.keys[i], *hi) >= 0 {
+			return false
+		}
+		if !yield(n.keys[i], n.values[i]) {
+			return false
+		}
+	}
+	return n.leaf() || t.ascend(n.children[len(n.keys)], lo, hi, yield)
+}
+
+// Load fills an empty tree with the keys and values of seq, whose keys must
+// be strictly increasing. It builds the tree bottom up, one level at a time
+// with every node nearly full, which takes O(n) time and writes each node
+// once. It returns ErrNotEmpty or ErrUnsorted, leaving the tree unchanged,
+// if the tree has keys or seq is not sorted, and for a tree stored in a
+// file, an error wrapping ErrNodeTooLarge if a node would not fit in a page.
+func (t *Tree[K, V]) Load(seq iter.Seq2[K, V]) (err error) {
+	if t.err != nil {
+		return t.err
+	}
+	if t.root != 0 {
+		return ErrNotEmpty
+	}
+	var keys []K
+	var values []V
+	for k, v := range seq {
+		if len(keys) > 0 && t.cmp(keys[len(keys)-1], k) >= 0 {
+			return ErrUnsorted
+		}
+		keys, values = append(keys, k), append(values, v)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if fits := t.pages.fits(); fits != nil {
+		// The nodes take the same space whatever their ids.
+		if _, err := t.build(keys, values, func(n *node[K, V]) (pageID, error) { return 0, fits(n) }); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		t.catch()
+		err = t.err
+	}()
+	t.root, _ = t.build(keys, values, func(n *node[K, V]) (pageID, error) {
+		node := t.alloc(n.leaf())
+		node.keys, node.values, node.children = n.keys, n.values, n.children
+		t.write(node)
+		return node.id, nil
+	})
+	t.len = len(keys)
+	return nil
+}
+
+// build lays out the keys and values, strictly increasing, in the nodes of
+// a tree, passing each node to add, from the leaves up, and returns the id
+// that add gives the root. It stops at the first error of add.
+func (t *Tree[K, V]) build(keys []K, values []V, add func(n *node[K, V]) (pageID, error)) (pageID, error) {
+	var children []pageID // ids of the level below, nil for the leaves
+	for {
+		// Use the fewest nodes that hold the keys of this level, less
+		// one separator between each two nodes that goes up to the next
+		// level, and share the keys evenly among them.
+		count := (len(keys) + t.order) / t.order
+		share := len(keys) - (count - 1)
+		var upKeys []K
+		var upValues []V
+		var ids []pageID
+		for j := range count {
+			size := share / count
+			if j < share%count {
+				size++
+			}
+			node := &node[K, V]{keys: keys[:size:size], values: values[:size:size]}
+			if children != nil {
+				node.children, children = children[:size+1:size+1], children[size+1:]
+			}
+			id, err := add(node)
+			if err != nil {
+				return 0, err
+			}
+			ids = append(ids, id)
+			keys, values = keys[size:], values[size:]
+			if j < count-1 {
+				upKeys, upValues = append(upKeys, keys[0]), append(upValues, values[0])
+				keys, values = keys[1:], values[1:]
+			}
+		}
+		if count == 1 {
+			return ids[0], nil
+		}
+		keys, values, children = upKeys, upValues, ids
+	}
+}
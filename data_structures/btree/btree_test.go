@@ -0,0 +1,281 @@
+package btree
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkTree checks the invariants of t: the keys are in order, every node
+// but the root has between ⌈m/2⌉-1 and m-1 keys, internal nodes have one
+// more child than keys, all leaves are at the same depth, and Len counts the
+// keys.
+func checkTree[K, V any](t *Tree[K, V]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(failure).err
+		}
+	}()
+	if t.root == 0 {
+		if t.len != 0 {
+			return fmt.Errorf("empty tree has Len %d", t.len)
+		}
+		return nil
+	}
+	leafDepth := -1
+	count := 0
+	var walk func(id pageID, depth int, lo, hi *K) error
+	walk = func(id pageID, depth int, lo, hi *K) error {
+		n := t.node(id)
+		if id != t.root && (len(n.keys) < t.minKeys() || len(n.keys) > t.maxKeys()) ||
+			id == t.root && (len(n.keys) < 1 || len(n.keys) > t.maxKeys()) {
+			return fmt.Errorf("node %d has %d keys", id, len(n.keys))
+		}
+		if len(n.values) != len(n.keys) {
+			return fmt.Errorf("node %d has %d keys and %d values", id, len(n.keys), len(n.values))
+		}
+		for i, k := range n.keys {
+			if i > 0 && t.cmp(n.keys[i-1], k) >= 0 || lo != nil && t.cmp(*lo, k) >= 0 || hi != nil && t.cmp(k, *hi) >= 0 {
+				return fmt.Errorf("node %d has key %v out of order", id, k)
+			}
+		}
+		count += len(n.keys)
+		if n.leaf() {
+			if leafDepth < 0 {
+				leafDepth = depth
+			}
+			if depth != leafDepth {
+				return fmt.Errorf("leaf %d is at depth %d, not %d", id, depth, leafDepth)
+			}
+			return nil
+		}
+		if len(n.children) != len(n.keys)+1 {
+			return fmt.Errorf("node %d has %d keys and %d children", id, len(n.keys), len(n.children))
+		}
+		for i, c := range n.children {
+			clo, chi := lo, hi
+			if i > 0 {
+				clo = &n.keys[i-1]
+			}
+			if i < len(n.keys) {
+				chi = &n.keys[i]
+			}
+			if err := walk(c, depth+1, clo, chi); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(t.root, 0, nil, nil); err != nil {
+		return err
+	}
+	if count != t.len {
+		return fmt.Errorf("tree has %d keys but Len %d", count, t.len)
+	}
+	return nil
+}
+
+// keys returns the keys of seq.
+func keys(seq func(yield func(int, string) bool)) []int {
+	var ks []int
+	for k := range seq {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+// del deletes k from tree, failing the test on error, and reports whether
+// k was present.
+func del[K, V any](t *testing.T, tree *Tree[K, V], k K) bool {
+	t.Helper()
+	ok, err := tree.Delete(k)
+	require.NoError(t, err)
+	return ok
+}
+
+// shape returns the number of keys of each node of t, level by level from
+// the root, left to right.
+func shape[K, V any](t *Tree[K, V]) [][]int {
+	var levels [][]int
+	for ids := []pageID{t.root}; t.root != 0 && len(ids) > 0; {
+		var sizes []int
+		var below []pageID
+		for _, id := range ids {
+			n := t.node(id)
+			sizes = append(sizes, len(n.keys))
+			below = append(below, n.children...)
+		}
+		levels, ids = append(levels, sizes), below
+	}
+	return levels
+}
+
+// TestTreeSplitMerge tests that a leaf splits when it passes m-1 keys, into
+// halves that both hold at least ⌈m/2⌉-1, and that when the right half
+// drops below that, it borrows from its sibling if the sibling has a key to
+// spare and otherwise merges with it into a node of exactly m-1 keys.
+func TestTreeSplitMerge(t *testing.T) {
+	for order := 3; order <= 9; order++ {
+		t.Run(fmt.Sprint("order ", order), func(t *testing.T) {
+			tree := New[int, string](order)
+			maxKeys, minKeys := order-1, (order+1)/2-1
+			for k := range maxKeys {
+				tree.Put(k, fmt.Sprint(k))
+			}
+			assert.Equal(t, [][]int{{maxKeys}}, shape(tree))
+
+			// The key that overflows the leaf splits it around its
+			// middle key, which becomes the new root.
+			tree.Put(maxKeys, fmt.Sprint(maxKeys))
+			require.NoError(t, checkTree(tree))
+			mid := order / 2
+			assert.Equal(t, [][]int{{1}, {mid, order - 1 - mid}}, shape(tree))
+			assert.Equal(t, minKeys, order-1-mid, "the right half is at the minimum")
+			assert.Equal(t, 2, tree.Height())
+			k, _, _ := tree.Min()
+			assert.Equal(t, 0, k)
+
+			require.True(t, del(t, tree, maxKeys))
+			require.NoError(t, checkTree(tree))
+			if mid > minKeys {
+				// An even order leaves the left half a key to lend.
+				assert.Equal(t, [][]int{{1}, {mid - 1, minKeys}}, shape(tree))
+			} else {
+				// Both halves at the minimum merge with the separator
+				// into a full root.
+				assert.Equal(t, [][]int{{maxKeys}}, shape(tree))
+				assert.Equal(t, 1, tree.Height())
+			}
+			for k := range maxKeys {
+				v, ok := tree.Get(k)
+				assert.True(t, ok)
+				assert.Equal(t, fmt.Sprint(k), v)
+			}
+		})
+	}
+
+	assert.PanicsWithValue(t, "btree: order must be at least 3", func() { New[int, int](2) })
+}
+
+// TestTreeHeight tests that the height of a tree built by single inserts
+// and shrunk by deletes stays between the heights of the fullest and the
+// emptiest B-trees of the same number of keys, log_m(n+1) and
+// 1+log_⌈m/2⌉((n+1)/2).
+func TestTreeHeight(t *testing.T) {
+	bounds := func(order, n int) (lo, hi int) {
+		for full := 1; full < n+1; full *= order {
+			lo++
+		}
+		hi = 1
+		for sparse := 2; sparse*((order+1)/2) <= n+1; sparse *= (order + 1) / 2 {
+			hi++
+		}
+		return lo, hi
+	}
+	for _, order := range []int{3, 4, 5, 16} {
+		t.Run(fmt.Sprint("order ", order), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(order), 7))
+			tree := New[int, string](order)
+			perm := rng.Perm(1000)
+			for i, k := range perm {
+				tree.Put(k, "")
+				lo, hi := bounds(order, i+1)
+				h := tree.Height()
+				assert.True(t, lo <= h && h <= hi, "%d keys: Expected: height from %d to %d, Got: %d", i+1, lo, hi, h)
+			}
+			require.NoError(t, checkTree(tree))
+			for i, k := range rng.Perm(1000) {
+				require.True(t, del(t, tree, k))
+				lo, hi := bounds(order, 999-i)
+				h := tree.Height()
+				assert.True(t, lo <= h && h <= hi, "%d keys: Expected: height from %d to %d, Got: %d", 999-i, lo, hi, h)
+				if i%100 == 0 {
+					require.NoError(t, checkTree(tree))
+				}
+			}
+			assert.Zero(t, tree.Len())
+			assert.Zero(t, tree.Height())
+		})
+	}
+
+	// Ascending inserts only ever split the rightmost node of a level,
+	// leaving every other node with the ⌊m/2⌋ keys before the middle one:
+	// at the minimum for an odd order.
+	tree := NewFunc[int, string](5, func(a, b int) int { return b - a })
+	for k := range 1000 {
+		tree.Put(-k, "")
+	}
+	require.NoError(t, checkTree(tree))
+	k, _, _ := tree.Min()
+	assert.Equal(t, 0, k)
+	levels := shape(tree)
+	for _, sizes := range levels[1:] {
+		for i, size := range sizes[:len(sizes)-1] {
+			assert.Equal(t, 2, size, "node %d of %v", i, sizes)
+		}
+	}
+	_, hi := bounds(5, 1000)
+	assert.Equal(t, hi, tree.Height())
+}
+
+// TestTreeLoad tests bulk loading against the invariants and sizes of the
+// result.
+func TestTreeLoad(t *testing.T) {
+	testCases := []struct {
+		order  int
+		n      int
+		height int
+	}{
+		{3, 1, 1},
+		{3, 2, 1},
+		{3, 3, 2},
+		{3, 100, 5},
+		{4, 1000, 5},
+		{5, 24, 2},
+		{5, 25, 3},
+		{64, 10000, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("order %d n %d", tc.order, tc.n), func(t *testing.T) {
+			tree := New[int, string](tc.order)
+			require.NoError(t, tree.Load(func(yield func(int, string) bool) {
+				for k := range tc.n {
+					if !yield(k, fmt.Sprint(k)) {
+						return
+					}
+				}
+			}))
+			require.NoError(t, checkTree(tree))
+			assert.Equal(t, tc.n, tree.Len())
+			assert.Equal(t, tc.height, tree.Height(), "Expected: %v, Got: %v", tc.height, tree.Height())
+			all := keys(tree.All())
+			assert.Len(t, all, tc.n)
+			assert.True(t, slices.IsSorted(all))
+
+			// The loaded tree takes updates like any other.
+			for k := range tc.n {
+				if k%3 == 0 {
+					assert.True(t, del(t, tree, k))
+				}
+			}
+			tree.Put(-1, "-1")
+			require.NoError(t, checkTree(tree))
+		})
+	}
+
+	tree := New[int, string](4)
+	assert.NoError(t, tree.Load(maps.All(map[int]string{})))
+	assert.Zero(t, tree.Len())
+	assert.ErrorIs(t, tree.Load(func(yield func(int, string) bool) {
+		_ = yield(1, "a") && yield(3, "c") && yield(3, "c")
+	}), ErrUnsorted)
+	assert.Zero(t, tree.Len())
+	tree.Put(1, "one")
+	assert.ErrorIs(t, tree.Load(maps.All(map[int]string{2: "b"})), ErrNotEmpty)
+}
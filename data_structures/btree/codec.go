@@ -0,0 +1,64 @@
+package btree
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrCorrupt is returned for a page or header that cannot be decoded.
+var ErrCorrupt = errors.New("btree: corrupt page")
+
+// Codec encodes the keys or the values of a tree stored in a file.
+type Codec[T any] interface {
+	// Append appends the encoding of v to dst and returns the result.
+	Append(dst []byte, v T) []byte
+	// Decode decodes a value from the start of src and returns it with the
+	// number of bytes it took.
+	Decode(src []byte) (v T, n int, err error)
+}
+
+// integer is the set of integer types.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Int returns a codec of integers as zig-zag varints, which take one byte
+// for values near zero and at most ten.
+func Int[T integer]() Codec[T] {
+	return intCodec[T]{}
+}
+
+type intCodec[T integer] struct{}
+
+func (intCodec[T]) Append(dst []byte, v T) []byte {
+	return binary.AppendVarint(dst, int64(v))
+}
+
+func (intCodec[T]) Decode(src []byte) (T, int, error) {
+	v, n := binary.Varint(src)
+	if n <= 0 {
+		return 0, 0, ErrCorrupt
+	}
+	return T(v), n, nil
+}
+
+// String returns a codec of strings as their length, a varint, followed by
+// their bytes.
+func String() Codec[string] {
+	return stringCodec{}
+}
+
+type stringCodec struct{}
+
+func (stringCodec) Append(dst []byte, s string) []byte {
+	return append(binary.AppendUvarint(dst, uint64(len(s))), s...)
+}
+
+func (stringCodec) Decode(src []byte) (string, int, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 || size > uint64(len(src)-n) {
+		return "", 0, ErrCorrupt
+	}
+	return string(src[n : n+int(size)]), n + int(size), nil
+}
@@ -0,0 +1,388 @@
+package btree
+
+import (
+	"cmp"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNodeTooLarge is the error of a node that does not fit in a page: the
+// order is too large for the page size and the sizes of the keys and
+// values. Put, Delete and Load check the nodes they would write and return
+// it before changing the tree.
+var ErrNodeTooLarge = errors.New("btree: node does not fit in a page")
+
+// Options configures a tree stored in a file.
+type Options struct {
+	// Order is the order of a new tree, 64 if 0, and otherwise at least
+	// 3. When reopening a file, it must be 0 or the order of the tree in
+	// the file.
+	Order int
+	// PageSize is the size in bytes of a page of a new file, 4096 if 0.
+	// It must be at least 64. When reopening a file, it must be 0 or the
+	// page size of the file.
+	PageSize int
+	// CachePages is the number of decoded nodes kept in the buffer pool,
+	// 64 if 0, and must not be negative. An update keeps every node it
+	// touches, about three per level, until it is done, even beyond this
+	// limit.
+	CachePages int
+}
+
+// Defaults of Options.
+const (
+	defaultOrder      = 64
+	defaultPageSize   = 4096
+	defaultCachePages = 64
+	minPageSize       = 64
+)
+
+// Open opens the tree stored in the file at path, creating the file if it
+// does not exist, with keys in their natural order. keys and values encode
+// the keys and values in pages. The keys must be stored in the same order
+// each time the file is opened.
+func Open[K cmp.Ordered, V any](path string, keys Codec[K], values Codec[V], opts Options) (*Tree[K, V], error) {
+	return OpenFunc(path, cmp.Compare[K], keys, values, opts)
+}
+
+// OpenFunc is like Open but orders keys by cmp, which returns a negative
+// number, zero or a positive number as a is less than, equal to or greater
+// than b.
+func OpenFunc[K, V any](path string, cmp func(a, b K) int, keys Codec[K], values Codec[V], opts Options) (*Tree[K, V], error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	p := &filePager[K, V]{
+		f:      f,
+		keys:   keys,
+		values: values,
+		frames: make(map[pageID]*list.Element),
+	}
+	t := &Tree[K, V]{pages: p, cmp: cmp}
+	if err := p.open(t, opts); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("btree: opening %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// A file is a sequence of pages of the same size. Page 0 is the header:
+//
+//	magic      [8]byte "GODSABT1"
+//	page size  uint32
+//	order      uint32
+//	root       uint32, 0 for an empty tree
+//	pages      uint32, number of pages in the file
+//	free       uint32, first page of the free list, 0 for none
+//	length     uint64, number of keys
+//
+// Every other page is a node or free. A node page is a byte 1 for a leaf or
+// 2 for an internal node, the number of keys as a uvarint, for an internal
+// node the ids of its children as uint32s, and then each key followed by
+// its value. A free page is a byte 3 and the id of the next free page as a
+// uint32. All integers but varints are little-endian.
+const (
+	magic      = "GODSABT1"
+	headerSize = 36
+
+	leafPage     = 1
+	internalPage = 2
+	freePage     = 3
+)
+
+// filePager keeps nodes in the pages of a file, with a buffer pool of the
+// most recently used nodes in memory. Changed nodes are written when they
+// are evicted from the pool or synced.
+type filePager[K, V any] struct {
+	f        *os.File
+	keys     Codec[K]
+	values   Codec[V]
+	order    int
+	pageSize int
+	pages    pageID // number of pages in the file, counting the header
+	freeList pageID // first free page, 0 for none
+
+	capacity int                      // nodes to keep in the pool
+	frames   map[pageID]*list.Element // of *frame, by node id
+	recent   list.List                // of *frame, most recently used first
+	holding  bool                     // whether eviction is held off
+	buf      []byte                   // one page
+	scratch  []byte                   // a node measured by fits
+	counts   Stats
+}
+
+// frame is a node in the buffer pool.
+type frame[K, V any] struct {
+	node  *node[K, V]
+	dirty bool // whether the node has changed since it was written
+}
+
+// open reads the header of the file into p and t, or writes the header of
+// a new tree if the file is empty.
+func (p *filePager[K, V]) open(t *Tree[K, V], opts Options) error {
+	switch {
+	case opts.Order < 0 || opts.Order > 0 && opts.Order < 3:
+		return fmt.Errorf("order %d is less than 3", opts.Order)
+	case opts.PageSize < 0 || opts.PageSize > 0 && opts.PageSize < minPageSize:
+		return fmt.Errorf("page size %d is less than %d", opts.PageSize, minPageSize)
+	case opts.CachePages < 0:
+		return fmt.Errorf("cache of %d pages", opts.CachePages)
+	}
+	p.capacity = cmp.Or(opts.CachePages, defaultCachePages)
+	info, err := p.f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		p.order = cmp.Or(opts.Order, defaultOrder)
+		p.pageSize = cmp.Or(opts.PageSize, defaultPageSize)
+		p.buf = make([]byte, p.pageSize)
+		p.pages = 1
+		t.order = p.order
+		return p.writeHeader(0, 0)
+	}
+
+	header := make([]byte, headerSize)
+	if _, err := p.f.ReadAt(header, 0); err != nil {
+		return err
+	}
+	if string(header[:8]) != magic {
+		return errors.New("not a B-tree file")
+	}
+	le := binary.LittleEndian
+	p.pageSize = int(le.Uint32(header[8:]))
+	p.order = int(le.Uint32(header[12:]))
+	t.root = pageID(le.Uint32(header[16:]))
+	p.pages = pageID(le.Uint32(header[20:]))
+	p.freeList = pageID(le.Uint32(header[24:]))
+	t.len = int(le.Uint64(header[28:]))
+	switch {
+	case opts.Order != 0 && opts.Order != p.order:
+		return fmt.Errorf("tree has order %d, not %d", p.order, opts.Order)
+	case opts.PageSize != 0 && opts.PageSize != p.pageSize:
+		return fmt.Errorf("file has pages of %d bytes, not %d", p.pageSize, opts.PageSize)
+	case p.pageSize < minPageSize || p.order < 3 || t.root >= p.pages || p.freeList >= p.pages:
+		return ErrCorrupt
+	}
+	t.order = p.order
+	p.buf = make([]byte, p.pageSize)
+	return nil
+}
+
+// writeHeader writes page 0.
+func (p *filePager[K, V]) writeHeader(root pageID, count int) error {
+	le := binary.LittleEndian
+	header := append([]byte(magic), make([]byte, headerSize-8)...)
+	le.PutUint32(header[8:], uint32(p.pageSize))
+	le.PutUint32(header[12:], uint32(p.order))
+	le.PutUint32(header[16:], uint32(root))
+	le.PutUint32(header[20:], uint32(p.pages))
+	le.PutUint32(header[24:], uint32(p.freeList))
+	le.PutUint64(header[28:], uint64(count))
+	_, err := p.f.WriteAt(header, 0)
+	return err
+}
+
+// readPage reads page id into p.buf.
+func (p *filePager[K, V]) readPage(id pageID) error {
+	p.counts.Reads++
+	if _, err := p.f.ReadAt(p.buf, int64(id)*int64(p.pageSize)); err != nil {
+		return fmt.Errorf("btree: reading page %d: %w", id, err)
+	}
+	return nil
+}
+
+// writePage writes page, a prefix of p.buf, padded with zeros, to page id.
+func (p *filePager[K, V]) writePage(id pageID, page []byte) error {
+	p.counts.Writes++
+	clear(page[len(page):cap(page)])
+	if _, err := p.f.WriteAt(page[:p.pageSize], int64(id)*int64(p.pageSize)); err != nil {
+		return fmt.Errorf("btree: writing page %d: %w", id, err)
+	}
+	return nil
+}
+
+func (p *filePager[K, V]) read(id pageID) (*node[K, V], error) {
+	if e, ok := p.frames[id]; ok {
+		p.counts.Hits++
+		p.recent.MoveToFront(e)
+		return e.Value.(*frame[K, V]).node, nil
+	}
+	if err := p.readPage(id); err != nil {
+		return nil, err
+	}
+	n, err := p.decode(id, p.buf)
+	if err != nil {
+		return nil, err
+	}
+	p.add(&frame[K, V]{node: n})
+	return n, p.evict()
+}
+
+// decode decodes the node on page id.
+func (p *filePager[K, V]) decode(id pageID, page []byte) (*node[K, V], error) {
+	corrupt := fmt.Errorf("%w %d", ErrCorrupt, id)
+	n := &node[K, V]{id: id}
+	kind, page := page[0], page[1:]
+	if kind != leafPage && kind != internalPage {
+		return nil, corrupt
+	}
+	count, size := binary.Uvarint(page)
+	if size <= 0 || count > uint64(len(page)) {
+		return nil, corrupt
+	}
+	page = page[size:]
+	if kind == internalPage {
+		if uint64(len(page)) < 4*(count+1) {
+			return nil, corrupt
+		}
+		n.children = make([]pageID, count+1)
+		for i := range n.children {
+			n.children[i] = pageID(binary.LittleEndian.Uint32(page[4*i:]))
+		}
+		page = page[4*(count+1):]
+	}
+	n.keys, n.values = make([]K, count), make([]V, count)
+	for i := range count {
+		var err error
+		if n.keys[i], size, err = p.keys.Decode(page); err != nil {
+			return nil, corrupt
+		}
+		page = page[size:]
+		if n.values[i], size, err = p.values.Decode(page); err != nil {
+			return nil, corrupt
+		}
+		page = page[size:]
+	}
+	return n, nil
+}
+
+// encode writes node n to its page.
+func (p *filePager[K, V]) encode(n *node[K, V]) error {
+	page := p.append(p.buf[:0], n)
+	if len(page) > p.pageSize {
+		return fmt.Errorf("%w: page %d needs %d bytes of %d", ErrNodeTooLarge, n.id, len(page), p.pageSize)
+	}
+	return p.writePage(n.id, page)
+}
+
+// append appends the page of node n to page and returns the result.
+func (p *filePager[K, V]) append(page []byte, n *node[K, V]) []byte {
+	if n.leaf() {
+		page = append(page, leafPage)
+	} else {
+		page = append(page, internalPage)
+	}
+	page = binary.AppendUvarint(page, uint64(len(n.keys)))
+	for _, c := range n.children {
+		page = binary.LittleEndian.AppendUint32(page, uint32(c))
+	}
+	for i, k := range n.keys {
+		page = p.keys.Append(page, k)
+		page = p.values.Append(page, n.values[i])
+	}
+	return page
+}
+
+// add puts f at the front of the pool.
+func (p *filePager[K, V]) add(f *frame[K, V]) {
+	p.frames[f.node.id] = p.recent.PushFront(f)
+}
+
+// evict removes the least recently used nodes from the pool, writing those
+// that have changed, until it is within capacity, unless eviction is held
+// off.
+func (p *filePager[K, V]) evict() error {
+	for !p.holding && p.recent.Len() > p.capacity {
+		e := p.recent.Back()
+		f := e.Value.(*frame[K, V])
+		if f.dirty {
+			if err := p.encode(f.node); err != nil {
+				return err
+			}
+		}
+		p.recent.Remove(e)
+		delete(p.frames, f.node.id)
+	}
+	return nil
+}
+
+func (p *filePager[K, V]) alloc() (*node[K, V], error) {
+	id := p.pages
+	if p.freeList != 0 {
+		id = p.freeList
+		if err := p.readPage(id); err != nil {
+			return nil, err
+		}
+		if p.buf[0] != freePage {
+			return nil, fmt.Errorf("%w %d", ErrCorrupt, id)
+		}
+		p.freeList = pageID(binary.LittleEndian.Uint32(p.buf[1:]))
+	} else {
+		p.pages++
+	}
+	n := &node[K, V]{id: id}
+	p.add(&frame[K, V]{node: n, dirty: true})
+	return n, p.evict()
+}
+
+func (p *filePager[K, V]) write(n *node[K, V]) {
+	p.frames[n.id].Value.(*frame[K, V]).dirty = true
+}
+
+func (p *filePager[K, V]) free(n *node[K, V]) error {
+	if e, ok := p.frames[n.id]; ok {
+		p.recent.Remove(e)
+		delete(p.frames, n.id)
+	}
+	page := append(p.buf[:0], freePage)
+	page = binary.LittleEndian.AppendUint32(page, uint32(p.freeList))
+	p.freeList = n.id
+	return p.writePage(n.id, page)
+}
+
+func (p *filePager[K, V]) fits() func(n *node[K, V]) error {
+	return func(n *node[K, V]) error {
+		p.scratch = p.append(p.scratch[:0], n)
+		if len(p.scratch) > p.pageSize {
+			return fmt.Errorf("%w: a node needs %d bytes of %d", ErrNodeTooLarge, len(p.scratch), p.pageSize)
+		}
+		return nil
+	}
+}
+
+func (p *filePager[K, V]) hold() {
+	p.holding = true
+}
+
+func (p *filePager[K, V]) release() error {
+	p.holding = false
+	return p.evict()
+}
+
+func (p *filePager[K, V]) sync(root pageID, count int) error {
+	for e := p.recent.Front(); e != nil; e = e.Next() {
+		if f := e.Value.(*frame[K, V]); f.dirty {
+			if err := p.encode(f.node); err != nil {
+				return err
+			}
+			f.dirty = false
+		}
+	}
+	if err := p.writeHeader(root, count); err != nil {
+		return err
+	}
+	return p.f.Sync()
+}
+
+func (p *filePager[K, V]) close() error {
+	return p.f.Close()
+}
+
+func (p *filePager[K, V]) stats() Stats {
+	return p.counts
+}
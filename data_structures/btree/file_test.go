@@ -0,0 +1,326 @@
+package btree
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// open opens the tree in path with int keys and string values, failing the
+// test on error.
+func open(t *testing.T, path string, opts Options) *Tree[int, string] {
+	t.Helper()
+	tree, err := Open(path, Int[int](), String(), opts)
+	require.NoError(t, err)
+	return tree
+}
+
+// TestFileReopen tests that a tree stored in a file, with a buffer pool much
+// smaller than the tree, has the same keys after it is closed and reopened.
+func TestFileReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	opts := Options{Order: 8, PageSize: 256, CachePages: 4}
+	rng := rand.New(rand.NewPCG(3, 4))
+	model := map[int]string{}
+
+	for round := range 3 {
+		tree := open(t, path, opts)
+		assert.Equal(t, len(model), tree.Len(), "round %d", round)
+		for step := range 1000 {
+			k := rng.IntN(600)
+			if rng.IntN(4) == 0 {
+				_, present := model[k]
+				assert.Equal(t, present, del(t, tree, k))
+				delete(model, k)
+			} else {
+				v := fmt.Sprint(round, step)
+				tree.Put(k, v)
+				model[k] = v
+			}
+		}
+		require.NoError(t, checkTree(tree))
+		require.NoError(t, tree.Close())
+		assert.ErrorIs(t, tree.Err(), ErrClosed)
+
+		tree = open(t, path, Options{})
+		assert.Equal(t, 8, tree.Order())
+		require.NoError(t, checkTree(tree))
+		assert.Equal(t, model, maps.Collect(tree.All()))
+		require.NoError(t, tree.Close())
+	}
+}
+
+// TestFilePages tests that every node decodes from its page to the node
+// that was encoded, and that a reopened tree re-reads the same nodes from
+// the file: the same shape, keys and values, with every page read once.
+func TestFilePages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path, Options{Order: 5, PageSize: 128, CachePages: 2})
+	for _, k := range rand.New(rand.NewPCG(5, 6)).Perm(300) {
+		require.NoError(t, tree.Put(k-150, strings.Repeat("v", k%9)))
+	}
+	for k := -150; k < 150; k += 4 {
+		require.True(t, del(t, tree, k))
+	}
+
+	p := tree.pages.(*filePager[int, string])
+	var nodes int
+	var walk func(id pageID)
+	walk = func(id pageID) {
+		n := tree.node(id)
+		nodes++
+		got, err := p.decode(id, p.append(nil, n))
+		require.NoError(t, err)
+		assert.Equal(t, n, got, "page %d", id)
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(tree.root)
+
+	expected, all := shape(tree), maps.Collect(tree.All())
+	require.NoError(t, tree.Close())
+
+	tree = open(t, path, Options{CachePages: nodes})
+	defer tree.Close()
+	assert.Equal(t, expected, shape(tree))
+	assert.Equal(t, nodes, tree.Stats().Reads, "Expected: %v, Got: %v", nodes, tree.Stats().Reads)
+	assert.Equal(t, all, maps.Collect(tree.All()))
+	assert.Equal(t, nodes, tree.Stats().Reads)
+	require.NoError(t, checkTree(tree))
+}
+
+// TestFileDeleteSizes tests deleting every key of a tree whose values vary
+// in length, so that merging nodes or moving a key between them can make a
+// node too large for its page. Such a Delete is refused, leaving the tree
+// unchanged and usable, and goes through once the tree has shrunk; the file
+// reopens with exactly the keys left each time.
+func TestFileDeleteSizes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path, Options{Order: 5, PageSize: 128, CachePages: 4})
+	rng := rand.New(rand.NewPCG(149, 149))
+	model := map[int]string{}
+	for k := range 200 {
+		v := strings.Repeat("v", rng.IntN(28))
+		require.NoError(t, tree.Put(k, v))
+		model[k] = v
+	}
+
+	pending := rng.Perm(200)
+	for round := 0; len(pending) > 0; round++ {
+		require.Less(t, round, 5, "keys left: %v", pending)
+		var refused []int
+		for _, k := range pending {
+			ok, err := tree.Delete(k)
+			if err != nil {
+				require.ErrorIs(t, err, ErrNodeTooLarge)
+				assert.False(t, ok)
+				v, found := tree.Get(k)
+				assert.True(t, found, "refused key %d", k)
+				assert.Equal(t, model[k], v)
+				refused = append(refused, k)
+			} else {
+				assert.True(t, ok, "key %d", k)
+				delete(model, k)
+			}
+			require.NoError(t, tree.Err())
+			assert.Equal(t, len(model), tree.Len())
+		}
+		if round == 0 {
+			assert.NotEmpty(t, refused, "some deletes overfill a page")
+		}
+		require.NoError(t, checkTree(tree))
+		require.NoError(t, tree.Close())
+
+		tree = open(t, path, Options{CachePages: 4})
+		require.NoError(t, checkTree(tree))
+		assert.Equal(t, model, maps.Collect(tree.All()))
+		pending = refused
+	}
+	assert.Zero(t, tree.Len())
+	require.NoError(t, tree.Close())
+}
+
+// TestFileLoad tests bulk loading into a file and range scans through a
+// small buffer pool.
+func TestFileLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path, Options{Order: 16, PageSize: 512, CachePages: 2})
+	require.NoError(t, tree.Load(func(yield func(int, string) bool) {
+		for k := range 5000 {
+			if !yield(k, strings.Repeat("v", k%7)) {
+				return
+			}
+		}
+	}))
+	require.NoError(t, tree.Close())
+
+	tree = open(t, path, Options{CachePages: 8})
+	defer tree.Close()
+	assert.Equal(t, 5000, tree.Len())
+	assert.Equal(t, []int{1234, 1235, 1236}, keys(tree.Range(1234, 1237)))
+	v, ok := tree.Get(4999)
+	assert.True(t, ok)
+	assert.Equal(t, "v", v)
+	stats := tree.Stats()
+	assert.Positive(t, stats.Reads)
+	assert.Zero(t, stats.Writes)
+
+	// A lookup of the same key again finds its nodes in the pool.
+	_, _ = tree.Get(4999)
+	assert.Equal(t, stats.Reads, tree.Stats().Reads)
+	assert.Greater(t, tree.Stats().Hits, stats.Hits)
+}
+
+// TestFileFreePages tests that the pages of deleted nodes are reused.
+func TestFileFreePages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path, Options{Order: 4, PageSize: 128})
+	for k := range 500 {
+		tree.Put(k, "")
+	}
+	require.NoError(t, tree.Flush())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	size := info.Size()
+
+	for k := range 500 {
+		del(t, tree, k)
+	}
+	require.NoError(t, tree.Close())
+	tree = open(t, path, Options{})
+	for k := range 500 {
+		tree.Put(1000+k, "")
+	}
+	require.NoError(t, tree.Close())
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, size, info.Size(), "Expected: %v, Got: %v", size, info.Size())
+}
+
+// TestFileErrors tests opening files that do not hold a matching tree, and
+// that a Put or Load that would leave a node too large for its page is
+// refused without changing or stopping the tree.
+func TestFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tree.db")
+	require.NoError(t, open(t, path, Options{Order: 5, PageSize: 128}).Close())
+
+	testCases := []struct {
+		name string
+		path string
+		opts Options
+		err  string
+	}{
+		{"order", path, Options{Order: 6}, "tree has order 5, not 6"},
+		{"page size", path, Options{PageSize: 256}, "file has pages of 128 bytes, not 256"},
+		{"not a tree", filepath.Join(dir, "text"), Options{}, "not a B-tree file"},
+		{"directory", dir, Options{}, "is a directory"},
+		{"order too small", filepath.Join(dir, "new.db"), Options{Order: 2}, "order 2 is less than 3"},
+		{"page too small", filepath.Join(dir, "new.db"), Options{PageSize: 32}, "page size 32 is less than 64"},
+		{"negative page size", path, Options{PageSize: -1}, "page size -1 is less than 64"},
+		{"negative cache", path, Options{CachePages: -1}, "cache of -1 pages"},
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "text"), []byte(strings.Repeat("text\n", 10)), 0o644))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Open(tc.path, Int[int](), String(), tc.opts)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// Two keys with values of 20 bytes fit in a page of 64 bytes, but not
+	// three, nor a root whose value does not fit on its own.
+	small := filepath.Join(dir, "small.db")
+	tree := open(t, small, Options{Order: 8, PageSize: 64, CachePages: 1})
+	require.NoError(t, tree.Put(1, strings.Repeat("x", 20)))
+	require.NoError(t, tree.Put(2, strings.Repeat("y", 20)))
+	assert.ErrorIs(t, tree.Put(3, strings.Repeat("z", 20)), ErrNodeTooLarge)
+	assert.ErrorIs(t, tree.Put(1, strings.Repeat("x", 50)), ErrNodeTooLarge)
+	assert.NoError(t, tree.Err())
+	require.NoError(t, tree.Put(3, "z"))
+	require.NoError(t, checkTree(tree))
+	require.NoError(t, tree.Close())
+
+	tree = open(t, small, Options{})
+	assert.Equal(t, map[int]string{1: strings.Repeat("x", 20), 2: strings.Repeat("y", 20), 3: "z"}, maps.Collect(tree.All()))
+	require.NoError(t, tree.Close())
+
+	// Load lays out nodes of four values of 40 bytes, which do not fit
+	// either, and refuses before writing any of them.
+	loaded := open(t, filepath.Join(dir, "loaded.db"), Options{Order: 5, PageSize: 128})
+	assert.ErrorIs(t, loaded.Load(func(yield func(int, string) bool) {
+		for k := range 50 {
+			if !yield(k, strings.Repeat("l", 40)) {
+				return
+			}
+		}
+	}), ErrNodeTooLarge)
+	assert.NoError(t, loaded.Err())
+	assert.Zero(t, loaded.Len())
+	assert.Zero(t, loaded.Stats().Writes)
+	require.NoError(t, loaded.Put(1, "one"))
+	require.NoError(t, loaded.Close())
+
+	// With the default order and page size, 64 keys of 100 bytes do not
+	// fit in a node: the Put that would split or overfill one is refused
+	// at whatever depth, and the tree goes on.
+	words := filepath.Join(dir, "words.db")
+	strs, err := Open(words, String(), Int[int](), Options{})
+	require.NoError(t, err)
+	refused := 0
+	for i := range 2000 {
+		err := strs.Put(fmt.Sprintf("%0100d", i*7919%2000), i)
+		if err != nil {
+			require.ErrorIs(t, err, ErrNodeTooLarge)
+			refused++
+		}
+	}
+	assert.Positive(t, refused)
+	assert.Equal(t, 2000-refused, strs.Len())
+	require.NoError(t, checkTree(strs))
+	require.NoError(t, strs.Close())
+	strs, err = Open(words, String(), Int[int](), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 2000-refused, strs.Len())
+	require.NoError(t, checkTree(strs))
+	require.NoError(t, strs.Close())
+}
+
+// TestCodecs tests that the built-in codecs decode what they encode.
+func TestCodecs(t *testing.T) {
+	ints := Int[int64]()
+	for _, v := range []int64{0, 1, -1, 63, -64, 1 << 40, math.MaxInt64, math.MinInt64} {
+		b := ints.Append([]byte{9}, v)
+		got, n, err := ints.Decode(b[1:])
+		require.NoError(t, err)
+		assert.Equal(t, v, got)
+		assert.Equal(t, len(b)-1, n)
+	}
+	got, _, err := Int[uint64]().Decode(Int[uint64]().Append(nil, math.MaxUint64))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), got)
+
+	strs := String()
+	for _, s := range []string{"", "a", strings.Repeat("é", 100)} {
+		b := strs.Append(nil, s)
+		got, n, err := strs.Decode(slices.Concat(b, []byte("rest")))
+		require.NoError(t, err)
+		assert.Equal(t, s, got)
+		assert.Equal(t, len(b), n)
+	}
+	_, _, err = strs.Decode([]byte{5, 'a'})
+	assert.ErrorIs(t, err, ErrCorrupt)
+	_, _, err = ints.Decode(nil)
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
@@ -0,0 +1,82 @@
+package btree
+
+// pageID identifies a node of a tree: its page in a file, or its slot in
+// memory. 0 is never a node.
+type pageID uint32
+
+// pager stores the nodes of a tree.
+type pager[K, V any] interface {
+	// read returns the node with the given id.
+	read(id pageID) (*node[K, V], error)
+	// alloc returns a new empty leaf.
+	alloc() (*node[K, V], error)
+	// write records that node n has changed.
+	write(n *node[K, V])
+	// free releases node n, whose id may then be reused.
+	free(n *node[K, V]) error
+	// fits returns the function that returns an error wrapping
+	// ErrNodeTooLarge for a node too large to store, or nil if nodes of
+	// any size can be stored.
+	fits() func(n *node[K, V]) error
+
+	// hold keeps every node read or allocated in memory until release,
+	// so that changes made through the pointers are not lost.
+	hold()
+	release() error
+
+	// sync saves the changed nodes and the root and size of the tree.
+	sync(root pageID, count int) error
+	// close releases the storage.
+	close() error
+	// stats returns the counts of work done so far.
+	stats() Stats
+}
+
+// Stats counts the work of the buffer pool of a tree stored in a file.
+type Stats struct {
+	Hits   int // nodes found in the pool
+	Reads  int // pages read from the file
+	Writes int // pages written to the file
+}
+
+// Stats returns the counts of work done by the buffer pool so far, all zero
+// for a tree in memory.
+func (t *Tree[K, V]) Stats() Stats {
+	return t.pages.stats()
+}
+
+// memoryPager keeps nodes in a slice indexed by id.
+type memoryPager[K, V any] struct {
+	nodes  []*node[K, V] // nodes[0] is always nil
+	unused []pageID      // ids of freed nodes
+}
+
+func (p *memoryPager[K, V]) read(id pageID) (*node[K, V], error) {
+	return p.nodes[id], nil
+}
+
+func (p *memoryPager[K, V]) alloc() (*node[K, V], error) {
+	n := &node[K, V]{id: pageID(len(p.nodes))}
+	if last := len(p.unused) - 1; last >= 0 {
+		n.id, p.unused = p.unused[last], p.unused[:last]
+		p.nodes[n.id] = n
+	} else {
+		p.nodes = append(p.nodes, n)
+	}
+	return n, nil
+}
+
+func (p *memoryPager[K, V]) write(n *node[K, V]) {}
+
+func (p *memoryPager[K, V]) free(n *node[K, V]) error {
+	p.nodes[n.id] = nil
+	p.unused = append(p.unused, n.id)
+	return nil
+}
+
+func (p *memoryPager[K, V]) fits() func(n *node[K, V]) error   { return nil }
+func (p *memoryPager[K, V]) hold()                             {}
+func (p *memoryPager[K, V]) release() error                    { return nil }
+func (p *memoryPager[K, V]) sync(root pageID, count int) error { return nil }
+func (p *memoryPager[K, V]) close() error                      { return nil }
+func (p *memoryPager[K, V]) stats() Stats                      { return Stats{} }
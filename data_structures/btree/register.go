@@ -0,0 +1,41 @@
+package btree
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	registry.Register(registry.Algorithm{
+		Name:     "BTree.Put",
+		Package:  "data_structures/btree",
+		Category: "data_structures",
+		Summary:  "Insertion of n keys into an in-memory B-tree of order 32",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			t := New[int, int](32)
+			for i := range n {
+				t.Put(i*7919%(n+1), i)
+			}
+			return t.Len()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "BTree.Load",
+		Package:  "data_structures/btree",
+		Category: "data_structures",
+		Summary:  "Bottom-up bulk loading of n sorted keys into a B-tree of order 32",
+		Time:     "O(n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			t := New[int, int](32)
+			t.Load(func(yield func(int, int) bool) {
+				for i := range n {
+					if !yield(i, i) {
+						return
+					}
+				}
+			})
+			return t.Height()
+		},
+	})
+}
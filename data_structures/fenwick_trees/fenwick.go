@@ -0,0 +1,127 @@
+// Package fenwick_trees implements Fenwick trees, or binary indexed trees:
+// arrays of numbers that answer prefix sums in O(log n) time while values
+// change, in a single array as long as the values.
+//
+//	Tree       point updates, prefix and range sums, search by prefix sum
+//	RangeTree  additions to ranges, prefix and range sums
+//	Tree2D     point updates, sums of rectangles
+//
+// Ranges are half-open, [lo, hi). Sums of integers wrap around on overflow,
+// as the sums of Go do, and stay right modulo 2^bits, so that unsigned
+// values may be taken away as well as added.
+package fenwick_trees
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Number is the set of types of the values summed.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Tree is a Fenwick tree. Its node i, from 1, holds the sum of the values in
+// (i - lowbit(i), i], for lowbit(i) the lowest set bit of i, so a prefix
+// sum adds the nodes reached by clearing the lowest bit in turn, and an
+// update changes those reached by adding it.
+type Tree[T Number] struct {
+	tree []T // nodes from 1
+}
+
+// New returns a tree of n zeros.
+func New[T Number](n int) *Tree[T] {
+	if n < 0 {
+		panic("fenwick_trees: negative length")
+	}
+	return &Tree[T]{tree: make([]T, n+1)}
+}
+
+// Build returns a tree of values in O(n) time, adding each node to the one
+// above it once its own sum is complete.
+func Build[T Number](values []T) *Tree[T] {
+	t := &Tree[T]{tree: make([]T, len(values)+1)}
+	copy(t.tree[1:], values)
+	for i := 1; i < len(t.tree); i++ {
+		if j := i + i&-i; j < len(t.tree) {
+			t.tree[j] += t.tree[i]
+		}
+	}
+	return t
+}
+
+// checkIndex panics if i is not an index of n values.
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("fenwick_trees: index %d out of range with length %d", i, n))
+	}
+}
+
+// checkRange panics if [lo, hi) is not a range of n values.
+func checkRange(lo, hi, n int) {
+	if lo < 0 || lo > hi || hi > n {
+		panic(fmt.Sprintf("fenwick_trees: range [%d:%d] out of range with length %d", lo, hi, n))
+	}
+}
+
+// Len returns the number of values.
+func (t *Tree[T]) Len() int {
+	return len(t.tree) - 1
+}
+
+// Add adds delta to the value at index i in O(log n) time.
+func (t *Tree[T]) Add(i int, delta T) {
+	checkIndex(i, t.Len())
+	for i++; i < len(t.tree); i += i & -i {
+		t.tree[i] += delta
+	}
+}
+
+// Sum returns the sum of the values in [0, hi) in O(log n) time.
+func (t *Tree[T]) Sum(hi int) T {
+	checkRange(0, hi, t.Len())
+	var s T
+	for ; hi > 0; hi &= hi - 1 {
+		s += t.tree[hi]
+	}
+	return s
+}
+
+// RangeSum returns the sum of the values in [lo, hi) in O(log n) time.
+func (t *Tree[T]) RangeSum(lo, hi int) T {
+	checkRange(lo, hi, t.Len())
+	return t.Sum(hi) - t.Sum(lo)
+}
+
+// Get returns the value at index i in O(log n) time.
+func (t *Tree[T]) Get(i int) T {
+	checkIndex(i, t.Len())
+	return t.RangeSum(i, i+1)
+}
+
+// Set sets the value at index i to v in O(log n) time.
+func (t *Tree[T]) Set(i int, v T) {
+	t.Add(i, v-t.Get(i))
+}
+
+// LowerBound returns the least i such that Sum(i+1) is at least target, or
+// Len if there is none, for values that are not negative. With the count of
+// each value at its index, LowerBound(k) is the k-th least value, from 1.
+// It takes O(log n) time, descending the implicit tree of nodes by powers of
+// two rather than searching with O(log n) prefix sums.
+func (t *Tree[T]) LowerBound(target T) int {
+	n := t.Len()
+	if n == 0 {
+		return 0
+	}
+	i := 0 // Sum(i) < target, with the remaining target below
+	for step := 1 << (bits.Len(uint(n)) - 1); step > 0; step >>= 1 {
+		if j := i + step; j <= n && t.tree[j] < target {
+			i = j
+			target -= t.tree[j]
+		}
+	}
+	return i
+}
@@ -0,0 +1,246 @@
+package fenwick_trees
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// naiveSum returns the sum of values[lo:hi].
+func naiveSum[T Number](values []T, lo, hi int) T {
+	var s T
+	for _, v := range values[lo:hi] {
+		s += v
+	}
+	return s
+}
+
+// naiveLowerBound returns the least i such that the sum of values[:i+1] is
+// at least target, or len(values).
+func naiveLowerBound(values []int, target int) int {
+	s := 0
+	for i, v := range values {
+		if s += v; s >= target {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// lengths are the lengths of the random tests, around powers of two.
+var lengths = []int{0, 1, 2, 7, 8, 9, 100}
+
+// TestTreeRandom compares a tree with a slice under random additions and
+// sets, checking every prefix sum, a range sum and a lower bound after each.
+func TestTreeRandom(t *testing.T) {
+	for _, n := range lengths {
+		t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(n), 1))
+			values := make([]int, n)
+			for i := range values {
+				values[i] = rng.IntN(10)
+			}
+			tree := Build(values)
+			require.Equal(t, n, tree.Len())
+			for step := range 300 {
+				if n > 0 {
+					i := rng.IntN(n)
+					if rng.IntN(2) == 0 {
+						delta := rng.IntN(10)
+						tree.Add(i, delta)
+						values[i] += delta
+					} else {
+						v := rng.IntN(10)
+						tree.Set(i, v)
+						values[i] = v
+					}
+					require.Equal(t, values[i], tree.Get(i))
+				}
+				for hi := range n + 1 {
+					require.Equal(t, naiveSum(values, 0, hi), tree.Sum(hi), "step %d: Sum(%d)", step, hi)
+				}
+				lo := rng.IntN(n + 1)
+				hi := lo + rng.IntN(n-lo+1)
+				assert.Equal(t, naiveSum(values, lo, hi), tree.RangeSum(lo, hi))
+
+				target := rng.IntN(naiveSum(values, 0, n) + 2)
+				assert.Equal(t, naiveLowerBound(values, target), tree.LowerBound(target),
+					"step %d: LowerBound(%d)", step, target)
+			}
+		})
+	}
+}
+
+// TestTreeBuild tests that Build makes the same nodes as adding the values
+// one by one.
+func TestTreeBuild(t *testing.T) {
+	for _, n := range lengths {
+		t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(n), 2))
+			values := make([]float64, n)
+			added := New[float64](n)
+			for i := range values {
+				values[i] = float64(rng.IntN(100)) / 4
+				added.Add(i, values[i])
+			}
+			built := Build(values)
+			assert.Equal(t, added.tree, built.tree)
+		})
+	}
+}
+
+// TestTreeUnsigned tests that sums of unsigned values stay right modulo
+// 2^bits when they overflow and when values are taken away.
+func TestTreeUnsigned(t *testing.T) {
+	values := []uint8{200, 100, 50, 255, 1, 0, 30}
+	tree := Build(values)
+	tree.Set(3, 5) // takes 250 away
+	values[3] = 5
+	for hi := range len(values) + 1 {
+		expected := naiveSum(values, 0, hi)
+		got := tree.Sum(hi)
+		assert.Equal(t, expected, got, "Expected: %v, Got: %v", expected, got)
+	}
+	assert.Equal(t, uint8(55), tree.RangeSum(2, 4))
+}
+
+// TestOrderStatistics tests LowerBound as the k-th least value of a
+// multiset of small values kept as counts, against a sorted slice.
+func TestOrderStatistics(t *testing.T) {
+	const universe = 50
+	rng := rand.New(rand.NewPCG(3, 4))
+	counts := New[int](universe)
+	var sorted []int
+	for step := range 2000 {
+		if v := rng.IntN(universe); len(sorted) == 0 || rng.IntN(3) > 0 {
+			counts.Add(v, 1)
+			i, _ := slices.BinarySearch(sorted, v)
+			sorted = slices.Insert(sorted, i, v)
+		} else {
+			i := rng.IntN(len(sorted))
+			counts.Add(sorted[i], -1)
+			sorted = slices.Delete(sorted, i, i+1)
+		}
+		k := 1 + rng.IntN(len(sorted)+1)
+		expected := universe
+		if k <= len(sorted) {
+			expected = sorted[k-1]
+		}
+		require.Equal(t, expected, counts.LowerBound(k), "step %d: the %d-th of %v", step, k, sorted)
+		// The rank of a value is the count of the lesser values.
+		v := rng.IntN(universe)
+		rank, _ := slices.BinarySearch(sorted, v)
+		require.Equal(t, rank, counts.Sum(v))
+	}
+}
+
+// TestRangeTreeRandom compares a range tree with a slice under random
+// additions to ranges, checking every prefix sum, a range sum and a value
+// after each.
+func TestRangeTreeRandom(t *testing.T) {
+	for _, n := range lengths {
+		t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(n), 5))
+			values := make([]int64, n)
+			for i := range values {
+				values[i] = rng.Int64N(21) - 10
+			}
+			tree := BuildRange(values)
+			require.Equal(t, n, tree.Len())
+			for step := range 300 {
+				lo := rng.IntN(n + 1)
+				hi := lo + rng.IntN(n-lo+1)
+				delta := rng.Int64N(21) - 10
+				tree.Add(lo, hi, delta)
+				for i := lo; i < hi; i++ {
+					values[i] += delta
+				}
+				for p := range n + 1 {
+					require.Equal(t, naiveSum(values, 0, p), tree.Sum(p), "step %d: Sum(%d)", step, p)
+				}
+				lo = rng.IntN(n + 1)
+				hi = lo + rng.IntN(n-lo+1)
+				assert.Equal(t, naiveSum(values, lo, hi), tree.RangeSum(lo, hi))
+				if n > 0 {
+					i := rng.IntN(n)
+					assert.Equal(t, values[i], tree.Get(i))
+				}
+			}
+			assert.Equal(t, n, NewRange[int64](n).Len())
+		})
+	}
+}
+
+// TestTree2DRandom compares a two-dimensional tree with a grid under random
+// additions, checking every prefix sum and a rectangle after each.
+func TestTree2DRandom(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {1, 1}, {1, 9}, {8, 3}, {13, 17}} {
+		rows, cols := size[0], size[1]
+		t.Run(fmt.Sprintf("%dx%d", rows, cols), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(rows), uint64(cols)))
+			grid := make([][]int, rows)
+			for r := range grid {
+				grid[r] = make([]int, cols)
+				for c := range grid[r] {
+					grid[r][c] = rng.IntN(10)
+				}
+			}
+			rectangle := func(r0, c0, r1, c1 int) int {
+				s := 0
+				for _, row := range grid[r0:r1] {
+					s += naiveSum(row, c0, c1)
+				}
+				return s
+			}
+
+			tree := Build2D(grid)
+			r, c := tree.Size()
+			require.Equal(t, [2]int{rows, cols}, [2]int{r, c})
+			for step := range 200 {
+				if rows > 0 && cols > 0 {
+					r, c, delta := rng.IntN(rows), rng.IntN(cols), rng.IntN(19)-9
+					tree.Add(r, c, delta)
+					grid[r][c] += delta
+					require.Equal(t, grid[r][c], tree.Get(r, c))
+				}
+				for r := range rows + 1 {
+					for c := range cols + 1 {
+						require.Equal(t, rectangle(0, 0, r, c), tree.Sum(r, c), "step %d: Sum(%d, %d)", step, r, c)
+					}
+				}
+				r0, c0 := rng.IntN(rows+1), rng.IntN(cols+1)
+				r1, c1 := r0+rng.IntN(rows-r0+1), c0+rng.IntN(cols-c0+1)
+				assert.Equal(t, rectangle(r0, c0, r1, c1), tree.RangeSum(r0, c0, r1, c1))
+			}
+		})
+	}
+}
+
+// TestPanics tests the panics on bad indexes, ranges, cells and rows.
+func TestPanics(t *testing.T) {
+	tree := New[int](4)
+	grid := New2D[int](2, 3)
+	testCases := []struct {
+		name     string
+		call     func()
+		expected string
+	}{
+		{"index", func() { tree.Add(4, 1) }, "fenwick_trees: index 4 out of range with length 4"},
+		{"range", func() { tree.RangeSum(3, 2) }, "fenwick_trees: range [3:2] out of range with length 4"},
+		{"range tree", func() { NewRange[int](4).Add(0, 5, 1) }, "fenwick_trees: range [0:5] out of range with length 4"},
+		{"length", func() { New[int](-1) }, "fenwick_trees: negative length"},
+		{"cell", func() { grid.Add(2, 0, 1) }, "fenwick_trees: cell (2, 0) out of range with size 2×3"},
+		{"corner", func() { grid.Sum(2, 4) }, "fenwick_trees: cell (2, 4) out of range with size 2×3"},
+		{"rectangle", func() { grid.RangeSum(1, 2, 0, 3) }, "fenwick_trees: rectangle [1:0]×[2:3] reversed"},
+		{"ragged", func() { Build2D([][]int{{1, 2}, {3}}) }, "fenwick_trees: row 1 of length 1, not 2"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.PanicsWithValue(t, tc.expected, tc.call)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package fenwick_trees
+
+// RangeTree is a Fenwick tree with additions to ranges. It keeps two trees
+// over the differences d of the values, d[i] = a[i] - a[i-1]: one of d[i]
+// and one of i*d[i]. The prefix sum of the values before p, the sum over
+// i < p of (p - i)*d[i], is then p times the prefix sum of the first tree
+// less that of the second, and an addition to a range changes only the two
+// differences at its ends.
+type RangeTree[T Number] struct {
+	diff, weighted Tree[T]
+}
+
+// NewRange returns a tree of n zeros.
+func NewRange[T Number](n int) *RangeTree[T] {
+	return &RangeTree[T]{*New[T](n), *New[T](n)}
+}
+
+// BuildRange returns a tree of values in O(n) time.
+func BuildRange[T Number](values []T) *RangeTree[T] {
+	diff := make([]T, len(values))
+	weighted := make([]T, len(values))
+	var last T
+	for i, v := range values {
+		diff[i] = v - last
+		weighted[i] = T(i) * diff[i]
+		last = v
+	}
+	return &RangeTree[T]{*Build(diff), *Build(weighted)}
+}
+
+// Len returns the number of values.
+func (t *RangeTree[T]) Len() int {
+	return t.diff.Len()
+}
+
+// Add adds delta to every value in [lo, hi) in O(log n) time.
+func (t *RangeTree[T]) Add(lo, hi int, delta T) {
+	checkRange(lo, hi, t.Len())
+	if lo == hi {
+		return
+	}
+	t.diff.Add(lo, delta)
+	t.weighted.Add(lo, T(lo)*delta)
+	if hi < t.Len() {
+		t.diff.Add(hi, -delta)
+		t.weighted.Add(hi, -(T(hi) * delta))
+	}
+}
+
+// Sum returns the sum of the values in [0, hi) in O(log n) time.
+func (t *RangeTree[T]) Sum(hi int) T {
+	checkRange(0, hi, t.Len())
+	return T(hi)*t.diff.Sum(hi) - t.weighted.Sum(hi)
+}
+
+// RangeSum returns the sum of the values in [lo, hi) in O(log n) time.
+func (t *RangeTree[T]) RangeSum(lo, hi int) T {
+	checkRange(lo, hi, t.Len())
+	return t.Sum(hi) - t.Sum(lo)
+}
+
+// Get returns the value at index i, the sum of the differences up to it, in
+// O(log n) time.
+func (t *RangeTree[T]) Get(i int) T {
+	checkIndex(i, t.Len())
+	return t.diff.Sum(i + 1)
+}
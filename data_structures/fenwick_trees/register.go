@@ -0,0 +1,80 @@
+package fenwick_trees
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	registry.Register(registry.Algorithm{
+		Name:     "Tree.Add",
+		Package:  "data_structures/fenwick_trees",
+		Category: "data_structures",
+		Summary:  "n point additions to a Fenwick tree, then each of its prefix sums",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			t := New[int](n)
+			for i := range n {
+				t.Add(i*7919%n, i)
+			}
+			sums := make([]int, n+1)
+			for i := range sums {
+				sums[i] = t.Sum(i)
+			}
+			return sums
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Tree.LowerBound",
+		Package:  "data_structures/fenwick_trees",
+		Category: "data_structures",
+		Summary:  "Each k-th least of n values counted in a Fenwick tree built in O(n)",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			counts := make([]int, n)
+			for i := range n {
+				counts[i*7919%n]++
+			}
+			t := Build(counts)
+			kth := make([]int, n)
+			for k := range kth {
+				kth[k] = t.LowerBound(k + 1)
+			}
+			return kth
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "RangeTree.Add",
+		Package:  "data_structures/fenwick_trees",
+		Category: "data_structures",
+		Summary:  "Additions to n ranges of a range-update Fenwick tree, then its total",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			t := NewRange[int](n)
+			for i := range n {
+				t.Add(i/2, n-i/2, i%7)
+			}
+			return t.Sum(n)
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Tree2D.Add",
+		Package:  "data_structures/fenwick_trees",
+		Category: "data_structures",
+		Summary:  "n additions to a √n × √n Fenwick grid, then the sum of the whole",
+		Time:     "O(log² n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			side := 1
+			for side*side < n {
+				side++
+			}
+			t := New2D[int](side, side)
+			for i := range n {
+				t.Add(i%side, i*7919%side, i)
+			}
+			return t.Sum(side, side)
+		},
+	})
+}
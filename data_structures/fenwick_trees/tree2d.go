@@ -0,0 +1,112 @@
+package fenwick_trees
+
+import "fmt"
+
+// Tree2D is a two-dimensional Fenwick tree over a grid of rows and columns:
+// a Fenwick tree of rows whose nodes are Fenwick trees of columns. Its node
+// (i, j) holds the sum of the cells in (i - lowbit(i), i] × (j - lowbit(j),
+// j], and an update or a prefix sum takes O(log r · log c) time.
+type Tree2D[T Number] struct {
+	rows, cols int
+	tree       []T // (rows+1) × (cols+1) nodes by rows, from (1, 1)
+}
+
+// New2D returns a grid of rows × cols zeros.
+func New2D[T Number](rows, cols int) *Tree2D[T] {
+	if rows < 0 || cols < 0 {
+		panic("fenwick_trees: negative size")
+	}
+	return &Tree2D[T]{rows, cols, make([]T, (rows+1)*(cols+1))}
+}
+
+// Build2D returns a grid of the values, whose rows must all be as long, in
+// O(r · c) time, building each row and then each column as Build does.
+func Build2D[T Number](values [][]T) *Tree2D[T] {
+	cols := 0
+	if len(values) > 0 {
+		cols = len(values[0])
+	}
+	t := New2D[T](len(values), cols)
+	for r, row := range values {
+		if len(row) != cols {
+			panic(fmt.Sprintf("fenwick_trees: row %d of length %d, not %d", r, len(row), cols))
+		}
+		copy(t.tree[t.at(r+1, 1):], row)
+	}
+	for i := 1; i <= t.rows; i++ {
+		for j := 1; j <= t.cols; j++ {
+			if k := j + j&-j; k <= t.cols {
+				t.tree[t.at(i, k)] += t.tree[t.at(i, j)]
+			}
+		}
+	}
+	for i := 1; i <= t.rows; i++ {
+		if k := i + i&-i; k <= t.rows {
+			for j := 1; j <= t.cols; j++ {
+				t.tree[t.at(k, j)] += t.tree[t.at(i, j)]
+			}
+		}
+	}
+	return t
+}
+
+// at returns the position of node (i, j) in the array of nodes.
+func (t *Tree2D[T]) at(i, j int) int {
+	return i*(t.cols+1) + j
+}
+
+// checkCell panics if (r, c) is not a cell of t, or, with edge, its far
+// corner.
+func (t *Tree2D[T]) checkCell(r, c int, edge bool) {
+	rows, cols := t.rows, t.cols
+	if edge {
+		rows, cols = rows+1, cols+1
+	}
+	if r < 0 || r >= rows || c < 0 || c >= cols {
+		panic(fmt.Sprintf("fenwick_trees: cell (%d, %d) out of range with size %d×%d", r, c, t.rows, t.cols))
+	}
+}
+
+// Size returns the numbers of rows and columns.
+func (t *Tree2D[T]) Size() (rows, cols int) {
+	return t.rows, t.cols
+}
+
+// Add adds delta to the cell in row r and column c.
+func (t *Tree2D[T]) Add(r, c int, delta T) {
+	t.checkCell(r, c, false)
+	for i := r + 1; i <= t.rows; i += i & -i {
+		for j := c + 1; j <= t.cols; j += j & -j {
+			t.tree[t.at(i, j)] += delta
+		}
+	}
+}
+
+// Sum returns the sum of the cells in the rows [0, r) and columns [0, c).
+func (t *Tree2D[T]) Sum(r, c int) T {
+	t.checkCell(r, c, true)
+	var s T
+	for i := r; i > 0; i &= i - 1 {
+		for j := c; j > 0; j &= j - 1 {
+			s += t.tree[t.at(i, j)]
+		}
+	}
+	return s
+}
+
+// RangeSum returns the sum of the cells in the rows [r0, r1) and columns
+// [c0, c1), by inclusion and exclusion of four prefix sums.
+func (t *Tree2D[T]) RangeSum(r0, c0, r1, c1 int) T {
+	t.checkCell(r0, c0, true)
+	t.checkCell(r1, c1, true)
+	if r0 > r1 || c0 > c1 {
+		panic(fmt.Sprintf("fenwick_trees: rectangle [%d:%d]×[%d:%d] reversed", r0, r1, c0, c1))
+	}
+	return t.Sum(r1, c1) - t.Sum(r0, c1) - t.Sum(r1, c0) + t.Sum(r0, c0)
+}
+
+// Get returns the cell in row r and column c.
+func (t *Tree2D[T]) Get(r, c int) T {
+	t.checkCell(r, c, false)
+	return t.RangeSum(r, c, r+1, c+1)
+}
@@ -0,0 +1,166 @@
+package heaps
+
+// DAry is a d-ary heap: a complete d-ary tree stored level by level in an
+// array, every node at most its children. The children of node i are nodes
+// d*i+1 to d*i+d.
+//
+// A larger d makes the tree shallower, so pushing and decreasing a value,
+// which move it up, get faster, while popping, which looks at all d children
+// on each level down, gets slower. Workloads with many decrease-keys per pop,
+// as Dijkstra's algorithm on dense graphs, favour d of 4 or more; d = 4 is
+// also friendlier to the cache than d = 2.
+type DAry[T any] struct {
+	items []*Element[T]
+	d     int
+	cmp   func(a, b T) int
+}
+
+// NewDAry returns an empty d-ary heap ordered by cmp, which returns a
+// negative number, zero or a positive number as a is less than, equal to or
+// greater than b. It panics if d < 2.
+func NewDAry[T any](d int, cmp func(a, b T) int) *DAry[T] {
+	if d < 2 {
+		panic("heaps: d-ary heap with d less than 2")
+	}
+	return &DAry[T]{d: d, cmp: cmp}
+}
+
+// NewBinary returns an empty binary heap, a d-ary heap with d = 2, ordered
+// by cmp.
+func NewBinary[T any](cmp func(a, b T) int) *DAry[T] {
+	return NewDAry(2, cmp)
+}
+
+var _ Heap[int] = (*DAry[int])(nil)
+
+// Len returns the number of values.
+func (h *DAry[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds v and returns its element.
+func (h *DAry[T]) Push(v T) *Element[T] {
+	e := newElement(v)
+	e.index = len(h.items)
+	h.items = append(h.items, e)
+	h.up(e.index)
+	return e
+}
+
+// PushAll adds values and returns their elements. When there are at least as
+// many values as the heap holds, it appends them all and restores the heap
+// bottom up, sifting down each internal node from the last, which takes
+// O(n) time in all (Floyd); otherwise it pushes them one by one.
+func (h *DAry[T]) PushAll(values ...T) []*Element[T] {
+	if len(values) < len(h.items) {
+		return pushAll(h, values)
+	}
+	elements := make([]*Element[T], len(values))
+	for i, v := range values {
+		e := newElement(v)
+		e.index = len(h.items)
+		h.items = append(h.items, e)
+		elements[i] = e
+	}
+	for i := (len(h.items) - 2) / h.d; i >= 0; i-- {
+		h.down(i)
+	}
+	return elements
+}
+
+// Peek returns the least value, or false if the heap is empty.
+func (h *DAry[T]) Peek() (v T, ok bool) {
+	if len(h.items) == 0 {
+		return v, false
+	}
+	return h.items[0].value, true
+}
+
+// Pop removes the least value and returns it, or false if the heap is empty.
+func (h *DAry[T]) Pop() (v T, ok bool) {
+	if len(h.items) == 0 {
+		return v, false
+	}
+	return h.Remove(h.items[0]), true
+}
+
+// Update changes the value of element e to v and moves it up or down to its
+// place, in O(log n) time up or O(d log n) down.
+func (h *DAry[T]) Update(e *Element[T], v T) {
+	h.check(e)
+	e.value = v
+	h.fix(e.index)
+}
+
+// Remove removes element e and returns its value. It moves the last value
+// into the place of e and then up or down.
+func (h *DAry[T]) Remove(e *Element[T]) T {
+	h.check(e)
+	i, last := e.index, len(h.items)-1
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i != last {
+		h.fix(i)
+	}
+	e.index = -1
+	return e.value
+}
+
+// check panics unless e is in h.
+func (h *DAry[T]) check(e *Element[T]) {
+	checkIn(e)
+	if e.index >= len(h.items) || h.items[e.index] != e {
+		panic("heaps: element is not in the heap")
+	}
+}
+
+// fix moves the value at i up or down to its place.
+func (h *DAry[T]) fix(i int) {
+	if !h.up(i) {
+		h.down(i)
+	}
+}
+
+// up moves the value at i up while it is less than its parent, and reports
+// whether it moved.
+func (h *DAry[T]) up(i int) bool {
+	start := i
+	for i > 0 {
+		parent := (i - 1) / h.d
+		if h.cmp(h.items[i].value, h.items[parent].value) >= 0 {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+	return i != start
+}
+
+// down moves the value at i down while a child is less than it.
+func (h *DAry[T]) down(i int) {
+	n := len(h.items)
+	for {
+		least := i
+		first := h.d*i + 1
+		for c := first; c < first+h.d && c < n; c++ {
+			if h.cmp(h.items[c].value, h.items[least].value) < 0 {
+				least = c
+			}
+		}
+		if least == i {
+			return
+		}
+		h.swap(i, least)
+		i = least
+	}
+}
+
+// swap swaps the values at i and j.
+func (h *DAry[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
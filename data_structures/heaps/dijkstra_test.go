@@ -0,0 +1,168 @@
+package heaps
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/gen"
+	"github.com/stretchr/testify/assert"
+)
+
+// vertex is a vertex in the queue of Dijkstra's algorithm with its
+// tentative distance.
+type vertex struct {
+	dist, v int
+}
+
+// byDist orders vertices by distance.
+func byDist(a, b vertex) int {
+	return a.dist - b.dist
+}
+
+// arc is an edge out of a vertex.
+type arc struct {
+	to, w int
+}
+
+// adjacency returns the arcs out of each vertex of g, 0-based, both ways
+// for an undirected graph.
+func adjacency(g gen.Graph) [][]arc {
+	adj := make([][]arc, g.N)
+	for _, e := range g.Edges {
+		adj[e.U-1] = append(adj[e.U-1], arc{e.V - 1, e.W})
+		if !g.Directed {
+			adj[e.V-1] = append(adj[e.V-1], arc{e.U - 1, e.W})
+		}
+	}
+	return adj
+}
+
+// dijkstra returns the distances from vertex 0 of adj, with -1 for
+// unreachable vertices, using h as the queue and decreasing keys through the
+// elements of the vertices in it.
+func dijkstra(adj [][]arc, h Heap[vertex]) []int {
+	dist := make([]int, len(adj))
+	for i := range dist {
+		dist[i] = -1
+	}
+	elements := make([]*Element[vertex], len(adj))
+	done := make([]bool, len(adj))
+	dist[0] = 0
+	elements[0] = h.Push(vertex{0, 0})
+	for u, ok := h.Pop(); ok; u, ok = h.Pop() {
+		done[u.v] = true
+		for _, a := range adj[u.v] {
+			d := u.dist + a.w
+			switch {
+			case done[a.to]:
+			case elements[a.to] == nil:
+				dist[a.to] = d
+				elements[a.to] = h.Push(vertex{d, a.to})
+			case d < dist[a.to]:
+				dist[a.to] = d
+				h.Update(elements[a.to], vertex{d, a.to})
+			}
+		}
+	}
+	return dist
+}
+
+// slowDijkstra returns the distances from vertex 0 of adj, with -1 for
+// unreachable vertices, by scanning for the nearest vertex in O(n^2).
+func slowDijkstra(adj [][]arc) []int {
+	dist := make([]int, len(adj))
+	for i := range dist {
+		dist[i] = math.MaxInt
+	}
+	done := make([]bool, len(adj))
+	dist[0] = 0
+	for range adj {
+		u := -1
+		for v := range adj {
+			if !done[v] && dist[v] < math.MaxInt && (u < 0 || dist[v] < dist[u]) {
+				u = v
+			}
+		}
+		if u < 0 {
+			break
+		}
+		done[u] = true
+		for _, a := range adj[u] {
+			dist[a.to] = min(dist[a.to], dist[u]+a.w)
+		}
+	}
+	for i := range dist {
+		if dist[i] == math.MaxInt {
+			dist[i] = -1
+		}
+	}
+	return dist
+}
+
+// queues are the heaps compared on Dijkstra's algorithm.
+var queues = []struct {
+	name string
+	new  func() Heap[vertex]
+}{
+	{"binary", func() Heap[vertex] { return NewBinary(byDist) }},
+	{"4-ary", func() Heap[vertex] { return NewDAry(4, byDist) }},
+	{"8-ary", func() Heap[vertex] { return NewDAry(8, byDist) }},
+	{"pairing", func() Heap[vertex] { return NewPairing(byDist) }},
+	{"leftist", func() Heap[vertex] { return NewLeftist(byDist) }},
+	{"Fibonacci", func() Heap[vertex] { return NewFibonacci(byDist) }},
+}
+
+// TestDijkstra tests every heap as the queue of Dijkstra's algorithm against
+// the quadratic version on random graphs.
+func TestDijkstra(t *testing.T) {
+	testCases := []struct {
+		name string
+		n, m int
+	}{
+		{"single vertex", 1, 0},
+		{"tree", 200, 199},
+		{"sparse", 300, 900},
+		{"dense", 100, 3000},
+	}
+
+	for _, tc := range testCases {
+		g := gen.New(uint64(tc.n + tc.m))
+		adj := adjacency(g.Weigh(g.Connected(tc.n, tc.m), 1, 100))
+		// A vertex with no edges is unreachable.
+		adj = append(adj, nil)
+		expected := slowDijkstra(adj)
+		for _, q := range queues {
+			t.Run(tc.name+"/"+q.name, func(t *testing.T) {
+				got := dijkstra(adj, q.new())
+				assert.Equal(t, expected, got, "Expected: %v, Got: %v", expected, got)
+			})
+		}
+	}
+}
+
+// benchGraphs are the graphs of BenchmarkDijkstra: a sparse one, where
+// pushes and pops dominate, and a dense one, with many decrease-keys per pop.
+var benchGraphs = sync.OnceValue(func() map[string][][]arc {
+	g := gen.New(1)
+	return map[string][][]arc{
+		"sparse n=100000 m=400000": adjacency(g.Weigh(g.Connected(100000, 400000), 1, 1000)),
+		"dense n=2000 m=1000000":   adjacency(g.Weigh(g.Connected(2000, 1000000), 1, 1000)),
+	}
+})
+
+// BenchmarkDijkstra compares the heaps as the queue of Dijkstra's algorithm,
+// e.g. go test -bench Dijkstra -run ^$ ./data_structures/heaps.
+func BenchmarkDijkstra(b *testing.B) {
+	for _, name := range []string{"sparse n=100000 m=400000", "dense n=2000 m=1000000"} {
+		adj := benchGraphs()[name]
+		for _, q := range queues {
+			b.Run(fmt.Sprintf("%s/%s", name, q.name), func(b *testing.B) {
+				for range b.N {
+					dijkstra(adj, q.new())
+				}
+			})
+		}
+	}
+}
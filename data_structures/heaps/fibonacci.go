@@ -0,0 +1,226 @@
+package heaps
+
+// Fibonacci is a Fibonacci heap: a list of heap-ordered trees that is only
+// tidied when the least value is popped. Push and Merge add to the list of
+// roots in O(1). Pop removes the least root, adds its children to the roots
+// and then links roots of equal degree until all degrees differ, which takes
+// O(log n) amortised time. A decrease cuts a node whose value falls below its
+// parent's off to the roots, and cuts each parent that has lost a second
+// child too, which keeps a node of degree k with a subtree of at least
+// F(k+2) nodes and decrease-key at O(1) amortised.
+//
+// An element's parent is its parent, child any of its children, and prev
+// and next its neighbours in the circular list of its siblings or of the
+// roots; degree is its number of children and mark whether it has lost a
+// child since it became a child itself.
+type Fibonacci[T any] struct {
+	min   *Element[T] // least root, nil when the heap is empty
+	len   int
+	cmp   func(a, b T) int
+	roots []*Element[T] // scratch space for Pop
+}
+
+// NewFibonacci returns an empty Fibonacci heap ordered by cmp, which returns
+// a negative number, zero or a positive number as a is less than, equal to
+// or greater than b.
+func NewFibonacci[T any](cmp func(a, b T) int) *Fibonacci[T] {
+	return &Fibonacci[T]{cmp: cmp}
+}
+
+var _ Heap[int] = (*Fibonacci[int])(nil)
+
+// Len returns the number of values.
+func (h *Fibonacci[T]) Len() int {
+	return h.len
+}
+
+// Push adds v and returns its element, in O(1).
+func (h *Fibonacci[T]) Push(v T) *Element[T] {
+	e := newElement(v)
+	e.prev, e.next = e, e
+	h.addRoots(e)
+	h.len++
+	return e
+}
+
+// PushAll adds values and returns their elements, in O(1) each.
+func (h *Fibonacci[T]) PushAll(values ...T) []*Element[T] {
+	return pushAll(h, values)
+}
+
+// Peek returns the least value, or false if the heap is empty.
+func (h *Fibonacci[T]) Peek() (v T, ok bool) {
+	if h.min == nil {
+		return v, false
+	}
+	return h.min.value, true
+}
+
+// Pop removes the least value and returns it, or false if the heap is empty.
+func (h *Fibonacci[T]) Pop() (v T, ok bool) {
+	if h.min == nil {
+		return v, false
+	}
+	return h.Remove(h.min), true
+}
+
+// Update changes the value of element e to v. A decrease takes O(1)
+// amortised time; an increase removes e and pushes it back.
+func (h *Fibonacci[T]) Update(e *Element[T], v T) {
+	checkIn(e)
+	if h.cmp(v, e.value) > 0 {
+		h.Remove(e)
+		e.value, e.index = v, 0
+		e.prev, e.next = e, e
+		h.addRoots(e)
+		h.len++
+		return
+	}
+	e.value = v
+	if p := e.parent; p != nil && h.cmp(v, p.value) < 0 {
+		h.cut(e)
+		h.cascade(p)
+	}
+	if h.cmp(v, h.min.value) < 0 {
+		h.min = e
+	}
+}
+
+// Remove removes element e and returns its value. Unless e is a root, it is
+// cut off to the roots first, as by a decrease; it is then popped as if it
+// were the least root.
+func (h *Fibonacci[T]) Remove(e *Element[T]) T {
+	checkIn(e)
+	if p := e.parent; p != nil {
+		h.cut(e)
+		h.cascade(p)
+	}
+
+	// Move the children of e to the roots and take e out of them.
+	for c, i := e.child, 0; i < e.degree; c, i = c.next, i+1 {
+		c.parent, c.mark = nil, false
+	}
+	if e.child != nil {
+		splice(e, e.child)
+	}
+	if e.next == e {
+		h.min = nil
+	} else {
+		e.prev.next, e.next.prev = e.next, e.prev
+		h.min = e.next
+		h.consolidate()
+	}
+	e.prev, e.next, e.child, e.degree = nil, nil, nil, 0
+	h.len--
+	e.index = -1
+	return e.value
+}
+
+// Merge moves all values of other into h, in O(1), leaving other empty.
+// Both heaps must have the same order.
+func (h *Fibonacci[T]) Merge(other *Fibonacci[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+	h.addRoots(other.min)
+	h.len += other.len
+	other.min, other.len = nil, 0
+}
+
+// addRoots adds the circular list starting at e to the roots.
+func (h *Fibonacci[T]) addRoots(e *Element[T]) {
+	if h.min == nil {
+		h.min = e
+		return
+	}
+	splice(h.min, e)
+	if h.cmp(e.value, h.min.value) < 0 {
+		h.min = e
+	}
+}
+
+// splice inserts the circular list starting at b into the circular list
+// containing a, after a.
+func splice[T any](a, b *Element[T]) {
+	aNext, bLast := a.next, b.prev
+	a.next, b.prev = b, a
+	bLast.next, aNext.prev = aNext, bLast
+}
+
+// cut moves e from the children of its parent to the roots.
+func (h *Fibonacci[T]) cut(e *Element[T]) {
+	p := e.parent
+	if e.next == e {
+		p.child = nil
+	} else {
+		e.prev.next, e.next.prev = e.next, e.prev
+		if p.child == e {
+			p.child = e.next
+		}
+	}
+	p.degree--
+	e.parent, e.mark = nil, false
+	e.prev, e.next = e, e
+	h.addRoots(e)
+}
+
+// cascade marks e, which has just lost a child, or, if it had already lost
+// one, cuts it off too and goes on with its parent.
+func (h *Fibonacci[T]) cascade(e *Element[T]) {
+	for p := e.parent; p != nil; e, p = p, p.parent {
+		if !e.mark {
+			e.mark = true
+			return
+		}
+		h.cut(e)
+	}
+}
+
+// consolidate links roots of equal degree, the one with the larger value
+// becoming a child of the other, until all roots have different degrees, and
+// then finds the least root. h.min must be any root.
+func (h *Fibonacci[T]) consolidate() {
+	h.roots = h.roots[:0]
+	for r := h.min; ; {
+		h.roots = append(h.roots, r)
+		if r = r.next; r == h.min {
+			break
+		}
+	}
+
+	// A node of degree k has at least F(k+2) ≥ φ^k nodes below it, so 93
+	// degrees are enough for any heap that fits in memory.
+	var byDegree [93]*Element[T]
+	for _, x := range h.roots {
+		for byDegree[x.degree] != nil {
+			y := byDegree[x.degree]
+			byDegree[x.degree] = nil
+			if h.cmp(y.value, x.value) < 0 {
+				x, y = y, x
+			}
+			h.adopt(x, y)
+		}
+		byDegree[x.degree] = x
+	}
+	clear(h.roots)
+
+	h.min = nil
+	for _, r := range byDegree {
+		if r != nil && (h.min == nil || h.cmp(r.value, h.min.value) < 0) {
+			h.min = r
+		}
+	}
+}
+
+// adopt moves root y to the children of root x.
+func (h *Fibonacci[T]) adopt(x, y *Element[T]) {
+	y.prev.next, y.next.prev = y.next, y.prev
+	y.prev, y.next = y, y
+	y.parent, y.mark = x, false
+	if x.child == nil {
+		x.child = y
+	} else {
+		splice(x.child, y)
+	}
+	x.degree++
+}
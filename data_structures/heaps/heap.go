@@ -0,0 +1,100 @@
+// Package heaps implements priority queues: d-ary heaps in an array,
+// including the binary heap, and the mergeable pairing, leftist and
+// Fibonacci heaps built from linked nodes.
+//
+// Every heap orders its values by a comparison function and pops the least;
+// pass Min for a min-heap of ordered values or Max for a max-heap, or any
+// other function for other values. Push returns an Element, a handle that
+// stays valid however the heap moves its values, through which Update
+// changes a value, as decrease-key in Dijkstra's or Prim's algorithm, and
+// Remove deletes it.
+//
+// Amortised costs, for a heap of n values:
+//
+//	           Push        Pop           decrease    Merge
+//	d-ary      O(log_d n)  O(d log_d n)  O(log_d n)  -
+//	pairing    O(1)        O(log n)      o(log n)    O(1)
+//	leftist    O(log n)    O(log n)      O(log n)    O(log n)
+//	Fibonacci  O(1)        O(log n)      O(1)        O(1)
+//
+// The amortised cost of decrease-key in a pairing heap is only known to lie
+// between Ω(log log n) and O(2^(2√(log log n))); in practice the pairing heap
+// is often the fastest of the linked heaps.
+package heaps
+
+import "cmp"
+
+// Heap is a priority queue of values of type T.
+type Heap[T any] interface {
+	// Len returns the number of values.
+	Len() int
+	// Push adds v and returns its element.
+	Push(v T) *Element[T]
+	// PushAll adds values and returns their elements, in the same order,
+	// faster than pushing them one by one where the heap allows: into an
+	// empty heap, it takes O(len(values)) time.
+	PushAll(values ...T) []*Element[T]
+	// Peek returns the least value, or false if the heap is empty.
+	Peek() (T, bool)
+	// Pop removes the least value and returns it, or false if the heap is
+	// empty.
+	Pop() (T, bool)
+	// Update changes the value of element e, which must be in the heap, to
+	// v.
+	Update(e *Element[T], v T)
+	// Remove removes element e, which must be in the heap, and returns its
+	// value.
+	Remove(e *Element[T]) T
+}
+
+// Min compares ordered values for a min-heap, which pops the smallest.
+func Min[T cmp.Ordered](a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+// Max compares ordered values for a max-heap, which pops the largest.
+func Max[T cmp.Ordered](a, b T) int {
+	return cmp.Compare(b, a)
+}
+
+// Element is a value in a heap. It is created by Push and leaves the heap
+// when it is popped or removed, after which the heap no longer uses it.
+//
+// Elements do not record their heap, which keeps merging O(1). Passing an
+// element of one heap to a method of another is a programming error that is
+// caught where cheaply possible, as when removing an element twice.
+type Element[T any] struct {
+	value T
+	index int // DAry: position in the array; all heaps: -1 when not in one
+
+	// Links of the linked heaps; each heap documents its use of them.
+	parent, child, prev, next *Element[T]
+	degree                    int  // Fibonacci: number of children; leftist: rank
+	mark                      bool // Fibonacci: whether e lost a child since it became one
+}
+
+// Value returns the value of e.
+func (e *Element[T]) Value() T {
+	return e.value
+}
+
+// newElement returns an element of value v, in a heap.
+func newElement[T any](v T) *Element[T] {
+	return &Element[T]{value: v}
+}
+
+// checkIn panics unless e is in a heap.
+func checkIn[T any](e *Element[T]) {
+	if e.index < 0 {
+		panic("heaps: element is not in the heap")
+	}
+}
+
+// pushAll pushes values into h one by one.
+func pushAll[T any](h Heap[T], values []T) []*Element[T] {
+	elements := make([]*Element[T], len(values))
+	for i, v := range values {
+		elements[i] = h.Push(v)
+	}
+	return elements
+}
@@ -0,0 +1,277 @@
+package heaps
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kinds are the kinds of heap that every test runs on.
+var kinds = []struct {
+	name string
+	new  func(cmp func(a, b int) int) Heap[int]
+}{
+	{"binary", func(cmp func(a, b int) int) Heap[int] { return NewBinary(cmp) }},
+	{"4-ary", func(cmp func(a, b int) int) Heap[int] { return NewDAry(4, cmp) }},
+	{"pairing", func(cmp func(a, b int) int) Heap[int] { return NewPairing(cmp) }},
+	{"leftist", func(cmp func(a, b int) int) Heap[int] { return NewLeftist(cmp) }},
+	{"Fibonacci", func(cmp func(a, b int) int) Heap[int] { return NewFibonacci(cmp) }},
+}
+
+// drain pops every value of h.
+func drain(h Heap[int]) []int {
+	var values []int
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// TestHeapOrder tests that every heap pops its values in order, as a
+// min-heap and as a max-heap, however they were added.
+func TestHeapOrder(t *testing.T) {
+	values := []int{5, 3, 9, 1, 5, 7, 2, 8, 0, 6, 4, 5}
+	sorted := slices.Sorted(slices.Values(values))
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+
+	testCases := []struct {
+		name     string
+		cmp      func(a, b int) int
+		fill     func(h Heap[int])
+		expected []int
+	}{
+		{"min by Push", Min[int], func(h Heap[int]) {
+			for _, v := range values {
+				h.Push(v)
+			}
+		}, sorted},
+		{"max by Push", Max[int], func(h Heap[int]) {
+			for _, v := range values {
+				h.Push(v)
+			}
+		}, reversed},
+		{"min by PushAll", Min[int], func(h Heap[int]) { h.PushAll(values...) }, sorted},
+		{"max by PushAll onto values", Max[int], func(h Heap[int]) {
+			h.Push(values[0])
+			h.PushAll(values[1:]...)
+		}, reversed},
+	}
+
+	for _, heap := range kinds {
+		for _, tc := range testCases {
+			t.Run(heap.name+"/"+tc.name, func(t *testing.T) {
+				h := heap.new(tc.cmp)
+				tc.fill(h)
+				require.NoError(t, checkHeap(h))
+				assert.Equal(t, len(values), h.Len())
+				top, ok := h.Peek()
+				assert.True(t, ok)
+				assert.Equal(t, tc.expected[0], top)
+				got := drain(h)
+				assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+
+				_, ok = h.Peek()
+				assert.False(t, ok)
+				assert.Zero(t, h.Len())
+			})
+		}
+	}
+}
+
+// TestHeapSort tests that draining a heap sorts random values with many
+// duplicates, including after popping half of them and pushing them back,
+// which leaves the linked heaps with deep and uneven trees.
+func TestHeapSort(t *testing.T) {
+	for _, heap := range kinds {
+		for _, n := range []int{1, 2, 3, 10, 1000} {
+			t.Run(fmt.Sprintf("%s/n=%d", heap.name, n), func(t *testing.T) {
+				rng := rand.New(rand.NewPCG(uint64(n), 7))
+				values := make([]int, n)
+				for i := range values {
+					values[i] = rng.IntN(n/2 + 1)
+				}
+				h := heap.new(Min[int])
+				h.PushAll(values[:n/2]...)
+				for _, v := range values[n/2:] {
+					h.Push(v)
+				}
+
+				var popped []int
+				for range n / 2 {
+					v, _ := h.Pop()
+					popped = append(popped, v)
+				}
+				assert.True(t, slices.IsSorted(popped), "popped %v", popped)
+				for _, v := range popped {
+					h.Push(v)
+				}
+				require.NoError(t, checkHeap(h))
+
+				expected := slices.Sorted(slices.Values(values))
+				assert.Equal(t, expected, drain(h))
+			})
+		}
+	}
+}
+
+// TestHeapHandles tests Update and Remove through the elements returned by
+// PushAll, and that a removed element is refused.
+func TestHeapHandles(t *testing.T) {
+	for _, heap := range kinds {
+		t.Run(heap.name, func(t *testing.T) {
+			h := heap.new(Min[int])
+			e := h.PushAll(10, 20, 30, 40, 50, 60)
+			for _, x := range e {
+				h.Push(x.Value() + 5)
+			}
+			h.Pop() // make the linked heaps link their trees
+
+			h.Update(e[4], 1) // decrease 50 to 1
+			h.Update(e[1], 100)
+			assert.Equal(t, 100, e[1].Value())
+			assert.Equal(t, 30, h.Remove(e[2]))
+			require.NoError(t, checkHeap(h))
+			assert.Equal(t, []int{1, 15, 25, 35, 40, 45, 55, 60, 65, 100}, drain(h))
+
+			assert.PanicsWithValue(t, "heaps: element is not in the heap", func() { h.Remove(e[2]) })
+			assert.PanicsWithValue(t, "heaps: element is not in the heap", func() { h.Update(e[0], 0) })
+		})
+	}
+}
+
+// TestHeapUpdates tests that elements keep their values while the heap moves
+// them: each value is raised and lowered many times, a third are then
+// removed, and the rest must pop in the order of their last values.
+func TestHeapUpdates(t *testing.T) {
+	const n = 300
+	for _, heap := range kinds {
+		t.Run(heap.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(5, 6))
+			h := heap.new(Min[int])
+			elements := h.PushAll(rng.Perm(n)...)
+			for range 10 * n {
+				e := elements[rng.IntN(n)]
+				if rng.IntN(4) == 0 {
+					h.Update(e, e.Value()+rng.IntN(n)) // increase
+				} else {
+					h.Update(e, e.Value()-rng.IntN(n)) // decrease
+				}
+			}
+			require.NoError(t, checkHeap(h))
+
+			rng.Shuffle(n, func(i, j int) { elements[i], elements[j] = elements[j], elements[i] })
+			removed, kept := elements[:n/3], elements[n/3:]
+			for _, e := range removed {
+				v := e.Value()
+				assert.Equal(t, v, h.Remove(e))
+			}
+			require.NoError(t, checkHeap(h))
+			assert.Equal(t, len(kept), h.Len())
+
+			var expected []int
+			for _, e := range kept {
+				expected = append(expected, e.Value())
+			}
+			slices.Sort(expected)
+			assert.Equal(t, expected, drain(h))
+			for _, e := range elements {
+				assert.PanicsWithValue(t, "heaps: element is not in the heap", func() { h.Update(e, 0) })
+			}
+		})
+	}
+}
+
+// mergeable is a heap that merges with others of its kind.
+type mergeable[H any] interface {
+	Heap[int]
+	Merge(other H)
+}
+
+// testMerge tests merging two heaps made by newHeap of the given sizes:
+// the result holds the values of both, the elements of the other heap work
+// through it, and the other heap is left empty and usable.
+func testMerge[H mergeable[H]](t *testing.T, newHeap func() H) {
+	for _, sizes := range [][2]int{{0, 0}, {0, 5}, {5, 0}, {50, 70}} {
+		t.Run(fmt.Sprintf("%d+%d", sizes[0], sizes[1]), func(t *testing.T) {
+			h, other := newHeap(), newHeap()
+			var expected []int
+			for i := range sizes[0] {
+				expected = append(expected, h.Push(3*i).Value())
+			}
+			var elements []*Element[int]
+			for i := range sizes[1] {
+				elements = append(elements, other.Push(2*i+1))
+				expected = append(expected, 2*i+1)
+			}
+			// Pop from each to give the linked heaps some shape.
+			for _, heap := range []Heap[int]{h, other} {
+				if v, ok := heap.Pop(); ok {
+					i := slices.Index(expected, v)
+					expected = slices.Delete(expected, i, i+1)
+				}
+			}
+			h.Merge(other)
+			h.Merge(h)
+			require.NoError(t, checkHeap(h))
+			assert.Zero(t, other.Len())
+			_, ok := other.Peek()
+			assert.False(t, ok)
+
+			if len(elements) > 1 {
+				// An element of other is now one of h.
+				last := elements[len(elements)-1]
+				i := slices.Index(expected, last.Value())
+				h.Update(last, -1)
+				expected[i] = -1
+			}
+			slices.Sort(expected)
+			assert.Equal(t, expected, drain(h))
+
+			other.Push(7)
+			v, ok := other.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, 7, v)
+		})
+	}
+}
+
+// TestHeapMerge tests merging each of the mergeable heaps.
+func TestHeapMerge(t *testing.T) {
+	t.Run("pairing", func(t *testing.T) {
+		testMerge(t, func() *Pairing[int] { return NewPairing(Min[int]) })
+	})
+	t.Run("leftist", func(t *testing.T) {
+		testMerge(t, func() *Leftist[int] { return NewLeftist(Min[int]) })
+	})
+	t.Run("Fibonacci", func(t *testing.T) {
+		testMerge(t, func() *Fibonacci[int] { return NewFibonacci(Min[int]) })
+	})
+}
+
+// TestDAryBuild tests that PushAll builds a d-ary heap in linear time: the
+// bottom-up build makes fewer than 2n comparisons.
+func TestDAryBuild(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprint("d=", d), func(t *testing.T) {
+			const n = 10000
+			comparisons := 0
+			h := NewDAry(d, func(a, b int) int {
+				comparisons++
+				return a - b
+			})
+			values := rand.New(rand.NewPCG(1, 2)).Perm(n)
+			elements := h.PushAll(values...)
+			assert.Less(t, comparisons, 2*n)
+			require.NoError(t, checkDAry(h))
+			for i, e := range elements {
+				assert.Equal(t, values[i], e.Value())
+			}
+		})
+	}
+	assert.PanicsWithValue(t, "heaps: d-ary heap with d less than 2", func() { NewDAry(1, Min[int]) })
+}
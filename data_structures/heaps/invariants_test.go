@@ -0,0 +1,167 @@
+package heaps
+
+import "fmt"
+
+// checkHeap checks the invariants of h, whichever kind of heap it is.
+func checkHeap[T any](h Heap[T]) error {
+	switch h := h.(type) {
+	case *DAry[T]:
+		return checkDAry(h)
+	case *Pairing[T]:
+		return checkPairing(h)
+	case *Leftist[T]:
+		return checkLeftist(h)
+	case *Fibonacci[T]:
+		return checkFibonacci(h)
+	}
+	return fmt.Errorf("no invariants for %T", h)
+}
+
+// checkDAry checks that every value of h is at least its parent and that
+// every element knows its index.
+func checkDAry[T any](h *DAry[T]) error {
+	for i, e := range h.items {
+		if e.index != i {
+			return fmt.Errorf("element at %d has index %d", i, e.index)
+		}
+		if p := (i - 1) / h.d; i > 0 && h.cmp(h.items[p].value, e.value) > 0 {
+			return fmt.Errorf("value %v at %d is less than its parent %v", e.value, i, h.items[p].value)
+		}
+	}
+	return nil
+}
+
+// checkPairing checks the heap order and the sibling links of h and that it
+// holds Len values.
+func checkPairing[T any](h *Pairing[T]) error {
+	if h.root != nil && (h.root.prev != nil || h.root.next != nil) {
+		return fmt.Errorf("root %v has siblings", h.root.value)
+	}
+	count := 0
+	var walk func(e *Element[T]) error
+	walk = func(e *Element[T]) error {
+		count++
+		if e.index < 0 {
+			return fmt.Errorf("element %v is marked removed", e.value)
+		}
+		prev := e
+		for c := e.child; c != nil; prev, c = c, c.next {
+			if c.prev != prev {
+				return fmt.Errorf("child %v of %v has a wrong prev link", c.value, e.value)
+			}
+			if h.cmp(e.value, c.value) > 0 {
+				return fmt.Errorf("child %v is less than its parent %v", c.value, e.value)
+			}
+			if err := walk(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if h.root != nil {
+		if err := walk(h.root); err != nil {
+			return err
+		}
+	}
+	if count != h.len {
+		return fmt.Errorf("heap has %d values but Len %d", count, h.len)
+	}
+	return nil
+}
+
+// checkLeftist checks the heap order, the ranks, the leftist property and
+// the parent links of h and that it holds Len values.
+func checkLeftist[T any](h *Leftist[T]) error {
+	if h.root != nil && h.root.parent != nil {
+		return fmt.Errorf("root %v has a parent", h.root.value)
+	}
+	count := 0
+	var walk func(e *Element[T]) error
+	walk = func(e *Element[T]) error {
+		count++
+		if e.degree != rank(e.next)+1 {
+			return fmt.Errorf("element %v has rank %d, want %d", e.value, e.degree, rank(e.next)+1)
+		}
+		if rank(e.child) < rank(e.next) {
+			return fmt.Errorf("element %v has a left child of rank %d and a right of %d", e.value, rank(e.child), rank(e.next))
+		}
+		for _, c := range []*Element[T]{e.child, e.next} {
+			if c == nil {
+				continue
+			}
+			if c.parent != e {
+				return fmt.Errorf("child %v of %v has a wrong parent link", c.value, e.value)
+			}
+			if h.cmp(e.value, c.value) > 0 {
+				return fmt.Errorf("child %v is less than its parent %v", c.value, e.value)
+			}
+			if err := walk(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if h.root != nil {
+		if err := walk(h.root); err != nil {
+			return err
+		}
+	}
+	if count != h.len {
+		return fmt.Errorf("heap has %d values but Len %d", count, h.len)
+	}
+	return nil
+}
+
+// checkFibonacci checks the circular lists, the parent links, the degrees
+// and the heap order of h, that min is the least root, that no root is
+// marked and that it holds Len values.
+func checkFibonacci[T any](h *Fibonacci[T]) error {
+	count := 0
+	// walk checks the circular list starting at first, whose elements have
+	// the given parent, and returns its length.
+	var walk func(first, parent *Element[T]) (int, error)
+	walk = func(first, parent *Element[T]) (int, error) {
+		n := 0
+		for e := first; ; {
+			n++
+			count++
+			if e.next.prev != e {
+				return 0, fmt.Errorf("element %v has a wrong next link", e.value)
+			}
+			if e.parent != parent {
+				return 0, fmt.Errorf("element %v has a wrong parent link", e.value)
+			}
+			if parent == nil && e.mark {
+				return 0, fmt.Errorf("root %v is marked", e.value)
+			}
+			if parent == nil && h.cmp(e.value, h.min.value) < 0 {
+				return 0, fmt.Errorf("root %v is less than min %v", e.value, h.min.value)
+			}
+			if parent != nil && h.cmp(parent.value, e.value) > 0 {
+				return 0, fmt.Errorf("child %v is less than its parent %v", e.value, parent.value)
+			}
+			children := 0
+			if e.child != nil {
+				var err error
+				if children, err = walk(e.child, e); err != nil {
+					return 0, err
+				}
+			}
+			if children != e.degree {
+				return 0, fmt.Errorf("element %v has %d children but degree %d", e.value, children, e.degree)
+			}
+			if e = e.next; e == first {
+				return n, nil
+			}
+		}
+	}
+	if h.min != nil {
+		if _, err := walk(h.min, nil); err != nil {
+			return err
+		}
+	}
+	if count != h.len {
+		return fmt.Errorf("heap has %d values but Len %d", count, h.len)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package heaps
+
+// Leftist is a leftist heap: a heap-ordered binary tree where the rank of
+// each node, the length of its rightmost path to a missing child, is at
+// least that of its right child plus one, and the rank of its left child is
+// at least that of its right. The right spine is then at most log(n+1) long,
+// and two heaps merge along their right spines in O(log n). Push, Pop and
+// Remove are all merges.
+//
+// An element's child is its left child and next its right child; parent is
+// its parent and degree its rank.
+type Leftist[T any] struct {
+	root *Element[T]
+	len  int
+	cmp  func(a, b T) int
+}
+
+// NewLeftist returns an empty leftist heap ordered by cmp, which returns a
+// negative number, zero or a positive number as a is less than, equal to or
+// greater than b.
+func NewLeftist[T any](cmp func(a, b T) int) *Leftist[T] {
+	return &Leftist[T]{cmp: cmp}
+}
+
+var _ Heap[int] = (*Leftist[int])(nil)
+
+// Len returns the number of values.
+func (h *Leftist[T]) Len() int {
+	return h.len
+}
+
+// Push adds v and returns its element, in O(log n).
+func (h *Leftist[T]) Push(v T) *Element[T] {
+	e := newElement(v)
+	e.degree = 1
+	h.setRoot(h.merge(h.root, e))
+	h.len++
+	return e
+}
+
+// PushAll adds values and returns their elements. It builds a heap of the
+// values by merging them in pairs, the results in pairs and so on, which
+// takes O(len(values)) time, and merges that into h.
+func (h *Leftist[T]) PushAll(values ...T) []*Element[T] {
+	elements := make([]*Element[T], len(values))
+	queue := make([]*Element[T], len(values))
+	for i, v := range values {
+		e := newElement(v)
+		e.degree = 1
+		elements[i], queue[i] = e, e
+	}
+	for len(queue) > 1 {
+		queue = append(queue[2:], h.merge(queue[0], queue[1]))
+	}
+	if len(queue) == 1 {
+		h.setRoot(h.merge(h.root, queue[0]))
+	}
+	h.len += len(values)
+	return elements
+}
+
+// Peek returns the least value, or false if the heap is empty.
+func (h *Leftist[T]) Peek() (v T, ok bool) {
+	if h.root == nil {
+		return v, false
+	}
+	return h.root.value, true
+}
+
+// Pop removes the least value and returns it, or false if the heap is empty.
+func (h *Leftist[T]) Pop() (v T, ok bool) {
+	if h.root == nil {
+		return v, false
+	}
+	return h.Remove(h.root), true
+}
+
+// Update changes the value of element e to v by removing it and pushing it
+// back, in O(log n).
+func (h *Leftist[T]) Update(e *Element[T], v T) {
+	h.Remove(e)
+	e.value, e.index, e.degree = v, 0, 1
+	h.setRoot(h.merge(h.root, e))
+	h.len++
+}
+
+// Remove removes element e and returns its value. The merge of the children
+// of e takes its place, and the ranks above are corrected up to the first
+// that does not change.
+func (h *Leftist[T]) Remove(e *Element[T]) T {
+	checkIn(e)
+	sub := h.merge(e.child, e.next)
+	parent := e.parent
+	switch {
+	case parent == nil:
+		h.setRoot(sub)
+	case parent.child == e:
+		parent.child = sub
+	default:
+		parent.next = sub
+	}
+	if sub != nil && parent != nil {
+		sub.parent = parent
+	}
+	for x := parent; x != nil; x = x.parent {
+		if rank(x.child) < rank(x.next) {
+			x.child, x.next = x.next, x.child
+		}
+		r := rank(x.next) + 1
+		if r == x.degree {
+			break
+		}
+		x.degree = r
+	}
+	e.parent, e.child, e.next = nil, nil, nil
+	h.len--
+	e.index = -1
+	return e.value
+}
+
+// Merge moves all values of other into h, in O(log n), leaving other empty.
+// Both heaps must have the same order.
+func (h *Leftist[T]) Merge(other *Leftist[T]) {
+	if other == h {
+		return
+	}
+	h.setRoot(h.merge(h.root, other.root))
+	h.len += other.len
+	other.root, other.len = nil, 0
+}
+
+// setRoot makes r, which may be nil, the root.
+func (h *Leftist[T]) setRoot(r *Element[T]) {
+	if r != nil {
+		r.parent = nil
+	}
+	h.root = r
+}
+
+// merge merges the trees rooted at a and b, either of which may be nil,
+// along their right spines, and returns the root of the result.
+func (h *Leftist[T]) merge(a, b *Element[T]) *Element[T] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case h.cmp(b.value, a.value) < 0:
+		a, b = b, a
+	}
+	a.next = h.merge(a.next, b)
+	a.next.parent = a
+	if rank(a.child) < rank(a.next) {
+		a.child, a.next = a.next, a.child
+	}
+	a.degree = rank(a.next) + 1
+	return a
+}
+
+// rank returns the rank of the subtree rooted at e, 0 if e is nil.
+func rank[T any](e *Element[T]) int {
+	if e == nil {
+		return 0
+	}
+	return e.degree
+}
@@ -0,0 +1,165 @@
+package heaps
+
+// Pairing is a pairing heap: a heap-ordered tree of any shape, where each
+// node has a list of children. Push and Merge link two trees by making the
+// root with the larger value the first child of the other, in O(1). Pop
+// removes the root and combines its children in two passes, linking them in
+// pairs from left to right and then the pairs from right to left, which
+// takes O(log n) amortised time.
+//
+// An element's child is its first child and next its next sibling; prev is
+// its previous sibling, or its parent if it is a first child.
+type Pairing[T any] struct {
+	root *Element[T]
+	len  int
+	cmp  func(a, b T) int
+}
+
+// NewPairing returns an empty pairing heap ordered by cmp, which returns a
+// negative number, zero or a positive number as a is less than, equal to or
+// greater than b.
+func NewPairing[T any](cmp func(a, b T) int) *Pairing[T] {
+	return &Pairing[T]{cmp: cmp}
+}
+
+var _ Heap[int] = (*Pairing[int])(nil)
+
+// Len returns the number of values.
+func (h *Pairing[T]) Len() int {
+	return h.len
+}
+
+// Push adds v and returns its element, in O(1).
+func (h *Pairing[T]) Push(v T) *Element[T] {
+	e := newElement(v)
+	h.root = h.link(h.root, e)
+	h.len++
+	return e
+}
+
+// PushAll adds values and returns their elements, in O(1) each.
+func (h *Pairing[T]) PushAll(values ...T) []*Element[T] {
+	return pushAll(h, values)
+}
+
+// Peek returns the least value, or false if the heap is empty.
+func (h *Pairing[T]) Peek() (v T, ok bool) {
+	if h.root == nil {
+		return v, false
+	}
+	return h.root.value, true
+}
+
+// Pop removes the least value and returns it, or false if the heap is empty.
+func (h *Pairing[T]) Pop() (v T, ok bool) {
+	if h.root == nil {
+		return v, false
+	}
+	return h.Remove(h.root), true
+}
+
+// Update changes the value of element e to v. A decrease cuts the subtree
+// of e off and links it with the root; an increase removes e and pushes it
+// back.
+func (h *Pairing[T]) Update(e *Element[T], v T) {
+	checkIn(e)
+	if h.cmp(v, e.value) > 0 {
+		h.Remove(e)
+		e.value, e.index = v, 0
+		h.root = h.link(h.root, e)
+		h.len++
+		return
+	}
+	e.value = v
+	if e != h.root {
+		h.cut(e)
+		h.root = h.link(h.root, e)
+	}
+}
+
+// Remove removes element e and returns its value: the children of e are
+// combined into one tree, which takes the place of e.
+func (h *Pairing[T]) Remove(e *Element[T]) T {
+	checkIn(e)
+	children := h.combine(e.child)
+	e.child = nil
+	if e == h.root {
+		h.root = children
+	} else {
+		h.cut(e)
+		h.root = h.link(h.root, children)
+	}
+	h.len--
+	e.index = -1
+	return e.value
+}
+
+// Merge moves all values of other into h, in O(1), leaving other empty.
+// Both heaps must have the same order.
+func (h *Pairing[T]) Merge(other *Pairing[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.len += other.len
+	other.root, other.len = nil, 0
+}
+
+// link links the trees rooted at a and b, either of which may be nil, which
+// have no siblings, and returns the root of the result.
+func (h *Pairing[T]) link(a, b *Element[T]) *Element[T] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case h.cmp(b.value, a.value) < 0:
+		a, b = b, a
+	}
+	b.prev, b.next = a, a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// cut removes e, with its subtree, from the list of children of its parent.
+func (h *Pairing[T]) cut(e *Element[T]) {
+	if e.prev.child == e {
+		e.prev.child = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+// combine links the trees in the list of siblings starting at first into
+// one in two passes and returns its root, or nil if the list is empty.
+func (h *Pairing[T]) combine(first *Element[T]) *Element[T] {
+	// Link pairs from left to right, keeping the results in a list
+	// through next in reverse order.
+	var pairs *Element[T]
+	for first != nil {
+		a, b := first, first.next
+		first = nil
+		if b != nil {
+			first = b.next
+			b.prev, b.next = nil, nil
+		}
+		a.prev, a.next = nil, nil
+		a = h.link(a, b)
+		a.next, pairs = pairs, a
+	}
+	// Link the results from right to left.
+	var root *Element[T]
+	for pairs != nil {
+		a := pairs
+		pairs, a.next = a.next, nil
+		root = h.link(a, root)
+	}
+	return root
+}
@@ -0,0 +1,60 @@
+package heaps
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	// values returns n values in a scrambled order.
+	values := func(n int) []int {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = i * 7919 % (n + 1)
+		}
+		return a
+	}
+	// sort pops all values of h after pushing them one by one.
+	sort := func(h Heap[int], a []int) []int {
+		for _, v := range a {
+			h.Push(v)
+		}
+		for i := range a {
+			a[i], _ = h.Pop()
+		}
+		return a
+	}
+
+	registry.Register(registry.Algorithm{
+		Name:     "DAry.PushAll",
+		Package:  "data_structures/heaps",
+		Category: "data_structures",
+		Summary:  "Bottom-up construction of a binary heap from n values (Floyd)",
+		Time:     "O(n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			h := NewBinary(Min[int])
+			h.PushAll(values(n)...)
+			return h.Len()
+		},
+	})
+	for _, heap := range []struct {
+		name, kind, time string
+		new              func() Heap[int]
+	}{
+		{"DAry", "4-ary", "O(log n)", func() Heap[int] { return NewDAry(4, Min[int]) }},
+		{"Pairing", "pairing", "O(log n) amortised", func() Heap[int] { return NewPairing(Min[int]) }},
+		{"Leftist", "leftist", "O(log n)", func() Heap[int] { return NewLeftist(Min[int]) }},
+		{"Fibonacci", "Fibonacci", "O(log n) amortised", func() Heap[int] { return NewFibonacci(Min[int]) }},
+	} {
+		registry.Register(registry.Algorithm{
+			Name:     heap.name + ".Pop",
+			Package:  "data_structures/heaps",
+			Category: "data_structures",
+			Summary:  "Removal of the least value of a " + heap.kind + " heap, popping all of n values",
+			Time:     heap.time,
+			Space:    "O(n)",
+			Run: func(n int) any {
+				return sort(heap.new(), values(n))
+			},
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package linked_lists
+
+import "iter"
+
+// DoublyNode is a node of a doubly linked list.
+type DoublyNode[T any] struct {
+	Value      T
+	prev, next *DoublyNode[T]
+}
+
+// Next returns the node after n, or nil if n is the last.
+func (n *DoublyNode[T]) Next() *DoublyNode[T] {
+	return n.next
+}
+
+// Prev returns the node before n, or nil if n is the first.
+func (n *DoublyNode[T]) Prev() *DoublyNode[T] {
+	return n.prev
+}
+
+// Doubly is a doubly linked list. The zero value is an empty list ready to
+// use.
+//
+// Nodes do not record their list, which keeps SpliceAfter O(1). Passing a
+// node of one list to a method of another is a programming error that is
+// caught where cheaply possible, as when removing a node twice.
+type Doubly[T any] struct {
+	head, tail *DoublyNode[T]
+	len        int
+}
+
+// NewDoubly returns a doubly linked list of values, in order.
+func NewDoubly[T any](values ...T) *Doubly[T] {
+	l := &Doubly[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Len returns the number of values in the list.
+func (l *Doubly[T]) Len() int {
+	return l.len
+}
+
+// Front returns the first node of the list, or nil if it is empty.
+func (l *Doubly[T]) Front() *DoublyNode[T] {
+	return l.head
+}
+
+// Back returns the last node of the list, or nil if it is empty.
+func (l *Doubly[T]) Back() *DoublyNode[T] {
+	return l.tail
+}
+
+// PushFront inserts v at the front of the list and returns its node.
+func (l *Doubly[T]) PushFront(v T) *DoublyNode[T] {
+	return l.InsertAfter(nil, v)
+}
+
+// PushBack inserts v at the back of the list and returns its node.
+func (l *Doubly[T]) PushBack(v T) *DoublyNode[T] {
+	return l.InsertBefore(nil, v)
+}
+
+// PopFront removes the first value of the list and returns it, or returns
+// false if the list is empty.
+func (l *Doubly[T]) PopFront() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.Remove(l.head), true
+}
+
+// PopBack removes the last value of the list and returns it, or returns false
+// if the list is empty.
+func (l *Doubly[T]) PopBack() (T, bool) {
+	if l.tail == nil {
+		var zero T
+		return zero, false
+	}
+	return l.Remove(l.tail), true
+}
+
+// InsertAfter inserts v after the node at, or at the front if at is nil, and
+// returns its node. at must be a node of l.
+func (l *Doubly[T]) InsertAfter(at *DoublyNode[T], v T) *DoublyNode[T] {
+	next := l.head
+	if at != nil {
+		next = at.next
+	}
+	return l.link(&DoublyNode[T]{Value: v}, at, next)
+}
+
+// InsertBefore inserts v before the node at, or at the back if at is nil,
+// and returns its node. at must be a node of l.
+func (l *Doubly[T]) InsertBefore(at *DoublyNode[T], v T) *DoublyNode[T] {
+	prev := l.tail
+	if at != nil {
+		prev = at.prev
+	}
+	return l.link(&DoublyNode[T]{Value: v}, prev, at)
+}
+
+// link inserts n between the adjacent nodes prev and next, either of which is
+// nil at an end of the list.
+func (l *Doubly[T]) link(n, prev, next *DoublyNode[T]) *DoublyNode[T] {
+	n.prev, n.next = prev, next
+	if prev == nil {
+		l.head = n
+	} else {
+		prev.next = n
+	}
+	if next == nil {
+		l.tail = n
+	} else {
+		next.prev = n
+	}
+	l.len++
+	return n
+}
+
+// Remove removes the node n from the list and returns its value. It panics if
+// n is evidently not in l, such as when it was removed already.
+func (l *Doubly[T]) Remove(n *DoublyNode[T]) T {
+	if (n.prev == nil) != (l.head == n) || (n.next == nil) != (l.tail == n) {
+		panic("linked_lists: node is not in the list")
+	}
+	if n.prev == nil {
+		l.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		l.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+	n.prev, n.next = nil, nil
+	l.len--
+	return n.Value
+}
+
+// SpliceAfter moves all values of other after the node at, or to the front
+// if at is nil, leaving other empty. It relinks the ends of other without
+// visiting its nodes, so it takes O(1) time. at must be a node of l, and
+// other must not be l.
+func (l *Doubly[T]) SpliceAfter(at *DoublyNode[T], other *Doubly[T]) {
+	if other == l {
+		panic("linked_lists: splicing a list into itself")
+	}
+	if other.len == 0 {
+		return
+	}
+
+	first, last := other.head, other.tail
+	next := l.head
+	if at != nil {
+		next = at.next
+	}
+	first.prev, last.next = at, next
+	if at == nil {
+		l.head = first
+	} else {
+		at.next = first
+	}
+	if next == nil {
+		l.tail = last
+	} else {
+		next.prev = last
+	}
+	l.len += other.len
+	*other = Doubly[T]{}
+}
+
+// Reverse reverses the order of the list in place.
+func (l *Doubly[T]) Reverse() {
+	for n := l.head; n != nil; n = n.prev {
+		n.prev, n.next = n.next, n.prev
+	}
+	l.head, l.tail = l.tail, l.head
+}
+
+// Merge moves the values of other into l, leaving other empty. Both lists
+// must be sorted by cmp, and the result is too. The merge is stable: equal
+// values keep their order, those of l first. It relinks the nodes, taking
+// O(n+m) time and O(1) space. other must not be l.
+func (l *Doubly[T]) Merge(other *Doubly[T], cmp func(a, b T) int) {
+	if other == l {
+		panic("linked_lists: merging a list with itself")
+	}
+
+	var head DoublyNode[T] // placeholder before the first merged node
+	tail := &head
+	a, b := l.head, other.head
+	for a != nil && b != nil {
+		if cmp(b.Value, a.Value) < 0 {
+			tail.next, b = b, b.next
+		} else {
+			tail.next, a = a, a.next
+		}
+		tail.next.prev = tail
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	if tail.next != nil {
+		tail.next.prev = tail
+	}
+
+	l.head = head.next
+	if l.head != nil {
+		l.head.prev = nil
+	}
+	if b != nil {
+		l.tail = other.tail
+	}
+	l.len += other.len
+	*other = Doubly[T]{}
+}
+
+// All returns an iterator over the positions and values of the list, from
+// front to back. The node of the current value may be removed during the
+// iteration.
+func (l *Doubly[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for n := l.head; n != nil; i++ {
+			next := n.next
+			if !yield(i, n.Value) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// Backward returns an iterator over the positions and values of the list,
+// from back to front. The node of the current value may be removed during the
+// iteration.
+func (l *Doubly[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := l.len - 1
+		for n := l.tail; n != nil; i-- {
+			prev := n.prev
+			if !yield(i, n.Value) {
+				return
+			}
+			n = prev
+		}
+	}
+}
+
+// Values returns an iterator over the values of the list, from front to back.
+func (l *Doubly[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
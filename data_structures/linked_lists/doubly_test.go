@@ -0,0 +1,202 @@
+package linked_lists
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkDoubly checks that the length, ends and backward links of l agree
+// with its forward links, and returns its values.
+func checkDoubly[T any](t *testing.T, l *Doubly[T]) []T {
+	t.Helper()
+	var values []T
+	var prev *DoublyNode[T]
+	for n := l.Front(); n != nil; n = n.Next() {
+		assert.Same(t, prev, n.Prev(), "Expected the node before %v to link back to it", n.Value)
+		values = append(values, n.Value)
+		prev = n
+	}
+	assert.Equal(t, len(values), l.Len(), "Expected: length %d, Got: %d", len(values), l.Len())
+	assert.Same(t, prev, l.Back())
+	return values
+}
+
+// TestDoublyPushPop tests pushing and popping at both ends.
+func TestDoublyPushPop(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ops      func(l *Doubly[int]) []int // returns the popped values
+		expected []int
+		popped   []int
+	}{
+		{"push back", func(l *Doubly[int]) []int {
+			l.PushBack(1)
+			l.PushBack(2)
+			return nil
+		}, []int{1, 2}, nil},
+		{"push front", func(l *Doubly[int]) []int {
+			l.PushFront(1)
+			l.PushFront(2)
+			return nil
+		}, []int{2, 1}, nil},
+		{"pop both ends", func(l *Doubly[int]) []int {
+			*l = *NewDoubly(1, 2, 3, 4)
+			a, _ := l.PopFront()
+			b, _ := l.PopBack()
+			return []int{a, b}
+		}, []int{2, 3}, []int{1, 4}},
+		{"pop to empty", func(l *Doubly[int]) []int {
+			l.PushFront(7)
+			v, _ := l.PopBack()
+			l.PushBack(8)
+			w, _ := l.PopFront()
+			return []int{v, w}
+		}, nil, []int{7, 8}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &Doubly[int]{}
+			popped := tc.ops(l)
+			assert.Equal(t, tc.popped, popped, "Expected: %v, Got: %v", tc.popped, popped)
+			values := checkDoubly(t, l)
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+		})
+	}
+
+	var empty Doubly[string]
+	_, ok := empty.PopFront()
+	assert.False(t, ok)
+	_, ok = empty.PopBack()
+	assert.False(t, ok)
+}
+
+// TestDoublyHandles tests inserting and removing at nodes.
+func TestDoublyHandles(t *testing.T) {
+	l := NewDoubly(2, 4)
+	two, four := l.Front(), l.Back()
+	l.InsertAfter(two, 3)
+	l.InsertBefore(two, 1)
+	l.InsertAfter(four, 5)
+	l.InsertBefore(nil, 6)
+	l.InsertAfter(nil, 0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, checkDoubly(t, l))
+
+	assert.Equal(t, 2, l.Remove(two))
+	assert.Equal(t, 0, l.Remove(l.Front()))
+	assert.Equal(t, 6, l.Remove(l.Back()))
+	assert.Equal(t, []int{1, 3, 4, 5}, checkDoubly(t, l))
+	assert.Nil(t, two.Next())
+	assert.Nil(t, two.Prev())
+
+	assert.PanicsWithValue(t, "linked_lists: node is not in the list", func() { l.Remove(two) })
+	other := NewDoubly(9)
+	assert.Panics(t, func() { l.Remove(other.Front()) })
+	assert.Equal(t, []int{1, 3, 4, 5}, checkDoubly(t, l))
+}
+
+// TestDoublySpliceAfter tests moving a whole list into another.
+func TestDoublySpliceAfter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		list     []int
+		at       int // position of the node to splice after; -1 for the front
+		other    []int
+		expected []int
+	}{
+		{"at front", []int{3, 4}, -1, []int{1, 2}, []int{1, 2, 3, 4}},
+		{"in the middle", []int{1, 4}, 0, []int{2, 3}, []int{1, 2, 3, 4}},
+		{"at back", []int{1, 2}, 1, []int{3, 4}, []int{1, 2, 3, 4}},
+		{"into empty", nil, -1, []int{1, 2}, []int{1, 2}},
+		{"empty other", []int{1, 2}, 0, nil, []int{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, other := NewDoubly(tc.list...), NewDoubly(tc.other...)
+			var at *DoublyNode[int]
+			for i, n := 0, l.Front(); i <= tc.at; i, n = i+1, n.Next() {
+				at = n
+			}
+			l.SpliceAfter(at, other)
+			values := checkDoubly(t, l)
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+			assert.Empty(t, checkDoubly(t, other))
+		})
+	}
+	assert.PanicsWithValue(t, "linked_lists: splicing a list into itself", func() {
+		l := NewDoubly(1)
+		l.SpliceAfter(nil, l)
+	})
+}
+
+// TestDoublyReverse tests reversing lists of several lengths.
+func TestDoublyReverse(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5} {
+		var values []int
+		for i := range n {
+			values = append(values, i)
+		}
+		l := NewDoubly(values...)
+		l.Reverse()
+		slices.Reverse(values)
+		got := checkDoubly(t, l)
+		assert.Equal(t, values, got, "Expected: %v, Got: %v", values, got)
+	}
+}
+
+// TestDoublyMerge tests merging sorted lists, keeping equal values stable.
+func TestDoublyMerge(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     []item
+		expected []item
+	}{
+		{"both empty", nil, nil, nil},
+		{"empty other", []item{{1, "a"}}, nil, []item{{1, "a"}}},
+		{"empty list", nil, []item{{1, "b"}}, []item{{1, "b"}}},
+		{"interleaved", []item{{1, "a"}, {4, "a"}, {6, "a"}}, []item{{2, "b"}, {3, "b"}, {7, "b"}},
+			[]item{{1, "a"}, {2, "b"}, {3, "b"}, {4, "a"}, {6, "a"}, {7, "b"}}},
+		{"ties take the list first", []item{{1, "a"}, {2, "a"}}, []item{{1, "b"}, {2, "b"}},
+			[]item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, other := NewDoubly(tc.a...), NewDoubly(tc.b...)
+			l.Merge(other, byKey)
+			values := checkDoubly(t, l)
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+			assert.Empty(t, checkDoubly(t, other))
+		})
+	}
+}
+
+// TestDoublyIterators tests All, Backward and Values, stopping early and
+// removing the current node while iterating.
+func TestDoublyIterators(t *testing.T) {
+	l := NewDoubly("a", "b", "c", "d")
+
+	var forward, backward []int
+	for i := range l.All() {
+		forward = append(forward, i)
+	}
+	for i, v := range l.Backward() {
+		backward = append(backward, i)
+		if v == "b" {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3}, forward)
+	assert.Equal(t, []int{3, 2, 1}, backward)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(l.Values()))
+
+	// Pop each value from the back while visiting it.
+	for _, v := range l.Backward() {
+		got, _ := l.PopBack()
+		assert.Equal(t, v, got)
+	}
+	assert.Empty(t, checkDoubly(t, l))
+}
@@ -0,0 +1,95 @@
+package linked_lists
+
+import (
+	"cmp"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+)
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "data_structures/linked_lists"
+	registry.Register(registry.Algorithm{
+		Name:     "Singly.Reverse",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Reversal of a singly linked list by turning each link around",
+		Time:     "O(n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run: func(n int) any {
+			l := NewSingly(count(n)...)
+			l.Reverse()
+			return l.Front().Value
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Singly.Merge",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Stable merge of two sorted singly linked lists by relinking nodes",
+		Time:     "O(n + m)",
+		Space:    "O(1)",
+		Stable:   true,
+		InPlace:  true,
+		Run: func(n int) any {
+			evens, odds := interleaved(n, NewSingly[int])
+			evens.Merge(odds, cmp.Compare[int])
+			return evens.Back().Value
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Doubly.Merge",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Stable merge of two sorted doubly linked lists by relinking nodes",
+		Time:     "O(n + m)",
+		Space:    "O(1)",
+		Stable:   true,
+		InPlace:  true,
+		Run: func(n int) any {
+			evens, odds := interleaved(n, NewDoubly[int])
+			evens.Merge(odds, cmp.Compare[int])
+			return evens.Back().Value
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "FindCycle",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Entry and length of a cycle in a chain of nodes by Floyd's tortoise and hare",
+		Time:     "O(n)",
+		Space:    "O(1)",
+		InPlace:  true,
+		Run: func(n int) any {
+			// A chain of n nodes whose last links back to the middle one.
+			l := NewSingly(count(n)...)
+			middle := l.Front()
+			for range n / 2 {
+				middle = middle.Next
+			}
+			l.Back().Next = middle
+			_, length := FindCycle(l.Front())
+			return length
+		},
+	})
+}
+
+// count returns the numbers 0 to n-1.
+func count(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+// interleaved returns the even and odd numbers below 2n as two lists made by
+// newList.
+func interleaved[L any](n int, newList func(...int) L) (evens, odds L) {
+	even, odd := make([]int, n), make([]int, n)
+	for i := range n {
+		even[i], odd[i] = 2*i, 2*i+1
+	}
+	return newList(even...), newList(odd...)
+}
@@ -0,0 +1,246 @@
+// Package linked_lists implements generic singly and doubly linked lists.
+//
+// Both lists keep their first node, last node and length, so pushing at
+// either end takes O(1) time. So does popping, except at the back of a singly
+// linked list, whose last node has no link to the one before it: that takes
+// O(n). Nodes returned by the lists are handles that stay valid while their
+// value is in the list, for inserting and removing next to them in O(1).
+package linked_lists
+
+import "iter"
+
+// SinglyNode is a node of a singly linked list. Its fields are exported for
+// algorithms on bare chains of nodes, such as FindCycle. While the node is in
+// a Singly list, its Value may change but its Next belongs to the list.
+type SinglyNode[T any] struct {
+	Value T
+	Next  *SinglyNode[T]
+}
+
+// Singly is a singly linked list. The zero value is an empty list ready to
+// use.
+type Singly[T any] struct {
+	head, tail *SinglyNode[T]
+	len        int
+}
+
+// NewSingly returns a singly linked list of values, in order.
+func NewSingly[T any](values ...T) *Singly[T] {
+	l := &Singly[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Len returns the number of values in the list.
+func (l *Singly[T]) Len() int {
+	return l.len
+}
+
+// Front returns the first node of the list, or nil if it is empty.
+func (l *Singly[T]) Front() *SinglyNode[T] {
+	return l.head
+}
+
+// Back returns the last node of the list, or nil if it is empty.
+func (l *Singly[T]) Back() *SinglyNode[T] {
+	return l.tail
+}
+
+// PushFront inserts v at the front of the list and returns its node.
+func (l *Singly[T]) PushFront(v T) *SinglyNode[T] {
+	return l.InsertAfter(nil, v)
+}
+
+// PushBack inserts v at the back of the list and returns its node.
+func (l *Singly[T]) PushBack(v T) *SinglyNode[T] {
+	return l.InsertAfter(l.tail, v)
+}
+
+// PopFront removes the first value of the list and returns it, or returns
+// false if the list is empty.
+func (l *Singly[T]) PopFront() (T, bool) {
+	return l.RemoveAfter(nil)
+}
+
+// PopBack removes the last value of the list and returns it, or returns false
+// if the list is empty. It walks the list to find the node before the last,
+// so it takes O(n) time.
+func (l *Singly[T]) PopBack() (T, bool) {
+	if l.head == l.tail {
+		return l.RemoveAfter(nil)
+	}
+	prev := l.head
+	for prev.Next != l.tail {
+		prev = prev.Next
+	}
+	return l.RemoveAfter(prev)
+}
+
+// InsertAfter inserts v after the node at, or at the front if at is nil, and
+// returns its node. at must be a node of l.
+func (l *Singly[T]) InsertAfter(at *SinglyNode[T], v T) *SinglyNode[T] {
+	n := &SinglyNode[T]{Value: v}
+	if at == nil {
+		n.Next, l.head = l.head, n
+	} else {
+		n.Next, at.Next = at.Next, n
+	}
+	if n.Next == nil {
+		l.tail = n
+	}
+	l.len++
+	return n
+}
+
+// RemoveAfter removes the value after the node at, or the first value if at
+// is nil, and returns it. It returns false if there is no such value. at must
+// be a node of l.
+func (l *Singly[T]) RemoveAfter(at *SinglyNode[T]) (T, bool) {
+	n := l.head
+	if at != nil {
+		n = at.Next
+	}
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+
+	if at == nil {
+		l.head = n.Next
+	} else {
+		at.Next = n.Next
+	}
+	if l.tail == n {
+		l.tail = at
+	}
+	n.Next = nil
+	l.len--
+	return n.Value, true
+}
+
+// SpliceAfter moves all values of other after the node at, or to the front
+// if at is nil, leaving other empty. It relinks the ends of other without
+// visiting its nodes, so it takes O(1) time. at must be a node of l, and
+// other must not be l.
+func (l *Singly[T]) SpliceAfter(at *SinglyNode[T], other *Singly[T]) {
+	if other == l {
+		panic("linked_lists: splicing a list into itself")
+	}
+	if other.len == 0 {
+		return
+	}
+
+	if at == nil {
+		other.tail.Next, l.head = l.head, other.head
+	} else {
+		other.tail.Next, at.Next = at.Next, other.head
+	}
+	if other.tail.Next == nil {
+		l.tail = other.tail
+	}
+	l.len += other.len
+	*other = Singly[T]{}
+}
+
+// Reverse reverses the order of the list in place.
+func (l *Singly[T]) Reverse() {
+	var prev *SinglyNode[T]
+	l.tail = l.head
+	for n := l.head; n != nil; {
+		next := n.Next
+		n.Next = prev
+		prev, n = n, next
+	}
+	l.head = prev
+}
+
+// Merge moves the values of other into l, leaving other empty. Both lists
+// must be sorted by cmp, and the result is too. The merge is stable: equal
+// values keep their order, those of l first. It relinks the nodes, taking
+// O(n+m) time and O(1) space. other must not be l.
+func (l *Singly[T]) Merge(other *Singly[T], cmp func(a, b T) int) {
+	if other == l {
+		panic("linked_lists: merging a list with itself")
+	}
+
+	var head SinglyNode[T] // placeholder before the first merged node
+	tail := &head
+	a, b := l.head, other.head
+	for a != nil && b != nil {
+		if cmp(b.Value, a.Value) < 0 {
+			tail.Next, b = b, b.Next
+		} else {
+			tail.Next, a = a, a.Next
+		}
+		tail = tail.Next
+	}
+	if a != nil {
+		tail.Next = a
+	} else {
+		tail.Next = b
+	}
+
+	l.head = head.Next
+	if b != nil {
+		l.tail = other.tail
+	}
+	l.len += other.len
+	*other = Singly[T]{}
+}
+
+// All returns an iterator over the positions and values of the list, from
+// front to back. The node of the current value may be removed during the
+// iteration.
+func (l *Singly[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for n := l.head; n != nil; i++ {
+			next := n.Next
+			if !yield(i, n.Value) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// Values returns an iterator over the values of the list, from front to back.
+func (l *Singly[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FindCycle finds a cycle in the chain of nodes starting at head by Floyd's
+// tortoise and hare: a pointer moving one node at a time and another moving
+// two meet inside the cycle if there is one. It returns the first node on the
+// cycle and the number of nodes in it, or nil and 0 if the chain ends. It
+// takes O(n) time and O(1) space.
+func FindCycle[T any](head *SinglyNode[T]) (entry *SinglyNode[T], length int) {
+	slow, fast := head, head
+	for fast != nil && fast.Next != nil {
+		slow, fast = slow.Next, fast.Next.Next
+		if slow != fast {
+			continue
+		}
+
+		length = 1
+		for n := slow.Next; n != slow; n = n.Next {
+			length++
+		}
+		// The meeting point is as far from the entry, going round the
+		// cycle, as head is from it along the chain.
+		entry = head
+		for entry != slow {
+			entry, slow = entry.Next, slow.Next
+		}
+		return entry, length
+	}
+	return nil, 0
+}
@@ -0,0 +1,263 @@
+package linked_lists
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkSingly checks that the length and last node of l agree with its
+// chain of nodes, and returns its values.
+func checkSingly[T any](t *testing.T, l *Singly[T]) []T {
+	t.Helper()
+	var values []T
+	var last *SinglyNode[T]
+	for n := l.Front(); n != nil; n = n.Next {
+		values = append(values, n.Value)
+		last = n
+	}
+	assert.Equal(t, len(values), l.Len(), "Expected: length %d, Got: %d", len(values), l.Len())
+	assert.Same(t, last, l.Back())
+	return values
+}
+
+// TestSinglyPushPop tests pushing and popping at both ends.
+func TestSinglyPushPop(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ops      func(l *Singly[int]) []int // returns the popped values
+		expected []int
+		popped   []int
+	}{
+		{"push back", func(l *Singly[int]) []int {
+			l.PushBack(1)
+			l.PushBack(2)
+			return nil
+		}, []int{1, 2}, nil},
+		{"push front", func(l *Singly[int]) []int {
+			l.PushFront(1)
+			l.PushFront(2)
+			return nil
+		}, []int{2, 1}, nil},
+		{"pop front", func(l *Singly[int]) []int {
+			*l = *NewSingly(1, 2, 3)
+			v, _ := l.PopFront()
+			return []int{v}
+		}, []int{2, 3}, []int{1}},
+		{"pop back", func(l *Singly[int]) []int {
+			*l = *NewSingly(1, 2, 3)
+			a, _ := l.PopBack()
+			b, _ := l.PopBack()
+			return []int{a, b}
+		}, []int{1}, []int{3, 2}},
+		{"pop to empty", func(l *Singly[int]) []int {
+			l.PushBack(7)
+			v, _ := l.PopBack()
+			l.PushBack(8)
+			w, _ := l.PopFront()
+			return []int{v, w}
+		}, nil, []int{7, 8}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &Singly[int]{}
+			popped := tc.ops(l)
+			assert.Equal(t, tc.popped, popped, "Expected: %v, Got: %v", tc.popped, popped)
+			values := checkSingly(t, l)
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+		})
+	}
+
+	var empty Singly[string]
+	_, ok := empty.PopFront()
+	assert.False(t, ok)
+	_, ok = empty.PopBack()
+	assert.False(t, ok)
+}
+
+// TestSinglyHandles tests inserting and removing next to nodes.
+func TestSinglyHandles(t *testing.T) {
+	l := NewSingly(1, 3)
+	one := l.Front()
+	l.InsertAfter(one, 2)
+	four := l.InsertAfter(l.Back(), 4)
+	l.InsertAfter(nil, 0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, checkSingly(t, l))
+	assert.Same(t, four, l.Back())
+
+	v, ok := l.RemoveAfter(one)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	v, ok = l.RemoveAfter(nil)
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	_, ok = l.RemoveAfter(four)
+	assert.False(t, ok)
+	assert.Equal(t, []int{1, 3, 4}, checkSingly(t, l))
+
+	// Removing the last value moves the back to the node before it.
+	v, _ = l.RemoveAfter(one.Next)
+	assert.Equal(t, 4, v)
+	assert.Equal(t, []int{1, 3}, checkSingly(t, l))
+	l.PushBack(5)
+	assert.Equal(t, []int{1, 3, 5}, checkSingly(t, l))
+}
+
+// TestSinglySpliceAfter tests moving a whole list into another.
+func TestSinglySpliceAfter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		list     []int
+		at       int // position of the node to splice after; -1 for the front
+		other    []int
+		expected []int
+	}{
+		{"at front", []int{3, 4}, -1, []int{1, 2}, []int{1, 2, 3, 4}},
+		{"in the middle", []int{1, 4}, 0, []int{2, 3}, []int{1, 2, 3, 4}},
+		{"at back", []int{1, 2}, 1, []int{3, 4}, []int{1, 2, 3, 4}},
+		{"into empty", nil, -1, []int{1, 2}, []int{1, 2}},
+		{"empty other", []int{1, 2}, 0, nil, []int{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, other := NewSingly(tc.list...), NewSingly(tc.other...)
+			var at *SinglyNode[int]
+			for i, n := 0, l.Front(); i <= tc.at; i, n = i+1, n.Next {
+				at = n
+			}
+			l.SpliceAfter(at, other)
+			values := checkSingly(t, l)
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+			assert.Empty(t, checkSingly(t, other))
+		})
+	}
+	assert.PanicsWithValue(t, "linked_lists: splicing a list into itself", func() {
+		l := NewSingly(1)
+		l.SpliceAfter(nil, l)
+	})
+}
+
+// TestSinglyReverse tests reversing lists of several lengths.
+func TestSinglyReverse(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5} {
+		var values []int
+		for i := range n {
+			values = append(values, i)
+		}
+		l := NewSingly(values...)
+		l.Reverse()
+		slices.Reverse(values)
+		got := checkSingly(t, l)
+		assert.Equal(t, values, got, "Expected: %v, Got: %v", values, got)
+	}
+}
+
+// item is a value with a label, to tell equal values apart in stable merges.
+type item struct {
+	key   int
+	label string
+}
+
+func byKey(a, b item) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+// TestSinglyMerge tests merging sorted lists, keeping equal values stable.
+func TestSinglyMerge(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     []item
+		expected []item
+	}{
+		{"both empty", nil, nil, nil},
+		{"empty other", []item{{1, "a"}}, nil, []item{{1, "a"}}},
+		{"empty list", nil, []item{{1, "b"}}, []item{{1, "b"}}},
+		{"interleaved", []item{{1, "a"}, {4, "a"}, {6, "a"}}, []item{{2, "b"}, {3, "b"}, {7, "b"}},
+			[]item{{1, "a"}, {2, "b"}, {3, "b"}, {4, "a"}, {6, "a"}, {7, "b"}}},
+		{"ties take the list first", []item{{1, "a"}, {2, "a"}}, []item{{1, "b"}, {2, "b"}},
+			[]item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, other := NewSingly(tc.a...), NewSingly(tc.b...)
+			l.Merge(other, byKey)
+			values := checkSingly(t, l)
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+			assert.Empty(t, checkSingly(t, other))
+		})
+	}
+}
+
+// TestSinglyIterators tests All and Values, stopping early and removing the
+// current node while iterating.
+func TestSinglyIterators(t *testing.T) {
+	l := NewSingly("a", "b", "c", "d")
+	var positions []int
+	for i, v := range l.All() {
+		positions = append(positions, i)
+		if v == "c" {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2}, positions)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(l.Values()))
+
+	// Pop each value while visiting it.
+	for v := range l.Values() {
+		got, _ := l.PopFront()
+		assert.Equal(t, v, got)
+	}
+	assert.Zero(t, l.Len())
+}
+
+// TestFindCycle tests cycle detection on chains with and without cycles.
+func TestFindCycle(t *testing.T) {
+	testCases := []struct {
+		name   string
+		length int // nodes in the chain
+		loopTo int // position the last node links back to; -1 for none
+	}{
+		{"empty", 0, -1},
+		{"single node", 1, -1},
+		{"no cycle", 10, -1},
+		{"self loop", 1, 0},
+		{"whole chain", 6, 0},
+		{"tail into cycle", 10, 3},
+		{"loop on the last node", 7, 6},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodes := make([]*SinglyNode[int], tc.length)
+			for i := range nodes {
+				nodes[i] = &SinglyNode[int]{Value: i}
+				if i > 0 {
+					nodes[i-1].Next = nodes[i]
+				}
+			}
+			var head *SinglyNode[int]
+			if tc.length > 0 {
+				head = nodes[0]
+			}
+			if tc.loopTo >= 0 {
+				nodes[tc.length-1].Next = nodes[tc.loopTo]
+			}
+
+			entry, length := FindCycle(head)
+			if tc.loopTo < 0 {
+				assert.Nil(t, entry)
+				assert.Zero(t, length)
+				return
+			}
+			require.NotNil(t, entry)
+			assert.Same(t, nodes[tc.loopTo], entry, "Expected: entry %d, Got: %d", tc.loopTo, entry.Value)
+			assert.Equal(t, tc.length-tc.loopTo, length)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package queues
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by the operations of a closed Blocking queue.
+var ErrClosed = errors.New("queues: queue closed")
+
+// Blocking is a first-in, first-out queue of bounded capacity that is safe
+// for concurrent use: Put waits while it is full and Take while it is empty.
+// It suits producers and consumers running at different speeds, where the
+// bound stops a fast producer from using unlimited memory.
+//
+// A mutex guards the values. Waiting goroutines block on a channel instead of
+// a sync.Cond so that they can also select on a context: every change that
+// might let a waiter proceed closes the channel, waking them all to check
+// again, and replaces it with a new one for the next wait.
+type Blocking[T any] struct {
+	mu       sync.Mutex
+	values   Deque[T]
+	capacity int
+	changed  chan struct{} // closed on the next Put, Take or Close
+	closed   bool
+}
+
+// NewBlocking returns an empty blocking queue holding up to capacity values.
+// It panics if capacity < 1.
+func NewBlocking[T any](capacity int) *Blocking[T] {
+	if capacity < 1 {
+		panic("queues: blocking queue of capacity less than one")
+	}
+	return &Blocking[T]{capacity: capacity, changed: make(chan struct{})}
+}
+
+// Len returns the number of values in the queue.
+func (q *Blocking[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.values.Len()
+}
+
+// Cap returns the most values the queue holds.
+func (q *Blocking[T]) Cap() int {
+	return q.capacity
+}
+
+// Put adds v at the back of the queue, waiting while the queue is full. It
+// returns ErrClosed if the queue is closed, and the error of ctx if ctx is
+// done first.
+func (q *Blocking[T]) Put(ctx context.Context, v T) error {
+	q.mu.Lock()
+	for !q.closed && q.values.Len() == q.capacity {
+		if err := q.wait(ctx); err != nil {
+			return err
+		}
+	}
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	q.values.PushBack(v)
+	q.broadcast()
+	return nil
+}
+
+// Take removes the value at the front of the queue and returns it, waiting
+// while the queue is empty. Once the queue is closed, Take still returns the
+// values left in it and then ErrClosed. It returns the error of ctx if ctx is
+// done first.
+func (q *Blocking[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for !q.closed && q.values.Len() == 0 {
+		if err := q.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	defer q.mu.Unlock()
+	v, ok := q.values.PopFront()
+	if !ok {
+		return v, ErrClosed
+	}
+	q.broadcast()
+	return v, nil
+}
+
+// TryPut adds v at the back of the queue if it is open and not full, and
+// reports whether it did.
+func (q *Blocking[T]) TryPut(v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.values.Len() == q.capacity {
+		return false
+	}
+	q.values.PushBack(v)
+	q.broadcast()
+	return true
+}
+
+// TryTake removes the value at the front of the queue and returns it, or
+// returns false if the queue is empty.
+func (q *Blocking[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	v, ok := q.values.PopFront()
+	if ok {
+		q.broadcast()
+	}
+	return v, ok
+}
+
+// Close closes the queue: waiting and later calls of Put return ErrClosed,
+// and Take returns the values left before it does too. Closing a closed
+// queue has no effect.
+func (q *Blocking[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.broadcast()
+	}
+}
+
+// wait releases the lock until the queue next changes or ctx is done, then
+// takes it back. On error it returns without the lock.
+func (q *Blocking[T]) wait(ctx context.Context) error {
+	changed := q.changed
+	q.mu.Unlock()
+	select {
+	case <-changed:
+		q.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast wakes every waiting goroutine. The lock must be held.
+func (q *Blocking[T]) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
@@ -0,0 +1,152 @@
+package queues
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlockingProducersConsumers tests that every value put by several
+// producers is taken exactly once by several consumers, through a queue much
+// smaller than the number of values.
+func TestBlockingProducersConsumers(t *testing.T) {
+	const producers, consumers, perProducer = 4, 3, 1000
+	q := NewBlocking[int](5)
+	ctx := context.Background()
+
+	var producing sync.WaitGroup
+	for p := range producers {
+		producing.Add(1)
+		go func() {
+			defer producing.Done()
+			for i := range perProducer {
+				assert.NoError(t, q.Put(ctx, p*perProducer+i))
+			}
+		}()
+	}
+
+	var consuming sync.WaitGroup
+	var mu sync.Mutex
+	taken := make(map[int]int)
+	for range consumers {
+		consuming.Add(1)
+		go func() {
+			defer consuming.Done()
+			for {
+				v, err := q.Take(ctx)
+				if err != nil {
+					assert.ErrorIs(t, err, ErrClosed)
+					return
+				}
+				assert.LessOrEqual(t, q.Len(), q.Cap())
+				mu.Lock()
+				taken[v]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producing.Wait()
+	q.Close()
+	consuming.Wait()
+
+	require.Len(t, taken, producers*perProducer)
+	for v, count := range taken {
+		assert.Equal(t, 1, count, "Expected: %d taken once, Got: %d times", v, count)
+	}
+}
+
+// TestBlockingWaits tests that Put waits while the queue is full and Take
+// while it is empty, until the other side makes room or a value.
+func TestBlockingWaits(t *testing.T) {
+	q := NewBlocking[string](1)
+	ctx := context.Background()
+	require.NoError(t, q.Put(ctx, "first"))
+
+	put := make(chan error)
+	go func() { put <- q.Put(ctx, "second") }()
+	select {
+	case err := <-put:
+		t.Fatalf("Put into a full queue returned %v without waiting", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	v, err := q.Take(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+	require.NoError(t, <-put)
+
+	v, err = q.Take(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "second", v)
+
+	taken := make(chan string)
+	go func() {
+		v, _ := q.Take(ctx)
+		taken <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, q.TryPut("third"))
+	assert.Equal(t, "third", <-taken)
+}
+
+// TestBlockingContext tests that waiting stops when the context is done.
+func TestBlockingContext(t *testing.T) {
+	q := NewBlocking[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := q.Take(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.True(t, q.TryPut(1))
+	assert.ErrorIs(t, q.Put(ctx, 2), context.DeadlineExceeded)
+	assert.False(t, q.TryPut(2))
+
+	// The queue still works after the waits gave up.
+	v, ok := q.TryTake()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	_, ok = q.TryTake()
+	assert.False(t, ok)
+}
+
+// TestBlockingClose tests that closing wakes waiting goroutines, rejects new
+// values and leaves the remaining ones to be taken.
+func TestBlockingClose(t *testing.T) {
+	q := NewBlocking[int](2)
+	ctx := context.Background()
+
+	waiting := make(chan error)
+	go func() {
+		_, err := q.Take(ctx)
+		waiting <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert.ErrorIs(t, <-waiting, ErrClosed)
+	q.Close()
+
+	q = NewBlocking[int](2)
+	require.NoError(t, q.Put(ctx, 1))
+	require.NoError(t, q.Put(ctx, 2))
+	go func() { waiting <- q.Put(ctx, 3) }()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert.ErrorIs(t, <-waiting, ErrClosed)
+	assert.False(t, q.TryPut(4))
+
+	for _, expected := range []int{1, 2} {
+		v, err := q.Take(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+	_, err := q.Take(ctx)
+	assert.ErrorIs(t, err, ErrClosed)
+
+	assert.PanicsWithValue(t, "queues: blocking queue of capacity less than one", func() { NewBlocking[int](0) })
+}
@@ -0,0 +1,177 @@
+// Package queues implements generic FIFO queues and double-ended queues on a
+// growable ring buffer, a monotonic queue for sliding-window minima and
+// maxima, and a bounded blocking queue for producers and consumers.
+package queues
+
+import (
+	"fmt"
+	"iter"
+)
+
+// minCapacity is the smallest buffer a Deque allocates, and the size below
+// which it stops shrinking.
+const minCapacity = 8
+
+// Deque is a double-ended queue on a ring buffer: its values wrap around the
+// end of a slice whose length is a power of two. The buffer doubles when
+// full and halves when a quarter full, so pushes and pops at either end take
+// amortised O(1) time and the memory stays proportional to the length. The
+// zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T // len(buf) is zero or a power of two, at least minCapacity
+	head int // index in buf of the front value
+	len  int
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// Cap returns the number of values the deque holds before it grows.
+func (d *Deque[T]) Cap() int {
+	return len(d.buf)
+}
+
+// index returns the index in buf of position i of the deque.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// PushBack inserts v at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	if d.len == len(d.buf) {
+		d.resize(max(2*len(d.buf), minCapacity))
+	}
+	d.buf[d.index(d.len)] = v
+	d.len++
+}
+
+// PushFront inserts v at the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	if d.len == len(d.buf) {
+		d.resize(max(2*len(d.buf), minCapacity))
+	}
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopFront removes the front value and returns it, or returns false if the
+// deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // drop the reference for the garbage collector
+	d.head = d.index(1)
+	d.len--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes the back value and returns it, or returns false if the
+// deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	i := d.index(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	d.shrink()
+	return v, true
+}
+
+// Front returns the front value, or false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back value, or false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+// At returns the value at position i, counting from 0 at the front. It panics
+// if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.len {
+		panic(fmt.Sprintf("queues: index %d out of range with length %d", i, d.len))
+	}
+	return d.buf[d.index(i)]
+}
+
+// Clear removes all values and releases the buffer.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// shrink halves the buffer once it is at most a quarter full. Waiting for a
+// quarter rather than a half means a push right after a shrink does not grow
+// it straight back.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minCapacity && d.len <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the values to a new buffer of the given capacity, starting at
+// its beginning.
+func (d *Deque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if d.len > 0 {
+		// The values run from head to the end of buf, then wrap to its start.
+		n := copy(buf, d.buf[d.head:min(d.head+d.len, len(d.buf))])
+		copy(buf[n:], d.buf[:d.len-n])
+	}
+	d.buf, d.head = buf, 0
+}
+
+// All returns an iterator over the positions and values of the deque, from
+// front to back. The deque must not change during the iteration.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range d.len {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the positions and values of the deque,
+// from back to front. The deque must not change during the iteration.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.len - 1; i >= 0; i-- {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the deque, from front to
+// back. The deque must not change during the iteration.
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.len {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
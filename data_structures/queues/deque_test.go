@@ -0,0 +1,212 @@
+package queues
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDequeEnds tests pushing and popping at both ends, including across the
+// end of the buffer.
+func TestDequeEnds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ops      func(d *Deque[int]) []int // returns the popped values
+		expected []int
+		popped   []int
+	}{
+		{"push back", func(d *Deque[int]) []int {
+			for i := range 3 {
+				d.PushBack(i)
+			}
+			return nil
+		}, []int{0, 1, 2}, nil},
+		{"push front", func(d *Deque[int]) []int {
+			for i := range 3 {
+				d.PushFront(i)
+			}
+			return nil
+		}, []int{2, 1, 0}, nil},
+		{"pop both ends", func(d *Deque[int]) []int {
+			for i := range 4 {
+				d.PushBack(i)
+			}
+			a, _ := d.PopFront()
+			b, _ := d.PopBack()
+			return []int{a, b}
+		}, []int{1, 2}, []int{0, 3}},
+		{"wrap around", func(d *Deque[int]) []int {
+			// Fill the buffer, then move its window past the end.
+			for i := range minCapacity {
+				d.PushBack(i)
+			}
+			var popped []int
+			for i := range 5 {
+				v, _ := d.PopFront()
+				popped = append(popped, v)
+				d.PushBack(minCapacity + i)
+			}
+			return popped
+		}, []int{5, 6, 7, 8, 9, 10, 11, 12}, []int{0, 1, 2, 3, 4}},
+		{"grow while wrapped", func(d *Deque[int]) []int {
+			for i := range minCapacity {
+				d.PushFront(i)
+			}
+			d.PushBack(-1)
+			return nil
+		}, []int{7, 6, 5, 4, 3, 2, 1, 0, -1}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d Deque[int]
+			popped := tc.ops(&d)
+			assert.Equal(t, tc.popped, popped, "Expected: %v, Got: %v", tc.popped, popped)
+			values := slices.Collect(d.Values())
+			assert.Equal(t, tc.expected, values, "Expected: %v, Got: %v", tc.expected, values)
+			assert.Equal(t, len(tc.expected), d.Len())
+		})
+	}
+}
+
+// TestDequeEmpty tests the operations of an empty deque.
+func TestDequeEmpty(t *testing.T) {
+	var d Deque[string]
+	for _, op := range []func() (string, bool){d.PopFront, d.PopBack, d.Front, d.Back} {
+		_, ok := op()
+		assert.False(t, ok)
+	}
+	assert.Zero(t, d.Cap())
+	assert.PanicsWithValue(t, "queues: index 0 out of range with length 0", func() { d.At(0) })
+}
+
+// TestDequeCapacity tests that the buffer doubles when full and halves when
+// a quarter full, without going below minCapacity.
+func TestDequeCapacity(t *testing.T) {
+	var d Deque[int]
+	d.PushBack(0)
+	assert.Equal(t, minCapacity, d.Cap())
+	for i := 1; i < 100; i++ {
+		d.PushBack(i)
+	}
+	assert.Equal(t, 128, d.Cap())
+
+	for d.Len() > 33 {
+		d.PopFront()
+	}
+	assert.Equal(t, 128, d.Cap())
+	d.PopBack()
+	assert.Equal(t, 64, d.Cap(), "Expected: the buffer to halve at a quarter full")
+	for d.Len() > 0 {
+		d.PopFront()
+	}
+	assert.Equal(t, minCapacity, d.Cap())
+
+	d.PushBack(1)
+	d.Clear()
+	assert.Zero(t, d.Len())
+	assert.Zero(t, d.Cap())
+}
+
+// TestDequeModel compares a deque with a slice under random operations.
+func TestDequeModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var d Deque[int]
+	var model []int
+	for i := range 20_000 {
+		// Favour pushes in the first half and pops in the second, so the
+		// buffer both grows and shrinks.
+		push := rng.IntN(10) < 6
+		if i >= 10_000 {
+			push = !push
+		}
+		switch front := rng.IntN(2) == 0; {
+		case push && front:
+			d.PushFront(i)
+			model = slices.Insert(model, 0, i)
+		case push:
+			d.PushBack(i)
+			model = append(model, i)
+		case front:
+			v, ok := d.PopFront()
+			require.Equal(t, len(model) > 0, ok)
+			if ok {
+				require.Equal(t, model[0], v)
+				model = model[1:]
+			}
+		default:
+			v, ok := d.PopBack()
+			require.Equal(t, len(model) > 0, ok)
+			if ok {
+				require.Equal(t, model[len(model)-1], v)
+				model = model[:len(model)-1]
+			}
+		}
+
+		require.Equal(t, len(model), d.Len())
+		if len(model) > 0 {
+			j := rng.IntN(len(model))
+			require.Equal(t, model[j], d.At(j))
+		}
+		require.True(t, d.Cap() <= max(minCapacity, 4*d.Len()), "Expected: capacity %d within four times the length %d", d.Cap(), d.Len())
+	}
+}
+
+// TestDequeIterators tests All and Backward, stopping early.
+func TestDequeIterators(t *testing.T) {
+	var d Deque[string]
+	for _, s := range []string{"b", "c", "d"} {
+		d.PushBack(s)
+	}
+	d.PushFront("a")
+
+	var forward, backward []int
+	for i, v := range d.All() {
+		forward = append(forward, i)
+		assert.Equal(t, d.At(i), v)
+	}
+	for i := range d.Backward() {
+		backward = append(backward, i)
+		if i == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3}, forward)
+	assert.Equal(t, []int{3, 2}, backward)
+}
+
+// TestQueue tests that values leave a queue in the order they came.
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+	_, ok := q.Peek()
+	assert.False(t, ok)
+
+	for i := range 20 {
+		q.Enqueue(i)
+	}
+	front, _ := q.Peek()
+	assert.Equal(t, 0, front)
+	assert.Equal(t, 20, q.Len())
+	assert.Equal(t, 190, sum(q.Values()))
+
+	for i := range 20 {
+		v, ok := q.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, i, v, "Expected: %v, Got: %v", i, v)
+	}
+	_, ok = q.Dequeue()
+	assert.False(t, ok)
+}
+
+// sum returns the sum of the values of seq.
+func sum(seq iter.Seq[int]) int {
+	total := 0
+	for v := range seq {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,103 @@
+package queues
+
+import "cmp"
+
+// Monotonic is a queue that reports the minimum of its values, by cmp, in
+// O(1). Values are pushed at the back and popped from the front, as in a
+// sliding window. A value is dropped as soon as a smaller or equal one comes
+// after it, because it can no longer be the minimum, so the values kept are
+// increasing from the front. Each value is dropped at most once, making a
+// push or pop amortised O(1).
+type Monotonic[T any] struct {
+	cmp    func(a, b T) int
+	kept   Deque[entry[T]]
+	pushed int // number of values pushed
+	popped int // number of values popped
+}
+
+// entry is a value kept by a Monotonic queue with its sequence number.
+type entry[T any] struct {
+	seq   int
+	value T
+}
+
+// NewMonotonic returns an empty monotonic queue reporting the minimum by cmp.
+// For the maximum, pass a cmp with its arguments reversed.
+func NewMonotonic[T any](cmp func(a, b T) int) *Monotonic[T] {
+	return &Monotonic[T]{cmp: cmp}
+}
+
+// Len returns the number of values in the queue, counting those dropped for
+// being unable to become the minimum.
+func (m *Monotonic[T]) Len() int {
+	return m.pushed - m.popped
+}
+
+// Push adds v at the back of the queue.
+func (m *Monotonic[T]) Push(v T) {
+	for {
+		back, ok := m.kept.Back()
+		if !ok || m.cmp(back.value, v) < 0 {
+			break
+		}
+		m.kept.PopBack()
+	}
+	m.kept.PushBack(entry[T]{seq: m.pushed, value: v})
+	m.pushed++
+}
+
+// Pop removes the value at the front of the queue, the oldest. It returns
+// false if the queue is empty.
+func (m *Monotonic[T]) Pop() bool {
+	if m.Len() == 0 {
+		return false
+	}
+	if front, _ := m.kept.Front(); front.seq == m.popped {
+		m.kept.PopFront()
+	}
+	m.popped++
+	return true
+}
+
+// Min returns the minimum of the values in the queue, or false if it is
+// empty. Of equal values, it is the newest.
+func (m *Monotonic[T]) Min() (T, bool) {
+	front, ok := m.kept.Front()
+	return front.value, ok
+}
+
+// SlidingMin returns the minimum of each window of k consecutive values of
+// a, from the window starting at a[0] to the one ending at the last value.
+// It takes O(n) time. It panics if k < 1.
+func SlidingMin[T cmp.Ordered](a []T, k int) []T {
+	return sliding(a, k, cmp.Compare[T])
+}
+
+// SlidingMax returns the maximum of each window of k consecutive values of
+// a, like SlidingMin.
+func SlidingMax[T cmp.Ordered](a []T, k int) []T {
+	return sliding(a, k, func(x, y T) int { return cmp.Compare(y, x) })
+}
+
+// sliding returns the minimum by cmp of each window of k values of a.
+func sliding[T any](a []T, k int, cmp func(a, b T) int) []T {
+	if k < 1 {
+		panic("queues: window of fewer than one value")
+	}
+	if len(a) < k {
+		return nil
+	}
+	m := NewMonotonic(cmp)
+	result := make([]T, 0, len(a)-k+1)
+	for i, v := range a {
+		m.Push(v)
+		if i >= k {
+			m.Pop()
+		}
+		if i >= k-1 {
+			least, _ := m.Min()
+			result = append(result, least)
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package queues
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSliding tests sliding-window minima and maxima on known cases.
+func TestSliding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        []int
+		k        int
+		min, max []int
+	}{
+		{"classic", []int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []int{-1, -3, -3, -3, 3, 3}, []int{3, 3, 5, 5, 6, 7}},
+		{"window of one", []int{4, 2, 9}, 1, []int{4, 2, 9}, []int{4, 2, 9}},
+		{"whole array", []int{4, 2, 9}, 3, []int{2}, []int{9}},
+		{"window too large", []int{4, 2}, 3, nil, nil},
+		{"equal values", []int{5, 5, 5, 5}, 2, []int{5, 5, 5}, []int{5, 5, 5}},
+		{"increasing", []int{1, 2, 3, 4, 5}, 2, []int{1, 2, 3, 4}, []int{2, 3, 4, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotMin, gotMax := SlidingMin(tc.a, tc.k), SlidingMax(tc.a, tc.k)
+			assert.Equal(t, tc.min, gotMin, "Expected: %v, Got: %v", tc.min, gotMin)
+			assert.Equal(t, tc.max, gotMax, "Expected: %v, Got: %v", tc.max, gotMax)
+		})
+	}
+	assert.PanicsWithValue(t, "queues: window of fewer than one value", func() { SlidingMin([]int{1}, 0) })
+}
+
+// TestSlidingRandom compares SlidingMin with taking the minimum of every
+// window.
+func TestSlidingRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		a := make([]int, 1+rng.IntN(50))
+		for i := range a {
+			a[i] = rng.IntN(10)
+		}
+		k := 1 + rng.IntN(len(a))
+
+		var expected []int
+		for i := 0; i+k <= len(a); i++ {
+			expected = append(expected, slices.Min(a[i:i+k]))
+		}
+		assert.Equal(t, expected, SlidingMin(a, k), "a = %v, k = %d", a, k)
+	}
+}
+
+// TestMonotonic tests a monotonic queue driven by hand, with a custom order
+// and the newest of equal values reported.
+func TestMonotonic(t *testing.T) {
+	type task struct {
+		priority int
+		name     string
+	}
+	m := NewMonotonic(func(a, b task) int { return cmp.Compare(a.priority, b.priority) })
+	_, ok := m.Min()
+	assert.False(t, ok)
+	assert.False(t, m.Pop())
+
+	m.Push(task{3, "a"})
+	m.Push(task{1, "b"})
+	m.Push(task{2, "c"})
+	m.Push(task{1, "d"})
+	least, _ := m.Min()
+	assert.Equal(t, task{1, "d"}, least)
+	assert.Equal(t, 4, m.Len())
+
+	for _, expected := range []string{"d", "d", "d", "d"} {
+		least, _ = m.Min()
+		assert.Equal(t, expected, least.name)
+		m.Pop()
+	}
+	assert.Zero(t, m.Len())
+	_, ok = m.Min()
+	assert.False(t, ok)
+}
@@ -0,0 +1,37 @@
+package queues
+
+import "iter"
+
+// Queue is a first-in, first-out queue on a Deque. The zero value is an empty
+// queue ready to use.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// Len returns the number of values in the queue.
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+// Enqueue adds v at the back of the queue.
+func (q *Queue[T]) Enqueue(v T) {
+	q.d.PushBack(v)
+}
+
+// Dequeue removes the value at the front of the queue, the oldest, and
+// returns it, or returns false if the queue is empty.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Peek returns the value at the front of the queue, or false if the queue is
+// empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
+}
+
+// Values returns an iterator over the values of the queue, from front to
+// back. The queue must not change during the iteration.
+func (q *Queue[T]) Values() iter.Seq[T] {
+	return q.d.Values()
+}
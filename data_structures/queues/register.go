@@ -0,0 +1,36 @@
+package queues
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	const pkg = "data_structures/queues"
+	registry.Register(registry.Algorithm{
+		Name:     "SlidingMin",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Minimum of every window of k consecutive values by a monotonic queue",
+		Time:     "O(n)",
+		Space:    "O(k)",
+		Run:      func(n int) any { return SlidingMin(zigzag(n), max(1, n/10)) },
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "SlidingMax",
+		Package:  pkg,
+		Category: "data_structures",
+		Summary:  "Maximum of every window of k consecutive values by a monotonic queue",
+		Time:     "O(n)",
+		Space:    "O(k)",
+		Run:      func(n int) any { return SlidingMax(zigzag(n), max(1, n/10)) },
+	})
+}
+
+// zigzag returns n values that rise and fall, so that windows keep several
+// candidates.
+func zigzag(n int) []int {
+	a := make([]int, n)
+	for i := range a {
+		a[i] = i*7919%101 - i%13
+	}
+	return a
+}
@@ -0,0 +1,252 @@
+package segment_trees
+
+import "fmt"
+
+// Dynamic is a segment tree with range updates over the coordinates [lo, hi)
+// of int64, however wide, whose nodes are made only when an operation first
+// passes through them: each operation makes at most O(log w) nodes, for w
+// the width of the coordinates, about 4 log w for an update of a range. The
+// values of a range no operation has reached are given by a function of the
+// range, which for a sum over zeros is the width of the range and a zero sum.
+type Dynamic[T, F any] struct {
+	m      Monoid[T]
+	a      Action[T, F]
+	fill   func(lo, hi int64) T
+	lo, hi int64
+	root   *dynamicNode[T, F]
+	nodes  int
+}
+
+// dynamicNode is a node of a dynamic tree, whose children are both made
+// together when it is first pushed down.
+type dynamicNode[T, F any] struct {
+	left, right *dynamicNode[T, F]
+	value       T
+	pending     F
+	hasPending  bool
+}
+
+// NewDynamic returns a tree over the coordinates [lo, hi), updated by a,
+// whose untouched ranges [l, h) hold fill(l, h): it must equal the
+// combination of fill(l, m) and fill(m, h) for any l < m < h. The width hi -
+// lo must be positive and fit in an int64.
+func NewDynamic[T, F any](m Monoid[T], a Action[T, F], lo, hi int64, fill func(lo, hi int64) T) *Dynamic[T, F] {
+	if hi-lo <= 0 {
+		panic(fmt.Sprintf("segment_trees: coordinates [%d:%d] empty or too wide", lo, hi))
+	}
+	return &Dynamic[T, F]{m: m, a: a, fill: fill, lo: lo, hi: hi}
+}
+
+// Bounds returns the range of the coordinates.
+func (t *Dynamic[T, F]) Bounds() (lo, hi int64) {
+	return t.lo, t.hi
+}
+
+// Nodes returns the number of nodes made.
+func (t *Dynamic[T, F]) Nodes() int {
+	return t.nodes
+}
+
+// checkIndex panics if i is not a coordinate of t.
+func (t *Dynamic[T, F]) checkIndex(i int64) {
+	if i < t.lo || i >= t.hi {
+		panic(fmt.Sprintf("segment_trees: index %d out of range [%d:%d]", i, t.lo, t.hi))
+	}
+}
+
+// checkRange panics if [lo, hi) is not a range of coordinates of t.
+func (t *Dynamic[T, F]) checkRange(lo, hi int64) {
+	if lo < t.lo || lo > hi || hi > t.hi {
+		panic(fmt.Sprintf("segment_trees: range [%d:%d] out of range [%d:%d]", lo, hi, t.lo, t.hi))
+	}
+}
+
+// node returns a new node for the range [lo, hi).
+func (t *Dynamic[T, F]) node(lo, hi int64) *dynamicNode[T, F] {
+	t.nodes++
+	return &dynamicNode[T, F]{value: t.fill(lo, hi), pending: t.a.Identity}
+}
+
+// getRoot returns the root, making it if need be.
+func (t *Dynamic[T, F]) getRoot() *dynamicNode[T, F] {
+	if t.root == nil {
+		t.root = t.node(t.lo, t.hi)
+	}
+	return t.root
+}
+
+// apply applies f to n and records it as pending.
+func (t *Dynamic[T, F]) apply(n *dynamicNode[T, F], f F) {
+	n.value = t.a.Apply(f, n.value)
+	if n.hasPending {
+		n.pending = t.a.Compose(f, n.pending)
+	} else {
+		n.pending, n.hasPending = f, true
+	}
+}
+
+// push makes the children of n, for [lo, hi) split at mid, if it has none,
+// and pushes its pending update down to them.
+func (t *Dynamic[T, F]) push(n *dynamicNode[T, F], lo, mid, hi int64) {
+	if n.left == nil {
+		n.left, n.right = t.node(lo, mid), t.node(mid, hi)
+	}
+	if n.hasPending {
+		t.apply(n.left, n.pending)
+		t.apply(n.right, n.pending)
+		n.pending, n.hasPending = t.a.Identity, false
+	}
+}
+
+// middle returns the point splitting [lo, hi) in two, without overflow.
+func middle(lo, hi int64) int64 {
+	return lo + (hi-lo)/2
+}
+
+// Get returns the value at coordinate i in O(log w) time.
+func (t *Dynamic[T, F]) Get(i int64) T {
+	t.checkIndex(i)
+	n, lo, hi := t.getRoot(), t.lo, t.hi
+	for hi-lo > 1 {
+		mid := middle(lo, hi)
+		t.push(n, lo, mid, hi)
+		if i < mid {
+			n, hi = n.left, mid
+		} else {
+			n, lo = n.right, mid
+		}
+	}
+	return n.value
+}
+
+// Set sets the value at coordinate i to v in O(log w) time.
+func (t *Dynamic[T, F]) Set(i int64, v T) {
+	t.checkIndex(i)
+	var set func(n *dynamicNode[T, F], lo, hi int64)
+	set = func(n *dynamicNode[T, F], lo, hi int64) {
+		if hi-lo == 1 {
+			n.value = v
+			return
+		}
+		mid := middle(lo, hi)
+		t.push(n, lo, mid, hi)
+		if i < mid {
+			set(n.left, lo, mid)
+		} else {
+			set(n.right, mid, hi)
+		}
+		n.value = t.m.Combine(n.left.value, n.right.value)
+	}
+	set(t.getRoot(), t.lo, t.hi)
+}
+
+// Query returns the combination of the values in [lo, hi), or the identity
+// for an empty range, in O(log w) time.
+func (t *Dynamic[T, F]) Query(lo, hi int64) T {
+	t.checkRange(lo, hi)
+	var query func(n *dynamicNode[T, F], nodeLo, nodeHi int64) T
+	query = func(n *dynamicNode[T, F], nodeLo, nodeHi int64) T {
+		if hi <= nodeLo || nodeHi <= lo {
+			return t.m.Identity
+		}
+		if lo <= nodeLo && nodeHi <= hi {
+			return n.value
+		}
+		mid := middle(nodeLo, nodeHi)
+		t.push(n, nodeLo, mid, nodeHi)
+		return t.m.Combine(query(n.left, nodeLo, mid), query(n.right, mid, nodeHi))
+	}
+	if lo == hi {
+		return t.m.Identity
+	}
+	return query(t.getRoot(), t.lo, t.hi)
+}
+
+// Apply applies f to every value in [lo, hi) in O(log w) time.
+func (t *Dynamic[T, F]) Apply(lo, hi int64, f F) {
+	t.checkRange(lo, hi)
+	var update func(n *dynamicNode[T, F], nodeLo, nodeHi int64)
+	update = func(n *dynamicNode[T, F], nodeLo, nodeHi int64) {
+		if hi <= nodeLo || nodeHi <= lo {
+			return
+		}
+		if lo <= nodeLo && nodeHi <= hi {
+			t.apply(n, f)
+			return
+		}
+		mid := middle(nodeLo, nodeHi)
+		t.push(n, nodeLo, mid, nodeHi)
+		update(n.left, nodeLo, mid)
+		update(n.right, mid, nodeHi)
+		n.value = t.m.Combine(n.left.value, n.right.value)
+	}
+	if lo < hi {
+		update(t.getRoot(), t.lo, t.hi)
+	}
+}
+
+// MaxRight returns the greatest hi such that pred holds on Query(lo, hi),
+// for a pred that holds on the identity and, once false on a range, on every
+// range that extends it to the right. It takes O(log w) time.
+func (t *Dynamic[T, F]) MaxRight(lo int64, pred func(T) bool) int64 {
+	t.checkRange(lo, lo)
+	checkPredicate(pred, t.m.Identity)
+	acc, end := t.m.Identity, t.hi
+	// search takes in the nodes from lo while pred holds and reports whether
+	// it still does, leaving in end the coordinate where it stopped.
+	var search func(n *dynamicNode[T, F], nodeLo, nodeHi int64) bool
+	search = func(n *dynamicNode[T, F], nodeLo, nodeHi int64) bool {
+		if nodeHi <= lo {
+			return true
+		}
+		if lo <= nodeLo {
+			if next := t.m.Combine(acc, n.value); pred(next) {
+				acc = next
+				return true
+			}
+			if nodeHi-nodeLo == 1 {
+				end = nodeLo
+				return false
+			}
+		}
+		mid := middle(nodeLo, nodeHi)
+		t.push(n, nodeLo, mid, nodeHi)
+		return search(n.left, nodeLo, mid) && search(n.right, mid, nodeHi)
+	}
+	if lo < t.hi {
+		search(t.getRoot(), t.lo, t.hi)
+	}
+	return end
+}
+
+// MinLeft returns the least lo such that pred holds on Query(lo, hi), for a
+// pred that holds on the identity and, once false on a range, on every range
+// that extends it to the left. It takes O(log w) time.
+func (t *Dynamic[T, F]) MinLeft(hi int64, pred func(T) bool) int64 {
+	t.checkRange(hi, hi)
+	checkPredicate(pred, t.m.Identity)
+	acc, start := t.m.Identity, t.lo
+	var search func(n *dynamicNode[T, F], nodeLo, nodeHi int64) bool
+	search = func(n *dynamicNode[T, F], nodeLo, nodeHi int64) bool {
+		if hi <= nodeLo {
+			return true
+		}
+		if nodeHi <= hi {
+			if next := t.m.Combine(n.value, acc); pred(next) {
+				acc = next
+				return true
+			}
+			if nodeHi-nodeLo == 1 {
+				start = nodeHi
+				return false
+			}
+		}
+		mid := middle(nodeLo, nodeHi)
+		t.push(n, nodeLo, mid, nodeHi)
+		return search(n.right, mid, nodeHi) && search(n.left, nodeLo, mid)
+	}
+	if hi > t.lo {
+		search(t.getRoot(), t.lo, t.hi)
+	}
+	return start
+}
@@ -0,0 +1,110 @@
+package segment_trees
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zeros fills untouched ranges with a sum of zeros of their width.
+func zeros(lo, hi int64) segment {
+	return segment{0, int(hi - lo)}
+}
+
+// TestDynamicWindow compares a dynamic tree over [-1e18, 1e18) with a slice
+// for a window of coordinates near the top under random range updates, sets,
+// queries and searches; the coordinates outside the window stay zero.
+func TestDynamicWindow(t *testing.T) {
+	const lo, hi = -1e18, 1e18
+	const base, n = hi - 150, 100
+	rng := rand.New(rand.NewPCG(9, 10))
+	tree := NewDynamic(sum, affineAction, lo, hi, zeros)
+	values := make([]int, n)
+	for step := range 1000 {
+		l := rng.IntN(n + 1)
+		h := l + rng.IntN(n-l+1)
+		switch op := rng.IntN(3); op {
+		case 0:
+			f := affine{rng.IntN(2), rng.IntN(5)}
+			tree.Apply(base+int64(l), base+int64(h), f)
+			for i := l; i < h; i++ {
+				values[i] = (f.mul*values[i] + f.add) % modulus
+			}
+		case 1:
+			i, v := rng.IntN(n), rng.IntN(10)
+			tree.Set(base+int64(i), segment{v, 1})
+			values[i] = v
+		}
+		expected := segment{naiveSum(values, l, h), h - l}
+		require.Equal(t, expected, tree.Query(base+int64(l), base+int64(h)), "step %d: Query(%d, %d)", step, l, h)
+		whole := segment{naiveSum(values, 0, n), int(hi - lo)}
+		require.Equal(t, whole, tree.Query(lo, hi), "step %d", step)
+
+		bound := rng.IntN(60)
+		right := int64(hi)
+		if r := naiveMaxRight(values, l, bound); r < n {
+			right = base + int64(r)
+		}
+		assert.Equal(t, right, tree.MaxRight(base+int64(l), atMost(bound)), "step %d: MaxRight(%d, %d)", step, l, bound)
+		left := int64(lo)
+		if r := naiveMinLeft(values, h, bound); r > 0 {
+			left = base + int64(r)
+		}
+		assert.Equal(t, left, tree.MinLeft(base+int64(h), atMost(bound)), "step %d: MinLeft(%d, %d)", step, h, bound)
+	}
+	for i := range values {
+		assert.Equal(t, segment{values[i], 1}, tree.Get(base+int64(i)))
+	}
+	// Each operation made O(log w) nodes, about 60 levels deep.
+	assert.Less(t, tree.Nodes(), 1000*4*61)
+}
+
+// TestDynamicWide tests range updates that span the whole width of a tree
+// over [-1e18, 1e18), and the few nodes they make.
+func TestDynamicWide(t *testing.T) {
+	add := Action[segment, int]{
+		Apply:   func(f int, x segment) segment { return segment{x.sum + f*x.width, x.width} },
+		Compose: func(f, g int) int { return f + g },
+	}
+	tree := NewDynamic(sum, add, -1e18, 1e18, zeros)
+	assert.Equal(t, segment{0, 2e18}, tree.Query(-1e18, 1e18))
+	assert.Equal(t, 1, tree.Nodes(), "a query of the whole range makes only the root")
+
+	tree.Apply(-1e18, 1e18, 1)
+	tree.Apply(0, 10, 5)
+	tree.Set(-1e18, segment{-7, 1})
+	testCases := []struct {
+		name     string
+		lo, hi   int64
+		expected int
+	}{
+		{"whole", -1e18, 1e18, 2e18 + 50 - 8},
+		{"updated twice", 0, 10, 60},
+		{"across", -5, 12, 17 + 50},
+		{"set", -1e18, -1e18 + 3, -7 + 2},
+		{"top", 1e18 - 1, 1e18, 1},
+		{"empty", 3, 3, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tree.Query(tc.lo, tc.hi).sum
+			assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+		})
+	}
+	assert.Equal(t, segment{6, 1}, tree.Get(9))
+	// The sum of [-1e18, x) stays within 1e18 - 8 up to x = 0.
+	assert.Equal(t, int64(0), tree.MaxRight(-1e18, atMost(1e18-8)))
+	assert.Equal(t, int64(10), tree.MinLeft(1e18, atMost(1e18-10)))
+	lo, hi := tree.Bounds()
+	assert.Equal(t, [2]int64{-1e18, 1e18}, [2]int64{lo, hi})
+	assert.Less(t, tree.Nodes(), 1000)
+
+	assert.PanicsWithValue(t, "segment_trees: coordinates [5:5] empty or too wide", func() {
+		NewDynamic(sum, add, 5, 5, zeros)
+	})
+	assert.PanicsWithValue(t, "segment_trees: index 1000000000000000000 out of range [-1000000000000000000:1000000000000000000]", func() {
+		tree.Get(1e18)
+	})
+}
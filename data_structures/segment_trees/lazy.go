@@ -0,0 +1,244 @@
+package segment_trees
+
+import "math/bits"
+
+// Lazy is a segment tree with range updates. An update of a range applies to
+// the O(log n) nodes covering it and is recorded as pending on the inner
+// ones, to be pushed down to their children only when a later operation
+// passes through them.
+type Lazy[T, F any] struct {
+	m       Monoid[T]
+	a       Action[T, F]
+	n       int
+	log     int // depth of the leaves
+	size    int // number of leaves, 1 << log
+	data    []T // nodes from 1; leaves from size
+	pending []F // pending update of each inner node
+}
+
+// NewLazy returns a tree of n values, all the identity of m, updated by a.
+func NewLazy[T, F any](m Monoid[T], a Action[T, F], n int) *Lazy[T, F] {
+	t := newLazy(m, a, n)
+	for i := range t.data {
+		t.data[i] = m.Identity
+	}
+	return t
+}
+
+// BuildLazy returns a tree of values updated by a, built bottom-up in O(n)
+// time.
+func BuildLazy[T, F any](m Monoid[T], a Action[T, F], values []T) *Lazy[T, F] {
+	t := newLazy(m, a, len(values))
+	copy(t.data[t.size:], values)
+	for i := t.size + len(values); i < len(t.data); i++ {
+		t.data[i] = m.Identity
+	}
+	for i := t.size - 1; i > 0; i-- {
+		t.update(i)
+	}
+	return t
+}
+
+// newLazy returns a tree of n values with room for its nodes and no pending
+// updates.
+func newLazy[T, F any](m Monoid[T], a Action[T, F], n int) *Lazy[T, F] {
+	if n < 0 {
+		panic("segment_trees: negative length")
+	}
+	size := leaves(n)
+	t := &Lazy[T, F]{
+		m: m, a: a, n: n,
+		log: bits.TrailingZeros(uint(size)), size: size,
+		data: make([]T, 2*size), pending: make([]F, size),
+	}
+	for i := range t.pending {
+		t.pending[i] = a.Identity
+	}
+	return t
+}
+
+// update recomputes node i from its children.
+func (t *Lazy[T, F]) update(i int) {
+	t.data[i] = t.m.Combine(t.data[2*i], t.data[2*i+1])
+}
+
+// apply applies f to node i, and records it as pending if i is inner.
+func (t *Lazy[T, F]) apply(i int, f F) {
+	t.data[i] = t.a.Apply(f, t.data[i])
+	if i < t.size {
+		t.pending[i] = t.a.Compose(f, t.pending[i])
+	}
+}
+
+// push pushes the pending update of inner node i down to its children.
+func (t *Lazy[T, F]) push(i int) {
+	t.apply(2*i, t.pending[i])
+	t.apply(2*i+1, t.pending[i])
+	t.pending[i] = t.a.Identity
+}
+
+// pushTo pushes down the pending updates above leaf i, from the root.
+func (t *Lazy[T, F]) pushTo(i int) {
+	for d := t.log; d >= 1; d-- {
+		t.push(i >> d)
+	}
+}
+
+// pushBounds pushes down the pending updates above the ends of [lo, hi),
+// given as leaves, for the nodes that cover it only in part.
+func (t *Lazy[T, F]) pushBounds(lo, hi int) {
+	for d := t.log; d >= 1; d-- {
+		if (lo>>d)<<d != lo {
+			t.push(lo >> d)
+		}
+		if (hi>>d)<<d != hi {
+			t.push((hi - 1) >> d)
+		}
+	}
+}
+
+// Len returns the number of values.
+func (t *Lazy[T, F]) Len() int {
+	return t.n
+}
+
+// Get returns the value at index i in O(log n) time.
+func (t *Lazy[T, F]) Get(i int) T {
+	checkIndex(i, t.n)
+	i += t.size
+	t.pushTo(i)
+	return t.data[i]
+}
+
+// Set sets the value at index i to v in O(log n) time.
+func (t *Lazy[T, F]) Set(i int, v T) {
+	checkIndex(i, t.n)
+	i += t.size
+	t.pushTo(i)
+	t.data[i] = v
+	for i >>= 1; i > 0; i >>= 1 {
+		t.update(i)
+	}
+}
+
+// Query returns the combination of the values in [lo, hi), or the identity
+// for an empty range, in O(log n) time.
+func (t *Lazy[T, F]) Query(lo, hi int) T {
+	checkRange(lo, hi, t.n)
+	if lo == hi {
+		return t.m.Identity
+	}
+	lo, hi = lo+t.size, hi+t.size
+	t.pushBounds(lo, hi)
+	left, right := t.m.Identity, t.m.Identity
+	for ; lo < hi; lo, hi = lo>>1, hi>>1 {
+		if lo&1 == 1 {
+			left = t.m.Combine(left, t.data[lo])
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			right = t.m.Combine(t.data[hi], right)
+		}
+	}
+	return t.m.Combine(left, right)
+}
+
+// Apply applies f to every value in [lo, hi) in O(log n) time.
+func (t *Lazy[T, F]) Apply(lo, hi int, f F) {
+	checkRange(lo, hi, t.n)
+	if lo == hi {
+		return
+	}
+	lo, hi = lo+t.size, hi+t.size
+	t.pushBounds(lo, hi)
+	for l, h := lo, hi; l < h; l, h = l>>1, h>>1 {
+		if l&1 == 1 {
+			t.apply(l, f)
+			l++
+		}
+		if h&1 == 1 {
+			h--
+			t.apply(h, f)
+		}
+	}
+	// Recompute the nodes above the ends that cover the range in part.
+	for d := 1; d <= t.log; d++ {
+		if (lo>>d)<<d != lo {
+			t.update(lo >> d)
+		}
+		if (hi>>d)<<d != hi {
+			t.update((hi - 1) >> d)
+		}
+	}
+}
+
+// MaxRight returns the greatest hi such that pred holds on Query(lo, hi),
+// for a pred that holds on the identity and, once false on a range, on every
+// range that extends it to the right. It takes O(log n) time.
+func (t *Lazy[T, F]) MaxRight(lo int, pred func(T) bool) int {
+	checkRange(lo, lo, t.n)
+	checkPredicate(pred, t.m.Identity)
+	if lo == t.n {
+		return t.n
+	}
+	i, acc := lo+t.size, t.m.Identity
+	t.pushTo(i)
+	for {
+		for i&1 == 0 {
+			i >>= 1
+		}
+		next := t.m.Combine(acc, t.data[i])
+		if !pred(next) {
+			for i < t.size {
+				t.push(i)
+				i *= 2
+				if next := t.m.Combine(acc, t.data[i]); pred(next) {
+					acc = next
+					i++
+				}
+			}
+			return i - t.size
+		}
+		acc = next
+		i++
+		if i&-i == i {
+			return t.n
+		}
+	}
+}
+
+// MinLeft returns the least lo such that pred holds on Query(lo, hi), for a
+// pred that holds on the identity and, once false on a range, on every range
+// that extends it to the left. It takes O(log n) time.
+func (t *Lazy[T, F]) MinLeft(hi int, pred func(T) bool) int {
+	checkRange(hi, hi, t.n)
+	checkPredicate(pred, t.m.Identity)
+	if hi == 0 {
+		return 0
+	}
+	i, acc := hi+t.size, t.m.Identity
+	t.pushTo(i - 1)
+	for {
+		i--
+		for i > 1 && i&1 == 1 {
+			i >>= 1
+		}
+		next := t.m.Combine(t.data[i], acc)
+		if !pred(next) {
+			for i < t.size {
+				t.push(i)
+				i = 2*i + 1
+				if next := t.m.Combine(t.data[i], acc); pred(next) {
+					acc = next
+					i--
+				}
+			}
+			return i + 1 - t.size
+		}
+		acc = next
+		if i&-i == i {
+			return 0
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package segment_trees implements segment trees: arrays that answer the
+// combination of any range of values, under any monoid, in O(log n) time
+// while values change. The range of an update or query is half-open, [lo,
+// hi).
+//
+//	Tree        point updates, range queries
+//	Lazy        range updates by an action on the values, range queries
+//	Persistent  point updates that keep every earlier version, range queries
+//	Dynamic     Lazy over int64 coordinates, with nodes made on demand
+//
+// Each also finds, with MaxRight and MinLeft, how far a range can grow from
+// one end while a monotone predicate holds on its combination, in O(log n)
+// time: the first index where a running sum passes a bound, for example.
+package segment_trees
+
+import "fmt"
+
+// Monoid is an associative operation on values with an identity:
+// Combine(a, Combine(b, c)) equals Combine(Combine(a, b), c), and
+// Combine(Identity, a) and Combine(a, Identity) equal a. Combine need not be
+// commutative; a range query combines its values from left to right.
+type Monoid[T any] struct {
+	Combine  func(a, b T) T
+	Identity T
+}
+
+// Action is a monoid of updates acting on the values of a Monoid. Apply must
+// distribute over Combine, so that Apply(f, Combine(a, b)) equals
+// Combine(Apply(f, a), Apply(f, b)); Compose(f, g) must act as g and then f;
+// and Identity must leave values alone.
+//
+// An update whose effect depends on the width of a range, like adding to
+// every value under a sum, keeps the width in the values it acts on.
+type Action[T, F any] struct {
+	Apply    func(f F, x T) T
+	Compose  func(f, g F) F
+	Identity F
+}
+
+// checkIndex panics if i is not an index of n values.
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("segment_trees: index %d out of range with length %d", i, n))
+	}
+}
+
+// checkRange panics if [lo, hi) is not a range of n values.
+func checkRange(lo, hi, n int) {
+	if lo < 0 || lo > hi || hi > n {
+		panic(fmt.Sprintf("segment_trees: range [%d:%d] out of range with length %d", lo, hi, n))
+	}
+}
+
+// checkPredicate panics if pred does not hold on identity, which the
+// searches need to be well defined.
+func checkPredicate[T any](pred func(T) bool, identity T) {
+	if !pred(identity) {
+		panic("segment_trees: predicate false on the identity")
+	}
+}
@@ -0,0 +1,159 @@
+package segment_trees
+
+// Persistent is an immutable segment tree of linked nodes. Set returns a new
+// version that copies only the O(log n) nodes on the path to the value and
+// shares the rest with the version it was made from, which stays as it was.
+type Persistent[T any] struct {
+	m    *Monoid[T] // shared by every version
+	n    int
+	root *persistentNode[T]
+}
+
+// persistentNode is a node of a persistent tree, never changed once made.
+type persistentNode[T any] struct {
+	left, right *persistentNode[T]
+	value       T
+}
+
+// NewPersistent returns the first version of a tree of values, built in O(n)
+// time.
+func NewPersistent[T any](m Monoid[T], values []T) *Persistent[T] {
+	var build func(lo, hi int) *persistentNode[T]
+	build = func(lo, hi int) *persistentNode[T] {
+		if hi-lo == 1 {
+			return &persistentNode[T]{value: values[lo]}
+		}
+		mid := lo + (hi-lo)/2
+		left, right := build(lo, mid), build(mid, hi)
+		return &persistentNode[T]{left, right, m.Combine(left.value, right.value)}
+	}
+	p := &Persistent[T]{m: &m, n: len(values)}
+	if len(values) > 0 {
+		p.root = build(0, len(values))
+	}
+	return p
+}
+
+// Len returns the number of values.
+func (p *Persistent[T]) Len() int {
+	return p.n
+}
+
+// Get returns the value at index i in O(log n) time.
+func (p *Persistent[T]) Get(i int) T {
+	checkIndex(i, p.n)
+	node, lo, hi := p.root, 0, p.n
+	for hi-lo > 1 {
+		if mid := lo + (hi-lo)/2; i < mid {
+			node, hi = node.left, mid
+		} else {
+			node, lo = node.right, mid
+		}
+	}
+	return node.value
+}
+
+// Set returns a version of p with the value at index i set to v, in
+// O(log n) time and space.
+func (p *Persistent[T]) Set(i int, v T) *Persistent[T] {
+	checkIndex(i, p.n)
+	var set func(node *persistentNode[T], lo, hi int) *persistentNode[T]
+	set = func(node *persistentNode[T], lo, hi int) *persistentNode[T] {
+		if hi-lo == 1 {
+			return &persistentNode[T]{value: v}
+		}
+		left, right := node.left, node.right
+		if mid := lo + (hi-lo)/2; i < mid {
+			left = set(left, lo, mid)
+		} else {
+			right = set(right, mid, hi)
+		}
+		return &persistentNode[T]{left, right, p.m.Combine(left.value, right.value)}
+	}
+	return &Persistent[T]{m: p.m, n: p.n, root: set(p.root, 0, p.n)}
+}
+
+// Query returns the combination of the values in [lo, hi), or the identity
+// for an empty range, in O(log n) time.
+func (p *Persistent[T]) Query(lo, hi int) T {
+	checkRange(lo, hi, p.n)
+	var query func(node *persistentNode[T], nodeLo, nodeHi int) T
+	query = func(node *persistentNode[T], nodeLo, nodeHi int) T {
+		if hi <= nodeLo || nodeHi <= lo {
+			return p.m.Identity
+		}
+		if lo <= nodeLo && nodeHi <= hi {
+			return node.value
+		}
+		mid := nodeLo + (nodeHi-nodeLo)/2
+		return p.m.Combine(query(node.left, nodeLo, mid), query(node.right, mid, nodeHi))
+	}
+	if lo == hi {
+		return p.m.Identity
+	}
+	return query(p.root, 0, p.n)
+}
+
+// MaxRight returns the greatest hi such that pred holds on Query(lo, hi),
+// for a pred that holds on the identity and, once false on a range, on every
+// range that extends it to the right. It takes O(log n) time.
+func (p *Persistent[T]) MaxRight(lo int, pred func(T) bool) int {
+	checkRange(lo, lo, p.n)
+	checkPredicate(pred, p.m.Identity)
+	acc, end := p.m.Identity, p.n
+	// search takes in the nodes from lo while pred holds and reports whether
+	// it still does, leaving in end the leaf where it stopped.
+	var search func(node *persistentNode[T], nodeLo, nodeHi int) bool
+	search = func(node *persistentNode[T], nodeLo, nodeHi int) bool {
+		if nodeHi <= lo {
+			return true
+		}
+		if lo <= nodeLo {
+			if next := p.m.Combine(acc, node.value); pred(next) {
+				acc = next
+				return true
+			}
+			if nodeHi-nodeLo == 1 {
+				end = nodeLo
+				return false
+			}
+		}
+		mid := nodeLo + (nodeHi-nodeLo)/2
+		return search(node.left, nodeLo, mid) && search(node.right, mid, nodeHi)
+	}
+	if lo < p.n {
+		search(p.root, 0, p.n)
+	}
+	return end
+}
+
+// MinLeft returns the least lo such that pred holds on Query(lo, hi), for a
+// pred that holds on the identity and, once false on a range, on every range
+// that extends it to the left. It takes O(log n) time.
+func (p *Persistent[T]) MinLeft(hi int, pred func(T) bool) int {
+	checkRange(hi, hi, p.n)
+	checkPredicate(pred, p.m.Identity)
+	acc, start := p.m.Identity, 0
+	var search func(node *persistentNode[T], nodeLo, nodeHi int) bool
+	search = func(node *persistentNode[T], nodeLo, nodeHi int) bool {
+		if hi <= nodeLo {
+			return true
+		}
+		if nodeHi <= hi {
+			if next := p.m.Combine(node.value, acc); pred(next) {
+				acc = next
+				return true
+			}
+			if nodeHi-nodeLo == 1 {
+				start = nodeHi
+				return false
+			}
+		}
+		mid := nodeLo + (nodeHi-nodeLo)/2
+		return search(node.right, mid, nodeHi) && search(node.left, nodeLo, mid)
+	}
+	if hi > 0 {
+		search(p.root, 0, p.n)
+	}
+	return start
+}
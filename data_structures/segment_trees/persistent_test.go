@@ -0,0 +1,86 @@
+package segment_trees
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPersistentVersions tests that setting a value of a version leaves the
+// version as it was.
+func TestPersistentVersions(t *testing.T) {
+	v0 := NewPersistent(concat, []string{"a", "b", "c", "d", "e"})
+	v1 := v0.Set(1, "B")
+	v2 := v1.Set(3, "D")
+	v3 := v0.Set(4, "E")
+
+	testCases := []struct {
+		name     string
+		version  *Persistent[string]
+		expected string
+	}{
+		{"first", v0, "abcde"},
+		{"one set", v1, "aBcde"},
+		{"two sets", v2, "aBcDe"},
+		{"branch from the first", v3, "abcdE"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.version.Query(0, tc.version.Len())
+			assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+		})
+	}
+	assert.Equal(t, "BcD", v2.Query(1, 4))
+	assert.Equal(t, "d", v1.Get(3))
+	assert.Equal(t, "", v2.Query(2, 2))
+
+	empty := NewPersistent(concat, nil)
+	assert.Equal(t, "", empty.Query(0, 0))
+	assert.Equal(t, 0, empty.MaxRight(0, func(string) bool { return true }))
+	assert.PanicsWithValue(t, "segment_trees: index 5 out of range with length 5", func() { v0.Set(5, "") })
+}
+
+// TestPersistentRandom compares every version of a persistent tree of sums
+// with a copy of its values under random sets of random versions, queries
+// and searches.
+func TestPersistentRandom(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 8, 9, 100} {
+		t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(n), 3))
+			values := make([]int, n)
+			for i := range values {
+				values[i] = rng.IntN(10)
+			}
+			versions := []*Persistent[segment]{NewPersistent(sum, segments(values))}
+			models := [][]int{values}
+			for step := range 500 {
+				k := rng.IntN(len(versions))
+				if rng.IntN(2) == 0 {
+					i, v := rng.IntN(n), rng.IntN(10)
+					versions = append(versions, versions[k].Set(i, segment{v, 1}))
+					model := slices.Clone(models[k])
+					model[i] = v
+					models = append(models, model)
+				}
+
+				p, model := versions[k], models[k]
+				lo := rng.IntN(n + 1)
+				hi := lo + rng.IntN(n-lo+1)
+				expected := segment{naiveSum(model, lo, hi), hi - lo}
+				require.Equal(t, expected, p.Query(lo, hi), "step %d: version %d Query(%d, %d)", step, k, lo, hi)
+				i := rng.IntN(n)
+				require.Equal(t, segment{model[i], 1}, p.Get(i))
+
+				bound := rng.IntN(40)
+				assert.Equal(t, naiveMaxRight(model, lo, bound), p.MaxRight(lo, atMost(bound)),
+					"step %d: MaxRight(%d, %d)", step, lo, bound)
+				assert.Equal(t, naiveMinLeft(model, hi, bound), p.MinLeft(hi, atMost(bound)),
+					"step %d: MinLeft(%d, %d)", step, hi, bound)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package segment_trees
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	add := Monoid[int]{Combine: func(a, b int) int { return a + b }}
+	registry.Register(registry.Algorithm{
+		Name:     "Tree.Query",
+		Package:  "data_structures/segment_trees",
+		Category: "data_structures",
+		Summary:  "Sums of n ranges of a segment tree built bottom-up over n values",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = i * 7919 % (n + 1)
+			}
+			t := Build(add, values)
+			total := 0
+			for i := range n {
+				total += t.Query(i/2, n-i/2)
+			}
+			return total
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Lazy.Apply",
+		Package:  "data_structures/segment_trees",
+		Category: "data_structures",
+		Summary:  "Additions to n ranges of a lazy segment tree of sums, then its total",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			t := NewLazy(sums, adds, n)
+			for i := range n {
+				t.Apply(i/2, n-i/2, i%7)
+			}
+			return t.Query(0, n)
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Persistent.Set",
+		Package:  "data_structures/segment_trees",
+		Category: "data_structures",
+		Summary:  "n versions of a persistent segment tree of sums, each setting one value",
+		Time:     "O(log n)",
+		Space:    "O(log n) per version",
+		Run: func(n int) any {
+			p := NewPersistent(add, make([]int, n))
+			for i := range n {
+				p = p.Set(i*7919%n, i)
+			}
+			return p.Query(0, n)
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Dynamic.Apply",
+		Package:  "data_structures/segment_trees",
+		Category: "data_structures",
+		Summary:  "Additions to n ranges of a dynamic segment tree over [0, 1e18)",
+		Time:     "O(log w)",
+		Space:    "O(log w) per update",
+		Run: func(n int) any {
+			t := NewDynamic(sums, adds, 0, 1e18, func(lo, hi int64) sized { return sized{0, int(hi - lo)} })
+			for i := range n {
+				lo := int64(i) * 999_999_937
+				t.Apply(lo, lo+int64(n), 1)
+			}
+			return t.Nodes()
+		},
+	})
+}
+
+// sized is a sum over a range with its width.
+type sized struct {
+	sum, width int
+}
+
+// sums is the monoid of sums with widths, for lazy additions.
+var sums = Monoid[sized]{
+	Combine: func(a, b sized) sized { return sized{a.sum + b.sum, a.width + b.width} },
+}
+
+// adds adds to every value of a range.
+var adds = Action[sized, int]{
+	Apply:   func(f int, x sized) sized { return sized{x.sum + f*x.width, x.width} },
+	Compose: func(f, g int) int { return f + g },
+}
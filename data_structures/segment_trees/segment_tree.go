@@ -0,0 +1,167 @@
+package segment_trees
+
+import "math/bits"
+
+// Tree is a segment tree with point updates: a complete binary tree stored
+// in an array, whose leaves are the values padded with the identity to a
+// power of two, and whose every inner node at i holds the combination of its
+// children at 2i and 2i+1.
+type Tree[T any] struct {
+	m    Monoid[T]
+	n    int
+	size int // number of leaves, a power of two
+	data []T // nodes from 1; leaves from size
+}
+
+// New returns a tree of n values, all the identity of m.
+func New[T any](m Monoid[T], n int) *Tree[T] {
+	t := newTree(m, n)
+	for i := range t.data {
+		t.data[i] = m.Identity
+	}
+	return t
+}
+
+// Build returns a tree of values, built bottom-up in O(n) time.
+func Build[T any](m Monoid[T], values []T) *Tree[T] {
+	t := newTree(m, len(values))
+	copy(t.data[t.size:], values)
+	for i := t.size + len(values); i < len(t.data); i++ {
+		t.data[i] = m.Identity
+	}
+	for i := t.size - 1; i > 0; i-- {
+		t.update(i)
+	}
+	return t
+}
+
+// newTree returns a tree of n values with room for its nodes.
+func newTree[T any](m Monoid[T], n int) *Tree[T] {
+	if n < 0 {
+		panic("segment_trees: negative length")
+	}
+	size := leaves(n)
+	return &Tree[T]{m: m, n: n, size: size, data: make([]T, 2*size)}
+}
+
+// leaves returns the least power of two not less than n, and at least 1.
+func leaves(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// update recomputes node i from its children.
+func (t *Tree[T]) update(i int) {
+	t.data[i] = t.m.Combine(t.data[2*i], t.data[2*i+1])
+}
+
+// Len returns the number of values.
+func (t *Tree[T]) Len() int {
+	return t.n
+}
+
+// Get returns the value at index i.
+func (t *Tree[T]) Get(i int) T {
+	checkIndex(i, t.n)
+	return t.data[t.size+i]
+}
+
+// Set sets the value at index i to v in O(log n) time.
+func (t *Tree[T]) Set(i int, v T) {
+	checkIndex(i, t.n)
+	i += t.size
+	t.data[i] = v
+	for i >>= 1; i > 0; i >>= 1 {
+		t.update(i)
+	}
+}
+
+// Query returns the combination of the values in [lo, hi), or the identity
+// for an empty range, in O(log n) time. It combines the nodes covering the
+// range from both ends inwards, keeping the left and right parts apart so as
+// to keep the order of a monoid that is not commutative.
+func (t *Tree[T]) Query(lo, hi int) T {
+	checkRange(lo, hi, t.n)
+	left, right := t.m.Identity, t.m.Identity
+	for lo, hi = lo+t.size, hi+t.size; lo < hi; lo, hi = lo>>1, hi>>1 {
+		if lo&1 == 1 {
+			left = t.m.Combine(left, t.data[lo])
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			right = t.m.Combine(t.data[hi], right)
+		}
+	}
+	return t.m.Combine(left, right)
+}
+
+// MaxRight returns the greatest hi such that pred holds on Query(lo, hi),
+// for a pred that holds on the identity and, once false on a range, on every
+// range that extends it to the right. It takes O(log n) time.
+func (t *Tree[T]) MaxRight(lo int, pred func(T) bool) int {
+	checkRange(lo, lo, t.n)
+	checkPredicate(pred, t.m.Identity)
+	if lo == t.n {
+		return t.n
+	}
+	// Climb over whole nodes while pred holds, then descend into the first
+	// node on which it fails.
+	i, acc := lo+t.size, t.m.Identity
+	for {
+		for i&1 == 0 {
+			i >>= 1
+		}
+		next := t.m.Combine(acc, t.data[i])
+		if !pred(next) {
+			for i < t.size {
+				i *= 2
+				if next := t.m.Combine(acc, t.data[i]); pred(next) {
+					acc = next
+					i++
+				}
+			}
+			return i - t.size
+		}
+		acc = next
+		i++
+		if i&-i == i {
+			return t.n
+		}
+	}
+}
+
+// MinLeft returns the least lo such that pred holds on Query(lo, hi), for a
+// pred that holds on the identity and, once false on a range, on every range
+// that extends it to the left. It takes O(log n) time.
+func (t *Tree[T]) MinLeft(hi int, pred func(T) bool) int {
+	checkRange(hi, hi, t.n)
+	checkPredicate(pred, t.m.Identity)
+	if hi == 0 {
+		return 0
+	}
+	i, acc := hi+t.size, t.m.Identity
+	for {
+		i--
+		for i > 1 && i&1 == 1 {
+			i >>= 1
+		}
+		next := t.m.Combine(t.data[i], acc)
+		if !pred(next) {
+			for i < t.size {
+				i = 2*i + 1
+				if next := t.m.Combine(t.data[i], acc); pred(next) {
+					acc = next
+					i--
+				}
+			}
+			return i + 1 - t.size
+		}
+		acc = next
+		if i&-i == i {
+			return 0
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package segment_trees
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concat is a monoid that is not commutative, to check the order of
+// combinations.
+var concat = Monoid[string]{
+	Combine:  func(a, b string) string { return a + b },
+	Identity: "",
+}
+
+// segment is the sum of a range of values with its width.
+type segment struct {
+	sum, width int
+}
+
+// sum is the monoid of segments.
+var sum = Monoid[segment]{
+	Combine:  func(a, b segment) segment { return segment{a.sum + b.sum, a.width + b.width} },
+	Identity: segment{},
+}
+
+// modulus bounds the values under affine updates.
+const modulus = 1_000_003
+
+// affine is the update x -> mul*x + add modulo modulus.
+type affine struct {
+	mul, add int
+}
+
+// affineAction applies affine updates to every value of a segment. Its
+// composition is not commutative.
+var affineAction = Action[segment, affine]{
+	Apply: func(f affine, x segment) segment {
+		return segment{(f.mul*x.sum + f.add*x.width) % modulus, x.width}
+	},
+	Compose: func(f, g affine) affine {
+		return affine{f.mul * g.mul % modulus, (f.mul*g.add + f.add) % modulus}
+	},
+	Identity: affine{1, 0},
+}
+
+// segments returns a segment of width one for each value.
+func segments(values []int) []segment {
+	s := make([]segment, len(values))
+	for i, v := range values {
+		s[i] = segment{v, 1}
+	}
+	return s
+}
+
+// naiveSum returns the sum of values[lo:hi] modulo modulus.
+func naiveSum(values []int, lo, hi int) int {
+	s := 0
+	for _, v := range values[lo:hi] {
+		s += v
+	}
+	return s % modulus
+}
+
+// naiveMaxRight returns the greatest hi such that the sum of values[lo:hi]
+// is at most bound, for values that are not negative.
+func naiveMaxRight(values []int, lo, bound int) int {
+	s := 0
+	for hi := lo; hi < len(values); hi++ {
+		if s += values[hi]; s > bound {
+			return hi
+		}
+	}
+	return len(values)
+}
+
+// naiveMinLeft returns the least lo such that the sum of values[lo:hi] is
+// at most bound, for values that are not negative.
+func naiveMinLeft(values []int, hi, bound int) int {
+	s := 0
+	for lo := hi - 1; lo >= 0; lo-- {
+		if s += values[lo]; s > bound {
+			return lo + 1
+		}
+	}
+	return 0
+}
+
+// atMost returns a predicate that holds on segments whose sum is at most
+// bound.
+func atMost(bound int) func(segment) bool {
+	return func(s segment) bool { return s.sum <= bound }
+}
+
+// TestTreeOrder tests queries of a monoid that is not commutative, which
+// must combine the values from left to right.
+func TestTreeOrder(t *testing.T) {
+	letters := []string{"a", "b", "c", "d", "e", "f", "g"}
+	tree := Build(concat, letters)
+	testCases := []struct {
+		name     string
+		lo, hi   int
+		expected string
+	}{
+		{"all", 0, 7, "abcdefg"},
+		{"inner", 1, 6, "bcdef"},
+		{"across the middle", 3, 5, "de"},
+		{"one", 4, 5, "e"},
+		{"empty", 2, 2, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tree.Query(tc.lo, tc.hi)
+			assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+		})
+	}
+
+	tree.Set(3, "D")
+	assert.Equal(t, "cDe", tree.Query(2, 5))
+	assert.Equal(t, "D", tree.Get(3))
+	assert.Equal(t, 3, tree.MaxRight(0, func(s string) bool { return len(s) <= 3 }))
+	assert.Equal(t, 4, tree.MinLeft(7, func(s string) bool { return len(s) <= 3 }))
+	assert.Equal(t, "", New(concat, 5).Query(0, 5))
+}
+
+// TestTreeRandom compares a tree of sums with a slice under random sets,
+// queries and searches, for lengths around powers of two.
+func TestTreeRandom(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 8, 9, 100} {
+		t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(n), 1))
+			values := make([]int, n)
+			for i := range values {
+				values[i] = rng.IntN(10)
+			}
+			tree := Build(sum, segments(values))
+			for step := range 500 {
+				if n > 0 && rng.IntN(2) == 0 {
+					i, v := rng.IntN(n), rng.IntN(10)
+					tree.Set(i, segment{v, 1})
+					values[i] = v
+				}
+				lo := rng.IntN(n + 1)
+				hi := lo + rng.IntN(n-lo+1)
+				expected := segment{naiveSum(values, lo, hi), hi - lo}
+				require.Equal(t, expected, tree.Query(lo, hi), "step %d: Query(%d, %d)", step, lo, hi)
+
+				bound := rng.IntN(40)
+				assert.Equal(t, naiveMaxRight(values, lo, bound), tree.MaxRight(lo, atMost(bound)),
+					"step %d: MaxRight(%d, %d)", step, lo, bound)
+				assert.Equal(t, naiveMinLeft(values, hi, bound), tree.MinLeft(hi, atMost(bound)),
+					"step %d: MinLeft(%d, %d)", step, hi, bound)
+			}
+			for i := range values {
+				assert.Equal(t, segment{values[i], 1}, tree.Get(i))
+			}
+		})
+	}
+}
+
+// TestLazyRandom compares a lazy tree of sums under affine updates with a
+// slice under random range updates, sets, queries and searches.
+func TestLazyRandom(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 8, 9, 100} {
+		t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(uint64(n), 2))
+			values := make([]int, n)
+			for i := range values {
+				values[i] = rng.IntN(10)
+			}
+			tree := BuildLazy(sum, affineAction, segments(values))
+			for step := range 500 {
+				lo := rng.IntN(n + 1)
+				hi := lo + rng.IntN(n-lo+1)
+				switch op := rng.IntN(3); {
+				case op == 0:
+					f := affine{rng.IntN(2), rng.IntN(5)} // keeps sums below modulus
+					tree.Apply(lo, hi, f)
+					for i := lo; i < hi; i++ {
+						values[i] = (f.mul*values[i] + f.add) % modulus
+					}
+				case op == 1 && n > 0:
+					i, v := rng.IntN(n), rng.IntN(10)
+					tree.Set(i, segment{v, 1})
+					values[i] = v
+				}
+				expected := segment{naiveSum(values, lo, hi), hi - lo}
+				require.Equal(t, expected, tree.Query(lo, hi), "step %d: Query(%d, %d)", step, lo, hi)
+
+				bound := rng.IntN(60)
+				assert.Equal(t, naiveMaxRight(values, lo, bound), tree.MaxRight(lo, atMost(bound)),
+					"step %d: MaxRight(%d, %d)", step, lo, bound)
+				assert.Equal(t, naiveMinLeft(values, hi, bound), tree.MinLeft(hi, atMost(bound)),
+					"step %d: MinLeft(%d, %d)", step, hi, bound)
+			}
+			for i := range values {
+				assert.Equal(t, segment{values[i], 1}, tree.Get(i))
+			}
+		})
+	}
+}
+
+// TestLazyAssign tests an update that assigns, whose composition keeps the
+// later of two assignments, on the minimum of a range.
+func TestLazyAssign(t *testing.T) {
+	minimum := Monoid[int]{Combine: func(a, b int) int { return min(a, b) }, Identity: 1 << 62}
+	// assign holds whether to assign and what.
+	type assign struct {
+		set bool
+		v   int
+	}
+	action := Action[int, assign]{
+		Apply: func(f assign, x int) int {
+			if f.set {
+				return f.v
+			}
+			return x
+		},
+		Compose: func(f, g assign) assign {
+			if f.set {
+				return f
+			}
+			return g
+		},
+	}
+	tree := NewLazy(minimum, action, 10)
+	assert.Equal(t, minimum.Identity, tree.Query(0, 10))
+	tree.Apply(0, 10, assign{true, 5})
+	tree.Apply(2, 6, assign{true, 3})
+	tree.Apply(4, 8, assign{true, 7})
+	tree.Set(9, 1)
+	testCases := []struct {
+		name     string
+		lo, hi   int
+		expected int
+	}{
+		{"left", 0, 2, 5},
+		{"overwritten", 2, 4, 3},
+		{"overwriting", 4, 8, 7},
+		{"across", 1, 7, 3},
+		{"set", 6, 10, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tree.Query(tc.lo, tc.hi)
+			assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+		})
+	}
+	// The first index from 0 where the minimum drops below 4.
+	assert.Equal(t, 2, tree.MaxRight(0, func(x int) bool { return x >= 4 }))
+}
+
+// TestTreePanics tests the panics on bad indexes, ranges and predicates.
+func TestTreePanics(t *testing.T) {
+	tree := New(sum, 4)
+	lazy := NewLazy(sum, affineAction, 4)
+	assert.PanicsWithValue(t, "segment_trees: index 4 out of range with length 4", func() { tree.Get(4) })
+	assert.PanicsWithValue(t, "segment_trees: index -1 out of range with length 4", func() { lazy.Set(-1, segment{}) })
+	assert.PanicsWithValue(t, "segment_trees: range [3:2] out of range with length 4", func() { tree.Query(3, 2) })
+	assert.PanicsWithValue(t, "segment_trees: range [0:5] out of range with length 4", func() { lazy.Apply(0, 5, affine{}) })
+	assert.PanicsWithValue(t, "segment_trees: predicate false on the identity", func() {
+		tree.MaxRight(0, func(segment) bool { return false })
+	})
+	assert.PanicsWithValue(t, "segment_trees: negative length", func() { New(sum, -1) })
+}
@@ -0,0 +1,233 @@
+package skip_lists
+
+import (
+	"cmp"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrent is an ordered set of keys of type K that is safe for concurrent
+// use by many goroutines. It is the lazy skip list of Herlihy, Lev,
+// Luchangco and Shavit:
+//
+//   - Contains takes no locks: it searches the list and checks the flags of
+//     the node it finds.
+//   - Insert finds the nodes before the new key on each of its levels, locks
+//     them from the bottom up, checks that nothing changed since the search,
+//     and links the new node in, bottom first. The node counts once it is
+//     marked fully linked.
+//   - Delete marks the node as removed, which deletes it logically, and
+//     then locks the nodes before it, checks them and unlinks it, top first.
+//
+// Operations that find the list changed under them search again. Links are
+// atomic pointers, so a search sees each link either before or after any
+// change. Concurrent has no ranks, which every update would have to adjust
+// on every level.
+type Concurrent[K any] struct {
+	head  cnode[K]
+	len   atomic.Int64
+	cmp   func(a, b K) int
+	state atomic.Uint64 // state of the level generator
+}
+
+// cnode is a node of a Concurrent skip list.
+type cnode[K any] struct {
+	key         K
+	next        []atomic.Pointer[cnode[K]]
+	mu          sync.Mutex  // held while linking or unlinking after the node
+	marked      atomic.Bool // whether the node is deleted
+	fullyLinked atomic.Bool // whether the node is linked on all its levels
+}
+
+// NewConcurrent returns an empty concurrent skip list of keys in their
+// natural order, whose levels are drawn from a generator seeded with seed.
+func NewConcurrent[K cmp.Ordered](seed uint64) *Concurrent[K] {
+	return NewConcurrentFunc(cmp.Compare[K], seed)
+}
+
+// NewConcurrentFunc returns an empty concurrent skip list of keys ordered by
+// cmp, which returns a negative number, zero or a positive number as a is
+// less than, equal to or greater than b, with levels drawn from a generator
+// seeded with seed.
+func NewConcurrentFunc[K any](cmp func(a, b K) int, seed uint64) *Concurrent[K] {
+	s := &Concurrent[K]{cmp: cmp}
+	s.head.next = make([]atomic.Pointer[cnode[K]], maxLevel)
+	s.state.Store(seed)
+	return s
+}
+
+// randomLevel returns the level of a new node. The generator is SplitMix64,
+// whose state only ever advances by a constant, so that goroutines can share
+// it with one atomic addition.
+func (s *Concurrent[K]) randomLevel() int {
+	z := s.state.Add(0x9e3779b97f4a7c15)
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return randomLevel(z ^ z>>31)
+}
+
+// Len returns the number of keys. While other goroutines update the set, it
+// may be out of date as soon as it returns.
+func (s *Concurrent[K]) Len() int {
+	return int(s.len.Load())
+}
+
+// find fills preds and succs with the nodes before and after k on each
+// level, and returns the highest level on which k was found, or -1.
+func (s *Concurrent[K]) find(k K, preds, succs *[maxLevel]*cnode[K]) int {
+	found := -1
+	pred := &s.head
+	for l := maxLevel - 1; l >= 0; l-- {
+		curr := pred.next[l].Load()
+		for curr != nil && s.cmp(curr.key, k) < 0 {
+			pred, curr = curr, curr.next[l].Load()
+		}
+		if found < 0 && curr != nil && s.cmp(curr.key, k) == 0 {
+			found = l
+		}
+		preds[l], succs[l] = pred, curr
+	}
+	return found
+}
+
+// Contains reports whether k is in the set.
+func (s *Concurrent[K]) Contains(k K) bool {
+	var preds, succs [maxLevel]*cnode[K]
+	l := s.find(k, &preds, &succs)
+	return l >= 0 && succs[l].fullyLinked.Load() && !succs[l].marked.Load()
+}
+
+// lockPreds locks preds[0:levels], each node once, from the bottom up, while
+// valid approves each level. It returns whether all levels were approved and
+// the function that unlocks the nodes locked.
+func lockPreds[K any](preds *[maxLevel]*cnode[K], levels int, valid func(l int) bool) (bool, func()) {
+	var locked []*cnode[K]
+	unlock := func() {
+		for _, n := range locked {
+			n.mu.Unlock()
+		}
+	}
+	for l := range levels {
+		// The node before the key on a level is the same as, or before,
+		// the one on the level below, so repeats are adjacent.
+		if pred := preds[l]; len(locked) == 0 || locked[len(locked)-1] != pred {
+			pred.mu.Lock()
+			locked = append(locked, pred)
+		}
+		if !valid(l) {
+			return false, unlock
+		}
+	}
+	return true, unlock
+}
+
+// Insert adds k to the set and reports whether it was absent.
+func (s *Concurrent[K]) Insert(k K) bool {
+	level := s.randomLevel()
+	var preds, succs [maxLevel]*cnode[K]
+	for {
+		if l := s.find(k, &preds, &succs); l >= 0 {
+			found := succs[l]
+			if found.marked.Load() {
+				// Being deleted: search again once it is gone.
+				runtime.Gosched()
+				continue
+			}
+			for !found.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+			return false
+		}
+
+		ok, unlock := lockPreds(&preds, level, func(l int) bool {
+			pred, succ := preds[l], succs[l]
+			return !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[l].Load() == succ
+		})
+		if !ok {
+			unlock()
+			continue
+		}
+		n := &cnode[K]{key: k, next: make([]atomic.Pointer[cnode[K]], level)}
+		for l := range level {
+			n.next[l].Store(succs[l])
+		}
+		for l := range level {
+			preds[l].next[l].Store(n)
+		}
+		n.fullyLinked.Store(true)
+		unlock()
+		s.len.Add(1)
+		return true
+	}
+}
+
+// Delete removes k from the set and reports whether it was present.
+func (s *Concurrent[K]) Delete(k K) bool {
+	var preds, succs [maxLevel]*cnode[K]
+	var victim *cnode[K]
+	for {
+		l := s.find(k, &preds, &succs)
+		if victim == nil {
+			// Only a fully linked node found on its top level can be
+			// deleted; one found lower is still being linked or unlinked.
+			if l < 0 {
+				return false
+			}
+			victim = succs[l]
+			if !victim.fullyLinked.Load() || len(victim.next)-1 != l || victim.marked.Load() {
+				return false
+			}
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				victim.mu.Unlock()
+				return false
+			}
+			victim.marked.Store(true)
+		}
+
+		levels := len(victim.next)
+		ok, unlock := lockPreds(&preds, levels, func(l int) bool {
+			return !preds[l].marked.Load() && preds[l].next[l].Load() == victim
+		})
+		if !ok {
+			unlock()
+			continue
+		}
+		for l := levels - 1; l >= 0; l-- {
+			preds[l].next[l].Store(victim.next[l].Load())
+		}
+		victim.mu.Unlock()
+		unlock()
+		s.len.Add(-1)
+		return true
+	}
+}
+
+// All returns an iterator over the keys in increasing order. While other
+// goroutines update the set, it yields every key present throughout the
+// iteration, and may or may not yield keys inserted or deleted during it.
+func (s *Concurrent[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := s.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+			if n.fullyLinked.Load() && !n.marked.Load() && !yield(n.key) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys from lo up to but not including
+// hi, in increasing order, as consistent as All.
+func (s *Concurrent[K]) Range(lo, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		var preds, succs [maxLevel]*cnode[K]
+		s.find(lo, &preds, &succs)
+		for n := succs[0]; n != nil && s.cmp(n.key, hi) < 0; n = n.next[0].Load() {
+			if n.fullyLinked.Load() && !n.marked.Load() && !yield(n.key) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package skip_lists
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkConcurrent checks the invariants of a concurrent skip list that no
+// goroutine is updating: every level is in increasing order and holds a
+// subsequence of the level below, every node is fully linked, unmarked and
+// unlocked, on all its levels and no others, and Len counts the bottom level.
+func checkConcurrent[K any](s *Concurrent[K]) error {
+	onLevel := make([]map[*cnode[K]]bool, maxLevel)
+	for l := range maxLevel {
+		onLevel[l] = map[*cnode[K]]bool{}
+		var prev *cnode[K]
+		for n := s.head.next[l].Load(); n != nil; n = n.next[l].Load() {
+			if prev != nil && s.cmp(prev.key, n.key) >= 0 {
+				return fmt.Errorf("level %d is out of order at %v", l+1, n.key)
+			}
+			if l > 0 && !onLevel[l-1][n] {
+				return fmt.Errorf("node %v is on level %d but not on level %d", n.key, l+1, l)
+			}
+			if len(n.next) <= l {
+				return fmt.Errorf("node %v of %d levels is on level %d", n.key, len(n.next), l+1)
+			}
+			if !n.fullyLinked.Load() || n.marked.Load() {
+				return fmt.Errorf("node %v is linked but not live", n.key)
+			}
+			if !n.mu.TryLock() {
+				return fmt.Errorf("node %v is still locked", n.key)
+			}
+			n.mu.Unlock()
+			onLevel[l][n] = true
+			prev = n
+		}
+	}
+	for n := range onLevel[0] {
+		if len(n.next) > 1 && !onLevel[len(n.next)-1][n] {
+			return fmt.Errorf("node %v of %d levels is missing from its top level", n.key, len(n.next))
+		}
+	}
+	if len(onLevel[0]) != s.Len() {
+		return fmt.Errorf("bottom level has %d keys but Len %d", len(onLevel[0]), s.Len())
+	}
+	return nil
+}
+
+// TestConcurrentSequential tests a concurrent skip list used by one
+// goroutine against the same updates on a SkipList.
+func TestConcurrentSequential(t *testing.T) {
+	c := NewConcurrent[int](1)
+	s := New[int](1)
+	for i := range 2000 {
+		k := i * 7919 % 301
+		if i%3 == 0 {
+			assert.Equal(t, s.Delete(k), c.Delete(k))
+		} else {
+			assert.Equal(t, s.Insert(k), c.Insert(k))
+		}
+		assert.Equal(t, s.Contains(k/2), c.Contains(k/2))
+	}
+	assert.NoError(t, checkConcurrent(c))
+	assert.Equal(t, s.Len(), c.Len())
+	assert.Equal(t, slices.Collect(s.All()), slices.Collect(c.All()))
+	assert.Equal(t, slices.Collect(s.Range(100, 200)), slices.Collect(c.Range(100, 200)))
+}
+
+// TestConcurrentCounter tests that when many goroutines insert the same keys
+// at once, each key is inserted exactly once: like a counter guarded by a
+// mutex, the total is the same on every run. Run it with -race.
+func TestConcurrentCounter(t *testing.T) {
+	const goroutines, keys = 8, 2000
+	c := NewConcurrent[int](1)
+	var inserted atomic.Int64
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range keys {
+				// Each goroutine walks the keys from a different place.
+				if c.Insert((i + g*keys/goroutines) % keys) {
+					inserted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, checkConcurrent(c))
+	assert.Equal(t, int64(keys), inserted.Load())
+	assert.Equal(t, keys, c.Len())
+	all := slices.Collect(c.All())
+	assert.Len(t, all, keys)
+	assert.True(t, slices.IsSorted(all))
+}
+
+// TestConcurrentMixed tests inserters, deleters and readers running at once:
+// keys that are never deleted must always be found, and at the end the set
+// holds exactly the keys not deleted. Run it with -race.
+func TestConcurrentMixed(t *testing.T) {
+	const workers, keys = 4, 4000
+	c := NewConcurrent[int](2)
+	for k := 0; k < keys; k += 2 {
+		c.Insert(k) // even keys stay throughout
+	}
+
+	var wg sync.WaitGroup
+	var deleted atomic.Int64
+	for w := range workers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			// Insert and then delete the odd keys of this worker.
+			for k := 2*w + 1; k < keys; k += 2 * workers {
+				assert.True(t, c.Insert(k))
+			}
+			for k := 2*w + 1; k < keys; k += 2 * workers {
+				if c.Delete(k) {
+					deleted.Add(1)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for k := 2 * w; k < keys; k += 2 * workers {
+				assert.True(t, c.Contains(k), "Expected: %d present, Got: absent", k)
+			}
+			prev := -1
+			for k := range c.Range(keys/4, keys/2) {
+				assert.Greater(t, k, prev)
+				prev = k
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, checkConcurrent(c))
+	assert.Equal(t, int64(keys/2), deleted.Load())
+	assert.Equal(t, keys/2, c.Len())
+	for k := range c.All() {
+		assert.Zero(t, k%2, "Expected: even keys only, Got: %d", k)
+	}
+}
+
+// TestConcurrentContended tests goroutines that insert and delete the same
+// few keys at once, so that most updates find their neighbours locked,
+// marked or relinked and must search again. Every key ends up inserted one
+// more time than deleted or one time as many, and the levels are left
+// consistent. Run it with -race.
+func TestConcurrentContended(t *testing.T) {
+	const goroutines, keys, rounds = 8, 64, 400
+	c := NewConcurrent[int](3)
+	var inserts, deletes [keys]atomic.Int64
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				k := (i*7 + g) % keys
+				if (i+g)%2 == 0 {
+					if c.Insert(k) {
+						inserts[k].Add(1)
+					}
+				} else if c.Delete(k) {
+					deletes[k].Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, checkConcurrent(c))
+	present := 0
+	for k := range keys {
+		balance := inserts[k].Load() - deletes[k].Load()
+		require.Contains(t, []int64{0, 1}, balance, "key %d", k)
+		assert.Equal(t, balance == 1, c.Contains(k), "key %d", k)
+		present += int(balance)
+	}
+	assert.Equal(t, present, c.Len())
+}
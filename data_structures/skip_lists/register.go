@@ -0,0 +1,56 @@
+package skip_lists
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	registry.Register(registry.Algorithm{
+		Name:     "SkipList.Insert",
+		Package:  "data_structures/skip_lists",
+		Category: "data_structures",
+		Summary:  "Insertion of n keys into a skip list with span counts",
+		Time:     "O(log n) expected",
+		Space:    "O(n) expected",
+		Run: func(n int) any {
+			s := New[int](1)
+			for i := range n {
+				s.Insert(i * 7919 % (n + 1))
+			}
+			return s.Len()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "SkipList.Rank",
+		Package:  "data_structures/skip_lists",
+		Category: "data_structures",
+		Summary:  "Rank of each key in a skip list by summing spans",
+		Time:     "O(log n) expected",
+		Space:    "O(n) expected",
+		Run: func(n int) any {
+			s := New[int](1)
+			for i := range n {
+				s.Insert(i)
+			}
+			ranks := make([]int, n)
+			for i := range ranks {
+				ranks[i] = s.Rank(i)
+			}
+			return ranks
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Concurrent.Insert",
+		Package:  "data_structures/skip_lists",
+		Category: "data_structures",
+		Summary:  "Insertion of n keys into a lazy concurrent skip list",
+		Time:     "O(log n) expected",
+		Space:    "O(n) expected",
+		Run: func(n int) any {
+			s := NewConcurrent[int](1)
+			for i := range n {
+				s.Insert(i * 7919 % (n + 1))
+			}
+			return s.Len()
+		},
+	})
+}
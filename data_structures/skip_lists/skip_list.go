@@ -0,0 +1,229 @@
+// Package skip_lists implements ordered sets on skip lists: sorted linked
+// lists with extra levels of express links that skip over many nodes.
+//
+// Each node is given a random number of levels, each one more with
+// probability 1/4, so a level holds about a quarter of the nodes of the level
+// below and a search that moves right on each level and then down takes
+// O(log n) expected time. Unlike a balanced tree, a skip list needs no
+// rebalancing, which makes a concurrent version with fine-grained locks
+// practical; see Concurrent.
+package skip_lists
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// maxLevel bounds the levels of a node; with p = 1/4 it suits sets of up to
+// 4^32 keys.
+const maxLevel = 32
+
+// randomLevel returns a level from 1 to maxLevel, each one more with
+// probability 1/4, from the random bits x: one more for each two low zero
+// bits.
+func randomLevel(x uint64) int {
+	return min(maxLevel, 1+bits.TrailingZeros64(x)/2)
+}
+
+// SkipList is an ordered set of keys of type K.
+//
+// Every link also records its span, the number of nodes it moves forward on
+// the bottom level. Summing spans along a search gives the rank of a key,
+// and descending by spans finds the key of a given rank, both in O(log n)
+// expected time.
+type SkipList[K any] struct {
+	head   node[K] // sentinel before the first key, with maxLevel links
+	levels int     // number of levels in use, at least 1
+	len    int
+	cmp    func(a, b K) int
+	rng    *rand.Rand
+}
+
+// node is a node of a skip list, with one link per level.
+type node[K any] struct {
+	key  K
+	next []link[K]
+}
+
+// link is a link from a node on one level: to the next node on that level,
+// nil at the end, and span nodes forward on the bottom level, counting the
+// end as one past the last node.
+type link[K any] struct {
+	to   *node[K]
+	span int
+}
+
+// New returns an empty skip list of keys in their natural order, whose levels
+// are drawn from a generator seeded with seed: the same seed and the same
+// updates build the same list.
+func New[K cmp.Ordered](seed uint64) *SkipList[K] {
+	return NewFunc(cmp.Compare[K], seed)
+}
+
+// NewFunc returns an empty skip list of keys ordered by cmp, which returns a
+// negative number, zero or a positive number as a is less than, equal to or
+// greater than b, with levels drawn from a generator seeded with seed.
+func NewFunc[K any](cmp func(a, b K) int, seed uint64) *SkipList[K] {
+	s := &SkipList[K]{
+		head:   node[K]{next: make([]link[K], maxLevel)},
+		levels: 1,
+		cmp:    cmp,
+		rng:    rand.New(rand.NewPCG(seed, 0)),
+	}
+	s.head.next[0].span = 1 // from the head to the end of the empty list
+	return s
+}
+
+// Len returns the number of keys.
+func (s *SkipList[K]) Len() int {
+	return s.len
+}
+
+// Levels returns the number of levels in use, at least 1.
+func (s *SkipList[K]) Levels() int {
+	return s.levels
+}
+
+// before returns, for each level in use, the last node before k on that
+// level, which is the head if there is none, and the rank of that node: its
+// position on the bottom level, counting the head as 0.
+func (s *SkipList[K]) before(k K) (last [maxLevel]*node[K], rank [maxLevel]int) {
+	x, r := &s.head, 0
+	for i := s.levels - 1; i >= 0; i-- {
+		for x.next[i].to != nil && s.cmp(x.next[i].to.key, k) < 0 {
+			r += x.next[i].span
+			x = x.next[i].to
+		}
+		last[i], rank[i] = x, r
+	}
+	return last, rank
+}
+
+// Contains reports whether k is in the set.
+func (s *SkipList[K]) Contains(k K) bool {
+	last, _ := s.before(k)
+	next := last[0].next[0].to
+	return next != nil && s.cmp(next.key, k) == 0
+}
+
+// Insert adds k to the set and reports whether it was absent.
+func (s *SkipList[K]) Insert(k K) bool {
+	last, rank := s.before(k)
+	if next := last[0].next[0].to; next != nil && s.cmp(next.key, k) == 0 {
+		return false
+	}
+
+	level := randomLevel(s.rng.Uint64())
+	for i := s.levels; i < level; i++ {
+		// A new level starts with a link from the head to the end.
+		last[i], rank[i] = &s.head, 0
+		s.head.next[i] = link[K]{span: s.len + 1}
+	}
+	s.levels = max(s.levels, level)
+
+	n := &node[K]{key: k, next: make([]link[K], level)}
+	for i := range level {
+		// The new node splits the link from last[i], which is rank[0] -
+		// rank[i] nodes behind the node before it on the bottom level.
+		behind := rank[0] - rank[i]
+		n.next[i] = link[K]{to: last[i].next[i].to, span: last[i].next[i].span - behind}
+		last[i].next[i] = link[K]{to: n, span: behind + 1}
+	}
+	for i := level; i < s.levels; i++ {
+		last[i].next[i].span++
+	}
+	s.len++
+	return true
+}
+
+// Delete removes k from the set and reports whether it was present.
+func (s *SkipList[K]) Delete(k K) bool {
+	last, _ := s.before(k)
+	n := last[0].next[0].to
+	if n == nil || s.cmp(n.key, k) != 0 {
+		return false
+	}
+	for i := range s.levels {
+		if i < len(n.next) {
+			last[i].next[i] = link[K]{to: n.next[i].to, span: last[i].next[i].span + n.next[i].span - 1}
+		} else {
+			last[i].next[i].span--
+		}
+	}
+	for s.levels > 1 && s.head.next[s.levels-1].to == nil {
+		s.levels--
+	}
+	s.len--
+	return true
+}
+
+// Rank returns the number of keys less than k, whether k is in the set or
+// not.
+func (s *SkipList[K]) Rank(k K) int {
+	_, rank := s.before(k)
+	return rank[0]
+}
+
+// Select returns the key of rank i, counting from 0, or false if i is out of
+// range.
+func (s *SkipList[K]) Select(i int) (k K, ok bool) {
+	if i < 0 || i >= s.len {
+		return k, false
+	}
+	x, r := &s.head, 0
+	for l := s.levels - 1; l >= 0; l-- {
+		for x.next[l].to != nil && r+x.next[l].span <= i+1 {
+			r += x.next[l].span
+			x = x.next[l].to
+		}
+	}
+	return x.key, true
+}
+
+// Min returns the smallest key, or false if the set is empty.
+func (s *SkipList[K]) Min() (k K, ok bool) {
+	if first := s.head.next[0].to; first != nil {
+		return first.key, true
+	}
+	return k, false
+}
+
+// Max returns the largest key, or false if the set is empty.
+func (s *SkipList[K]) Max() (k K, ok bool) {
+	x := &s.head
+	for l := s.levels - 1; l >= 0; l-- {
+		for x.next[l].to != nil {
+			x = x.next[l].to
+		}
+	}
+	return x.key, x != &s.head
+}
+
+// All returns an iterator over the keys in increasing order. The set must
+// not change during the iteration.
+func (s *SkipList[K]) All() iter.Seq[K] {
+	return s.from(&s.head, nil)
+}
+
+// Range returns an iterator over the keys from lo up to but not including
+// hi, in increasing order. The set must not change during the iteration.
+func (s *SkipList[K]) Range(lo, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		last, _ := s.before(lo)
+		s.from(last[0], &hi)(yield)
+	}
+}
+
+// from returns an iterator over the keys after node x on the bottom level,
+// up to but not including hi if hi is not nil.
+func (s *SkipList[K]) from(x *node[K], hi *K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := x.next[0].to; n != nil; n = n.next[0].to {
+			if hi != nil && s.cmp(n.key, *hi) >= 0 || !yield(n.key) {
+				return
+			}
+		}
+	}
+}
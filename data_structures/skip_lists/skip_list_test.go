@@ -0,0 +1,191 @@
+package skip_lists
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// check checks the invariants of s: the bottom level holds Len keys in
+// increasing order, every higher level holds a subsequence of the level
+// below, each node has between 1 and maxLevel levels, the top level in use
+// is not empty, and every span is the distance its link moves on the bottom
+// level.
+func check[K any](s *SkipList[K]) error {
+	position := map[*node[K]]int{&s.head: 0, nil: s.len + 1}
+	i := 0
+	for x, n := &s.head, s.head.next[0].to; n != nil; x, n = n, n.next[0].to {
+		i++
+		position[n] = i
+		if x != &s.head && s.cmp(x.key, n.key) >= 0 {
+			return fmt.Errorf("key %v is out of order", n.key)
+		}
+		if len(n.next) < 1 || len(n.next) > maxLevel {
+			return fmt.Errorf("node %v has %d levels", n.key, len(n.next))
+		}
+	}
+	if i != s.len {
+		return fmt.Errorf("bottom level has %d keys but Len %d", i, s.len)
+	}
+	if s.levels > 1 && s.head.next[s.levels-1].to == nil {
+		return fmt.Errorf("top level %d is empty", s.levels)
+	}
+	for l := range s.levels {
+		prev := &s.head
+		for x := &s.head; x != nil; x = x.next[l].to {
+			if x != &s.head && len(x.next) <= l {
+				return fmt.Errorf("node %v of %d levels is on level %d", x.key, len(x.next), l+1)
+			}
+			if x != prev && position[x] <= position[prev] {
+				return fmt.Errorf("level %d is out of order at %v", l+1, x.key)
+			}
+			if want := position[x.next[l].to] - position[x]; x.next[l].span != want {
+				return fmt.Errorf("link from position %d on level %d has span %d, want %d", position[x], l+1, x.next[l].span, want)
+			}
+			prev = x
+		}
+	}
+	return nil
+}
+
+// levelSizes returns the number of nodes on each level in use of s.
+func levelSizes[K any](s *SkipList[K]) []int {
+	sizes := make([]int, s.levels)
+	for l := range s.levels {
+		for x := s.head.next[l].to; x != nil; x = x.next[l].to {
+			sizes[l]++
+		}
+	}
+	return sizes
+}
+
+// TestRandomLevel tests that each two low zero bits add one level, up to
+// maxLevel.
+func TestRandomLevel(t *testing.T) {
+	testCases := []struct {
+		x     uint64
+		level int
+	}{
+		{1, 1},
+		{0b10, 1},
+		{0b100, 2},
+		{0b1000, 2},
+		{0b10000, 3},
+		{1 << 62, 32},
+		{1 << 63, 32},
+		{0, maxLevel},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.level, randomLevel(tc.x), "Expected: %v, Got: %v", tc.level, randomLevel(tc.x))
+	}
+}
+
+// TestSkipListSpans tests the spans on every level while keys are inserted
+// and deleted in orders that split and join links at the head, in the middle
+// and at the end of each level.
+func TestSkipListSpans(t *testing.T) {
+	s := New[int](3)
+	for k := 0; k < 400; k += 2 {
+		assert.True(t, s.Insert(k))
+		require.NoError(t, check(s), "insert %d", k)
+	}
+	for k := 399; k > 0; k -= 2 {
+		assert.True(t, s.Insert(k))
+		require.NoError(t, check(s), "insert %d", k)
+	}
+	assert.False(t, s.Insert(200))
+	for k := 0; k < 400; k += 3 {
+		assert.True(t, s.Delete(k))
+		require.NoError(t, check(s), "delete %d", k)
+	}
+	assert.False(t, s.Delete(3))
+
+	// The spans of each level add up to the whole bottom level.
+	for l := range s.levels {
+		sum := 0
+		for x := &s.head; x != nil; x = x.next[l].to {
+			sum += x.next[l].span
+		}
+		assert.Equal(t, s.Len()+1, sum, "level %d", l+1)
+	}
+
+	// Summing spans and descending by them are inverses.
+	for i := range s.Len() {
+		k, ok := s.Select(i)
+		require.True(t, ok)
+		assert.Equal(t, i, s.Rank(k), "Expected: %v, Got: %v", i, s.Rank(k))
+		assert.Equal(t, i+1, s.Rank(k+1))
+	}
+	_, ok := s.Select(s.Len())
+	assert.False(t, ok)
+	_, ok = s.Select(-1)
+	assert.False(t, ok)
+
+	// A range holds as many keys as the ranks of its bounds are apart.
+	for _, r := range [][2]int{{-5, 0}, {0, 400}, {17, 18}, {100, 250}, {398, 500}} {
+		got := slices.Collect(s.Range(r[0], r[1]))
+		assert.Len(t, got, s.Rank(r[1])-s.Rank(r[0]), "range %v", r)
+	}
+}
+
+// TestSkipListLevels tests that the levels stay within their expected
+// bounds: each level holds about a quarter of the nodes of the one below, no
+// more levels are in use than about log4(n) plus a few, and deletions give
+// levels back once they are empty.
+func TestSkipListLevels(t *testing.T) {
+	const n = 1 << 14
+	for seed := range uint64(4) {
+		t.Run(fmt.Sprint("seed ", seed), func(t *testing.T) {
+			s := New[int](seed)
+			for k := range n {
+				s.Insert(k)
+			}
+			require.NoError(t, check(s))
+
+			// log4(n) is 7; 4 more levels are reached with probability
+			// about n/4^11, below 1 in 250.
+			assert.GreaterOrEqual(t, s.Levels(), 5)
+			assert.LessOrEqual(t, s.Levels(), 11)
+
+			sizes := levelSizes(s)
+			assert.Equal(t, n, sizes[0])
+			for l := 1; l < len(sizes) && sizes[l-1] >= 1024; l++ {
+				ratio := float64(sizes[l]) / float64(sizes[l-1])
+				assert.InDelta(t, 0.25, ratio, 0.05, "level %d holds %d of %d", l+1, sizes[l], sizes[l-1])
+			}
+
+			for k := n - 1; k >= n/16; k-- {
+				s.Delete(k)
+			}
+			require.NoError(t, check(s))
+			assert.Less(t, s.Levels(), 11)
+			for k := range n / 16 {
+				s.Delete(k)
+			}
+			require.NoError(t, check(s))
+			assert.Zero(t, s.Len())
+			assert.Equal(t, 1, s.Levels())
+			assert.Equal(t, 1, s.head.next[0].span)
+		})
+	}
+}
+
+// TestSkipListSeed tests that the seed alone decides the levels.
+func TestSkipListSeed(t *testing.T) {
+	levels := func(seed uint64) []int {
+		s := New[int](seed)
+		for k := range 200 {
+			s.Insert(k)
+		}
+		var levels []int
+		for n := s.head.next[0].to; n != nil; n = n.next[0].to {
+			levels = append(levels, len(n.next))
+		}
+		return levels
+	}
+	assert.Equal(t, levels(7), levels(7))
+	assert.NotEqual(t, levels(7), levels(8))
+}
@@ -0,0 +1,63 @@
+package stacks
+
+import "cmp"
+
+// NextGreater returns, for each element of a, the index of the nearest
+// element after it that is strictly greater, or -1 if there is none.
+//
+// It keeps a stack of the indices still waiting for an answer, whose values
+// do not increase from bottom to top. Each new element answers, and pops,
+// the waiting ones smaller than itself before waiting in turn. Every index is
+// pushed and popped at most once, so the time is O(n).
+func NextGreater[T cmp.Ordered](a []T) []int {
+	return nearest(a, false, func(v, waiting T) bool { return v > waiting })
+}
+
+// NextSmaller returns, for each element of a, the index of the nearest
+// element after it that is strictly smaller, or -1 if there is none.
+func NextSmaller[T cmp.Ordered](a []T) []int {
+	return nearest(a, false, func(v, waiting T) bool { return v < waiting })
+}
+
+// PreviousGreater returns, for each element of a, the index of the nearest
+// element before it that is strictly greater, or -1 if there is none.
+func PreviousGreater[T cmp.Ordered](a []T) []int {
+	return nearest(a, true, func(v, waiting T) bool { return v > waiting })
+}
+
+// PreviousSmaller returns, for each element of a, the index of the nearest
+// element before it that is strictly smaller, or -1 if there is none.
+func PreviousSmaller[T cmp.Ordered](a []T) []int {
+	return nearest(a, true, func(v, waiting T) bool { return v < waiting })
+}
+
+// nearest scans a forwards, or backwards if backward is set, and answers
+// each element with the index of the first element met after it that beats
+// it, or -1.
+func nearest[T any](a []T, backward bool, beats func(v, waiting T) bool) []int {
+	answer := make([]int, len(a))
+	var waiting Stack[int]
+	visit := func(i int) {
+		answer[i] = -1
+		for {
+			top, ok := waiting.Peek()
+			if !ok || !beats(a[i], a[top]) {
+				break
+			}
+			waiting.Pop()
+			answer[top] = i
+		}
+		waiting.Push(i)
+	}
+
+	if backward {
+		for i := len(a) - 1; i >= 0; i-- {
+			visit(i)
+		}
+	} else {
+		for i := range a {
+			visit(i)
+		}
+	}
+	return answer
+}
@@ -0,0 +1,64 @@
+package stacks
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNearest tests the four nearest-neighbour searches on known cases.
+func TestNearest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		find     func([]int) []int
+		a        []int
+		expected []int
+	}{
+		{"next greater", NextGreater[int], []int{2, 1, 2, 4, 3}, []int{3, 2, 3, -1, -1}},
+		{"next smaller", NextSmaller[int], []int{4, 5, 2, 10, 8}, []int{2, 2, -1, 4, -1}},
+		{"previous greater", PreviousGreater[int], []int{10, 4, 2, 20, 40, 12, 30}, []int{-1, 0, 1, -1, -1, 4, 4}},
+		{"previous smaller", PreviousSmaller[int], []int{1, 6, 4, 10, 2, 5}, []int{-1, 0, 0, 2, 0, 4}},
+		{"equal values are not greater", NextGreater[int], []int{3, 3, 3}, []int{-1, -1, -1}},
+		{"empty", NextGreater[int], nil, []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.find(tc.a)
+			assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+		})
+	}
+}
+
+// TestNearestRandom compares the searches with looking at every neighbour
+// in turn.
+func TestNearestRandom(t *testing.T) {
+	brute := func(a []int, step int, beats func(v, w int) bool) []int {
+		answer := make([]int, len(a))
+		for i := range a {
+			answer[i] = -1
+			for j := i + step; 0 <= j && j < len(a); j += step {
+				if beats(a[j], a[i]) {
+					answer[i] = j
+					break
+				}
+			}
+		}
+		return answer
+	}
+	greater := func(v, w int) bool { return v > w }
+	smaller := func(v, w int) bool { return v < w }
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		a := make([]int, rng.IntN(40))
+		for i := range a {
+			a[i] = rng.IntN(8)
+		}
+		assert.Equal(t, brute(a, 1, greater), NextGreater(a), "a = %v", a)
+		assert.Equal(t, brute(a, 1, smaller), NextSmaller(a), "a = %v", a)
+		assert.Equal(t, brute(a, -1, greater), PreviousGreater(a), "a = %v", a)
+		assert.Equal(t, brute(a, -1, smaller), PreviousSmaller(a), "a = %v", a)
+	}
+}
@@ -0,0 +1,22 @@
+package stacks
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	registry.Register(registry.Algorithm{
+		Name:     "NextGreater",
+		Package:  "data_structures/stacks",
+		Category: "data_structures",
+		Summary:  "Index of the nearest greater element after each element by a monotonic stack",
+		Time:     "O(n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = i * 7919 % 101
+			}
+			return NextGreater(a)
+		},
+	})
+}
@@ -0,0 +1,51 @@
+// Package stacks implements a generic last-in, first-out stack and the
+// monotonic stack technique for finding the nearest greater or smaller
+// neighbours of every element.
+package stacks
+
+import (
+	"iter"
+
+	"github.com/ignoreAnt/go-dsa/data_structures/queues"
+)
+
+// Stack is a last-in, first-out stack on the ring buffer of queues.Deque, so
+// pushes and pops take amortised O(1) time and the buffer shrinks as the
+// stack empties. The zero value is an empty stack ready to use.
+type Stack[T any] struct {
+	d queues.Deque[T]
+}
+
+// Len returns the number of values on the stack.
+func (s *Stack[T]) Len() int {
+	return s.d.Len()
+}
+
+// Push puts v on top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.d.PushBack(v)
+}
+
+// Pop removes the value on top of the stack, the newest, and returns it, or
+// returns false if the stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	return s.d.PopBack()
+}
+
+// Peek returns the value on top of the stack, or false if the stack is
+// empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	return s.d.Back()
+}
+
+// Values returns an iterator over the values of the stack, from the top
+// down. The stack must not change during the iteration.
+func (s *Stack[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.d.Backward() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
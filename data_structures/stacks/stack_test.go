@@ -0,0 +1,33 @@
+package stacks
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStack tests that values leave a stack in the reverse of the order they
+// came.
+func TestStack(t *testing.T) {
+	var s Stack[int]
+	_, ok := s.Peek()
+	assert.False(t, ok)
+	_, ok = s.Pop()
+	assert.False(t, ok)
+
+	for i := range 20 {
+		s.Push(i)
+	}
+	top, _ := s.Peek()
+	assert.Equal(t, 19, top)
+	assert.Equal(t, 20, s.Len())
+	assert.Equal(t, []int{19, 18, 17}, slices.Collect(s.Values())[:3])
+
+	for i := 19; i >= 0; i-- {
+		v, ok := s.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, v, "Expected: %v, Got: %v", i, v)
+	}
+	assert.Zero(t, s.Len())
+}
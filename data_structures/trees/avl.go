@@ -0,0 +1,144 @@
+package trees
+
+import "cmp"
+
+// AVL is an ordered map on an AVL tree: a binary search tree in which the
+// heights of the two subtrees of every node differ by at most one. That
+// bounds its height by about 1.44 log₂ n, slightly less than a red-black
+// tree's, so lookups are a little faster and updates rotate a little more.
+// Use NewAVL or NewAVLFunc to make one.
+type AVL[K, V any] struct {
+	tree[K, V]
+}
+
+var _ OrderedMap[int, int] = (*AVL[int, int])(nil)
+
+// NewAVL returns an empty AVL tree with keys in their natural order.
+func NewAVL[K cmp.Ordered, V any]() *AVL[K, V] {
+	return NewAVLFunc[K, V](cmp.Compare[K])
+}
+
+// NewAVLFunc returns an empty AVL tree with keys ordered by cmp, which
+// returns a negative number, zero or a positive number as a is less than,
+// equal to or greater than b.
+func NewAVLFunc[K, V any](cmp func(a, b K) int) *AVL[K, V] {
+	return &AVL[K, V]{tree[K, V]{cmp: cmp}}
+}
+
+// Put sets the value of key k to v, adding k if it is absent.
+func (t *AVL[K, V]) Put(k K, v V) {
+	t.root = t.put(t.root, k, v)
+}
+
+func (t *AVL[K, V]) put(n *node[K, V], k K, v V) *node[K, V] {
+	if n == nil {
+		return &node[K, V]{key: k, value: v, size: 1, height: 1}
+	}
+	switch c := t.cmp(k, n.key); {
+	case c < 0:
+		n.left = t.put(n.left, k, v)
+	case c > 0:
+		n.right = t.put(n.right, k, v)
+	default:
+		n.value = v
+		return n
+	}
+	return rebalance(n)
+}
+
+// Delete removes key k and reports whether it was present.
+func (t *AVL[K, V]) Delete(k K) bool {
+	var found bool
+	t.root, found = t.delete(t.root, k)
+	return found
+}
+
+func (t *AVL[K, V]) delete(n *node[K, V], k K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var found bool
+	switch c := t.cmp(k, n.key); {
+	case c < 0:
+		n.left, found = t.delete(n.left, k)
+	case c > 0:
+		n.right, found = t.delete(n.right, k)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Put the successor, the smallest key on the right, in n's place.
+		var successor *node[K, V]
+		n.right, successor = deleteMinAVL(n.right)
+		successor.left, successor.right = n.left, n.right
+		n, found = successor, true
+	}
+	if !found {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+// deleteMinAVL removes the node with the smallest key from the subtree at n
+// and returns the rebalanced subtree and the removed node.
+func deleteMinAVL[K, V any](n *node[K, V]) (rest, least *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	n.left, least = deleteMinAVL(n.left)
+	return rebalance(n), least
+}
+
+// height returns the height of the subtree at n, 0 if it is empty.
+func height[K, V any](n *node[K, V]) int8 {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the size and height of n from its children.
+func update[K, V any](n *node[K, V]) {
+	n.size = 1 + size(n.left) + size(n.right)
+	n.height = 1 + max(height(n.left), height(n.right))
+}
+
+// rebalance updates n after a change below it and, if its subtrees now
+// differ in height by two, rotates to restore the balance. When the taller
+// grandchild is on the inside, a first rotation moves it outside, so that
+// the second leaves both sides even.
+func rebalance[K, V any](n *node[K, V]) *node[K, V] {
+	update(n)
+	switch balance := height(n.left) - height(n.right); {
+	case balance > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeftAVL(n.left)
+		}
+		return rotateRightAVL(n)
+	case balance < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRightAVL(n.right)
+		}
+		return rotateLeftAVL(n)
+	}
+	return n
+}
+
+// rotateLeftAVL is rotateLeft keeping the heights.
+func rotateLeftAVL[K, V any](n *node[K, V]) *node[K, V] {
+	r := rotateLeft(n)
+	update(n)
+	update(r)
+	return r
+}
+
+// rotateRightAVL is rotateRight keeping the heights.
+func rotateRightAVL[K, V any](n *node[K, V]) *node[K, V] {
+	l := rotateRight(n)
+	update(n)
+	update(l)
+	return l
+}
@@ -0,0 +1,103 @@
+package trees
+
+import "fmt"
+
+// checkBST checks that the keys of the subtree at n are in order and lie
+// strictly between lo and hi where those are not nil, and that every size is
+// right. It returns the size of the subtree.
+func checkBST[K, V any](n *node[K, V], cmp func(a, b K) int, lo, hi *K) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if lo != nil && cmp(n.key, *lo) <= 0 || hi != nil && cmp(n.key, *hi) >= 0 {
+		return 0, fmt.Errorf("key %v is out of order", n.key)
+	}
+	left, err := checkBST(n.left, cmp, lo, &n.key)
+	if err != nil {
+		return 0, err
+	}
+	right, err := checkBST(n.right, cmp, &n.key, hi)
+	if err != nil {
+		return 0, err
+	}
+	if n.size != 1+left+right {
+		return 0, fmt.Errorf("node %v has size %d, want %d", n.key, n.size, 1+left+right)
+	}
+	return n.size, nil
+}
+
+// checkAVL checks the AVL invariants of t: the heights are right and differ
+// by at most one between siblings.
+func checkAVL[K, V any](t *AVL[K, V]) error {
+	if _, err := checkBST(t.root, t.cmp, nil, nil); err != nil {
+		return err
+	}
+	_, err := checkHeights(t.root)
+	return err
+}
+
+// checkHeights checks the heights and balance of the subtree at n and returns
+// its height.
+func checkHeights[K, V any](n *node[K, V]) (int8, error) {
+	if n == nil {
+		return 0, nil
+	}
+	left, err := checkHeights(n.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := checkHeights(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if n.height != 1+max(left, right) {
+		return 0, fmt.Errorf("node %v has height %d, want %d", n.key, n.height, 1+max(left, right))
+	}
+	if left-right > 1 || right-left > 1 {
+		return 0, fmt.Errorf("node %v has subtrees of heights %d and %d", n.key, left, right)
+	}
+	return n.height, nil
+}
+
+// checkRedBlack checks the invariants of the left-leaning red-black tree t:
+// the root is black, red links lean left, no two are in a row, and every path
+// from the root to an empty link has the same number of black links.
+func checkRedBlack[K, V any](t *RedBlack[K, V]) error {
+	if _, err := checkBST(t.root, t.cmp, nil, nil); err != nil {
+		return err
+	}
+	if isRed(t.root) {
+		return fmt.Errorf("root %v is red", t.root.key)
+	}
+	_, err := checkColours(t.root)
+	return err
+}
+
+// checkColours checks the colours of the subtree at n and returns the number
+// of black links on each of its paths.
+func checkColours[K, V any](n *node[K, V]) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if isRed(n.right) {
+		return 0, fmt.Errorf("node %v has a red right link", n.key)
+	}
+	if isRed(n) && isRed(n.left) {
+		return 0, fmt.Errorf("node %v and its left child are both red", n.key)
+	}
+	left, err := checkColours(n.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := checkColours(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("node %v has %d black links on the left and %d on the right", n.key, left, right)
+	}
+	if !isRed(n) {
+		left++
+	}
+	return left, nil
+}
@@ -0,0 +1,181 @@
+package trees
+
+import "cmp"
+
+// RedBlack is an ordered map on a left-leaning red-black tree, Sedgewick's
+// simplification of the red-black tree. It encodes a 2-3 tree, which keeps
+// all leaves at the same depth, as a binary tree: a 3-node is two nodes
+// joined by a red link, which always leans left. Every path from the root to
+// a leaf then has the same number of black links and no two red links in a
+// row, so its height is at most 2 log₂ n. Use NewRedBlack or NewRedBlackFunc
+// to make one.
+type RedBlack[K, V any] struct {
+	tree[K, V]
+}
+
+var _ OrderedMap[int, int] = (*RedBlack[int, int])(nil)
+
+// NewRedBlack returns an empty red-black tree with keys in their natural
+// order.
+func NewRedBlack[K cmp.Ordered, V any]() *RedBlack[K, V] {
+	return NewRedBlackFunc[K, V](cmp.Compare[K])
+}
+
+// NewRedBlackFunc returns an empty red-black tree with keys ordered by cmp,
+// which returns a negative number, zero or a positive number as a is less
+// than, equal to or greater than b.
+func NewRedBlackFunc[K, V any](cmp func(a, b K) int) *RedBlack[K, V] {
+	return &RedBlack[K, V]{tree[K, V]{cmp: cmp}}
+}
+
+// Put sets the value of key k to v, adding k if it is absent.
+func (t *RedBlack[K, V]) Put(k K, v V) {
+	t.root = t.put(t.root, k, v)
+	t.root.red = false
+}
+
+func (t *RedBlack[K, V]) put(h *node[K, V], k K, v V) *node[K, V] {
+	if h == nil {
+		return &node[K, V]{key: k, value: v, size: 1, red: true}
+	}
+	switch c := t.cmp(k, h.key); {
+	case c < 0:
+		h.left = t.put(h.left, k, v)
+	case c > 0:
+		h.right = t.put(h.right, k, v)
+	default:
+		h.value = v
+	}
+	return fixUp(h)
+}
+
+// Delete removes key k and reports whether it was present.
+//
+// The descent keeps the current node out of a 2-node, borrowing from a
+// sibling or merging with it, so that the key finally removed sits in a 3-node
+// and leaves the black height unchanged; fixUp then restores the shape on the
+// way back up.
+func (t *RedBlack[K, V]) Delete(k K) bool {
+	if t.find(k) == nil {
+		return false
+	}
+	if !isRed(t.root.left) && !isRed(t.root.right) {
+		t.root.red = true
+	}
+	t.root = t.delete(t.root, k)
+	if t.root != nil {
+		t.root.red = false
+	}
+	return true
+}
+
+func (t *RedBlack[K, V]) delete(h *node[K, V], k K) *node[K, V] {
+	if t.cmp(k, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = t.delete(h.left, k)
+		return fixUp(h)
+	}
+
+	if isRed(h.left) {
+		h = rotateRightRB(h)
+	}
+	if t.cmp(k, h.key) == 0 && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+	if t.cmp(k, h.key) == 0 {
+		// Take the place of the successor, removed from the right.
+		var successor *node[K, V]
+		h.right, successor = deleteMinRB(h.right)
+		h.key, h.value = successor.key, successor.value
+	} else {
+		h.right = t.delete(h.right, k)
+	}
+	return fixUp(h)
+}
+
+// deleteMinRB removes the node with the smallest key from the subtree at h,
+// which is red or has a red left child, and returns the subtree and the
+// removed node.
+func deleteMinRB[K, V any](h *node[K, V]) (rest, least *node[K, V]) {
+	if h.left == nil {
+		return nil, h
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left, least = deleteMinRB(h.left)
+	return fixUp(h), least
+}
+
+// isRed reports whether the link to n is red; empty links are black.
+func isRed[K, V any](n *node[K, V]) bool {
+	return n != nil && n.red
+}
+
+// rotateLeftRB is rotateLeft keeping the colour of the link from above.
+func rotateLeftRB[K, V any](h *node[K, V]) *node[K, V] {
+	x := rotateLeft(h)
+	x.red, h.red = h.red, true
+	return x
+}
+
+// rotateRightRB is rotateRight keeping the colour of the link from above.
+func rotateRightRB[K, V any](h *node[K, V]) *node[K, V] {
+	x := rotateRight(h)
+	x.red, h.red = h.red, true
+	return x
+}
+
+// flipColors flips the colours of h and its children: splitting a temporary
+// 4-node, or, in reverse, merging h with its children into one.
+func flipColors[K, V any](h *node[K, V]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+// moveRedLeft makes the left child of h, or one of its children, red, given
+// that h is red and both its children are black, by merging with the right
+// sibling or borrowing from it.
+func moveRedLeft[K, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRightRB(h.right)
+		h = rotateLeftRB(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// moveRedRight makes the right child of h, or one of its children, red, like
+// moveRedLeft on the other side.
+func moveRedRight[K, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRightRB(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// fixUp restores the left-leaning shape at h on the way up from a change:
+// it turns a right-leaning red link left, straightens two red links in a row
+// and splits a node with two red children, then updates the size.
+func fixUp[K, V any](h *node[K, V]) *node[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeftRB(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRightRB(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	h.size = 1 + size(h.left) + size(h.right)
+	return h
+}
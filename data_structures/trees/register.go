@@ -0,0 +1,56 @@
+package trees
+
+import "github.com/ignoreAnt/go-dsa/registry"
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	registry.Register(registry.Algorithm{
+		Name:     "AVL.Put",
+		Package:  "data_structures/trees",
+		Category: "data_structures",
+		Summary:  "Insertion of n keys into an AVL tree with subtree sizes",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			m := NewAVL[int, int]()
+			for i := range n {
+				m.Put(i*7919%(n+1), i)
+			}
+			return m.Len()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "RedBlack.Put",
+		Package:  "data_structures/trees",
+		Category: "data_structures",
+		Summary:  "Insertion of n keys into a left-leaning red-black tree with subtree sizes",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			m := NewRedBlack[int, int]()
+			for i := range n {
+				m.Put(i*7919%(n+1), i)
+			}
+			return m.Len()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "AVL.Select",
+		Package:  "data_structures/trees",
+		Category: "data_structures",
+		Summary:  "Key of each rank in an AVL tree by descending on subtree sizes",
+		Time:     "O(log n)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			m := NewAVL[int, int]()
+			for i := range n {
+				m.Put(i, i)
+			}
+			keys := make([]int, n)
+			for i := range keys {
+				keys[i], _, _ = m.Select(i)
+			}
+			return keys
+		},
+	})
+}
@@ -0,0 +1,268 @@
+// Package trees implements ordered maps on self-balancing binary search
+// trees: AVL trees and left-leaning red-black trees.
+//
+// Both keep every path from the root to a leaf within a constant factor of
+// log n, so lookups, insertions and deletions take O(log n) time. Each node
+// also records the size of its subtree, which answers order statistics, the
+// rank of a key and the key of a given rank, in O(log n) as well.
+package trees
+
+import "iter"
+
+// OrderedMap is a map whose keys are kept in order.
+type OrderedMap[K, V any] interface {
+	// Len returns the number of keys.
+	Len() int
+	// Get returns the value of key k, or false if k is absent.
+	Get(k K) (V, bool)
+	// Put sets the value of key k to v, adding k if it is absent.
+	Put(k K, v V)
+	// Delete removes key k and reports whether it was present.
+	Delete(k K) bool
+
+	// Min and Max return the smallest and the largest key with its value,
+	// or false if the map is empty.
+	Min() (K, V, bool)
+	Max() (K, V, bool)
+	// Floor returns the largest key at most k, and Ceiling the smallest key
+	// at least k, with its value, or false if there is none.
+	Floor(k K) (K, V, bool)
+	Ceiling(k K) (K, V, bool)
+
+	// Rank returns the number of keys less than k, whether k is present or
+	// not.
+	Rank(k K) int
+	// Select returns the key of rank i, counting from 0, with its value, or
+	// false if i is out of range.
+	Select(i int) (K, V, bool)
+
+	// All and Backward return iterators over the keys and values in
+	// increasing and decreasing order of keys, and Range one over the keys
+	// from lo up to but not including hi. The map must not change during
+	// the iteration.
+	All() iter.Seq2[K, V]
+	Backward() iter.Seq2[K, V]
+	Range(lo, hi K) iter.Seq2[K, V]
+}
+
+// node is a node of a binary search tree, with the data of both kinds of
+// balanced tree.
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	size        int  // number of nodes in the subtree rooted here
+	height      int8 // AVL: height of the subtree, 1 for a leaf
+	red         bool // red-black: colour of the link from the parent
+}
+
+// size returns the number of nodes in the subtree rooted at n.
+func size[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// rotateLeft lifts the right child of n above it, keeping the keys in order,
+// and returns it. It updates the sizes; heights and colours are left to the
+// caller.
+func rotateLeft[K, V any](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	r.size = n.size
+	n.size = 1 + size(n.left) + size(n.right)
+	return r
+}
+
+// rotateRight lifts the left child of n above it, like rotateLeft.
+func rotateRight[K, V any](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	l.size = n.size
+	n.size = 1 + size(n.left) + size(n.right)
+	return l
+}
+
+// tree holds what the balanced trees have in common: the root, the order of
+// keys and the queries that only read the tree.
+type tree[K, V any] struct {
+	root *node[K, V]
+	cmp  func(a, b K) int
+}
+
+// entry returns the key and value of n, or false if n is nil.
+func entry[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, n.value, true
+}
+
+// Len returns the number of keys.
+func (t *tree[K, V]) Len() int {
+	return size(t.root)
+}
+
+// find returns the node of key k, or nil.
+func (t *tree[K, V]) find(k K) *node[K, V] {
+	n := t.root
+	for n != nil {
+		switch c := t.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Get returns the value of key k, or false if k is absent.
+func (t *tree[K, V]) Get(k K) (V, bool) {
+	_, v, ok := entry(t.find(k))
+	return v, ok
+}
+
+// Min returns the smallest key with its value, or false if the map is empty.
+func (t *tree[K, V]) Min() (K, V, bool) {
+	n := t.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return entry(n)
+}
+
+// Max returns the largest key with its value, or false if the map is empty.
+func (t *tree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return entry(n)
+}
+
+// Floor returns the largest key at most k with its value, or false if there
+// is none.
+func (t *tree[K, V]) Floor(k K) (K, V, bool) {
+	var best *node[K, V]
+	for n := t.root; n != nil; {
+		switch c := t.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			best, n = n, n.right
+		default:
+			return entry(n)
+		}
+	}
+	return entry(best)
+}
+
+// Ceiling returns the smallest key at least k with its value, or false if
+// there is none.
+func (t *tree[K, V]) Ceiling(k K) (K, V, bool) {
+	var best *node[K, V]
+	for n := t.root; n != nil; {
+		switch c := t.cmp(k, n.key); {
+		case c < 0:
+			best, n = n, n.left
+		case c > 0:
+			n = n.right
+		default:
+			return entry(n)
+		}
+	}
+	return entry(best)
+}
+
+// Rank returns the number of keys less than k. Going right past a node
+// counts it and its left subtree.
+func (t *tree[K, V]) Rank(k K) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		switch c := t.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += 1 + size(n.left)
+			n = n.right
+		default:
+			return rank + size(n.left)
+		}
+	}
+	return rank
+}
+
+// Select returns the key of rank i with its value, or false if i is out of
+// range. The size of the left subtree tells which way the key lies.
+func (t *tree[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= t.Len() {
+		return entry[K, V](nil)
+	}
+	n := t.root
+	for {
+		switch left := size(n.left); {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return entry(n)
+		}
+	}
+}
+
+// All returns an iterator over the keys and values in increasing order of
+// keys.
+func (t *tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+// Range returns an iterator over the keys from lo up to but not including hi,
+// with their values, in increasing order. It skips the subtrees outside the
+// range, taking O(log n + k) time for k keys.
+func (t *tree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.ascend(t.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the keys of the subtree at n from lo, if not nil, up to but
+// not including hi, if not nil. It returns false when yield asks to stop.
+func (t *tree[K, V]) ascend(n *node[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := lo == nil || t.cmp(n.key, *lo) >= 0
+	belowHi := hi == nil || t.cmp(n.key, *hi) < 0
+	if aboveLo && !t.ascend(n.left, lo, hi, yield) {
+		return false
+	}
+	if aboveLo && belowHi && !yield(n.key, n.value) {
+		return false
+	}
+	return !belowHi || t.ascend(n.right, lo, hi, yield)
+}
+
+// Backward returns an iterator over the keys and values in decreasing order
+// of keys.
+func (t *tree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.descend(t.root, yield)
+	}
+}
+
+// descend yields the keys of the subtree at n in decreasing order. It
+// returns false when yield asks to stop.
+func (t *tree[K, V]) descend(n *node[K, V], yield func(K, V) bool) bool {
+	return n == nil ||
+		t.descend(n.right, yield) && yield(n.key, n.value) && t.descend(n.left, yield)
+}
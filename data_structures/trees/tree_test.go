@@ -0,0 +1,218 @@
+package trees
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// implementation is a kind of OrderedMap under test with its invariants.
+type implementation struct {
+	name  string
+	new   func() OrderedMap[int, string]
+	check func(m OrderedMap[int, string]) error
+}
+
+var implementations = []implementation{
+	{"AVL", func() OrderedMap[int, string] { return NewAVL[int, string]() },
+		func(m OrderedMap[int, string]) error { return checkAVL(m.(*AVL[int, string])) }},
+	{"RedBlack", func() OrderedMap[int, string] { return NewRedBlack[int, string]() },
+		func(m OrderedMap[int, string]) error { return checkRedBlack(m.(*RedBlack[int, string])) }},
+}
+
+// collect returns the keys of seq.
+func collect(seq func(yield func(int, string) bool)) []int {
+	var keys []int
+	for k := range seq {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// TestOrderedMapQueries tests the queries of both trees on a fixed map.
+func TestOrderedMapQueries(t *testing.T) {
+	keys := []int{50, 20, 80, 10, 30, 70, 90, 60}
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			for _, k := range keys {
+				m.Put(k, strings.Repeat("x", k/10))
+			}
+			require.NoError(t, impl.check(m))
+			assert.Equal(t, 8, m.Len())
+
+			v, ok := m.Get(30)
+			assert.True(t, ok)
+			assert.Equal(t, "xxx", v)
+			_, ok = m.Get(35)
+			assert.False(t, ok)
+
+			testCases := []struct {
+				name     string
+				query    func() (int, string, bool)
+				expected int // -1 for none
+			}{
+				{"min", m.Min, 10},
+				{"max", m.Max, 90},
+				{"floor of a key", func() (int, string, bool) { return m.Floor(30) }, 30},
+				{"floor between keys", func() (int, string, bool) { return m.Floor(65) }, 60},
+				{"floor below all", func() (int, string, bool) { return m.Floor(5) }, -1},
+				{"ceiling between keys", func() (int, string, bool) { return m.Ceiling(65) }, 70},
+				{"ceiling above all", func() (int, string, bool) { return m.Ceiling(95) }, -1},
+				{"select first", func() (int, string, bool) { return m.Select(0) }, 10},
+				{"select middle", func() (int, string, bool) { return m.Select(4) }, 60},
+				{"select out of range", func() (int, string, bool) { return m.Select(8) }, -1},
+				{"select negative", func() (int, string, bool) { return m.Select(-1) }, -1},
+			}
+			for _, tc := range testCases {
+				k, v, ok := tc.query()
+				if tc.expected < 0 {
+					assert.False(t, ok, "%s: Expected none, Got: %d", tc.name, k)
+					continue
+				}
+				assert.True(t, ok, tc.name)
+				assert.Equal(t, tc.expected, k, "%s: Expected: %v, Got: %v", tc.name, tc.expected, k)
+				assert.Equal(t, strings.Repeat("x", k/10), v, tc.name)
+			}
+
+			assert.Equal(t, 0, m.Rank(5))
+			assert.Equal(t, 2, m.Rank(30))
+			assert.Equal(t, 3, m.Rank(35))
+			assert.Equal(t, 8, m.Rank(100))
+
+			assert.Equal(t, []int{10, 20, 30, 50, 60, 70, 80, 90}, collect(m.All()))
+			assert.Equal(t, []int{90, 80, 70, 60, 50, 30, 20, 10}, collect(m.Backward()))
+			assert.Equal(t, []int{30, 50, 60}, collect(m.Range(25, 70)))
+			assert.Equal(t, []int{20, 30}, collect(m.Range(20, 50)))
+			assert.Empty(t, collect(m.Range(51, 59)))
+			assert.Empty(t, collect(m.Range(70, 70)))
+
+			var first []int
+			for k := range m.All() {
+				if first = append(first, k); len(first) == 3 {
+					break
+				}
+			}
+			assert.Equal(t, []int{10, 20, 30}, first)
+		})
+	}
+}
+
+// TestOrderedMapUpdates tests replacing, deleting and emptying both trees.
+func TestOrderedMapUpdates(t *testing.T) {
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			_, _, ok := m.Min()
+			assert.False(t, ok)
+			assert.False(t, m.Delete(1))
+
+			m.Put(1, "one")
+			m.Put(1, "uno")
+			assert.Equal(t, 1, m.Len())
+			v, _ := m.Get(1)
+			assert.Equal(t, "uno", v)
+
+			for k := range 100 {
+				m.Put(k, "")
+			}
+			for k := 0; k < 100; k += 2 {
+				assert.True(t, m.Delete(k))
+				require.NoError(t, impl.check(m))
+			}
+			assert.False(t, m.Delete(0))
+			assert.Equal(t, 50, m.Len())
+			for k := 1; k < 100; k += 2 {
+				assert.True(t, m.Delete(k))
+			}
+			assert.Zero(t, m.Len())
+			assert.Empty(t, collect(m.All()))
+		})
+	}
+}
+
+// TestOrderedMapRandom compares both trees with a sorted slice of keys under
+// random updates, checking the invariants after each.
+func TestOrderedMapRandom(t *testing.T) {
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			m := impl.new()
+			var model []int // sorted keys; each value is the key in decimal
+			for step := range 4000 {
+				k := rng.IntN(500)
+				i, present := slices.BinarySearch(model, k)
+				if rng.IntN(3) == 0 {
+					assert.Equal(t, present, m.Delete(k))
+					if present {
+						model = slices.Delete(model, i, i+1)
+					}
+				} else {
+					m.Put(k, strconv.Itoa(k))
+					if !present {
+						model = slices.Insert(model, i, k)
+					}
+				}
+				require.NoError(t, impl.check(m), "step %d", step)
+				require.Equal(t, len(model), m.Len())
+
+				// Compare one random query of each kind with the model.
+				q := rng.IntN(520) - 10
+				j, found := slices.BinarySearch(model, q)
+				assert.Equal(t, j, m.Rank(q))
+				if len(model) > 0 {
+					r := rng.IntN(len(model))
+					got, v, _ := m.Select(r)
+					assert.Equal(t, model[r], got)
+					assert.Equal(t, strconv.Itoa(got), v)
+				}
+				floor, _, ok := m.Floor(q)
+				if want := j - 1; found {
+					assert.Equal(t, q, floor)
+				} else if want >= 0 {
+					assert.Equal(t, model[want], floor)
+				} else {
+					assert.False(t, ok)
+				}
+				ceiling, _, ok := m.Ceiling(q)
+				if j < len(model) {
+					assert.Equal(t, model[j], ceiling)
+				} else {
+					assert.False(t, ok)
+				}
+			}
+			assert.Equal(t, model, collect(m.All()))
+		})
+	}
+}
+
+// TestOrderedMapFunc tests both trees ordered by a custom comparator.
+func TestOrderedMapFunc(t *testing.T) {
+	byLength := func(a, b string) int { return cmp.Or(len(a)-len(b), strings.Compare(a, b)) }
+	maps := map[string]OrderedMap[string, int]{
+		"AVL":      NewAVLFunc[string, int](byLength),
+		"RedBlack": NewRedBlackFunc[string, int](byLength),
+	}
+	for name, m := range maps {
+		t.Run(name, func(t *testing.T) {
+			for i, w := range []string{"pear", "fig", "banana", "kiwi", "apple"} {
+				m.Put(w, i)
+			}
+			var words []string
+			for k := range m.All() {
+				words = append(words, k)
+			}
+			expected := []string{"fig", "kiwi", "pear", "apple", "banana"}
+			assert.Equal(t, expected, words, "Expected: %v, Got: %v", expected, words)
+			k, _, _ := m.Ceiling("zzzz")
+			assert.Equal(t, "apple", k)
+			assert.Equal(t, 2, m.Rank("lime"))
+		})
+	}
+}
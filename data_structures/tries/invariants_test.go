@@ -0,0 +1,115 @@
+package tries
+
+import (
+	"errors"
+	"fmt"
+)
+
+// checkTrie checks that the children of every node of t are in increasing
+// order, that every node but the root has a key or children, and that t
+// holds Len keys.
+func checkTrie[V any](t *Trie[V]) error {
+	keys := 0
+	var check func(n *trieNode[V], prefix string) error
+	check = func(n *trieNode[V], prefix string) error {
+		if n.has {
+			keys++
+		} else if n != &t.root && len(n.children) == 0 {
+			return fmt.Errorf("node %q has neither a key nor children", prefix)
+		}
+		for i, c := range n.children {
+			if i > 0 && n.children[i-1].label >= c.label {
+				return fmt.Errorf("children of %q out of order", prefix)
+			}
+			if err := check(c, prefix+string(c.label)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(&t.root, ""); err != nil {
+		return err
+	}
+	if keys != t.len {
+		return fmt.Errorf("%d keys, Len %d", keys, t.len)
+	}
+	return nil
+}
+
+// checkRadix checks that every node of t but the root has a label, that the
+// labels of the children of a node start with bytes in increasing order,
+// that every node but the root with no key has at least two children, and
+// that t holds Len keys.
+func checkRadix[V any](t *Radix[V]) error {
+	if t.root.label != "" {
+		return errors.New("root has a label")
+	}
+	keys := 0
+	var check func(n *radixNode[V], prefix string) error
+	check = func(n *radixNode[V], prefix string) error {
+		if n.has {
+			keys++
+		} else if n != &t.root && len(n.children) < 2 {
+			return fmt.Errorf("node %q has no key and %d children", prefix, len(n.children))
+		}
+		for i, c := range n.children {
+			if c.label == "" {
+				return fmt.Errorf("child of %q has no label", prefix)
+			}
+			if i > 0 && n.children[i-1].label[0] >= c.label[0] {
+				return fmt.Errorf("children of %q out of order", prefix)
+			}
+			if err := check(c, prefix+c.label); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(&t.root, ""); err != nil {
+		return err
+	}
+	if keys != t.len {
+		return fmt.Errorf("%d keys, Len %d", keys, t.len)
+	}
+	return nil
+}
+
+// checkTST checks that the lesser and greater nodes of every node of t form
+// a binary search tree on their bytes, that every node has a key or a next
+// node, and that t holds Len keys.
+func checkTST[V any](t *TST[V]) error {
+	keys := 0
+	if t.empty.has {
+		keys++
+	}
+	// check checks the tree rooted at n, whose bytes are in (lo, hi).
+	var check func(n *tstNode[V], prefix string, lo, hi int) error
+	check = func(n *tstNode[V], prefix string, lo, hi int) error {
+		if n == nil {
+			return nil
+		}
+		key := prefix + string(n.c)
+		if int(n.c) <= lo || int(n.c) >= hi {
+			return fmt.Errorf("node %q out of order", key)
+		}
+		if n.has {
+			keys++
+		} else if n.eq == nil {
+			return fmt.Errorf("node %q has neither a key nor a next node", key)
+		}
+		if err := check(n.lo, prefix, lo, int(n.c)); err != nil {
+			return err
+		}
+		if err := check(n.eq, key, -1, 256); err != nil {
+			return err
+		}
+		return check(n.hi, prefix, int(n.c), hi)
+	}
+	if err := check(t.root, "", -1, 256); err != nil {
+		return err
+	}
+	if keys != t.len {
+		return fmt.Errorf("%d keys, Len %d", keys, t.len)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package tries
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// Radix is a radix tree, or compressed trie: a trie in which every chain of
+// nodes with one child and no key is merged into one edge labelled with a
+// string. It has at most 2n nodes for n keys, however long they are, where
+// a trie may have one per byte. The zero value is an empty radix tree ready
+// to use.
+type Radix[V any] struct {
+	root radixNode[V]
+	len  int
+}
+
+// radixNode is a node of a radix tree. Apart from the root, a node without
+// a key has at least two children.
+type radixNode[V any] struct {
+	label    string          // label of the edge from the parent, not empty
+	children []*radixNode[V] // sorted by the first byte of their labels
+	has      bool            // whether the path to the node spells a key
+	value    V
+}
+
+// NewRadix returns an empty radix tree.
+func NewRadix[V any]() *Radix[V] {
+	return &Radix[V]{}
+}
+
+var _ PrefixMap[int] = (*Radix[int])(nil)
+
+// Len returns the number of keys.
+func (t *Radix[V]) Len() int {
+	return t.len
+}
+
+// child returns the position of the child of n whose label starts with b
+// and whether it is there.
+func (n *radixNode[V]) child(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(c *radixNode[V], b byte) int {
+		return cmp.Compare(c.label[0], b)
+	})
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Get returns the value of key, or false if key is absent.
+func (t *Radix[V]) Get(key string) (v V, ok bool) {
+	n := &t.root
+	for key != "" {
+		j, found := n.child(key[0])
+		if !found || !strings.HasPrefix(key, n.children[j].label) {
+			return v, false
+		}
+		n = n.children[j]
+		key = key[len(n.label):]
+	}
+	return n.value, n.has
+}
+
+// Put sets the value of key to v, adding key if it is absent. A key that
+// ends inside an edge, or leaves it, splits the edge at that point.
+func (t *Radix[V]) Put(key string, v V) {
+	n := &t.root
+	for key != "" {
+		j, found := n.child(key[0])
+		if !found {
+			n.children = slices.Insert(n.children, j, &radixNode[V]{label: key})
+			n = n.children[j]
+			break
+		}
+		c := n.children[j]
+		common := commonPrefix(c.label, key)
+		if common < len(c.label) {
+			mid := &radixNode[V]{label: c.label[:common], children: []*radixNode[V]{c}}
+			c.label = c.label[common:]
+			n.children[j] = mid
+			c = mid
+		}
+		n, key = c, key[common:]
+	}
+	if !n.has {
+		t.len++
+	}
+	n.has, n.value = true, v
+}
+
+// Delete removes key and reports whether it was present. A node left with
+// no key and one child is merged with the child, and one left with no key
+// and no children is removed, which may leave its parent to merge.
+func (t *Radix[V]) Delete(key string) bool {
+	path := []*radixNode[V]{&t.root}
+	n := &t.root
+	for key != "" {
+		j, found := n.child(key[0])
+		if !found || !strings.HasPrefix(key, n.children[j].label) {
+			return false
+		}
+		n = n.children[j]
+		key = key[len(n.label):]
+		path = append(path, n)
+	}
+	if !n.has {
+		return false
+	}
+	var zero V
+	n.has, n.value = false, zero
+	t.len--
+
+	if len(n.children) == 0 && len(path) > 1 {
+		parent := path[len(path)-2]
+		j, _ := parent.child(n.label[0])
+		parent.children = slices.Delete(parent.children, j, j+1)
+		n = parent
+		path = path[:len(path)-1]
+	}
+	if n != &t.root && !n.has && len(n.children) == 1 {
+		c := n.children[0]
+		n.label += c.label
+		n.children, n.has, n.value = c.children, c.has, c.value
+	}
+	return true
+}
+
+// WithPrefix returns an iterator over the keys that start with prefix, with
+// their values, in increasing order.
+func (t *Radix[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n, key := &t.root, []byte(nil)
+		for rest := prefix; rest != ""; {
+			j, found := n.child(rest[0])
+			if !found {
+				return
+			}
+			n = n.children[j]
+			if !strings.HasPrefix(n.label, rest) && !strings.HasPrefix(rest, n.label) {
+				return
+			}
+			key = append(key, n.label...)
+			rest = rest[min(len(rest), len(n.label)):]
+		}
+		n.walk(key, yield)
+	}
+}
+
+// walk yields the keys of the subtree of n, whose path spells key, and
+// reports whether to go on.
+func (n *radixNode[V]) walk(key []byte, yield func(string, V) bool) bool {
+	if n.has && !yield(string(key), n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(append(key, c.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, with its
+// value, or false if there is none.
+func (t *Radix[V]) LongestPrefix(s string) (key string, v V, ok bool) {
+	n, i := &t.root, 0
+	for {
+		if n.has {
+			key, v, ok = s[:i], n.value, true
+		}
+		if i == len(s) {
+			return key, v, ok
+		}
+		j, found := n.child(s[i])
+		if !found || !strings.HasPrefix(s[i:], n.children[j].label) {
+			return key, v, ok
+		}
+		n = n.children[j]
+		i += len(n.label)
+	}
+}
+
+// TopK returns up to k keys that start with prefix, those of the largest
+// weights first.
+func (t *Radix[V]) TopK(prefix string, k int, weight func(V) float64) []string {
+	return topK(t.WithPrefix(prefix), k, weight)
+}
+
+// Stats returns the number of nodes and their estimated size, counting the
+// bytes of the labels, which may share memory with the keys put.
+func (t *Radix[V]) Stats() Stats {
+	var s Stats
+	var count func(n *radixNode[V])
+	count = func(n *radixNode[V]) {
+		s.Nodes++
+		s.Bytes += int(unsafe.Sizeof(*n)) + cap(n.children)*int(unsafe.Sizeof(n)) + len(n.label)
+		for _, c := range n.children {
+			count(c)
+		}
+	}
+	count(&t.root)
+	return s
+}
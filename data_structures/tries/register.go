@@ -0,0 +1,75 @@
+package tries
+
+import (
+	"strconv"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+)
+
+// init registers the algorithms of this package; see package registry.
+func init() {
+	registry.Register(registry.Algorithm{
+		Name:     "Trie.Put",
+		Package:  "data_structures/tries",
+		Category: "data_structures",
+		Summary:  "Insertion of n decimal keys into a trie with sorted children",
+		Time:     "O(L log σ)",
+		Space:    "O(nL)",
+		Run: func(n int) any {
+			t := NewTrie[int]()
+			for i := range n {
+				t.Put(strconv.Itoa(i*7919%(n+1)), i)
+			}
+			return t.Stats()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Radix.Put",
+		Package:  "data_structures/tries",
+		Category: "data_structures",
+		Summary:  "Insertion of n decimal keys into a radix tree, splitting edges",
+		Time:     "O(L log σ)",
+		Space:    "O(n)",
+		Run: func(n int) any {
+			t := NewRadix[int]()
+			for i := range n {
+				t.Put(strconv.Itoa(i*7919%(n+1)), i)
+			}
+			return t.Stats()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "TST.Put",
+		Package:  "data_structures/tries",
+		Category: "data_structures",
+		Summary:  "Insertion of n decimal keys into a ternary search tree",
+		Time:     "O(L + log n) expected",
+		Space:    "O(nL)",
+		Run: func(n int) any {
+			t := NewTST[int]()
+			for i := range n {
+				t.Put(strconv.Itoa(i*7919%(n+1)), i)
+			}
+			return t.Stats()
+		},
+	})
+	registry.Register(registry.Algorithm{
+		Name:     "Trie.TopK",
+		Package:  "data_structures/tries",
+		Category: "data_structures",
+		Summary:  "Ten heaviest completions of each digit among n decimal keys",
+		Time:     "O(m log k)",
+		Space:    "O(k)",
+		Run: func(n int) any {
+			t := NewTrie[int]()
+			for i := range n {
+				t.Put(strconv.Itoa(i), i*7919%(n+1))
+			}
+			var top [][]string
+			for d := range 10 {
+				top = append(top, t.TopK(strconv.Itoa(d), 10, func(v int) float64 { return float64(v) }))
+			}
+			return top
+		},
+	})
+}
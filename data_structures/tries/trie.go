@@ -0,0 +1,253 @@
+// Package tries implements maps from strings that are indexed by prefix:
+// tries, radix trees and ternary search trees. Each finds the keys that
+// start with a prefix, and the longest key that is a prefix of a string, in
+// time proportional to the length of the prefix or string rather than to the
+// number of keys.
+//
+// Keys are compared byte by byte, which orders UTF-8 strings by code point.
+package tries
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"unsafe"
+
+	"github.com/ignoreAnt/go-dsa/data_structures/heaps"
+)
+
+// PrefixMap is a map from strings that is indexed by prefix.
+type PrefixMap[V any] interface {
+	// Len returns the number of keys.
+	Len() int
+	// Get returns the value of key, or false if key is absent.
+	Get(key string) (V, bool)
+	// Put sets the value of key to v, adding key if it is absent.
+	Put(key string, v V)
+	// Delete removes key and reports whether it was present.
+	Delete(key string) bool
+
+	// WithPrefix returns an iterator over the keys that start with prefix,
+	// with their values, in increasing order of keys. The map must not
+	// change during the iteration.
+	WithPrefix(prefix string) iter.Seq2[string, V]
+	// LongestPrefix returns the longest key that is a prefix of s, with its
+	// value, or false if there is none.
+	LongestPrefix(s string) (key string, v V, ok bool)
+	// TopK returns up to k keys that start with prefix, those of the
+	// largest weights of their values first and, among equal weights, the
+	// lesser keys, or nil if there are none. It takes O(m log k) time for
+	// m keys with the prefix.
+	TopK(prefix string, k int, weight func(V) float64) []string
+
+	// Stats returns the size of the map in memory.
+	Stats() Stats
+}
+
+// Stats describes the memory used by a map.
+type Stats struct {
+	Nodes int // number of nodes
+	Bytes int // estimated bytes of the nodes, their links and labels
+}
+
+// scored is a key with the weight of its value.
+type scored struct {
+	key    string
+	weight float64
+}
+
+// topK returns the keys of up to k of the highest weights in seq, ties going
+// to the lesser key, keeping the best k seen in a min-heap whose top is the
+// worst of them.
+func topK[V any](seq iter.Seq2[string, V], k int, weight func(V) float64) []string {
+	if k <= 0 {
+		return nil
+	}
+	worse := func(a, b scored) int {
+		return cmp.Or(cmp.Compare(a.weight, b.weight), strings.Compare(b.key, a.key))
+	}
+	best := heaps.NewBinary(worse)
+	for key, v := range seq {
+		s := scored{key, weight(v)}
+		if best.Len() < k {
+			best.Push(s)
+		} else if top, _ := best.Peek(); worse(top, s) < 0 {
+			best.Pop()
+			best.Push(s)
+		}
+	}
+	if best.Len() == 0 {
+		return nil
+	}
+	keys := make([]string, best.Len())
+	for i := len(keys) - 1; i >= 0; i-- {
+		s, _ := best.Pop()
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// Trie is a map from strings with one node per distinct prefix of the keys,
+// whose children are kept sorted by their byte. Every operation takes
+// O(L log σ) time for a key of length L and σ different bytes per node. The
+// zero value is an empty trie ready to use.
+type Trie[V any] struct {
+	root trieNode[V]
+	len  int
+}
+
+// trieNode is a node of a trie: the prefix spelled by the path to it.
+type trieNode[V any] struct {
+	children []*trieNode[V] // sorted by label
+	label    byte           // last byte of the prefix
+	has      bool           // whether the prefix is a key
+	value    V
+}
+
+// NewTrie returns an empty trie.
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+var _ PrefixMap[int] = (*Trie[int])(nil)
+
+// Len returns the number of keys.
+func (t *Trie[V]) Len() int {
+	return t.len
+}
+
+// child returns the position of the child of n with label b and whether it
+// is there.
+func (n *trieNode[V]) child(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(c *trieNode[V], b byte) int {
+		return cmp.Compare(c.label, b)
+	})
+}
+
+// find returns the node of prefix, or nil if no key starts with it.
+func (t *Trie[V]) find(prefix string) *trieNode[V] {
+	n := &t.root
+	for i := range len(prefix) {
+		j, ok := n.child(prefix[i])
+		if !ok {
+			return nil
+		}
+		n = n.children[j]
+	}
+	return n
+}
+
+// Get returns the value of key, or false if key is absent.
+func (t *Trie[V]) Get(key string) (v V, ok bool) {
+	if n := t.find(key); n != nil && n.has {
+		return n.value, true
+	}
+	return v, false
+}
+
+// Put sets the value of key to v, adding key if it is absent.
+func (t *Trie[V]) Put(key string, v V) {
+	n := &t.root
+	for i := range len(key) {
+		j, ok := n.child(key[i])
+		if !ok {
+			n.children = slices.Insert(n.children, j, &trieNode[V]{label: key[i]})
+		}
+		n = n.children[j]
+	}
+	if !n.has {
+		t.len++
+	}
+	n.has, n.value = true, v
+}
+
+// Delete removes key and reports whether it was present. It removes the
+// nodes left with neither a key nor children.
+func (t *Trie[V]) Delete(key string) bool {
+	path := make([]*trieNode[V], len(key)+1)
+	path[0] = &t.root
+	for i := range len(key) {
+		j, ok := path[i].child(key[i])
+		if !ok {
+			return false
+		}
+		path[i+1] = path[i].children[j]
+	}
+	n := path[len(key)]
+	if !n.has {
+		return false
+	}
+	var zero V
+	n.has, n.value = false, zero
+	t.len--
+	for i := len(key); i > 0 && !path[i].has && len(path[i].children) == 0; i-- {
+		j, _ := path[i-1].child(key[i-1])
+		path[i-1].children = slices.Delete(path[i-1].children, j, j+1)
+	}
+	return true
+}
+
+// WithPrefix returns an iterator over the keys that start with prefix, with
+// their values, in increasing order.
+func (t *Trie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if n := t.find(prefix); n != nil {
+			n.walk([]byte(prefix), yield)
+		}
+	}
+}
+
+// walk yields the keys of the subtree of n, whose prefix is key, and reports
+// whether to go on.
+func (n *trieNode[V]) walk(key []byte, yield func(string, V) bool) bool {
+	if n.has && !yield(string(key), n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(append(key, c.label), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, with its
+// value, or false if there is none.
+func (t *Trie[V]) LongestPrefix(s string) (key string, v V, ok bool) {
+	n := &t.root
+	for i := 0; ; i++ {
+		if n.has {
+			key, v, ok = s[:i], n.value, true
+		}
+		if i == len(s) {
+			return key, v, ok
+		}
+		j, found := n.child(s[i])
+		if !found {
+			return key, v, ok
+		}
+		n = n.children[j]
+	}
+}
+
+// TopK returns up to k keys that start with prefix, those of the largest
+// weights first.
+func (t *Trie[V]) TopK(prefix string, k int, weight func(V) float64) []string {
+	return topK(t.WithPrefix(prefix), k, weight)
+}
+
+// Stats returns the number of nodes and their estimated size.
+func (t *Trie[V]) Stats() Stats {
+	var s Stats
+	var count func(n *trieNode[V])
+	count = func(n *trieNode[V]) {
+		s.Nodes++
+		s.Bytes += int(unsafe.Sizeof(*n)) + cap(n.children)*int(unsafe.Sizeof(n))
+		for _, c := range n.children {
+			count(c)
+		}
+	}
+	count(&t.root)
+	return s
+}
@@ -0,0 +1,329 @@
+package tries
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// words are the keys of the query tests with their weights.
+var words = map[string]int{
+	"":        1,
+	"a":       40,
+	"an":      25,
+	"and":     90,
+	"ant":     7,
+	"any":     25,
+	"apple":   12,
+	"ban":     3,
+	"band":    15,
+	"bandana": 2,
+	"banner":  15,
+	"zebra":   5,
+}
+
+// collect returns the keys of seq in order.
+func collect(m PrefixMap[int], prefix string) []string {
+	var keys []string
+	for key, v := range m.WithPrefix(prefix) {
+		if v != words[key] {
+			return append(keys, "bad value of "+key)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// weight weighs a value by itself.
+func weight(v int) float64 {
+	return float64(v)
+}
+
+// TestTrie runs the prefix map tests on a trie.
+func TestTrie(t *testing.T) {
+	testPrefixMap(t, NewTrie[int], checkTrie[int])
+}
+
+// TestRadix runs the prefix map tests on a radix tree.
+func TestRadix(t *testing.T) {
+	testPrefixMap(t, NewRadix[int], checkRadix[int])
+}
+
+// TestTST runs the prefix map tests on a ternary search tree.
+func TestTST(t *testing.T) {
+	testPrefixMap(t, NewTST[int], checkTST[int])
+}
+
+// testPrefixMap tests the prefix maps made by newMap on what every PrefixMap
+// must do, whatever its nodes look like, checking their structure with check
+// after building and after deleting.
+func testPrefixMap[M PrefixMap[int]](t *testing.T, newMap func() M, check func(M) error) {
+	t.Run("queries", func(t *testing.T) { testQueries(t, newMap, check) })
+	t.Run("updates", func(t *testing.T) { testUpdates(t, newMap, check) })
+	t.Run("byte order", func(t *testing.T) { testByteOrder(t, newMap, check) })
+	t.Run("every prefix", func(t *testing.T) { testEveryPrefix(t, newMap, check) })
+}
+
+// testQueries tests the queries on a fixed set of keys, put in an order
+// that splits edges of the radix tree.
+func testQueries[M PrefixMap[int]](t *testing.T, newMap func() M, check func(M) error) {
+	testCases := []struct {
+		name     string
+		query    func(m PrefixMap[int]) any
+		expected any
+	}{
+		{"Len", func(m PrefixMap[int]) any { return m.Len() }, len(words)},
+		{"Get key", func(m PrefixMap[int]) any { v, _ := m.Get("band"); return v }, 15},
+		{"Get empty key", func(m PrefixMap[int]) any { v, _ := m.Get(""); return v }, 1},
+		{"Get inner prefix", func(m PrefixMap[int]) any { _, ok := m.Get("ap"); return ok }, false},
+		{"Get past a key", func(m PrefixMap[int]) any { _, ok := m.Get("bands"); return ok }, false},
+		{"Get absent", func(m PrefixMap[int]) any { _, ok := m.Get("cat"); return ok }, false},
+		{"WithPrefix all", func(m PrefixMap[int]) any { return collect(m, "") },
+			slices.Sorted(maps.Keys(words))},
+		{"WithPrefix key", func(m PrefixMap[int]) any { return collect(m, "an") },
+			[]string{"an", "and", "ant", "any"}},
+		{"WithPrefix inside an edge", func(m PrefixMap[int]) any { return collect(m, "bann") },
+			[]string{"banner"}},
+		{"WithPrefix inner", func(m PrefixMap[int]) any { return collect(m, "ba") },
+			[]string{"ban", "band", "bandana", "banner"}},
+		{"WithPrefix none", func(m PrefixMap[int]) any { return collect(m, "bandanas") }, []string(nil)},
+		{"WithPrefix diverging", func(m PrefixMap[int]) any { return collect(m, "bx") }, []string(nil)},
+		{"LongestPrefix", func(m PrefixMap[int]) any { key, _, _ := m.LongestPrefix("bandwidth"); return key },
+			"band"},
+		{"LongestPrefix whole", func(m PrefixMap[int]) any { key, _, _ := m.LongestPrefix("apple"); return key },
+			"apple"},
+		{"LongestPrefix inside an edge", func(m PrefixMap[int]) any { key, _, _ := m.LongestPrefix("applause"); return key },
+			"a"},
+		{"LongestPrefix empty key", func(m PrefixMap[int]) any { key, v, ok := m.LongestPrefix("cat"); return []any{key, v, ok} },
+			[]any{"", 1, true}},
+		{"TopK", func(m PrefixMap[int]) any { return m.TopK("a", 3, weight) },
+			[]string{"and", "a", "an"}},
+		{"TopK ties by key", func(m PrefixMap[int]) any { return m.TopK("an", 3, weight) },
+			[]string{"and", "an", "any"}},
+		{"TopK fewer than k", func(m PrefixMap[int]) any { return m.TopK("ban", 10, weight) },
+			[]string{"band", "banner", "ban", "bandana"}},
+		{"TopK none", func(m PrefixMap[int]) any { return m.TopK("c", 3, weight) }, []string(nil)},
+		{"TopK zero", func(m PrefixMap[int]) any { return m.TopK("a", 0, weight) }, []string(nil)},
+	}
+
+	// Put shorter keys after the longer ones they are prefixes of.
+	order := slices.SortedFunc(maps.Keys(words), func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	m := newMap()
+	for _, key := range order {
+		m.Put(key, words[key])
+	}
+	require.NoError(t, check(m))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.query(m)
+			assert.Equal(t, tc.expected, got, "Expected: %v, Got: %v", tc.expected, got)
+		})
+	}
+}
+
+// testUpdates tests Put over a key, Delete of keys that are prefixes of
+// others and of keys that only share a prefix, stopping an iteration early,
+// and that deleting every key prunes the map down to its root.
+func testUpdates[M PrefixMap[int]](t *testing.T, newMap func() M, check func(M) error) {
+	m := newMap()
+	_, _, ok := m.LongestPrefix("abc")
+	assert.False(t, ok)
+	assert.False(t, m.Delete("a"))
+
+	for key, v := range words {
+		m.Put(key, v)
+	}
+	m.Put("band", 16)
+	v, _ := m.Get("band")
+	assert.Equal(t, 16, v)
+	m.Put("band", words["band"])
+	assert.Equal(t, len(words), m.Len())
+
+	for _, key := range []string{"ban", "an", "", "bandana", "zebra"} {
+		assert.True(t, m.Delete(key), key)
+		assert.False(t, m.Delete(key), key)
+		require.NoError(t, check(m), key)
+	}
+	assert.False(t, m.Delete("ap"))
+	assert.False(t, m.Delete("banners"))
+	assert.Equal(t, len(words)-5, m.Len())
+	assert.Equal(t, []string{"a", "and", "ant", "any", "apple", "band", "banner"}, collect(m, ""))
+	key, _, _ := m.LongestPrefix("bandana")
+	assert.Equal(t, "band", key)
+
+	var first []string
+	for key := range m.WithPrefix("a") {
+		first = append(first, key)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "and"}, first)
+
+	for key := range words {
+		m.Delete(key)
+	}
+	require.NoError(t, check(m))
+	assert.Zero(t, m.Len())
+	// Only the root of a trie or radix tree is left.
+	assert.LessOrEqual(t, m.Stats().Nodes, 1)
+}
+
+// testByteOrder tests that keys are ordered and matched byte by byte: by
+// code point for UTF-8, with a prefix that may end inside a character, and
+// with zero bytes and invalid UTF-8 as ordinary bytes.
+func testByteOrder[M PrefixMap[int]](t *testing.T, newMap func() M, check func(M) error) {
+	keys := []string{"z", "é", "e\u0301", "e", "\xff", "a\x00b", "a", "a\x00", "ω", "日本", "日"}
+	m := newMap()
+	for i, key := range keys {
+		m.Put(key, i)
+	}
+	require.NoError(t, check(m))
+
+	keysWithPrefix := func(prefix string) []string {
+		var got []string
+		for key := range m.WithPrefix(prefix) {
+			got = append(got, key)
+		}
+		return got
+	}
+	assert.Equal(t, slices.Sorted(slices.Values(keys)), keysWithPrefix(""))
+	assert.Equal(t, []string{"a\x00", "a\x00b"}, keysWithPrefix("a\x00"))
+	assert.Equal(t, []string{"é"}, keysWithPrefix("é"[:1]))
+	assert.Equal(t, []string{"日", "日本"}, keysWithPrefix("日"))
+
+	key, v, ok := m.LongestPrefix("a\x00bc")
+	assert.True(t, ok)
+	assert.Equal(t, "a\x00b", key)
+	assert.Equal(t, 5, v)
+	key, _, _ = m.LongestPrefix("日本語")
+	assert.Equal(t, "日本", key)
+	_, _, ok = m.LongestPrefix("é"[:1])
+	assert.False(t, ok)
+}
+
+// testEveryPrefix tests WithPrefix, LongestPrefix and Get for every prefix
+// of a random set of keys with many shared prefixes, and for the strings one
+// byte past each key, before and after deleting half of the keys.
+func testEveryPrefix[M PrefixMap[int]](t *testing.T, newMap func() M, check func(M) error) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	present := map[string]int{}
+	for range 300 {
+		b := make([]byte, rng.IntN(9))
+		for i := range b {
+			b[i] = "abc"[rng.IntN(3)]
+		}
+		present[string(b)] = len(present)
+	}
+	queries := map[string]bool{}
+	for key := range present {
+		for i := range len(key) + 1 {
+			queries[key[:i]] = true
+		}
+		queries[key+"a"] = true
+		queries[key+"d"] = true
+	}
+
+	// verify compares m with present on every query.
+	verify := func(m M) {
+		sorted := slices.Sorted(maps.Keys(present))
+		for query := range queries {
+			var expected, got []string
+			for _, key := range sorted {
+				if strings.HasPrefix(key, query) {
+					expected = append(expected, key)
+				}
+			}
+			for key, v := range m.WithPrefix(query) {
+				assert.Equal(t, present[key], v, "WithPrefix(%q): value of %q", query, key)
+				got = append(got, key)
+			}
+			require.Equal(t, expected, got, "WithPrefix(%q)", query)
+
+			longest, found := "", false
+			for key := range present {
+				if strings.HasPrefix(query, key) && (!found || len(key) > len(longest)) {
+					longest, found = key, true
+				}
+			}
+			key, v, ok := m.LongestPrefix(query)
+			require.Equal(t, found, ok, "LongestPrefix(%q)", query)
+			assert.Equal(t, longest, key, "LongestPrefix(%q)", query)
+			assert.Equal(t, present[longest], v, "LongestPrefix(%q)", query)
+
+			v, ok = m.Get(query)
+			expectedValue, isKey := present[query]
+			assert.Equal(t, isKey, ok, "Get(%q)", query)
+			assert.Equal(t, expectedValue, v, "Get(%q)", query)
+		}
+	}
+
+	m := newMap()
+	keys := slices.Collect(maps.Keys(present))
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	for _, key := range keys {
+		m.Put(key, present[key])
+	}
+	require.NoError(t, check(m))
+	assert.Equal(t, len(present), m.Len())
+	verify(m)
+
+	for _, key := range keys[:len(keys)/2] {
+		require.True(t, m.Delete(key), "Delete(%q)", key)
+		delete(present, key)
+	}
+	require.NoError(t, check(m))
+	assert.Equal(t, len(present), m.Len())
+	verify(m)
+}
+
+// TestPrefixMapStats tests the node counts on keys sharing long prefixes:
+// a trie has a node per distinct prefix, a radix tree at most two per key,
+// and a ternary search tree as many as a trie without the root.
+func TestPrefixMapStats(t *testing.T) {
+	keys := []string{
+		"internationalisation",
+		"internationalization",
+		"international",
+		"internet",
+		"interval",
+	}
+	prefixes := map[string]bool{"": true}
+	for _, key := range keys {
+		for i := range len(key) {
+			prefixes[key[:i+1]] = true
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		m        PrefixMap[int]
+		expected int
+	}{
+		{"trie", NewTrie[int](), len(prefixes)},
+		// The root, "inter", "n", "val", "ational", "et", "i", "sation"
+		// and "zation".
+		{"radix", NewRadix[int](), 9},
+		{"TST", NewTST[int](), len(prefixes) - 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, key := range keys {
+				tc.m.Put(key, i)
+			}
+			stats := tc.m.Stats()
+			assert.Equal(t, tc.expected, stats.Nodes, "Expected: %v, Got: %v", tc.expected, stats.Nodes)
+			assert.Greater(t, stats.Bytes, stats.Nodes)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package tries
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// TST is a ternary search tree: a trie whose children are kept in a binary
+// search tree rather than an array. Each node holds one byte and links to
+// the nodes of lesser and greater bytes at the same position and to the
+// node of the next position. It uses far less memory than a trie over a
+// large alphabet, and a search takes O(L + log n) comparisons for a key of
+// length L when the keys are put in random order. The zero value is an
+// empty tree ready to use.
+type TST[V any] struct {
+	root  *tstNode[V]
+	empty struct { // the empty key, which has no node
+		has   bool
+		value V
+	}
+	len int
+}
+
+// tstNode is a node of a ternary search tree. Every node is on the path of
+// some key: it has a key or a next node.
+type tstNode[V any] struct {
+	c          byte
+	lo, eq, hi *tstNode[V]
+	has        bool // whether the path to the node spells a key
+	value      V
+}
+
+// NewTST returns an empty ternary search tree.
+func NewTST[V any]() *TST[V] {
+	return &TST[V]{}
+}
+
+var _ PrefixMap[int] = (*TST[int])(nil)
+
+// Len returns the number of keys.
+func (t *TST[V]) Len() int {
+	return t.len
+}
+
+// find returns the node of the last byte of the non-empty string s, or nil
+// if no key starts with s.
+func (t *TST[V]) find(s string) *tstNode[V] {
+	n, i := t.root, 0
+	for n != nil {
+		switch {
+		case s[i] < n.c:
+			n = n.lo
+		case s[i] > n.c:
+			n = n.hi
+		case i == len(s)-1:
+			return n
+		default:
+			n, i = n.eq, i+1
+		}
+	}
+	return nil
+}
+
+// Get returns the value of key, or false if key is absent.
+func (t *TST[V]) Get(key string) (v V, ok bool) {
+	if key == "" {
+		return t.empty.value, t.empty.has
+	}
+	if n := t.find(key); n != nil && n.has {
+		return n.value, true
+	}
+	return v, false
+}
+
+// Put sets the value of key to v, adding key if it is absent.
+func (t *TST[V]) Put(key string, v V) {
+	if key == "" {
+		if !t.empty.has {
+			t.len++
+		}
+		t.empty.has, t.empty.value = true, v
+		return
+	}
+	link, i := &t.root, 0
+	for {
+		if *link == nil {
+			*link = &tstNode[V]{c: key[i]}
+		}
+		n := *link
+		switch {
+		case key[i] < n.c:
+			link = &n.lo
+		case key[i] > n.c:
+			link = &n.hi
+		case i < len(key)-1:
+			link, i = &n.eq, i+1
+		default:
+			if !n.has {
+				t.len++
+			}
+			n.has, n.value = true, v
+			return
+		}
+	}
+}
+
+// Delete removes key and reports whether it was present. It then removes
+// the nodes left with neither a key nor a next node, from the last byte of
+// the key back, replacing each in the binary search tree of its position
+// as in the deletion from a binary search tree.
+func (t *TST[V]) Delete(key string) bool {
+	var zero V
+	if key == "" {
+		if !t.empty.has {
+			return false
+		}
+		t.empty.has, t.empty.value = false, zero
+		t.len--
+		return true
+	}
+
+	// links holds the link to each node on the search path.
+	var links []**tstNode[V]
+	link, i := &t.root, 0
+	for {
+		n := *link
+		if n == nil {
+			return false
+		}
+		links = append(links, link)
+		if key[i] < n.c {
+			link = &n.lo
+		} else if key[i] > n.c {
+			link = &n.hi
+		} else if i < len(key)-1 {
+			link, i = &n.eq, i+1
+		} else {
+			break
+		}
+	}
+	n := *link
+	if !n.has {
+		return false
+	}
+	n.has, n.value = false, zero
+	t.len--
+
+	// A node above on the path can only become empty by losing its next
+	// node, so the removals stop at the first node that stays.
+	for j := len(links) - 1; j >= 0; j-- {
+		n := *links[j]
+		if n.has || n.eq != nil {
+			break
+		}
+		*links[j] = unlink(n)
+	}
+	return true
+}
+
+// unlink returns the binary search tree of the lesser and greater nodes of
+// n without n: one of them if the other is empty, or else the greater one
+// with its least node moved up to replace n.
+func unlink[V any](n *tstNode[V]) *tstNode[V] {
+	if n.lo == nil {
+		return n.hi
+	}
+	if n.hi == nil {
+		return n.lo
+	}
+	link := &n.hi
+	for (*link).lo != nil {
+		link = &(*link).lo
+	}
+	least := *link
+	*link = least.hi
+	least.lo, least.hi = n.lo, n.hi
+	return least
+}
+
+// WithPrefix returns an iterator over the keys that start with prefix, with
+// their values, in increasing order.
+func (t *TST[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if prefix == "" {
+			if t.empty.has && !yield("", t.empty.value) {
+				return
+			}
+			t.root.walk(nil, yield)
+			return
+		}
+		n := t.find(prefix)
+		if n == nil || n.has && !yield(prefix, n.value) {
+			return
+		}
+		n.eq.walk([]byte(prefix), yield)
+	}
+}
+
+// walk yields the keys of the tree rooted at n, which all start with
+// prefix, in order, and reports whether to go on.
+func (n *tstNode[V]) walk(prefix []byte, yield func(string, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if !n.lo.walk(prefix, yield) {
+		return false
+	}
+	key := append(prefix, n.c)
+	if n.has && !yield(string(key), n.value) {
+		return false
+	}
+	return n.eq.walk(key, yield) && n.hi.walk(prefix, yield)
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, with its
+// value, or false if there is none.
+func (t *TST[V]) LongestPrefix(s string) (key string, v V, ok bool) {
+	if t.empty.has {
+		key, v, ok = "", t.empty.value, true
+	}
+	n, i := t.root, 0
+	for n != nil && i < len(s) {
+		switch {
+		case s[i] < n.c:
+			n = n.lo
+		case s[i] > n.c:
+			n = n.hi
+		default:
+			if n.has {
+				key, v, ok = s[:i+1], n.value, true
+			}
+			n, i = n.eq, i+1
+		}
+	}
+	return key, v, ok
+}
+
+// TopK returns up to k keys that start with prefix, those of the largest
+// weights first.
+func (t *TST[V]) TopK(prefix string, k int, weight func(V) float64) []string {
+	return topK(t.WithPrefix(prefix), k, weight)
+}
+
+// Stats returns the number of nodes and their estimated size.
+func (t *TST[V]) Stats() Stats {
+	var s Stats
+	var count func(n *tstNode[V])
+	count = func(n *tstNode[V]) {
+		if n == nil {
+			return
+		}
+		s.Nodes++
+		s.Bytes += int(unsafe.Sizeof(*n))
+		count(n.lo)
+		count(n.eq)
+		count(n.hi)
+	}
+	count(t.root)
+	return s
+}
@@ -0,0 +1,103 @@
+package fastio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// benchCount is the number of integers in the benchmark input, about 10 MB
+// of text.
+const benchCount = 1 << 20
+
+// benchInput is the benchmark input: benchCount random int64s of up to ten
+// digits, twenty per line.
+var benchInput = sync.OnceValue(func() []byte {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var b []byte
+	for i := range benchCount {
+		b = strconv.AppendInt(b, rng.Int64N(2e10)-1e10, 10)
+		if i%20 == 19 {
+			b = append(b, '\n')
+		} else {
+			b = append(b, ' ')
+		}
+	}
+	return b
+})
+
+// benchSum keeps the benchmarks from being optimised away.
+var benchSum int64
+
+// BenchmarkReadInts compares reading benchCount integers with a Reader, with
+// fmt.Fscan over a bufio.Reader and with a word-splitting bufio.Scanner and
+// strconv.ParseInt, e.g. go test -bench ReadInts -run ^$ ./fastio.
+func BenchmarkReadInts(b *testing.B) {
+	input := benchInput()
+
+	b.Run("fastio", func(b *testing.B) {
+		b.SetBytes(int64(len(input)))
+		for range b.N {
+			r := NewReader(bytes.NewReader(input))
+			var sum int64
+			for range benchCount {
+				v, _ := r.Int64()
+				sum += v
+			}
+			benchSum = sum
+		}
+	})
+	b.Run("fmt.Fscan", func(b *testing.B) {
+		b.SetBytes(int64(len(input)))
+		for range b.N {
+			r := bufio.NewReader(bytes.NewReader(input))
+			var sum, v int64
+			for range benchCount {
+				fmt.Fscan(r, &v)
+				sum += v
+			}
+			benchSum = sum
+		}
+	})
+	b.Run("bufio.Scanner", func(b *testing.B) {
+		b.SetBytes(int64(len(input)))
+		for range b.N {
+			s := bufio.NewScanner(bytes.NewReader(input))
+			s.Split(bufio.ScanWords)
+			var sum int64
+			for s.Scan() {
+				v, _ := strconv.ParseInt(s.Text(), 10, 64)
+				sum += v
+			}
+			benchSum = sum
+		}
+	})
+}
+
+// BenchmarkWriteInts compares writing benchCount integers with a Writer and
+// with fmt.Fprintln over a bufio.Writer.
+func BenchmarkWriteInts(b *testing.B) {
+	b.Run("fastio", func(b *testing.B) {
+		for range b.N {
+			w := NewWriter(discard{})
+			for i := range benchCount {
+				w.WriteInt(i * 7919)
+				w.WriteByte('\n')
+			}
+			w.Flush()
+		}
+	})
+	b.Run("fmt.Fprintln", func(b *testing.B) {
+		for range b.N {
+			w := bufio.NewWriterSize(discard{}, defaultBufferSize)
+			for i := range benchCount {
+				fmt.Fprintln(w, i*7919)
+			}
+			w.Flush()
+		}
+	})
+}
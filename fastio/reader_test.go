@@ -0,0 +1,164 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readers returns readers of input that exercise the buffer handling: a
+// large buffer, a tiny one that must grow for long tokens, and a tiny one fed
+// one byte at a time.
+func readers(input string) map[string]*Reader {
+	return map[string]*Reader{
+		"default":    NewReader(strings.NewReader(input)),
+		"small":      NewReaderSize(strings.NewReader(input), 16),
+		"small byte": NewReaderSize(iotest.OneByteReader(strings.NewReader(input)), 16),
+	}
+}
+
+// TestInt64 tests integer parsing, including the limits and invalid tokens.
+func TestInt64(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected int64
+		err      error
+	}{
+		{"zero", "0", 0, nil},
+		{"positive", "  12345\n", 12345, nil},
+		{"explicit plus", "+7", 7, nil},
+		{"negative", "\t-42 ", -42, nil},
+		{"leading zeros", "000123", 123, nil},
+		{"max", "9223372036854775807", math.MaxInt64, nil},
+		{"min", "-9223372036854775808", math.MinInt64, nil},
+		{"above max", "9223372036854775808", 0, ErrRange},
+		{"below min", "-9223372036854775809", 0, ErrRange},
+		{"far too long", strings.Repeat("9", 40), 0, ErrRange},
+		{"sign only", "-", 0, ErrSyntax},
+		{"letters", "12a", 0, ErrSyntax},
+		{"float", "1.5", 0, ErrSyntax},
+		{"empty input", "", 0, io.EOF},
+		{"only spaces", " \r\n\t ", 0, io.EOF},
+	}
+
+	for _, tc := range testCases {
+		for kind, r := range readers(tc.input) {
+			t.Run(tc.name+"/"+kind, func(t *testing.T) {
+				actual, err := r.Int64()
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+			})
+		}
+	}
+}
+
+// TestUint64 tests unsigned parsing at its limits.
+func TestUint64(t *testing.T) {
+	r := NewReader(strings.NewReader("18446744073709551615 +1 18446744073709551616 -1"))
+
+	v, err := r.Uint64()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), v)
+	v, err = r.Uint64()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), v)
+	_, err = r.Uint64()
+	assert.ErrorIs(t, err, ErrRange)
+	_, err = r.Uint64()
+	assert.ErrorIs(t, err, ErrSyntax)
+	_, err = r.Uint64()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+// TestFloat64 tests float parsing.
+func TestFloat64(t *testing.T) {
+	r := NewReader(strings.NewReader("3.25 -1e3 inf 1e999 x"))
+
+	for _, expected := range []float64{3.25, -1000, math.Inf(1)} {
+		v, err := r.Float64()
+		require.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+	_, err := r.Float64()
+	assert.ErrorIs(t, err, ErrRange)
+	_, err = r.Float64()
+	assert.ErrorIs(t, err, ErrSyntax)
+	assert.EqualError(t, err, `fastio: invalid number: "x"`)
+}
+
+// TestWordsAndLines tests that words and lines come out whole whatever the
+// buffer size, including tokens longer than the buffer.
+func TestWordsAndLines(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	input := "first line\r\n  " + long + " b\n\nlast"
+
+	for kind, r := range readers(input) {
+		t.Run(kind, func(t *testing.T) {
+			line, err := r.Line()
+			require.NoError(t, err)
+			assert.Equal(t, "first line", string(line))
+
+			word, err := r.Word()
+			require.NoError(t, err)
+			assert.Equal(t, long, string(word))
+
+			line, err = r.Line()
+			require.NoError(t, err)
+			assert.Equal(t, " b", string(line))
+
+			line, err = r.Line()
+			require.NoError(t, err)
+			assert.Equal(t, "", string(line))
+
+			line, err = r.Line()
+			require.NoError(t, err)
+			assert.Equal(t, "last", string(line))
+
+			_, err = r.Line()
+			assert.ErrorIs(t, err, io.EOF)
+			_, err = r.Word()
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+// TestReadError tests that errors of the underlying reader are returned.
+func TestReadError(t *testing.T) {
+	failure := errors.New("disk on fire")
+	r := NewReaderSize(io.MultiReader(strings.NewReader("1 23"), iotest.ErrReader(failure)), 16)
+
+	v, err := r.Int()
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	_, err = r.Int()
+	assert.ErrorIs(t, err, failure)
+	_, err = r.Line()
+	assert.ErrorIs(t, err, failure)
+}
+
+// TestReaderAllocations tests that reading numbers and words does not
+// allocate once the buffer is set up.
+func TestReaderAllocations(t *testing.T) {
+	input := strings.Repeat("123456789 -42 word 2.5\n", 4096)
+	src := strings.NewReader(input)
+	r := NewReader(src)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		if _, err := r.Int(); err == io.EOF {
+			src.Reset(input)
+			r = NewReader(src)
+			return
+		}
+		r.Int64()
+		r.Word()
+		r.Float64()
+	})
+	assert.Less(t, allocs, 0.01)
+}
@@ -0,0 +1,122 @@
+package fastio
+
+import (
+	"io"
+	"strconv"
+)
+
+// maxNumberLength is the room kept free in the buffer before formatting a
+// number, enough for any int64, uint64 or shortest float64.
+const maxNumberLength = 32
+
+// Writer buffers output to an io.Writer. Like bufio.Writer it remembers the
+// first write error, after which all methods do nothing; Flush reports it.
+// Call Flush when done.
+type Writer struct {
+	wr  io.Writer
+	buf []byte
+	err error
+}
+
+// NewWriter returns a Writer with a 64 KiB buffer.
+func NewWriter(wr io.Writer) *Writer {
+	return NewWriterSize(wr, defaultBufferSize)
+}
+
+// NewWriterSize returns a Writer with a buffer of at least size bytes.
+func NewWriterSize(wr io.Writer, size int) *Writer {
+	return &Writer{wr: wr, buf: make([]byte, 0, max(size, 2*maxNumberLength))}
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buf) > 0 {
+		_, w.err = w.wr.Write(w.buf)
+		w.buf = w.buf[:0]
+	}
+	return w.err
+}
+
+// reserve flushes the buffer unless n more bytes fit. It returns false after
+// an error.
+func (w *Writer) reserve(n int) bool {
+	if cap(w.buf)-len(w.buf) < n {
+		w.Flush()
+	}
+	return w.err == nil
+}
+
+// Write writes p, so that a Writer can be used with fmt.Fprintf. It returns
+// the sticky error, if any.
+func (w *Writer) Write(p []byte) (int, error) {
+	if !w.reserve(len(p)) {
+		return 0, w.err
+	}
+	if len(p) > cap(w.buf) {
+		return w.wr.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// WriteString writes s.
+func (w *Writer) WriteString(s string) (int, error) {
+	if !w.reserve(len(s)) {
+		return 0, w.err
+	}
+	if len(s) > cap(w.buf) {
+		return io.WriteString(w.wr, s)
+	}
+	w.buf = append(w.buf, s...)
+	return len(s), nil
+}
+
+// WriteByte writes c.
+func (w *Writer) WriteByte(c byte) error {
+	if !w.reserve(1) {
+		return w.err
+	}
+	w.buf = append(w.buf, c)
+	return nil
+}
+
+// WriteInt writes v in decimal.
+func (w *Writer) WriteInt(v int) {
+	w.WriteInt64(int64(v))
+}
+
+// WriteInt64 writes v in decimal.
+func (w *Writer) WriteInt64(v int64) {
+	if w.reserve(maxNumberLength) {
+		w.buf = strconv.AppendInt(w.buf, v, 10)
+	}
+}
+
+// WriteUint64 writes v in decimal.
+func (w *Writer) WriteUint64(v uint64) {
+	if w.reserve(maxNumberLength) {
+		w.buf = strconv.AppendUint(w.buf, v, 10)
+	}
+}
+
+// WriteFloat64 writes v with prec digits after the decimal point, or in the
+// shortest form that reads back exactly when prec is negative.
+func (w *Writer) WriteFloat64(v float64, prec int) {
+	if w.reserve(maxNumberLength + max(prec, 0)) {
+		w.buf = strconv.AppendFloat(w.buf, v, 'f', prec, 64)
+	}
+}
+
+// WriteInts writes vs separated by sep and followed by a newline.
+func (w *Writer) WriteInts(vs []int, sep byte) {
+	for i, v := range vs {
+		if i > 0 {
+			w.WriteByte(sep)
+		}
+		w.WriteInt(v)
+	}
+	w.WriteByte('\n')
+}
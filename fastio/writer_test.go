@@ -0,0 +1,83 @@
+package fastio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriter tests the formatting methods, with buffers large and small.
+func TestWriter(t *testing.T) {
+	expected := "-9223372036854775808 18446744073709551615 42\n" +
+		"3.14 0.1 2.000\n" +
+		"1,2,3\n" +
+		"formatted 7\n" +
+		strings.Repeat("y", 300) + "\n"
+
+	for _, size := range []int{1, 64, 4096} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			var out strings.Builder
+			w := NewWriterSize(&out, size)
+			w.WriteInt64(math.MinInt64)
+			w.WriteByte(' ')
+			w.WriteUint64(math.MaxUint64)
+			w.WriteByte(' ')
+			w.WriteInt(42)
+			w.WriteByte('\n')
+			w.WriteFloat64(3.14159, 2)
+			w.WriteByte(' ')
+			w.WriteFloat64(0.1, -1)
+			w.WriteByte(' ')
+			w.WriteFloat64(2, 3)
+			w.WriteByte('\n')
+			w.WriteInts([]int{1, 2, 3}, ',')
+			fmt.Fprintf(w, "formatted %d\n", 7)
+			w.WriteString(strings.Repeat("y", 300))
+			w.Write([]byte("\n"))
+			require.NoError(t, w.Flush())
+			assert.Equal(t, expected, out.String())
+		})
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{ err error }
+
+func (f failingWriter) Write([]byte) (int, error) { return 0, f.err }
+
+// TestWriterError tests that the first write error sticks.
+func TestWriterError(t *testing.T) {
+	failure := errors.New("pipe closed")
+	w := NewWriterSize(failingWriter{failure}, 64)
+	for range 100 {
+		w.WriteInt(123456789)
+	}
+	assert.ErrorIs(t, w.Flush(), failure)
+	assert.ErrorIs(t, w.WriteByte('x'), failure)
+	_, err := w.WriteString("x")
+	assert.ErrorIs(t, err, failure)
+}
+
+// TestWriterAllocations tests that formatting numbers does not allocate.
+func TestWriterAllocations(t *testing.T) {
+	w := NewWriter(discard{})
+	allocs := testing.AllocsPerRun(1000, func() {
+		w.WriteInt(-123456789)
+		w.WriteByte(' ')
+		w.WriteUint64(987654321)
+		w.WriteFloat64(2.5, 3)
+		w.WriteByte('\n')
+	})
+	assert.Zero(t, allocs)
+}
+
+// discard is io.Discard without the ReaderFrom fast path, so that Flush does
+// real writes.
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
@@ -0,0 +1,105 @@
+package gen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Shape is the arrangement of the values of an array made by Ints.
+type Shape int
+
+const (
+	Uniform      Shape = iota // independent uniform values
+	Sorted                    // uniform values in non-decreasing order
+	Reversed                  // uniform values in non-increasing order
+	NearlySorted              // sorted, then about one pair in twenty swapped
+	FewUnique                 // values drawn from at most eight distinct ones
+)
+
+// fewUnique is the most distinct values of a FewUnique array.
+const fewUnique = 8
+
+func (s Shape) String() string {
+	switch s {
+	case Uniform:
+		return "uniform"
+	case Sorted:
+		return "sorted"
+	case Reversed:
+		return "reversed"
+	case NearlySorted:
+		return "nearly sorted"
+	case FewUnique:
+		return "few unique"
+	}
+	return fmt.Sprintf("Shape(%d)", int(s))
+}
+
+// Ints returns n integers in [lo, hi] arranged as shape. It panics if lo > hi
+// and n > 0, or if shape is unknown.
+func (g *Generator) Ints(n, lo, hi int, shape Shape) []int {
+	a := make([]int, n)
+	if n == 0 {
+		return a
+	}
+
+	if shape == FewUnique {
+		values := make([]int, 1+g.rng.IntN(fewUnique))
+		for i := range values {
+			values[i] = g.Int(lo, hi)
+		}
+		for i := range a {
+			a[i] = values[g.rng.IntN(len(values))]
+		}
+		return a
+	}
+
+	for i := range a {
+		a[i] = g.Int(lo, hi)
+	}
+	switch shape {
+	case Uniform:
+	case Sorted:
+		slices.Sort(a)
+	case Reversed:
+		slices.Sort(a)
+		slices.Reverse(a)
+	case NearlySorted:
+		slices.Sort(a)
+		for range n/20 + 1 {
+			i, j := g.rng.IntN(n), g.rng.IntN(n)
+			a[i], a[j] = a[j], a[i]
+		}
+	default:
+		panic(fmt.Sprintf("gen: unknown shape %d", int(shape)))
+	}
+	return a
+}
+
+// Alphabets for String.
+const (
+	Binary       = "01"
+	Digits       = "0123456789"
+	Lowercase    = "abcdefghijklmnopqrstuvwxyz"
+	Uppercase    = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Alphanumeric = Digits + Uppercase + Lowercase
+)
+
+// String returns n characters drawn uniformly from the characters of
+// alphabet. It panics if alphabet is empty and n > 0.
+func (g *Generator) String(n int, alphabet string) string {
+	if n == 0 {
+		return ""
+	}
+	letters := []rune(alphabet)
+	if len(letters) == 0 {
+		panic("gen: empty alphabet")
+	}
+	var b strings.Builder
+	b.Grow(n)
+	for range n {
+		b.WriteRune(letters[g.rng.IntN(len(letters))])
+	}
+	return b.String()
+}
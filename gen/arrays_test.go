@@ -0,0 +1,81 @@
+package gen
+
+import (
+	"slices"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInts checks the range and arrangement of each shape of array.
+func TestInts(t *testing.T) {
+	testCases := []struct {
+		shape Shape
+		check func(t *testing.T, a []int)
+	}{
+		{Uniform, func(t *testing.T, a []int) {
+			assert.False(t, slices.IsSorted(a))
+		}},
+		{Sorted, func(t *testing.T, a []int) {
+			assert.True(t, slices.IsSorted(a))
+		}},
+		{Reversed, func(t *testing.T, a []int) {
+			assert.True(t, slices.IsSortedFunc(a, func(x, y int) int { return y - x }))
+		}},
+		{NearlySorted, func(t *testing.T, a []int) {
+			// Each swap breaks the order in at most four places.
+			descents := 0
+			for i := 1; i < len(a); i++ {
+				if a[i-1] > a[i] {
+					descents++
+				}
+			}
+			assert.LessOrEqual(t, descents, 4*(len(a)/20+1))
+		}},
+		{FewUnique, func(t *testing.T, a []int) {
+			distinct := slices.Compact(slices.Sorted(slices.Values(a)))
+			assert.LessOrEqual(t, len(distinct), fewUnique)
+		}},
+	}
+
+	g := New(1)
+	for _, tc := range testCases {
+		t.Run(tc.shape.String(), func(t *testing.T) {
+			a := g.Ints(1000, -50, 1e6, tc.shape)
+			assert.Len(t, a, 1000)
+			for _, v := range a {
+				assert.True(t, -50 <= v && v <= 1e6, "Expected: -50 <= %d <= 1e6", v)
+			}
+			tc.check(t, a)
+			assert.Empty(t, g.Ints(0, 1, 0, tc.shape))
+		})
+	}
+	assert.PanicsWithValue(t, "gen: unknown shape 9", func() { g.Ints(3, 0, 1, 9) })
+}
+
+// TestString checks that strings are drawn from their alphabet, counted in
+// characters rather than bytes.
+func TestString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		alphabet string
+	}{
+		{"binary", Binary},
+		{"alphanumeric", Alphanumeric},
+		{"greek", "αβγδ"},
+	}
+
+	g := New(1)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := g.String(200, tc.alphabet)
+			assert.Equal(t, 200, utf8.RuneCountInString(s))
+			for _, r := range s {
+				assert.Contains(t, tc.alphabet, string(r))
+			}
+		})
+	}
+	assert.Empty(t, g.String(0, ""))
+	assert.PanicsWithValue(t, "gen: empty alphabet", func() { g.String(1, "") })
+}
@@ -0,0 +1,89 @@
+// Package gen generates random test data: numbers with arithmetic properties,
+// arrays of a chosen shape, strings, trees and graphs. A Generator is seeded,
+// so the same seed always produces the same data, and the data can be written
+// as test files for package judge:
+//
+//	g := gen.New(42)
+//	n, _ := g.Semiprime(1e12, 1e13)
+//	tree := g.Weigh(g.Tree(100_000), 1, 1e9)
+//	gen.WriteGraph(os.Stdout, tree)
+package gen
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Generator produces random test data from a seed. It is not safe for
+// concurrent use.
+type Generator struct {
+	rng *rand.Rand
+}
+
+// New returns a generator seeded with seed.
+func New(seed uint64) *Generator {
+	return newStream(seed, 0)
+}
+
+// newStream returns a generator for one of several independent streams of
+// the same seed.
+func newStream(seed, stream uint64) *Generator {
+	return &Generator{rng: rand.New(rand.NewPCG(seed, stream))}
+}
+
+// Int returns a uniform integer in [lo, hi]. It panics if lo > hi.
+func (g *Generator) Int(lo, hi int) int {
+	if lo > hi {
+		panic("gen: empty range")
+	}
+	span := uint64(hi) - uint64(lo) // no overflow for any lo <= hi
+	if span == math.MaxUint64 {
+		return int(g.rng.Uint64())
+	}
+	return lo + int(g.rng.Uint64N(span+1))
+}
+
+// Permutation returns the numbers 1 to n in random order.
+func (g *Generator) Permutation(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i + 1
+	}
+	shuffle(g, p)
+	return p
+}
+
+// shuffle puts the elements of s in random order.
+func shuffle[T any](g *Generator, s []T) {
+	g.rng.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+}
+
+// sample returns k distinct integers of [0, n) that are not in exclude, in
+// random order. Every element of exclude must be in [0, n), and at least k
+// integers must remain.
+func (g *Generator) sample(n, k int, exclude map[int]bool) []int {
+	free := n - len(exclude)
+	if 2*k > free {
+		// Rejection would be slow with most candidates taken: list them all
+		// instead, which costs at most 2k plus the excluded ones.
+		all := make([]int, 0, free)
+		for i := range n {
+			if !exclude[i] {
+				all = append(all, i)
+			}
+		}
+		shuffle(g, all)
+		return all[:k]
+	}
+
+	picked := make([]int, 0, k)
+	seen := make(map[int]bool, k)
+	for len(picked) < k {
+		i := g.rng.IntN(n)
+		if !exclude[i] && !seen[i] {
+			seen[i] = true
+			picked = append(picked, i)
+		}
+	}
+	return picked
+}
@@ -0,0 +1,90 @@
+package gen
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInt checks that integers stay in range, including the full range of
+// int.
+func TestInt(t *testing.T) {
+	testCases := []struct {
+		name   string
+		lo, hi int
+	}{
+		{"small", -3, 3},
+		{"single value", 5, 5},
+		{"full range", math.MinInt, math.MaxInt},
+	}
+
+	g := New(1)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for range 1000 {
+				v := g.Int(tc.lo, tc.hi)
+				assert.True(t, tc.lo <= v && v <= tc.hi, "Expected: %d <= %d <= %d", tc.lo, v, tc.hi)
+			}
+		})
+	}
+	assert.PanicsWithValue(t, "gen: empty range", func() { g.Int(2, 1) })
+}
+
+// TestPermutation checks that a permutation holds each of 1 to n once.
+func TestPermutation(t *testing.T) {
+	p := New(1).Permutation(100)
+	sorted := slices.Sorted(slices.Values(p))
+	for i, v := range sorted {
+		assert.Equal(t, i+1, v, "Expected: %v, Got: %v", i+1, v)
+	}
+	assert.NotEqual(t, sorted, p)
+	assert.Empty(t, New(1).Permutation(0))
+}
+
+// TestSample checks that samples are distinct and avoid the excluded values,
+// both when drawn by rejection and when most values are taken.
+func TestSample(t *testing.T) {
+	exclude := map[int]bool{0: true, 5: true, 7: true}
+	testCases := []struct {
+		name string
+		n, k int
+	}{
+		{"sparse", 1000, 10},
+		{"dense", 20, 15},
+		{"all", 10, 7},
+		{"none", 10, 0},
+	}
+
+	g := New(1)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := g.sample(tc.n, tc.k, exclude)
+			assert.Len(t, s, tc.k)
+			seen := map[int]bool{}
+			for _, v := range s {
+				assert.True(t, 0 <= v && v < tc.n && !exclude[v] && !seen[v], "Expected a new value of [0, %d) not excluded, Got: %d", tc.n, v)
+				seen[v] = true
+			}
+		})
+	}
+}
+
+// TestDeterministic checks that the same seed produces the same data, and a
+// different seed different data.
+func TestDeterministic(t *testing.T) {
+	data := func(seed uint64) []any {
+		g := New(seed)
+		p, _ := g.Prime(1, 1e15)
+		return []any{
+			p,
+			g.Ints(50, 0, 1e9, Uniform),
+			g.String(50, Lowercase),
+			g.Connected(30, 60),
+			g.Weigh(g.DAG(30, 60), -5, 5),
+		}
+	}
+	assert.Equal(t, data(7), data(7))
+	assert.NotEqual(t, data(7), data(8))
+}
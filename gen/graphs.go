@@ -0,0 +1,198 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+)
+
+// Edge is an edge of a Graph between U and V, or from U to V in a directed
+// graph, with weight W.
+type Edge struct {
+	U, V, W int
+}
+
+// Graph is a graph on the vertices 1 to N, the numbering most problem
+// statements use.
+type Graph struct {
+	N        int
+	Edges    []Edge
+	Directed bool // each edge goes from U to V
+	Weighted bool // the weights of the edges are part of the graph
+}
+
+// FromPrufer returns the tree on the vertices 1 to len(seq)+2 whose Prüfer
+// sequence is seq. Every labelled tree has exactly one such sequence, so a
+// uniform random sequence gives a uniform random tree. It panics if an
+// element of seq is not a vertex.
+func FromPrufer(seq []int) Graph {
+	n := len(seq) + 2
+	degree := make([]int, n+1)
+	for v := 1; v <= n; v++ {
+		degree[v] = 1
+	}
+	for _, v := range seq {
+		if v < 1 || v > n {
+			panic(fmt.Sprintf("gen: Prüfer sequence names vertex %d of %d", v, n))
+		}
+		degree[v]++
+	}
+
+	// Each element of seq is joined to the smallest remaining leaf. Leaves
+	// appear either ahead of next, found by the scan, or behind it when a
+	// vertex loses its last child, in which case it is taken at once.
+	edges := make([]Edge, 0, n-1)
+	next := 1
+	for degree[next] != 1 {
+		next++
+	}
+	leaf := next
+	for _, v := range seq {
+		edges = append(edges, Edge{U: leaf, V: v})
+		degree[v]--
+		if degree[v] == 1 && v < next {
+			leaf = v
+			continue
+		}
+		for next++; degree[next] != 1; next++ {
+		}
+		leaf = next
+	}
+	edges = append(edges, Edge{U: leaf, V: n})
+	return Graph{N: n, Edges: edges}
+}
+
+// Tree returns a uniform random tree on n vertices, made from a random Prüfer
+// sequence. It panics if n < 1.
+func (g *Generator) Tree(n int) Graph {
+	if n < 1 {
+		panic("gen: a tree needs a vertex")
+	}
+	if n == 1 {
+		return Graph{N: 1}
+	}
+	seq := make([]int, n-2)
+	for i := range seq {
+		seq[i] = 1 + g.rng.IntN(n)
+	}
+	tree := FromPrufer(seq)
+	g.shuffleEdges(tree)
+	return tree
+}
+
+// Connected returns a random connected simple graph with n vertices and m
+// edges: a random tree with m-n+1 more edges between distinct pairs of
+// vertices. It panics unless n-1 <= m <= n(n-1)/2.
+func (g *Generator) Connected(n, m int) Graph {
+	if n < 1 || m < n-1 || m > pairs(n) {
+		panic(fmt.Sprintf("gen: no connected simple graph has %d vertices and %d edges", n, m))
+	}
+	graph := g.Tree(n)
+	taken := make(map[int]bool, m)
+	for _, e := range graph.Edges {
+		taken[pairIndex(e.U, e.V)] = true
+	}
+	for _, k := range g.sample(pairs(n), m-(n-1), taken) {
+		u, v := pairAt(k)
+		graph.Edges = append(graph.Edges, Edge{U: u, V: v})
+	}
+	g.shuffleEdges(graph)
+	return graph
+}
+
+// DAG returns a random directed acyclic graph with n vertices and m distinct
+// edges. The edges follow a random order of the vertices, which is therefore
+// a topological order. It panics unless 0 <= m <= n(n-1)/2.
+func (g *Generator) DAG(n, m int) Graph {
+	if n < 0 || m < 0 || m > pairs(n) {
+		panic(fmt.Sprintf("gen: no directed acyclic graph has %d vertices and %d edges", n, m))
+	}
+	order := g.Permutation(n)
+	graph := Graph{N: n, Edges: make([]Edge, 0, m), Directed: true}
+	for _, k := range g.sample(pairs(n), m, nil) {
+		u, v := pairAt(k)
+		graph.Edges = append(graph.Edges, Edge{U: order[u-1], V: order[v-1]})
+	}
+	return graph
+}
+
+// Bipartite returns a random bipartite graph with m distinct edges between
+// the vertices 1 to left and the vertices left+1 to left+right. Relabel it
+// to mix the two parts. It panics unless 0 <= m <= left*right.
+func (g *Generator) Bipartite(left, right, m int) Graph {
+	if left < 0 || right < 0 || m < 0 || m > left*right {
+		panic(fmt.Sprintf("gen: no bipartite graph has parts of %d and %d vertices and %d edges", left, right, m))
+	}
+	graph := Graph{N: left + right, Edges: make([]Edge, 0, m)}
+	for _, k := range g.sample(left*right, m, nil) {
+		graph.Edges = append(graph.Edges, Edge{U: 1 + k/right, V: left + 1 + k%right})
+	}
+	return graph
+}
+
+// Weigh returns a copy of graph with uniform random weights in [lo, hi] on
+// its edges. It panics if lo > hi and the graph has edges.
+func (g *Generator) Weigh(graph Graph, lo, hi int) Graph {
+	graph.Edges = append([]Edge(nil), graph.Edges...)
+	for i := range graph.Edges {
+		graph.Edges[i].W = g.Int(lo, hi)
+	}
+	graph.Weighted = true
+	return graph
+}
+
+// Relabel returns a copy of graph with its vertices numbered in a random
+// order, so that nothing about a vertex can be told from its number.
+func (g *Generator) Relabel(graph Graph) Graph {
+	label := append([]int{0}, g.Permutation(graph.N)...)
+	edges := make([]Edge, len(graph.Edges))
+	for i, e := range graph.Edges {
+		edges[i] = Edge{U: label[e.U], V: label[e.V], W: e.W}
+	}
+	graph.Edges = edges
+	g.shuffleEdges(graph)
+	return graph
+}
+
+// shuffleEdges puts the edges of graph in random order, and the ends of each
+// undirected edge too, so that no pattern of the construction shows.
+func (g *Generator) shuffleEdges(graph Graph) {
+	shuffle(g, graph.Edges)
+	if graph.Directed {
+		return
+	}
+	for i := range graph.Edges {
+		if g.rng.IntN(2) == 0 {
+			e := &graph.Edges[i]
+			e.U, e.V = e.V, e.U
+		}
+	}
+}
+
+// pairs returns the number of unordered pairs of n vertices.
+func pairs(n int) int {
+	return n * (n - 1) / 2
+}
+
+// pairIndex numbers the pair of distinct vertices u and v from 0 to
+// pairs(n)-1: the pairs with larger vertex v come after all pairs of smaller
+// vertices, ordered by the other vertex.
+func pairIndex(u, v int) int {
+	if u > v {
+		u, v = v, u
+	}
+	return pairs(v-1) + u - 1
+}
+
+// pairAt returns the pair u < v numbered k by pairIndex.
+func pairAt(k int) (u, v int) {
+	// v-1 is the largest m with pairs(m) <= k, about the root of 2k; start
+	// from the float estimate and correct its rounding.
+	m := int(0.5 + math.Sqrt(2*float64(k)+0.25))
+	for pairs(m) > k {
+		m--
+	}
+	for pairs(m+1) <= k {
+		m++
+	}
+	return k - pairs(m) + 1, m + 1
+}
@@ -0,0 +1,260 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkSimple checks that graph has valid vertices, no loops and no repeated
+// edges, reading undirected edges both ways.
+func checkSimple(t *testing.T, graph Graph) {
+	t.Helper()
+	seen := map[[2]int]bool{}
+	for _, e := range graph.Edges {
+		assert.True(t, 1 <= e.U && e.U <= graph.N && 1 <= e.V && e.V <= graph.N, "Expected vertices of 1 to %d, Got: %v", graph.N, e)
+		assert.NotEqual(t, e.U, e.V, "Expected no loops, Got: %v", e)
+		key := [2]int{e.U, e.V}
+		if !graph.Directed {
+			key = [2]int{min(e.U, e.V), max(e.U, e.V)}
+		}
+		assert.False(t, seen[key], "Expected no repeated edges, Got: %v twice", e)
+		seen[key] = true
+	}
+}
+
+// components returns the number of connected components of graph, ignoring
+// the directions of its edges.
+func components(graph Graph) int {
+	parent := make([]int, graph.N+1)
+	for v := range parent {
+		parent[v] = v
+	}
+	var find func(v int) int
+	find = func(v int) int {
+		if parent[v] != v {
+			parent[v] = find(parent[v])
+		}
+		return parent[v]
+	}
+	count := graph.N
+	for _, e := range graph.Edges {
+		if u, v := find(e.U), find(e.V); u != v {
+			parent[u] = v
+			count--
+		}
+	}
+	return count
+}
+
+// acyclic reports whether the directed graph has no cycle, by removing
+// vertices without incoming edges until none are left.
+func acyclic(graph Graph) bool {
+	in := make([]int, graph.N+1)
+	out := make([][]int, graph.N+1)
+	for _, e := range graph.Edges {
+		in[e.V]++
+		out[e.U] = append(out[e.U], e.V)
+	}
+	var ready []int
+	for v := 1; v <= graph.N; v++ {
+		if in[v] == 0 {
+			ready = append(ready, v)
+		}
+	}
+	removed := 0
+	for len(ready) > 0 {
+		u := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		removed++
+		for _, v := range out[u] {
+			if in[v]--; in[v] == 0 {
+				ready = append(ready, v)
+			}
+		}
+	}
+	return removed == graph.N
+}
+
+// TestFromPrufer checks the decoding of known Prüfer sequences.
+func TestFromPrufer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		seq      []int
+		expected []Edge
+	}{
+		{"two vertices", nil, []Edge{{U: 1, V: 2}}},
+		{"star", []int{4, 4, 4, 4}, []Edge{{U: 1, V: 4}, {U: 2, V: 4}, {U: 3, V: 4}, {U: 5, V: 4}, {U: 4, V: 6}}},
+		{"path", []int{2, 3, 4}, []Edge{{U: 1, V: 2}, {U: 2, V: 3}, {U: 3, V: 4}, {U: 4, V: 5}}},
+		{"leaf freed behind the scan", []int{4, 4, 4, 5}, []Edge{{U: 1, V: 4}, {U: 2, V: 4}, {U: 3, V: 4}, {U: 4, V: 5}, {U: 5, V: 6}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := FromPrufer(tc.seq)
+			assert.Equal(t, len(tc.seq)+2, tree.N)
+			assert.Equal(t, tc.expected, tree.Edges, "Expected: %v, Got: %v", tc.expected, tree.Edges)
+		})
+	}
+	assert.PanicsWithValue(t, "gen: Prüfer sequence names vertex 7 of 4", func() { FromPrufer([]int{1, 7}) })
+}
+
+// TestTree checks that trees have n-1 edges and are connected.
+func TestTree(t *testing.T) {
+	g := New(1)
+	for _, n := range []int{1, 2, 3, 10, 1000} {
+		tree := g.Tree(n)
+		assert.Equal(t, n, tree.N)
+		assert.Len(t, tree.Edges, n-1)
+		checkSimple(t, tree)
+		assert.Equal(t, 1, components(tree), "Expected a connected tree on %d vertices", n)
+	}
+	assert.Panics(t, func() { g.Tree(0) })
+}
+
+// TestTreeUniform checks that each of the 16 labelled trees on 4 vertices
+// appears about equally often.
+func TestTreeUniform(t *testing.T) {
+	g := New(1)
+	counts := map[[3]Edge]int{}
+	const draws = 16_000
+	for range draws {
+		tree := g.Tree(4)
+		var key [3]Edge
+		for i, e := range tree.Edges {
+			key[i] = Edge{U: min(e.U, e.V), V: max(e.U, e.V)}
+		}
+		// Sort the three edges so that each tree has one key.
+		for i := range key {
+			for j := i + 1; j < len(key); j++ {
+				if key[j].U < key[i].U || key[j].U == key[i].U && key[j].V < key[i].V {
+					key[i], key[j] = key[j], key[i]
+				}
+			}
+		}
+		counts[key]++
+	}
+	assert.Len(t, counts, 16)
+	for tree, count := range counts {
+		assert.InDelta(t, draws/16, count, 150, "Expected about %d of %v, Got: %d", draws/16, tree, count)
+	}
+}
+
+// TestConnected checks connected graphs from sparse to complete.
+func TestConnected(t *testing.T) {
+	testCases := []struct {
+		name string
+		n, m int
+	}{
+		{"single vertex", 1, 0},
+		{"tree", 50, 49},
+		{"sparse", 1000, 3000},
+		{"dense", 30, 400},
+		{"complete", 30, 435},
+	}
+
+	g := New(1)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			graph := g.Connected(tc.n, tc.m)
+			assert.Equal(t, tc.n, graph.N)
+			assert.Len(t, graph.Edges, tc.m)
+			checkSimple(t, graph)
+			assert.Equal(t, 1, components(graph))
+		})
+	}
+	assert.PanicsWithValue(t, "gen: no connected simple graph has 5 vertices and 3 edges", func() { g.Connected(5, 3) })
+	assert.Panics(t, func() { g.Connected(5, 11) })
+}
+
+// TestDAG checks that directed acyclic graphs have no cycles.
+func TestDAG(t *testing.T) {
+	g := New(1)
+	for _, tc := range []struct{ n, m int }{{0, 0}, {1, 0}, {10, 45}, {200, 1000}} {
+		graph := g.DAG(tc.n, tc.m)
+		assert.True(t, graph.Directed)
+		assert.Len(t, graph.Edges, tc.m)
+		checkSimple(t, graph)
+		assert.True(t, acyclic(graph), "Expected no cycle in %v", graph)
+	}
+	assert.Panics(t, func() { g.DAG(3, 4) })
+}
+
+// TestBipartite checks that edges join the two parts, also after relabelling.
+func TestBipartite(t *testing.T) {
+	g := New(1)
+	graph := g.Bipartite(20, 30, 100)
+	assert.Equal(t, 50, graph.N)
+	assert.Len(t, graph.Edges, 100)
+	checkSimple(t, graph)
+	for _, e := range graph.Edges {
+		assert.True(t, e.U <= 20 && e.V > 20, "Expected an edge between the parts, Got: %v", e)
+	}
+	assert.Len(t, g.Bipartite(3, 4, 12).Edges, 12)
+	assert.Panics(t, func() { g.Bipartite(3, 4, 13) })
+
+	// Two-colour the relabelled graph: it must still succeed.
+	relabelled := g.Relabel(graph)
+	assert.NotEqual(t, graph.Edges, relabelled.Edges)
+	checkSimple(t, relabelled)
+	side := make([]int, relabelled.N+1)
+	adjacent := make([][]int, relabelled.N+1)
+	for _, e := range relabelled.Edges {
+		adjacent[e.U] = append(adjacent[e.U], e.V)
+		adjacent[e.V] = append(adjacent[e.V], e.U)
+	}
+	for start := 1; start <= relabelled.N; start++ {
+		if side[start] != 0 {
+			continue
+		}
+		side[start] = 1
+		queue := []int{start}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adjacent[u] {
+				if side[v] == 0 {
+					side[v] = -side[u]
+					queue = append(queue, v)
+				}
+				assert.NotEqual(t, side[u], side[v], "Expected edge %d-%d between the parts", u, v)
+			}
+		}
+	}
+}
+
+// TestWeigh checks that weights are in range and the input is not changed.
+func TestWeigh(t *testing.T) {
+	g := New(1)
+	tree := g.Tree(100)
+	weighted := g.Weigh(tree, -3, 3)
+	assert.True(t, weighted.Weighted)
+	assert.False(t, tree.Weighted)
+	for i, e := range weighted.Edges {
+		assert.Zero(t, tree.Edges[i].W)
+		assert.Equal(t, tree.Edges[i].U, e.U)
+		assert.True(t, -3 <= e.W && e.W <= 3, "Expected: -3 <= %d <= 3", e.W)
+	}
+}
+
+// TestPairIndex checks that pairIndex and pairAt number the pairs of
+// vertices one to one, including large indexes where the square root is
+// inexact.
+func TestPairIndex(t *testing.T) {
+	k := 0
+	for v := 2; v <= 60; v++ {
+		for u := 1; u < v; u++ {
+			assert.Equal(t, k, pairIndex(u, v))
+			assert.Equal(t, k, pairIndex(v, u))
+			gotU, gotV := pairAt(k)
+			assert.Equal(t, [2]int{u, v}, [2]int{gotU, gotV})
+			k++
+		}
+	}
+	for _, v := range []int{1 << 20, 3_000_000_000} {
+		for _, u := range []int{1, v / 2, v - 1} {
+			gotU, gotV := pairAt(pairIndex(u, v))
+			assert.Equal(t, [2]int{u, v}, [2]int{gotU, gotV})
+		}
+	}
+}
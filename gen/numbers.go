@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrEmpty is returned when a range holds no number of the kind asked for.
+var ErrEmpty = errors.New("gen: no such number in range")
+
+// draws is the number of uniform draws made before a range is scanned.
+const draws = 64
+
+// Prime returns a random prime in [lo, hi], or ErrEmpty if there is none.
+func (g *Generator) Prime(lo, hi int) (int, error) {
+	return g.find(lo, hi, isPrime)
+}
+
+// Composite returns a random composite number, one with a divisor other than
+// 1 and itself, in [lo, hi], or ErrEmpty if there is none.
+func (g *Generator) Composite(lo, hi int) (int, error) {
+	return g.find(lo, hi, isComposite)
+}
+
+// Semiprime returns a random product of exactly two primes, not necessarily
+// distinct, in [lo, hi], or ErrEmpty if there is none.
+func (g *Generator) Semiprime(lo, hi int) (int, error) {
+	return g.find(lo, hi, isSemiprime)
+}
+
+// find returns a random number in [lo, hi] for which ok holds. It first draws
+// uniformly, which picks every such number with the same chance, and only if
+// they are too rare for that does it scan from a random start.
+func (g *Generator) find(lo, hi int, ok func(int) bool) (int, error) {
+	if lo > hi {
+		return 0, ErrEmpty
+	}
+	for range draws {
+		if n := g.Int(lo, hi); ok(n) {
+			return n, nil
+		}
+	}
+
+	start := g.Int(lo, hi)
+	for n := start; ; {
+		if ok(n) {
+			return n, nil
+		}
+		if n == hi {
+			n = lo
+		} else {
+			n++
+		}
+		if n == start {
+			return 0, ErrEmpty
+		}
+	}
+}
+
+// isPrime reports whether n is prime. ProbablyPrime(0) is exact below 2⁶⁴,
+// where trial division would take too long.
+func isPrime(n int) bool {
+	return n >= 2 && big.NewInt(int64(n)).ProbablyPrime(0)
+}
+
+// isComposite reports whether n is composite.
+func isComposite(n int) bool {
+	return n >= 4 && !isPrime(n)
+}
+
+// isSemiprime reports whether n is the product of two primes. A number with
+// no prime factor up to its cube root has at most two, so only that far
+// needs searching.
+func isSemiprime(n int) bool {
+	if n < 4 {
+		return false
+	}
+	for p := 2; p <= n/p/p; p++ {
+		if n%p == 0 {
+			return isPrime(n / p)
+		}
+	}
+	return !isPrime(n)
+}
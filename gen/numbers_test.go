@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+)
+
+// TestNumbers checks the generated numbers against their prime factors, in
+// ranges where they are common and where they are rare.
+func TestNumbers(t *testing.T) {
+	generators := []struct {
+		name    string
+		gen     func(g *Generator, lo, hi int) (int, error)
+		factors int // number of prime factors with multiplicity; 0 for two or more
+	}{
+		{"prime", (*Generator).Prime, 1},
+		{"composite", (*Generator).Composite, 0},
+		{"semiprime", (*Generator).Semiprime, 2},
+	}
+	ranges := []struct {
+		name   string
+		lo, hi int
+	}{
+		{"small", 1, 100},
+		{"around a prime gap", 1327, 1361},
+		{"large", 1e15, 1e15 + 1e6},
+		{"near the top of int", 1<<62 - 1e6, 1<<62 + 1e6},
+	}
+
+	g := New(1)
+	for _, gen := range generators {
+		for _, r := range ranges {
+			t.Run(gen.name+" "+r.name, func(t *testing.T) {
+				for range 20 {
+					n, err := gen.gen(g, r.lo, r.hi)
+					require.NoError(t, err)
+					assert.True(t, r.lo <= n && n <= r.hi, "Expected: %d <= %d <= %d", r.lo, n, r.hi)
+
+					got := len(prime_factors.PrimeFactorsBig(big.NewInt(int64(n))))
+					if gen.factors == 0 {
+						assert.GreaterOrEqual(t, got, 2, "Expected: %d to be composite", n)
+					} else {
+						assert.Equal(t, gen.factors, got, "Expected: %d prime factors of %d, Got: %d", gen.factors, n, got)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestNumbersEmpty checks ranges that hold no number of the kind asked for.
+func TestNumbersEmpty(t *testing.T) {
+	g := New(1)
+	testCases := []struct {
+		name string
+		gen  func(lo, hi int) (int, error)
+		lo   int
+		hi   int
+	}{
+		{"no prime between 24 and 28", g.Prime, 24, 28},
+		{"no prime below 2", g.Prime, -10, 1},
+		{"no composite below 4", g.Composite, 0, 3},
+		{"no semiprime in 7 and 8", g.Semiprime, 7, 8},
+		{"reversed range", g.Prime, 10, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.gen(tc.lo, tc.hi)
+			assert.ErrorIs(t, err, ErrEmpty)
+		})
+	}
+
+	n, err := g.Prime(1361, 1361)
+	require.NoError(t, err)
+	assert.Equal(t, 1361, n)
+}
@@ -0,0 +1,67 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ignoreAnt/go-dsa/fastio"
+	"github.com/ignoreAnt/go-dsa/judge"
+)
+
+// WriteInts writes a in the usual input format: its length on one line and
+// its elements, separated by spaces, on the next.
+func WriteInts(w io.Writer, a []int) error {
+	out := fastio.NewWriter(w)
+	out.WriteInt(len(a))
+	out.WriteByte('\n')
+	out.WriteInts(a, ' ')
+	return out.Flush()
+}
+
+// WriteGraph writes graph in the usual input format: a line with the numbers
+// of vertices and edges, then a line per edge with its ends and, if the graph
+// is weighted, its weight.
+func WriteGraph(w io.Writer, graph Graph) error {
+	out := fastio.NewWriter(w)
+	edge := make([]int, 0, 3)
+	out.WriteInts(append(edge, graph.N, len(graph.Edges)), ' ')
+	for _, e := range graph.Edges {
+		edge = append(edge[:0], e.U, e.V)
+		if graph.Weighted {
+			edge = append(edge, e.W)
+		}
+		out.WriteInts(edge, ' ')
+	}
+	return out.Flush()
+}
+
+// WriteTests writes count tests to dir, which is created if needed, in the
+// layout read by judge.LoadTests. Test i, from 1 to count, has the input that
+// input writes from a generator for stream i of seed, stored in i.in, and the
+// answer of the reference solution solve to it, stored in i.out.
+func WriteTests(dir string, count int, seed uint64, input func(g *Generator, w io.Writer) error, solve judge.Func) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i := 1; i <= count; i++ {
+		name := filepath.Join(dir, strconv.Itoa(i))
+		var in, out bytes.Buffer
+		if err := input(newStream(seed, uint64(i)), &in); err != nil {
+			return fmt.Errorf("gen: test %d: %w", i, err)
+		}
+		if err := solve(bytes.NewReader(in.Bytes()), &out); err != nil {
+			return fmt.Errorf("gen: test %d: reference solution: %w", i, err)
+		}
+		if err := os.WriteFile(name+".in", in.Bytes(), 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(name+".out", out.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
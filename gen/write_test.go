@@ -0,0 +1,90 @@
+package gen
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignoreAnt/go-dsa/judge"
+)
+
+// TestWriteInts checks the text format of arrays.
+func TestWriteInts(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, WriteInts(&out, []int{3, -1, 4}))
+	assert.Equal(t, "3\n3 -1 4\n", out.String())
+
+	out.Reset()
+	require.NoError(t, WriteInts(&out, nil))
+	assert.Equal(t, "0\n\n", out.String())
+}
+
+// TestWriteGraph checks the text format of graphs with and without weights.
+func TestWriteGraph(t *testing.T) {
+	graph := Graph{N: 3, Edges: []Edge{{U: 1, V: 2, W: 5}, {U: 3, V: 1, W: -2}}}
+	var out strings.Builder
+	require.NoError(t, WriteGraph(&out, graph))
+	assert.Equal(t, "3 2\n1 2\n3 1\n", out.String())
+
+	graph.Weighted = true
+	out.Reset()
+	require.NoError(t, WriteGraph(&out, graph))
+	assert.Equal(t, "3 2\n1 2 5\n3 1 -2\n", out.String())
+}
+
+// sum is a reference solution that adds up an array written by WriteInts.
+func sum(in io.Reader, out io.Writer) error {
+	r := bufio.NewReader(in)
+	var n, total int
+	fmt.Fscan(r, &n)
+	for range n {
+		var v int
+		if _, err := fmt.Fscan(r, &v); err != nil {
+			return err
+		}
+		total += v
+	}
+	_, err := fmt.Fprintln(out, total)
+	return err
+}
+
+// TestWriteTests checks that written tests load in package judge, are the
+// same for the same seed, and that the reference solution passes them.
+func TestWriteTests(t *testing.T) {
+	input := func(g *Generator, w io.Writer) error {
+		return WriteInts(w, g.Ints(g.Int(1, 100), -1e9, 1e9, Uniform))
+	}
+	dir := filepath.Join(t.TempDir(), "sum")
+	require.NoError(t, WriteTests(dir, 12, 5, input, sum))
+
+	tests, err := judge.LoadTests(dir)
+	require.NoError(t, err)
+	require.Len(t, tests, 12)
+	assert.Equal(t, "12", tests[11].Name)
+
+	results := judge.Run(context.Background(), tests, judge.Func(sum), judge.Options{})
+	assert.True(t, judge.AllAccepted(results))
+
+	again := t.TempDir()
+	require.NoError(t, WriteTests(again, 12, 5, input, sum))
+	for _, name := range []string{"1.in", "12.out"} {
+		first, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		second, err := os.ReadFile(filepath.Join(again, name))
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(second))
+	}
+
+	failing := func(io.Reader, io.Writer) error { return errors.New("no answer") }
+	err = WriteTests(t.TempDir(), 1, 5, input, failing)
+	assert.EqualError(t, err, "gen: test 1: reference solution: no answer")
+}
@@ -0,0 +1,89 @@
+package judge
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Checker compares the output of a solution with the expected output of a
+// test. It returns nil to accept the output, and otherwise an error saying
+// where it differs. input is the test input, for checkers of problems with
+// several correct answers.
+type Checker func(input, expected, actual []byte) error
+
+// Exact accepts output identical to the expected output, byte for byte.
+func Exact(input, expected, actual []byte) error {
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+	line := 1
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		if expected[i] != actual[i] {
+			break
+		}
+		if expected[i] == '\n' {
+			line++
+		}
+	}
+	return fmt.Errorf("output differs on line %d", line)
+}
+
+// Tokens accepts output with the same whitespace-separated tokens as the
+// expected output, however they are spaced or split into lines.
+func Tokens(input, expected, actual []byte) error {
+	want, got := bytes.Fields(expected), bytes.Fields(actual)
+	for i := range min(len(want), len(got)) {
+		if !bytes.Equal(want[i], got[i]) {
+			return fmt.Errorf("token %d: expected %q, got %q", i+1, want[i], got[i])
+		}
+	}
+	return tokenCount(len(want), len(got))
+}
+
+// tokenCount returns an error if the numbers of tokens differ.
+func tokenCount(want, got int) error {
+	if want != got {
+		return fmt.Errorf("expected %d tokens, got %d", want, got)
+	}
+	return nil
+}
+
+// Floats returns a checker like Tokens that accepts a number within
+// tolerance of the expected one, in absolute terms or relative to the
+// expected value, whichever is looser. An expected NaN or infinity needs the
+// same, and tokens of the expected output that are not numbers must match
+// exactly.
+func Floats(tolerance float64) Checker {
+	return func(input, expected, actual []byte) error {
+		want, got := bytes.Fields(expected), bytes.Fields(actual)
+		for i := range min(len(want), len(got)) {
+			w, errW := strconv.ParseFloat(string(want[i]), 64)
+			if errW != nil {
+				if !bytes.Equal(want[i], got[i]) {
+					return fmt.Errorf("token %d: expected %q, got %q", i+1, want[i], got[i])
+				}
+				continue
+			}
+			g, errG := strconv.ParseFloat(string(got[i]), 64)
+			if errG != nil {
+				return fmt.Errorf("token %d: expected a number near %s, got %q", i+1, want[i], got[i])
+			}
+			var ok bool
+			switch {
+			case math.IsNaN(w):
+				ok = math.IsNaN(g)
+			case math.IsInf(w, 0):
+				ok = g == w
+			default:
+				// False when g is a NaN or an infinity.
+				ok = math.Abs(w-g) <= tolerance*max(1, math.Abs(w))
+			}
+			if !ok {
+				return fmt.Errorf("token %d: expected %s within %g, got %s", i+1, want[i], tolerance, got[i])
+			}
+		}
+		return tokenCount(len(want), len(got))
+	}
+}
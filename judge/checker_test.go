@@ -0,0 +1,46 @@
+package judge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheckers tests the built-in checkers on matching and differing output.
+func TestCheckers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		checker  Checker
+		expected string
+		actual   string
+		err      string
+	}{
+		{"exact match", Exact, "1 2\n3\n", "1 2\n3\n", ""},
+		{"exact differs in spacing", Exact, "1 2\n3\n", "1 2\n3", "output differs on line 2"},
+		{"exact differs on line 2", Exact, "a\nb\n", "a\nc\n", "output differs on line 2"},
+		{"tokens ignore spacing", Tokens, "1 2\n3\n", "  1\n2 3", ""},
+		{"tokens differ", Tokens, "1 2 3", "1 5 3", `token 2: expected "2", got "5"`},
+		{"tokens missing", Tokens, "1 2 3", "1 2", "expected 3 tokens, got 2"},
+		{"tokens extra", Tokens, "1", "1 2", "expected 1 tokens, got 2"},
+		{"floats within absolute tolerance", Floats(1e-6), "0.333333", "0.3333334", ""},
+		{"floats within relative tolerance", Floats(1e-6), "1000000", "1000000.5", ""},
+		{"floats outside tolerance", Floats(1e-6), "0.5", "0.5001", "token 1: expected 0.5 within 1e-06, got 0.5001"},
+		{"floats compare words exactly", Floats(1e-6), "YES 1.5", "NO 1.5", `token 1: expected "YES", got "NO"`},
+		{"floats need a number", Floats(1e-6), "1.5", "x", `token 1: expected a number near 1.5, got "x"`},
+		{"floats reject NaN", Floats(1e-6), "1.5", "NaN", "token 1: expected 1.5 within 1e-06, got NaN"},
+		{"floats match infinity", Floats(1e-6), "inf -Inf", "+Inf -inf", ""},
+		{"floats reject a finite number for infinity", Floats(1e-6), "inf", "5", "token 1: expected inf within 1e-06, got 5"},
+		{"floats reject the opposite infinity", Floats(1e-6), "+Inf", "-Inf", "token 1: expected +Inf within 1e-06, got -Inf"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.checker(nil, []byte(tc.expected), []byte(tc.actual))
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
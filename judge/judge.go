@@ -0,0 +1,167 @@
+// Package judge runs a solution against a directory of tests, the way an
+// online judge does, and reports a verdict per test.
+//
+// A test directory holds pairs of files NN.in and NN.out: the input fed to
+// the solution and the output expected from it. The solution is either a Go
+// function run in this process, or a program run as a subprocess, which is
+// the only way to enforce a memory limit:
+//
+//	tests, err := judge.LoadTests("problems/two_sum")
+//	solution, cleanup, err := judge.Build(ctx, "./problems/two_sum/solution")
+//	defer cleanup()
+//	results := judge.Run(ctx, tests, solution, judge.Options{Checker: judge.Tokens})
+//	judge.WriteTable(os.Stdout, results)
+package judge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the outcome of one test.
+type Verdict string
+
+// The verdicts, with the abbreviations used by online judges.
+const (
+	Accepted            Verdict = "AC"
+	WrongAnswer         Verdict = "WA"
+	TimeLimitExceeded   Verdict = "TLE"
+	MemoryLimitExceeded Verdict = "MLE"
+	RuntimeError        Verdict = "RE"
+)
+
+// Test is one input file with its expected output.
+type Test struct {
+	Name     string // base name without extension, such as "01"
+	Input    string // path of the .in file
+	Expected string // path of the .out file
+}
+
+// LoadTests returns the tests in dir: every NN.in file with its NN.out file,
+// ordered by number. It is an error for an input to have no output file.
+func LoadTests(dir string) ([]Test, error) {
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.in"))
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("judge: no .in files in %s", dir)
+	}
+
+	tests := make([]Test, 0, len(inputs))
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".in")
+		expected := strings.TrimSuffix(input, ".in") + ".out"
+		if _, err := os.Stat(expected); err != nil {
+			return nil, fmt.Errorf("judge: test %s has no expected output: %w", name, err)
+		}
+		tests = append(tests, Test{Name: name, Input: input, Expected: expected})
+	}
+
+	// Order numerically, so that 10 follows 9, then by name.
+	sort.Slice(tests, func(i, j int) bool {
+		a, errA := strconv.Atoi(tests[i].Name)
+		b, errB := strconv.Atoi(tests[j].Name)
+		if errA == nil && errB == nil && a != b {
+			return a < b
+		}
+		if (errA == nil) != (errB == nil) {
+			return errA == nil
+		}
+		return tests[i].Name < tests[j].Name
+	})
+	return tests, nil
+}
+
+// Options control a run. Zero fields take the defaults listed with them.
+type Options struct {
+	Checker     Checker       // compares the output with the expected one; Exact
+	TimeLimit   time.Duration // wall-clock limit per test; 2s
+	MemoryLimit int64         // peak resident memory per test in bytes, subprocesses only; 256 MiB
+}
+
+// withDefaults returns o with the zero fields set to their defaults.
+func (o Options) withDefaults() Options {
+	if o.Checker == nil {
+		o.Checker = Exact
+	}
+	if o.TimeLimit <= 0 {
+		o.TimeLimit = 2 * time.Second
+	}
+	if o.MemoryLimit <= 0 {
+		o.MemoryLimit = 256 << 20
+	}
+	return o
+}
+
+// Result is the outcome of one test.
+type Result struct {
+	Test    Test
+	Verdict Verdict
+	Time    time.Duration // wall-clock time of the run
+	Memory  int64         // peak resident memory in bytes, 0 when unknown
+	Detail  string        // why the test failed, empty when accepted
+}
+
+// Run runs solution on each test in order and returns the results. A test
+// whose files cannot be read is reported as a runtime error of that test.
+func Run(ctx context.Context, tests []Test, solution Solution, opts Options) []Result {
+	opts = opts.withDefaults()
+
+	results := make([]Result, len(tests))
+	for i, test := range tests {
+		results[i] = runTest(ctx, test, solution, opts)
+	}
+	return results
+}
+
+// runTest runs solution on one test and judges its output.
+func runTest(ctx context.Context, test Test, solution Solution, opts Options) Result {
+	result := Result{Test: test}
+	input, err := os.ReadFile(test.Input)
+	if err == nil {
+		var expected []byte
+		if expected, err = os.ReadFile(test.Expected); err == nil {
+			return judge(ctx, result, input, expected, solution, opts)
+		}
+	}
+	result.Verdict = RuntimeError
+	result.Detail = err.Error()
+	return result
+}
+
+// judge runs solution on input and compares its output with expected.
+func judge(ctx context.Context, result Result, input, expected []byte, solution Solution, opts Options) Result {
+	ctx, cancel := context.WithTimeout(ctx, opts.TimeLimit)
+	defer cancel()
+
+	run := solution.run(ctx, input, opts.MemoryLimit)
+	result.Time, result.Memory = run.time, run.memory
+
+	switch {
+	case errors.Is(run.err, errMemoryLimit):
+		result.Verdict = MemoryLimitExceeded
+		result.Detail = fmt.Sprintf("used more than %s", formatBytes(opts.MemoryLimit))
+	case ctx.Err() != nil || run.time > opts.TimeLimit:
+		result.Verdict = TimeLimitExceeded
+		result.Detail = fmt.Sprintf("ran longer than %v", opts.TimeLimit)
+	case run.err != nil:
+		result.Verdict = RuntimeError
+		result.Detail = run.err.Error()
+	default:
+		if err := opts.Checker(input, expected, run.output); err != nil {
+			result.Verdict = WrongAnswer
+			result.Detail = err.Error()
+		} else {
+			result.Verdict = Accepted
+		}
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package judge
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadTests tests that tests are found in numeric order and that an
+// input without output is an error.
+func TestLoadTests(t *testing.T) {
+	tests, err := LoadTests("testdata/sum")
+	require.NoError(t, err)
+	var names []string
+	for _, test := range tests {
+		names = append(names, test.Name)
+	}
+	assert.Equal(t, []string{"1", "2", "10"}, names)
+	assert.Equal(t, filepath.Join("testdata", "sum", "10.out"), tests[2].Expected)
+
+	dir := t.TempDir()
+	_, err = LoadTests(dir)
+	assert.ErrorContains(t, err, "no .in files")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "01.in"), []byte("1\n"), 0o644))
+	_, err = LoadTests(dir)
+	assert.ErrorContains(t, err, "test 01 has no expected output")
+}
+
+// sum is a correct in-process solution of the testdata/sum problem.
+func sum(in io.Reader, out io.Writer) error {
+	r := bufio.NewReader(in)
+	var n, total int
+	fmt.Fscan(r, &n)
+	for range n {
+		var v int
+		if _, err := fmt.Fscan(r, &v); err != nil {
+			return err
+		}
+		total += v
+	}
+	_, err := fmt.Fprintln(out, total)
+	return err
+}
+
+// TestRunFunc tests the verdicts of in-process solutions.
+func TestRunFunc(t *testing.T) {
+	tests, err := LoadTests("testdata/sum")
+	require.NoError(t, err)
+	opts := Options{TimeLimit: 200 * time.Millisecond}
+
+	testCases := []struct {
+		name     string
+		solution Func
+		expected Verdict
+		detail   string
+	}{
+		{"accepted", sum, Accepted, ""},
+		{"wrong answer", func(in io.Reader, out io.Writer) error {
+			_, err := fmt.Fprintln(out, 42)
+			return err
+		}, WrongAnswer, "output differs on line 1"},
+		{"error", func(io.Reader, io.Writer) error {
+			return errors.New("bad input")
+		}, RuntimeError, "bad input"},
+		{"panic", func(io.Reader, io.Writer) error {
+			var a []int
+			_ = a[3]
+			return nil
+		}, RuntimeError, "panic: runtime error: index out of range [3] with length 0"},
+		{"time limit", func(io.Reader, io.Writer) error {
+			time.Sleep(time.Second)
+			return nil
+		}, TimeLimitExceeded, "ran longer than 200ms"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := Run(context.Background(), tests, tc.solution, opts)
+			require.Len(t, results, 3)
+			for _, r := range results {
+				assert.Equal(t, tc.expected, r.Verdict, "Expected: %v, Got: %v", tc.expected, r.Verdict)
+				assert.Equal(t, tc.detail, r.Detail)
+			}
+		})
+	}
+}
+
+// TestRunCommand tests the verdicts of a compiled solution, including the
+// memory limit where it is enforced.
+func TestRunCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a program")
+	}
+	ctx := context.Background()
+	solution, cleanup, err := Build(ctx, "./testdata/solution")
+	require.NoError(t, err)
+	defer cleanup()
+
+	tests, err := LoadTests("testdata/sum")
+	require.NoError(t, err)
+	opts := Options{TimeLimit: time.Second, MemoryLimit: 64 << 20}
+
+	testCases := []struct {
+		mode     string
+		expected Verdict
+		detail   string
+	}{
+		{"sum", Accepted, ""},
+		{"wrong", WrongAnswer, "output differs on line 1"},
+		{"sleep", TimeLimitExceeded, "ran longer than 1s"},
+		{"crash", RuntimeError, "exit status 2: panic: index out of range"},
+		{"hog", MemoryLimitExceeded, "used more than 64 MiB"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			if tc.mode == "hog" && runtime.GOOS != "linux" {
+				t.Skip("memory limits are enforced on Linux only")
+			}
+			solution := Command{Path: solution.Path, Args: []string{tc.mode}}
+			results := Run(ctx, tests[:1], solution, opts)
+			require.Len(t, results, 1)
+			assert.Equal(t, tc.expected, results[0].Verdict, "Expected: %v, Got: %v", tc.expected, results[0].Verdict)
+			assert.Equal(t, tc.detail, results[0].Detail)
+			if tc.expected == Accepted && runtime.GOOS == "linux" {
+				assert.Positive(t, results[0].Memory)
+			}
+		})
+	}
+}
+
+// TestBuildError tests that a compile error is reported with the compiler's
+// output.
+func TestBuildError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(file, []byte("package main\n\nfunc main() { undefined() }\n"), 0o644))
+
+	_, _, err := Build(context.Background(), file)
+	assert.ErrorContains(t, err, "undefined: undefined")
+}
+
+// TestWriteTable tests the layout of the verdict table.
+func TestWriteTable(t *testing.T) {
+	results := []Result{
+		{Test: Test{Name: "1"}, Verdict: Accepted, Time: 12 * time.Millisecond, Memory: 3 << 20},
+		{Test: Test{Name: "2"}, Verdict: WrongAnswer, Time: 8 * time.Millisecond, Detail: "token 1: expected \"6\", got \"7\""},
+	}
+	var out strings.Builder
+	require.NoError(t, WriteTable(&out, results))
+
+	expected := "TEST  VERDICT  TIME  MEMORY   DETAIL\n" +
+		"1     AC       12ms  3.0 MiB  \n" +
+		"2     WA       8ms   -        token 1: expected \"6\", got \"7\"\n" +
+		"\n1/2 accepted\n"
+	assert.Equal(t, expected, out.String())
+	assert.False(t, AllAccepted(results))
+	assert.True(t, AllAccepted(results[:1]))
+}
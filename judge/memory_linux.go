@@ -0,0 +1,66 @@
+package judge
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// memoryPollInterval is how often watchMemory samples a process.
+const memoryPollInterval = 5 * time.Millisecond
+
+// watchMemory samples the resident memory of p and kills it once it exceeds
+// limit. The returned function stops the sampling and reports whether p was
+// killed; call it after p has exited.
+func watchMemory(p *os.Process, limit int64) func() bool {
+	var exceeded atomic.Bool
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	statm := "/proc/" + strconv.Itoa(p.Pid) + "/statm"
+	pageSize := int64(os.Getpagesize())
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(memoryPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			// statm holds sizes in pages; the second field is the resident set.
+			data, err := os.ReadFile(statm)
+			if err != nil {
+				return
+			}
+			fields := bytes.Fields(data)
+			if len(fields) < 2 {
+				continue
+			}
+			pages, err := strconv.ParseInt(string(fields[1]), 10, 64)
+			if err == nil && pages*pageSize > limit {
+				exceeded.Store(true)
+				p.Kill()
+				return
+			}
+		}
+	}()
+
+	return func() bool {
+		close(stop)
+		<-done
+		return exceeded.Load()
+	}
+}
+
+// peakMemory returns the peak resident memory of an exited process in bytes.
+func peakMemory(state *os.ProcessState) int64 {
+	if usage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		return usage.Maxrss * 1024 // kilobytes on Linux
+	}
+	return 0
+}
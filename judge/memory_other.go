@@ -0,0 +1,16 @@
+//go:build !linux
+
+package judge
+
+import "os"
+
+// watchMemory does nothing outside Linux, where the resident memory of a
+// process is not sampled; the returned function reports false.
+func watchMemory(p *os.Process, limit int64) func() bool {
+	return func() bool { return false }
+}
+
+// peakMemory returns 0, meaning unknown, outside Linux.
+func peakMemory(state *os.ProcessState) int64 {
+	return 0
+}
@@ -0,0 +1,148 @@
+package judge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// errMemoryLimit is the error of a run killed for exceeding the memory limit.
+var errMemoryLimit = errors.New("judge: memory limit exceeded")
+
+// execution is the outcome of running a solution on one input.
+type execution struct {
+	output []byte
+	err    error // failure of the solution itself
+	time   time.Duration
+	memory int64 // peak resident memory in bytes, 0 when unknown
+}
+
+// Solution is a program under test: a Func or a Command.
+type Solution interface {
+	// run runs the solution on input until it exits or ctx is done.
+	run(ctx context.Context, input []byte, memoryLimit int64) execution
+}
+
+// Func is a solution run in this process: it reads the test input from in
+// and writes its answer to out. A returned error or a panic is a runtime
+// error. The time limit is enforced by abandoning the call, which keeps
+// running in the background, and the memory limit is not enforced; use a
+// Command for either to be strict.
+type Func func(in io.Reader, out io.Writer) error
+
+func (f Func) run(ctx context.Context, input []byte, memoryLimit int64) execution {
+	type outcome struct {
+		output []byte
+		err    error
+	}
+	done := make(chan outcome, 1)
+
+	start := time.Now()
+	go func() {
+		var out bytes.Buffer
+		defer func() {
+			if p := recover(); p != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", p)}
+			}
+		}()
+		err := f(bytes.NewReader(input), &out)
+		done <- outcome{output: out.Bytes(), err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return execution{output: o.output, err: o.err, time: time.Since(start)}
+	case <-ctx.Done():
+		return execution{err: ctx.Err(), time: time.Since(start)}
+	}
+}
+
+// Command is a solution run as a subprocess, fed the test input on standard
+// input. Its standard output is the answer; a non-zero exit status is a
+// runtime error, reported with the first line of its standard error, such as
+// the message of a panic. The process is killed when it runs out of time
+// and, on Linux, as soon as its resident memory exceeds the limit.
+type Command struct {
+	Path string   // program to run
+	Args []string // arguments after the program name
+	Dir  string   // working directory; the current one if empty
+}
+
+func (c Command) run(ctx context.Context, input []byte, memoryLimit int64) execution {
+	cmd := exec.CommandContext(ctx, c.Path, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return execution{err: err}
+	}
+	exceeded := watchMemory(cmd.Process, memoryLimit)
+	err := cmd.Wait()
+	run := execution{output: stdout.Bytes(), time: time.Since(start), memory: peakMemory(cmd.ProcessState)}
+
+	switch {
+	case exceeded() || run.memory > memoryLimit:
+		run.err = errMemoryLimit
+	case err != nil:
+		run.err = err
+		if line := firstLine(stderr.String()); line != "" {
+			run.err = fmt.Errorf("%w: %s", err, line)
+		}
+	}
+	return run
+}
+
+// firstLine returns the first line of text without surrounding space.
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
+
+// Build compiles the Go package or file pkg with "go build" into a
+// temporary directory and returns it as a Command. Call cleanup to remove
+// the binary.
+func Build(ctx context.Context, pkg string) (solution Command, cleanup func(), err error) {
+	dir, err := os.MkdirTemp("", "judge-")
+	if err != nil {
+		return Command{}, nil, err
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+
+	binary := filepath.Join(dir, "solution")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", binary, pkg)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return Command{}, nil, fmt.Errorf("judge: building %s: %w\n%s", pkg, err, stderr.String())
+	}
+	return Command{Path: binary}, cleanup, nil
+}
+
+// formatBytes formats n in the largest binary unit that keeps it whole.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30 && n%(1<<30) == 0:
+		return fmt.Sprintf("%d GiB", n>>30)
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%d MiB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%d KiB", n>>10)
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,41 @@
+package judge
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteTable writes results as an aligned table with a verdict, time and
+// memory per test, followed by a summary line.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEST\tVERDICT\tTIME\tMEMORY\tDETAIL")
+	accepted := 0
+	for _, r := range results {
+		if r.Verdict == Accepted {
+			accepted++
+		}
+		memory := "-"
+		if r.Memory > 0 {
+			memory = fmt.Sprintf("%.1f MiB", float64(r.Memory)/(1<<20))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Test.Name, r.Verdict, r.Time.Round(time.Millisecond), memory, r.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d/%d accepted\n", accepted, len(results))
+	return err
+}
+
+// AllAccepted reports whether every result is Accepted.
+func AllAccepted(results []Result) bool {
+	for _, r := range results {
+		if r.Verdict != Accepted {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+// Command solution sums the numbers of a test input, for the tests of
+// package judge. Its argument selects a way to behave: "sum" answers
+// correctly, "wrong" is off by one, "sleep" runs out of time, "crash" panics
+// and "hog" allocates memory until it is killed.
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+func main() {
+	mode := "sum"
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+
+	var n, sum int
+	fmt.Scan(&n)
+	for range n {
+		var v int
+		fmt.Scan(&v)
+		sum += v
+	}
+
+	switch mode {
+	case "wrong":
+		sum++
+	case "sleep":
+		time.Sleep(time.Minute)
+	case "crash":
+		panic("index out of range")
+	case "hog":
+		var blocks [][]byte
+		for {
+			block := make([]byte, 16<<20)
+			for i := range block {
+				block[i] = 1
+			}
+			blocks = append(blocks, block)
+		}
+	}
+	fmt.Println(sum)
+}
@@ -0,0 +1,36 @@
+// Package all registers every algorithm of the repository with package
+// registry. Import it for its side effect:
+//
+//	import _ "github.com/ignoreAnt/go-dsa/registry/all"
+package all
+
+//go:generate go run ../../cmd/dsa list --format markdown --output ../../CATALOGUE.md
+
+import (
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/count_digits"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/divisors_of_number"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/factorial"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/fibonacci"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/lcm"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/multiplicative"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/palindrome_number"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/polynomial"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_patterns"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/rational"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/sieve_of_eratosthenes"
+	_ "github.com/ignoreAnt/go-dsa/algorithms/mathematics/trailing_zeroes_factorial"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/arrays"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/btree"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/fenwick_trees"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/heaps"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/linked_lists"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/queues"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/segment_trees"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/skip_lists"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/stacks"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/trees"
+	_ "github.com/ignoreAnt/go-dsa/data_structures/tries"
+)
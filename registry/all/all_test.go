@@ -0,0 +1,49 @@
+package all
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCatalogueUpToDate tests that CATALOGUE.md matches the registry; run
+// "go generate ./registry/all" after changing a registration.
+func TestCatalogueUpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../../CATALOGUE.md")
+	require.NoError(t, err)
+
+	var generated strings.Builder
+	require.NoError(t, registry.WriteCatalogue(&generated, registry.All()))
+	assert.Equal(t, generated.String(), string(committed), "CATALOGUE.md is stale: run go generate ./registry/all")
+}
+
+// TestRunAll tests that every registered algorithm runs on small inputs.
+func TestRunAll(t *testing.T) {
+	algorithms := registry.All()
+	require.NotEmpty(t, algorithms)
+
+	for _, a := range algorithms {
+		t.Run(a.Qualified(), func(t *testing.T) {
+			for _, n := range []int{1, 2, 10, 100} {
+				assert.NotPanics(t, func() { a.Run(n) }, "n = %d", n)
+			}
+		})
+	}
+}
+
+// BenchmarkAlgorithms benchmarks every registered algorithm on inputs of size
+// 1000, e.g. go test -bench . -run ^$ ./registry/all.
+func BenchmarkAlgorithms(b *testing.B) {
+	for _, a := range registry.All() {
+		b.Run(fmt.Sprintf("%s/n=1000", a.Qualified()), func(b *testing.B) {
+			for range b.N {
+				a.Run(1000)
+			}
+		})
+	}
+}
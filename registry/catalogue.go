@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteCatalogue writes algorithms as a Markdown document with one table per
+// category.
+func WriteCatalogue(w io.Writer, algorithms []Algorithm) error {
+	var b strings.Builder
+	b.WriteString("# Algorithm catalogue\n\n")
+	b.WriteString("<!-- Code generated by \"dsa list --format markdown\"; DO NOT EDIT. -->\n")
+
+	category := ""
+	for _, a := range algorithms {
+		if a.Category != category {
+			category = a.Category
+			fmt.Fprintf(&b, "\n## %s\n\n", category)
+			b.WriteString("| Algorithm | Package | Time | Space | Stable | In place | Summary |\n")
+			b.WriteString("|---|---|---|---|---|---|---|\n")
+		}
+		fmt.Fprintf(&b, "| `%s` | [%s](%s) | %s | %s | %s | %s | %s |\n",
+			a.Name, a.Package, a.Package, a.Time, a.Space, yesNo(a.Stable), yesNo(a.InPlace), escape(a.Summary))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// yesNo formats a flag for the catalogue.
+func yesNo(flag bool) string {
+	if flag {
+		return "yes"
+	}
+	return "no"
+}
+
+// escape keeps text from breaking a Markdown table cell.
+func escape(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -0,0 +1,31 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteCatalogue tests the Markdown layout, with a table per category.
+func TestWriteCatalogue(t *testing.T) {
+	sorting := fake("algorithms/sorting/merge_sort", "mergeSort", "Merge sort | top-down")
+	sorting.Category, sorting.Time, sorting.Space, sorting.Stable = "sorting", "O(n log n)", "O(n)", true
+	algorithms := []Algorithm{fake("algorithms/mathematics/gcd", "gcdIterative", "Euclid"), sorting}
+
+	var b strings.Builder
+	require.NoError(t, WriteCatalogue(&b, algorithms))
+
+	expected := "# Algorithm catalogue\n\n" +
+		"<!-- Code generated by \"dsa list --format markdown\"; DO NOT EDIT. -->\n\n" +
+		"## testing\n\n" +
+		"| Algorithm | Package | Time | Space | Stable | In place | Summary |\n" +
+		"|---|---|---|---|---|---|---|\n" +
+		"| `gcdIterative` | [algorithms/mathematics/gcd](algorithms/mathematics/gcd) | O(n) | O(1) | no | no | Euclid |\n\n" +
+		"## sorting\n\n" +
+		"| Algorithm | Package | Time | Space | Stable | In place | Summary |\n" +
+		"|---|---|---|---|---|---|---|\n" +
+		"| `mergeSort` | [algorithms/sorting/merge_sort](algorithms/sorting/merge_sort) | O(n log n) | O(n) | yes | no | Merge sort \\| top-down |\n"
+	assert.Equal(t, expected, b.String())
+}
@@ -0,0 +1,133 @@
+// Package registry is a catalogue of the algorithms in this repository with
+// their complexity and a callable entry point, for tools that list, search or
+// benchmark them.
+//
+// Each algorithm package registers its algorithms from an init function, in a
+// file named register.go next to the code. Importing package registry/all
+// links in and registers every algorithm:
+//
+//	import _ "github.com/ignoreAnt/go-dsa/registry/all"
+//
+//	for _, a := range registry.All() {
+//		fmt.Println(a.Name, a.Time)
+//	}
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Algorithm describes one registered algorithm.
+type Algorithm struct {
+	Name     string `json:"name"`     // identifier in the source, such as "primeFactorsBrute"
+	Package  string `json:"package"`  // path of the package below the module root
+	Category string `json:"category"` // area of the repository, such as "mathematics"
+	Summary  string `json:"summary"`  // one-line description
+
+	Time  string `json:"time"`  // worst-case time complexity in big O notation
+	Space string `json:"space"` // auxiliary space complexity in big O notation
+
+	Stable  bool `json:"stable"`   // preserves the order of equal elements (sorting)
+	InPlace bool `json:"in_place"` // needs only O(1) or O(log n) space besides its input
+
+	// Run executes the algorithm on a representative input of size n >= 1: the
+	// number itself for arithmetic on one integer, the length for sequences.
+	// Inputs are deterministic, so runs can be compared and benchmarked.
+	Run func(n int) any `json:"-"`
+}
+
+var (
+	mu         sync.RWMutex
+	algorithms = make(map[string]Algorithm)
+)
+
+// Register adds a to the registry. It panics if a lacks a name, package,
+// category, complexity or Run function, or if an algorithm with the same
+// package and name is already registered; both are programming errors.
+func Register(a Algorithm) {
+	if a.Name == "" || a.Package == "" || a.Category == "" || a.Time == "" || a.Space == "" || a.Run == nil {
+		panic(fmt.Sprintf("registry: incomplete registration of %q in %q", a.Name, a.Package))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	key := a.Package + "." + a.Name
+	if _, ok := algorithms[key]; ok {
+		panic(fmt.Sprintf("registry: %s registered twice", key))
+	}
+	algorithms[key] = a
+}
+
+// All returns every registered algorithm ordered by category, package and
+// name.
+func All() []Algorithm {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Algorithm, 0, len(algorithms))
+	for _, a := range algorithms {
+		all = append(all, a)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Category != all[j].Category {
+			return all[i].Category < all[j].Category
+		}
+		if all[i].Package != all[j].Package {
+			return all[i].Package < all[j].Package
+		}
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
+
+// Lookup returns the algorithm with the given name. The name may be
+// qualified by the last element of its package, as in "gcd.gcdIterative",
+// which is needed when two packages use the same name.
+func Lookup(name string) (Algorithm, bool) {
+	var found []Algorithm
+	for _, a := range All() {
+		if a.Name == name || a.Qualified() == name {
+			found = append(found, a)
+		}
+	}
+	if len(found) != 1 {
+		return Algorithm{}, false
+	}
+	return found[0], true
+}
+
+// Search returns the algorithms whose name, package, category, summary or
+// complexity contains every word of query, ignoring case, in the order of
+// All.
+func Search(query string) []Algorithm {
+	words := strings.Fields(strings.ToLower(query))
+
+	var found []Algorithm
+	for _, a := range All() {
+		text := strings.ToLower(strings.Join([]string{a.Name, a.Package, a.Category, a.Summary, a.Time, a.Space}, " "))
+		matches := true
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+// Qualified returns the name prefixed with the last element of the package
+// path, such as "gcd.gcdIterative".
+func (a Algorithm) Qualified() string {
+	pkg := a.Package
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return pkg + "." + a.Name
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fake returns a complete registration for the tests.
+func fake(pkg, name, summary string) Algorithm {
+	return Algorithm{
+		Name:     name,
+		Package:  pkg,
+		Category: "testing",
+		Summary:  summary,
+		Time:     "O(n)",
+		Space:    "O(1)",
+		Run:      func(n int) any { return n },
+	}
+}
+
+// TestRegistry tests registration, lookup and search on a few fake entries.
+func TestRegistry(t *testing.T) {
+	Register(fake("test/alpha", "sortFast", "Sorts quickly"))
+	Register(fake("test/alpha", "sortSlow", "Sorts slowly"))
+	Register(fake("test/beta", "sortFast", "Sorts quickly too"))
+
+	t.Run("All is ordered", func(t *testing.T) {
+		var names []string
+		for _, a := range All() {
+			if a.Category == "testing" {
+				names = append(names, a.Qualified())
+			}
+		}
+		assert.Equal(t, []string{"alpha.sortFast", "alpha.sortSlow", "beta.sortFast"}, names)
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			query    string
+			expected bool
+		}{
+			{"unique name", "sortSlow", true},
+			{"ambiguous name", "sortFast", false},
+			{"qualified name", "beta.sortFast", true},
+			{"unknown name", "sortNever", false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				a, ok := Lookup(tc.query)
+				assert.Equal(t, tc.expected, ok, "Expected: %v, Got: %v", tc.expected, ok)
+				if ok {
+					assert.Equal(t, 7, a.Run(7))
+				}
+			})
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		testCases := []struct {
+			query    string
+			expected int
+		}{
+			{"SORTS", 3},
+			{"quickly beta", 1},
+			{"alpha slow", 1},
+			{"sorts nothing", 0},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.query, func(t *testing.T) {
+				actual := len(Search(tc.query))
+				assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+			})
+		}
+	})
+}
+
+// TestRegisterPanics tests that incomplete and duplicate registrations panic.
+func TestRegisterPanics(t *testing.T) {
+	incomplete := fake("test/gamma", "noRun", "")
+	incomplete.Run = nil
+	assert.PanicsWithValue(t, `registry: incomplete registration of "noRun" in "test/gamma"`, func() { Register(incomplete) })
+
+	Register(fake("test/gamma", "twice", ""))
+	assert.PanicsWithValue(t, "registry: test/gamma.twice registered twice", func() { Register(fake("test/gamma", "twice", "")) })
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/lcm"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_numbers"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_patterns"
+)
+
+// primalityTrialLimit is the largest n whose primality the server decides by
+// the 6k ± 1 trial division of prime_numbers.IsPrime, some 3.5 * 10^5
+// divisions at most. Larger numbers use big.Int.ProbablyPrime, which is exact
+// below 2^64.
+const primalityTrialLimit = 1 << 40
+
+// Int is an integer of any size. It decodes from a JSON number or a decimal
+// string.
+type Int struct {
+	big.Int
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A string must be a single JSON
+// string, so that unbalanced or repeated quotes are rejected.
+func (n *Int) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return errorf(http.StatusBadRequest, "invalid integer %s", data)
+		}
+	}
+	if _, ok := n.SetString(text, 10); !ok {
+		return errorf(http.StatusBadRequest, "invalid integer %s", data)
+	}
+	return nil
+}
+
+// decode unmarshals params into v, rejecting unknown fields.
+func decode(params json.RawMessage, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		if apiErr, ok := err.(*Error); ok {
+			return apiErr
+		}
+		return errorf(http.StatusBadRequest, "invalid parameters: %v", err)
+	}
+	return nil
+}
+
+// checkDigits rejects a missing n or one with more than limit digits.
+func checkDigits(n *Int, limit int) error {
+	if n == nil {
+		return errorf(http.StatusBadRequest, "missing integer")
+	}
+	// a number of more than 4*limit bits has more than limit digits, which
+	// keeps huge inputs from being converted to decimal
+	if n.BitLen() > 4*limit || len(new(big.Int).Abs(&n.Int).String()) > limit {
+		return errorf(http.StatusBadRequest, "integer has more than %d digits", limit)
+	}
+	return nil
+}
+
+type numberRequest struct {
+	N *Int `json:"n"`
+}
+
+type primalityResponse struct {
+	N     *big.Int `json:"n"`
+	Prime bool     `json:"prime"`
+}
+
+// primality reports whether n is prime.
+func (s *Server) primality(ctx context.Context, params json.RawMessage) (any, error) {
+	var req numberRequest
+	if err := decode(params, &req); err != nil {
+		return nil, err
+	}
+	if err := checkDigits(req.N, s.config.MaxDigits); err != nil {
+		return nil, err
+	}
+
+	n := &req.N.Int
+	return compute(ctx, func() (any, error) {
+		prime := false
+		if n.IsInt64() && n.Int64() <= primalityTrialLimit {
+			prime = prime_numbers.IsPrime(int(n.Int64()))
+		} else if n.Sign() > 0 {
+			prime = n.ProbablyPrime(20)
+		}
+		return primalityResponse{N: n, Prime: prime}, nil
+	})
+}
+
+type factorResponse struct {
+	N       *big.Int   `json:"n"`
+	Factors []*big.Int `json:"factors"`
+}
+
+// factor returns the prime factorisation of n >= 1.
+func (s *Server) factor(ctx context.Context, params json.RawMessage) (any, error) {
+	var req numberRequest
+	if err := decode(params, &req); err != nil {
+		return nil, err
+	}
+	if err := checkDigits(req.N, s.config.MaxFactorDigits); err != nil {
+		return nil, err
+	}
+	if req.N.Sign() <= 0 {
+		return nil, errorf(http.StatusBadRequest, "n must be positive")
+	}
+
+	n := &req.N.Int
+	return compute(ctx, func() (any, error) {
+		factors := prime_factors.PrimeFactorsBig(n)
+		if factors == nil {
+			factors = []*big.Int{}
+		}
+		return factorResponse{N: n, Factors: factors}, nil
+	})
+}
+
+type listRequest struct {
+	Numbers []*Int `json:"numbers"`
+}
+
+// numbers decodes and validates a non-empty list of integers.
+func (s *Server) numbers(params json.RawMessage) ([]*big.Int, error) {
+	var req listRequest
+	if err := decode(params, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Numbers) == 0 {
+		return nil, errorf(http.StatusBadRequest, "numbers must not be empty")
+	}
+	if len(req.Numbers) > s.config.MaxBatch {
+		return nil, errorf(http.StatusBadRequest, "more than %d numbers", s.config.MaxBatch)
+	}
+
+	numbers := make([]*big.Int, len(req.Numbers))
+	for i, n := range req.Numbers {
+		if err := checkDigits(n, s.config.MaxDigits); err != nil {
+			return nil, err
+		}
+		numbers[i] = new(big.Int).Abs(&n.Int)
+	}
+	return numbers, nil
+}
+
+type gcdResponse struct {
+	GCD *big.Int `json:"gcd"`
+}
+
+// gcd returns the greatest common divisor of the numbers, folding gcd.GCD
+// while the values fit in an int.
+func (s *Server) gcd(ctx context.Context, params json.RawMessage) (any, error) {
+	numbers, err := s.numbers(params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Int)
+	for _, n := range numbers {
+		if result.IsInt64() && n.IsInt64() {
+			result.SetInt64(int64(gcd.GCD(int(result.Int64()), int(n.Int64()))))
+		} else {
+			result.GCD(nil, nil, result, n)
+		}
+	}
+	return gcdResponse{GCD: result}, nil
+}
+
+type lcmResponse struct {
+	LCM *big.Int `json:"lcm"`
+}
+
+// lcm returns the lowest common multiple of the numbers, folding lcm.LCM
+// while the product of the values fits in an int.
+func (s *Server) lcm(ctx context.Context, params json.RawMessage) (any, error) {
+	numbers, err := s.numbers(params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := big.NewInt(1)
+	for _, n := range numbers {
+		if err := ctx.Err(); err != nil {
+			return nil, contextError(ctx)
+		}
+		switch {
+		case n.Sign() == 0 || result.Sign() == 0:
+			result.SetInt64(0)
+		case result.IsInt64() && n.IsInt64() && result.Int64() <= math.MaxInt64/n.Int64():
+			result.SetInt64(int64(lcm.LCM(int(result.Int64()), int(n.Int64()))))
+		default:
+			g := new(big.Int).GCD(nil, nil, result, n)
+			result.Mul(result, g.Quo(n, g))
+		}
+	}
+	return lcmResponse{LCM: result}, nil
+}
+
+type rangeRequest struct {
+	From *int `json:"from"`
+	To   *int `json:"to"`
+}
+
+type primesResponse struct {
+	Primes []int `json:"primes"`
+}
+
+// primes returns the primes in [from, to], sieved by prime_patterns.Primes.
+func (s *Server) primes(ctx context.Context, params json.RawMessage) (any, error) {
+	var req rangeRequest
+	if err := decode(params, &req); err != nil {
+		return nil, err
+	}
+	if req.From == nil || req.To == nil {
+		return nil, errorf(http.StatusBadRequest, "from and to are required")
+	}
+	from, to := *req.From, *req.To
+	if from < 0 || to < from {
+		return nil, errorf(http.StatusBadRequest, "from must be between 0 and to")
+	}
+	if to > s.config.MaxSieve {
+		return nil, errorf(http.StatusBadRequest, "to is larger than %d", s.config.MaxSieve)
+	}
+	if to-from >= s.config.MaxRange {
+		return nil, errorf(http.StatusBadRequest, "range is wider than %d", s.config.MaxRange)
+	}
+
+	primes := []int{}
+	for p := range prime_patterns.Primes(from, to) {
+		if len(primes)%1024 == 0 && ctx.Err() != nil {
+			return nil, contextError(ctx)
+		}
+		primes = append(primes, p)
+	}
+	return primesResponse{Primes: primes}, nil
+}
+
+type batchRequest struct {
+	Requests []struct {
+		Op     string          `json:"op"`
+		Params json.RawMessage `json:"params"`
+	} `json:"requests"`
+}
+
+type batchResult struct {
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+// batch runs several requests in order, reporting an error per request
+// rather than failing the batch. The batch as a whole shares one timeout.
+func (s *Server) batch(ctx context.Context, params json.RawMessage) (any, error) {
+	var req batchRequest
+	if err := decode(params, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Requests) > s.config.MaxBatch {
+		return nil, errorf(http.StatusBadRequest, "more than %d requests", s.config.MaxBatch)
+	}
+
+	results := make([]batchResult, len(req.Requests))
+	for i, r := range req.Requests {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx)
+		}
+		e, ok := s.endpoints[r.Op]
+		if !ok {
+			results[i].Error = errorf(http.StatusBadRequest, "unknown op %q", r.Op).Error()
+			continue
+		}
+		result, err := e(s, ctx, r.Params)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Result = result
+	}
+	return batchResponse{Results: results}, nil
+}
@@ -0,0 +1,204 @@
+// Package server exposes the algorithms/mathematics packages as a JSON API
+// over HTTP, for tools that would rather make a request than link Go code.
+//
+// Every endpoint takes a POST with a JSON object and answers with a JSON
+// object; failures are reported as {"error": "..."} with a 4xx or 5xx status.
+// Integers may be given as JSON numbers or decimal strings and are returned
+// as JSON numbers of arbitrary size.
+//
+//	POST /v1/primality  {"n": 97}                 -> {"n": 97, "prime": true}
+//	POST /v1/factor     {"n": 360}                -> {"n": 360, "factors": [2, 2, 2, 3, 3, 5]}
+//	POST /v1/gcd        {"numbers": [12, 18]}     -> {"gcd": 6}
+//	POST /v1/lcm        {"numbers": [4, 6]}       -> {"lcm": 12}
+//	POST /v1/primes     {"from": 10, "to": 20}    -> {"primes": [11, 13, 17, 19]}
+//	POST /v1/batch      {"requests": [{"op": "factor", "params": {"n": 12}}, ...]}
+//	GET  /healthz
+//
+// A batch answers {"results": [...]} with one {"result": ...} or
+// {"error": "..."} per request, in order.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Config holds the limits applied to every request. Zero fields take the
+// defaults listed with them.
+type Config struct {
+	MaxBodyBytes    int64         // size of a request body, 1 MiB
+	Timeout         time.Duration // time to answer a request, 5s
+	MaxBatch        int           // requests in a batch and numbers in a gcd/lcm list, 1000
+	MaxDigits       int           // decimal digits of an input number, 1000
+	MaxFactorDigits int           // decimal digits of a number to factor, 30
+	MaxRange        int           // width of a /v1/primes range, 10^6
+	MaxSieve        int           // upper end of a /v1/primes range, 10^12
+}
+
+// withDefaults returns c with its zero fields replaced by the defaults.
+func (c Config) withDefaults() Config {
+	if c.MaxBodyBytes <= 0 {
+		c.MaxBodyBytes = 1 << 20
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 5 * time.Second
+	}
+	if c.MaxBatch <= 0 {
+		c.MaxBatch = 1000
+	}
+	if c.MaxDigits <= 0 {
+		c.MaxDigits = 1000
+	}
+	if c.MaxFactorDigits <= 0 {
+		c.MaxFactorDigits = 30
+	}
+	if c.MaxRange <= 0 {
+		c.MaxRange = 1_000_000
+	}
+	if c.MaxSieve <= 0 {
+		c.MaxSieve = 1_000_000_000_000
+	}
+	return c
+}
+
+// Server is an http.Handler serving the API.
+type Server struct {
+	config    Config
+	mux       *http.ServeMux
+	endpoints map[string]endpoint
+}
+
+// endpoint decodes its parameters from params and computes the response.
+type endpoint func(s *Server, ctx context.Context, params json.RawMessage) (any, error)
+
+// New returns a Server enforcing the limits of config.
+func New(config Config) *Server {
+	s := &Server{
+		config: config.withDefaults(),
+		mux:    http.NewServeMux(),
+		endpoints: map[string]endpoint{
+			"primality": (*Server).primality,
+			"factor":    (*Server).factor,
+			"gcd":       (*Server).gcd,
+			"lcm":       (*Server).lcm,
+			"primes":    (*Server).primes,
+		},
+	}
+
+	for name, e := range s.endpoints {
+		s.mux.Handle("POST /v1/"+name, s.handle(e))
+	}
+	s.mux.Handle("POST /v1/batch", s.handle((*Server).batch))
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle adapts an endpoint to HTTP: it bounds the body size and the time
+// allowed, and turns errors into JSON error responses.
+func (s *Server) handle(e endpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+		defer cancel()
+
+		var params json.RawMessage
+		body := http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes)
+		if err := json.NewDecoder(body).Decode(&params); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, errorf(http.StatusRequestEntityTooLarge, "request body exceeds %d bytes", tooLarge.Limit))
+				return
+			}
+			writeError(w, errorf(http.StatusBadRequest, "invalid JSON: %v", err))
+			return
+		}
+
+		response, err := e(s, ctx, params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, response)
+	})
+}
+
+// compute runs f and returns its result, or an error once ctx is done.
+//
+// The algorithms cannot be interrupted, so f keeps running in the background
+// after a timeout; the input limits in Config bound how long that can be.
+func compute[T any](ctx context.Context, f func() (T, error)) (T, error) {
+	type outcome struct {
+		value T
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := f()
+		done <- outcome{value, err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.value, o.err
+	case <-ctx.Done():
+		var zero T
+		return zero, contextError(ctx)
+	}
+}
+
+// Error is an error with the HTTP status it is reported with.
+type Error struct {
+	Status  int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// errorf returns an *Error with a formatted message.
+func errorf(status int, format string, args ...any) *Error {
+	return &Error{Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+// contextError describes why ctx is done.
+func contextError(ctx context.Context) *Error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errorf(http.StatusServiceUnavailable, "request timed out")
+	}
+	return errorf(http.StatusServiceUnavailable, "request cancelled")
+}
+
+// writeError writes err as {"error": message}. Errors that are not an *Error
+// are reported as internal.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		status = apiErr.Status
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// errorResponse is the body of a failed request.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes v with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// post sends body to path on ts and returns the status and response body.
+func post(t *testing.T, ts *httptest.Server, path, body string) (int, string) {
+	t.Helper()
+	resp, err := http.Post(ts.URL+path, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	return resp.StatusCode, strings.TrimSpace(string(data))
+}
+
+// TestEndpoints checks each endpoint against expected JSON responses,
+// including validation failures.
+func TestEndpoints(t *testing.T) {
+	ts := httptest.NewServer(New(Config{MaxBatch: 3, MaxDigits: 40, MaxFactorDigits: 20, MaxRange: 100, MaxSieve: 1_000_000}))
+	defer ts.Close()
+
+	testCases := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"prime", "/v1/primality", `{"n": 97}`, http.StatusOK, `{"n":97,"prime":true}`},
+		{"composite", "/v1/primality", `{"n": 91}`, http.StatusOK, `{"n":91,"prime":false}`},
+		{"negative", "/v1/primality", `{"n": -7}`, http.StatusOK, `{"n":-7,"prime":false}`},
+		{"big prime as string", "/v1/primality", `{"n": "170141183460469231731687303715884105727"}`, http.StatusOK,
+			`{"n":170141183460469231731687303715884105727,"prime":true}`},
+		{"too many digits", "/v1/primality", `{"n": 10000000000000000000000000000000000000000}`, http.StatusBadRequest,
+			`{"error":"integer has more than 40 digits"}`},
+		{"missing n", "/v1/primality", `{}`, http.StatusBadRequest, `{"error":"missing integer"}`},
+		{"not an integer", "/v1/primality", `{"n": "12a"}`, http.StatusBadRequest, `{"error":"invalid integer \"12a\""}`},
+		{"unknown field", "/v1/primality", `{"m": 1}`, http.StatusBadRequest,
+			`{"error":"invalid parameters: json: unknown field \"m\""}`},
+		{"invalid JSON", "/v1/primality", `{"n": `, http.StatusBadRequest, `{"error":"invalid JSON: unexpected EOF"}`},
+
+		{"factor", "/v1/factor", `{"n": 360}`, http.StatusOK, `{"n":360,"factors":[2,2,2,3,3,5]}`},
+		{"factor one", "/v1/factor", `{"n": 1}`, http.StatusOK, `{"n":1,"factors":[]}`},
+		{"factor big", "/v1/factor", `{"n": "998244359987710471"}`, http.StatusOK,
+			`{"n":998244359987710471,"factors":[998244353,1000000007]}`},
+		{"factor zero", "/v1/factor", `{"n": 0}`, http.StatusBadRequest, `{"error":"n must be positive"}`},
+		{"factor too large", "/v1/factor", `{"n": 123456789012345678901}`, http.StatusBadRequest,
+			`{"error":"integer has more than 20 digits"}`},
+
+		{"gcd", "/v1/gcd", `{"numbers": [12, -18, 30]}`, http.StatusOK, `{"gcd":6}`},
+		{"gcd big", "/v1/gcd", `{"numbers": ["100000000000000000000", 250000000000000000000]}`, http.StatusOK,
+			`{"gcd":50000000000000000000}`},
+		{"gcd empty", "/v1/gcd", `{"numbers": []}`, http.StatusBadRequest, `{"error":"numbers must not be empty"}`},
+		{"gcd too many", "/v1/gcd", `{"numbers": [1, 2, 3, 4]}`, http.StatusBadRequest, `{"error":"more than 3 numbers"}`},
+		{"lcm", "/v1/lcm", `{"numbers": [4, 6, 10]}`, http.StatusOK, `{"lcm":60}`},
+		{"lcm overflowing int", "/v1/lcm", `{"numbers": [1000000000000, 999999999999]}`, http.StatusOK,
+			`{"lcm":999999999999000000000000}`},
+		{"lcm with zero", "/v1/lcm", `{"numbers": [4, 0]}`, http.StatusOK, `{"lcm":0}`},
+
+		{"primes", "/v1/primes", `{"from": 10, "to": 30}`, http.StatusOK, `{"primes":[11,13,17,19,23,29]}`},
+		{"no primes", "/v1/primes", `{"from": 24, "to": 28}`, http.StatusOK, `{"primes":[]}`},
+		{"primes missing to", "/v1/primes", `{"from": 1}`, http.StatusBadRequest, `{"error":"from and to are required"}`},
+		{"primes reversed", "/v1/primes", `{"from": 5, "to": 1}`, http.StatusBadRequest, `{"error":"from must be between 0 and to"}`},
+		{"primes too wide", "/v1/primes", `{"from": 0, "to": 100}`, http.StatusBadRequest, `{"error":"range is wider than 100"}`},
+		{"primes too high", "/v1/primes", `{"from": 1000000, "to": 1000001}`, http.StatusBadRequest,
+			`{"error":"to is larger than 1000000"}`},
+
+		{"batch", "/v1/batch",
+			`{"requests": [{"op": "factor", "params": {"n": 12}}, {"op": "gcd", "params": {"numbers": []}}, {"op": "sqrt", "params": {}}]}`,
+			http.StatusOK,
+			`{"results":[{"result":{"n":12,"factors":[2,2,3]}},{"error":"numbers must not be empty"},{"error":"unknown op \"sqrt\""}]}`},
+		{"batch too large", "/v1/batch", `{"requests": [{}, {}, {}, {}]}`, http.StatusBadRequest, `{"error":"more than 3 requests"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := post(t, ts, tc.path, tc.body)
+			assert.Equal(t, tc.wantStatus, status, "Expected: %v, Got: %v", tc.wantStatus, status)
+			assert.Equal(t, tc.wantBody, body)
+		})
+	}
+}
+
+// TestIntUnmarshalJSON checks that an Int decodes from a bare JSON number or
+// a single JSON string and from nothing else, such as unbalanced or repeated
+// quotes.
+func TestIntUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"number", `123`, "123", false},
+		{"negative number", `-45`, "-45", false},
+		{"string", `"123"`, "123", false},
+		{"escaped string", `"\u0031\u0032"`, "12", false},
+		{"opening quote only", `"123`, "", true},
+		{"closing quote only", `123"`, "", true},
+		{"repeated quotes", `""123""`, "", true},
+		{"quote inside", `"1"2"`, "", true},
+		{"empty string", `""`, "", true},
+		{"not a number", `"12a"`, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var n Int
+			err := n.UnmarshalJSON([]byte(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, n.String(), "Expected: %v, Got: %v", tc.want, n.String())
+		})
+	}
+}
+
+// TestLimits checks the body size limit, the timeout and method routing.
+func TestLimits(t *testing.T) {
+	t.Run("body too large", func(t *testing.T) {
+		ts := httptest.NewServer(New(Config{MaxBodyBytes: 16}))
+		defer ts.Close()
+
+		status, body := post(t, ts, "/v1/gcd", `{"numbers": [1, 2, 3, 4, 5, 6]}`)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+		assert.Equal(t, `{"error":"request body exceeds 16 bytes"}`, body)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ts := httptest.NewServer(New(Config{Timeout: time.Nanosecond}))
+		defer ts.Close()
+
+		status, body := post(t, ts, "/v1/primes", `{"from": 0, "to": 999999}`)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, `{"error":"request timed out"}`, body)
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		ts := httptest.NewServer(New(Config{}))
+		defer ts.Close()
+
+		resp, err := http.Get(ts.URL + "/v1/factor")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+		resp, err = http.Get(ts.URL + "/healthz")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"status":"ok"}`, strings.TrimSpace(string(data)))
+	})
+}
@@ -0,0 +1,122 @@
+package stress
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// Ints generates integers in [lo, hi]. One draw in eight is a boundary value
+// (lo, lo+1, hi-1 or hi), where off-by-one errors tend to show.
+func Ints(lo, hi int) Gen[int] {
+	span := uint64(hi) - uint64(lo) // no overflow for any lo <= hi
+	return func(rng *rand.Rand, size int) int {
+		if span >= 2 && rng.IntN(8) == 0 {
+			return []int{lo, lo + 1, hi - 1, hi}[rng.IntN(4)]
+		}
+		if span == math.MaxUint64 {
+			return int(rng.Uint64())
+		}
+		return lo + int(rng.Uint64N(span+1))
+	}
+}
+
+// SizedInts generates integers in [lo, lo+size*scale], so that inputs grow
+// over a run. It suits functions that are slow on large inputs, such as the
+// brute-force references.
+func SizedInts(lo, scale int) Gen[int] {
+	return func(rng *rand.Rand, size int) int {
+		return Ints(lo, lo+size*scale)(rng, size)
+	}
+}
+
+// ShrinkInt shrinks an integer towards target: it proposes target itself,
+// then values halving the distance to v, down to v±1.
+func ShrinkInt(target int) Shrinker[int] {
+	return func(v int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			for d := v - target; d != 0; d /= 2 {
+				if !yield(v - d) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Slices generates slices of up to size elements drawn from elem.
+func Slices[T any](elem Gen[T]) Gen[[]T] {
+	return func(rng *rand.Rand, size int) []T {
+		s := make([]T, rng.IntN(size+1))
+		for i := range s {
+			s[i] = elem(rng, size)
+		}
+		return s
+	}
+}
+
+// ShrinkSlice shrinks a slice by removing runs of elements, the longest
+// first, and then by shrinking single elements with elem if it is not nil.
+func ShrinkSlice[T any](elem Shrinker[T]) Shrinker[[]T] {
+	return func(s []T) iter.Seq[[]T] {
+		return func(yield func([]T) bool) {
+			for k := len(s); k > 0; k /= 2 {
+				for i := 0; i+k <= len(s); i += k {
+					shorter := append(append([]T{}, s[:i]...), s[i+k:]...)
+					if !yield(shorter) {
+						return
+					}
+				}
+			}
+
+			if elem == nil {
+				return
+			}
+			for i, v := range s {
+				for smaller := range elem(v) {
+					changed := append([]T{}, s...)
+					changed[i] = smaller
+					if !yield(changed) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Tuple is an input made of two values, such as the operands of gcd.
+type Tuple[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuples generates tuples whose parts come from first and second.
+func Tuples[A, B any](first Gen[A], second Gen[B]) Gen[Tuple[A, B]] {
+	return func(rng *rand.Rand, size int) Tuple[A, B] {
+		return Tuple[A, B]{first(rng, size), second(rng, size)}
+	}
+}
+
+// ShrinkTuple shrinks one part of a tuple at a time. A nil shrinker leaves
+// its part alone.
+func ShrinkTuple[A, B any](first Shrinker[A], second Shrinker[B]) Shrinker[Tuple[A, B]] {
+	return func(t Tuple[A, B]) iter.Seq[Tuple[A, B]] {
+		return func(yield func(Tuple[A, B]) bool) {
+			if first != nil {
+				for a := range first(t.First) {
+					if !yield(Tuple[A, B]{a, t.Second}) {
+						return
+					}
+				}
+			}
+			if second != nil {
+				for b := range second(t.Second) {
+					if !yield(Tuple[A, B]{t.First, b}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
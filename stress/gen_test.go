@@ -0,0 +1,54 @@
+package stress
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInts checks that generated integers stay in range, including the full
+// range of int.
+func TestInts(t *testing.T) {
+	testCases := []struct {
+		name   string
+		lo, hi int
+	}{
+		{"small", -3, 3},
+		{"single value", 5, 5},
+		{"full range", math.MinInt, math.MaxInt},
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := Ints(tc.lo, tc.hi)
+			for range 1000 {
+				v := gen(rng, 10)
+				assert.True(t, tc.lo <= v && v <= tc.hi, "Expected: %d <= %d <= %d", tc.lo, v, tc.hi)
+			}
+		})
+	}
+}
+
+// TestShrinkers checks the candidates proposed by the shrinkers.
+func TestShrinkers(t *testing.T) {
+	assert.Equal(t, []int{0, 50, 75, 88, 94, 97, 99}, slices.Collect(ShrinkInt(0)(100)))
+	assert.Equal(t, []int{1, -4, -7, -8}, slices.Collect(ShrinkInt(1)(-9)))
+	assert.Empty(t, slices.Collect(ShrinkInt(3)(3)))
+
+	assert.Equal(t, [][]int{
+		{},
+		{3, 4}, {1, 2},
+		{2, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 3},
+	}, slices.Collect(ShrinkSlice[int](nil)([]int{1, 2, 3, 4})))
+	assert.Equal(t, [][]int{{}, {0}}, slices.Collect(ShrinkSlice(ShrinkInt(0))([]int{1})))
+
+	tuple := Tuple[int, int]{2, 1}
+	assert.Equal(t, []Tuple[int, int]{{0, 1}, {1, 1}, {2, 0}},
+		slices.Collect(ShrinkTuple(ShrinkInt(0), ShrinkInt(0))(tuple)))
+	assert.Equal(t, []Tuple[int, int]{{2, 0}},
+		slices.Collect(ShrinkTuple[int](nil, ShrinkInt(0))(tuple)))
+}
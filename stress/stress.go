@@ -0,0 +1,221 @@
+// Package stress is a differential testing harness: it runs a reference
+// implementation and a candidate on random inputs, and when they disagree it
+// shrinks the input to a small counterexample that can be replayed.
+//
+// The algorithms in this repository mostly come in pairs, a simple brute-force
+// version next to an optimised one. The brute-force version is the reference;
+// a Pair describes how to generate inputs for both, and Check runs it from a
+// test:
+//
+//	stress.Check(t, stress.Pair[int, []int]{
+//		Name:      "primeFactors",
+//		Reference: primeFactorsBrute,
+//		Candidate: primeFactors,
+//		Gen:       stress.Ints(1, 100_000),
+//		Shrink:    stress.ShrinkInt(1),
+//		Equal:     slices.Equal[[]int],
+//	}, stress.Options{})
+//
+// Every run uses a seed. A failure report names it, and setting the STRESS_SEED
+// environment variable to that value replays the run exactly. STRESS_ITERATIONS
+// overrides the number of iterations, for longer runs on demand.
+package stress
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// Gen returns a random input. size grows from 1 to Options.MaxSize over a
+// run, so that the early iterations try small inputs; generators are free to
+// ignore it.
+type Gen[T any] func(rng *rand.Rand, size int) T
+
+// Shrinker returns inputs that are in some sense smaller than v, the most
+// aggressive first. It yields nothing when v cannot be shrunk further.
+type Shrinker[T any] func(v T) iter.Seq[T]
+
+// Pair is a reference implementation, a candidate expected to agree with it,
+// and the inputs to compare them on.
+type Pair[In, Out any] struct {
+	Name      string
+	Reference func(In) Out
+	Candidate func(In) Out
+	Gen       Gen[In]
+
+	// Shrink proposes smaller inputs once a failure is found. With a nil
+	// Shrink the first failing input is reported as it is.
+	Shrink Shrinker[In]
+
+	// Equal compares the outputs. It defaults to reflect.DeepEqual.
+	Equal func(a, b Out) bool
+}
+
+// Options control a run. Zero fields take the defaults listed with them.
+type Options struct {
+	Seed       uint64 // seed of the run; random by default
+	Iterations int    // number of random inputs, 1000
+	MaxSize    int    // size passed to Gen in the last iteration, 100
+	MaxShrinks int    // number of shrink candidates tried, 10000
+}
+
+// withDefaults returns o with its zero fields filled in, and the seed and
+// iteration count taken from STRESS_SEED and STRESS_ITERATIONS when set.
+func (o Options) withDefaults() (Options, error) {
+	if env := os.Getenv("STRESS_SEED"); env != "" {
+		seed, err := strconv.ParseUint(env, 10, 64)
+		if err != nil {
+			return o, fmt.Errorf("stress: invalid STRESS_SEED %q", env)
+		}
+		o.Seed = seed
+	}
+	if env := os.Getenv("STRESS_ITERATIONS"); env != "" {
+		iterations, err := strconv.Atoi(env)
+		if err != nil || iterations <= 0 {
+			return o, fmt.Errorf("stress: invalid STRESS_ITERATIONS %q", env)
+		}
+		o.Iterations = iterations
+	}
+
+	if o.Seed == 0 {
+		o.Seed = rand.Uint64() | 1
+	}
+	if o.Iterations <= 0 {
+		o.Iterations = 1000
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = 100
+	}
+	if o.MaxShrinks <= 0 {
+		o.MaxShrinks = 10000
+	}
+	return o, nil
+}
+
+// Counterexample is an input on which the candidate disagrees with the
+// reference.
+type Counterexample[In, Out any] struct {
+	Name      string
+	Seed      uint64
+	Iteration int // iteration that found the failure, from 0
+	Size      int // size passed to Gen in that iteration
+
+	Original In // input as generated
+	Input    In // input after shrinking
+	Shrinks  int
+
+	Reference Out
+	Candidate Out
+	Panic     string // non-empty if one of the functions panicked
+}
+
+// String describes the counterexample and how to reproduce it.
+func (c *Counterexample[In, Out]) String() string {
+	result := fmt.Sprintf("reference = %v, candidate = %v", c.Reference, c.Candidate)
+	if c.Panic != "" {
+		result = c.Panic
+	}
+	return fmt.Sprintf("%s: counterexample %#v (shrunk from %#v in %d steps): %s\n"+
+		"found at iteration %d (size %d); replay with STRESS_SEED=%d",
+		c.Name, c.Input, c.Original, c.Shrinks, result, c.Iteration, c.Size, c.Seed)
+}
+
+// Run compares p.Candidate with p.Reference on random inputs and returns the
+// first counterexample, shrunk, or nil if they agreed on every input.
+func Run[In, Out any](p Pair[In, Out], opts Options) (*Counterexample[In, Out], error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range opts.Iterations {
+		// a generator per iteration, so a failure depends on seed and i only
+		rng := rand.New(rand.NewPCG(opts.Seed, uint64(i)))
+		size := 1 + i*(opts.MaxSize-1)/max(opts.Iterations-1, 1)
+		input := p.Gen(rng, size)
+
+		if c := p.compare(input); c != nil {
+			c.Name, c.Seed, c.Iteration, c.Size = p.Name, opts.Seed, i, size
+			c.Original = input
+			p.shrink(c, opts.MaxShrinks)
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// Check runs p and fails t with the counterexample, if any.
+func Check[In, Out any](t testing.TB, p Pair[In, Out], opts Options) {
+	t.Helper()
+	c, err := Run(p, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != nil {
+		t.Fatal(c)
+	}
+}
+
+// compare runs both functions on input and returns a counterexample without
+// its run details if they disagree or either panics.
+func (p Pair[In, Out]) compare(input In) *Counterexample[In, Out] {
+	c := &Counterexample[In, Out]{Input: input}
+
+	var ok bool
+	if c.Reference, ok = call(p.Reference, input, &c.Panic, "reference"); !ok {
+		return c
+	}
+	if c.Candidate, ok = call(p.Candidate, input, &c.Panic, "candidate"); !ok {
+		return c
+	}
+
+	equal := p.Equal
+	if equal == nil {
+		equal = func(a, b Out) bool { return reflect.DeepEqual(a, b) }
+	}
+	if equal(c.Reference, c.Candidate) {
+		return nil
+	}
+	return c
+}
+
+// call returns f(input), or false with a description in *panicked if f panics.
+func call[In, Out any](f func(In) Out, input In, panicked *string, role string) (out Out, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			*panicked = fmt.Sprintf("%s panicked: %v", role, r)
+			ok = false
+		}
+	}()
+	return f(input), true
+}
+
+// shrink replaces c with a smaller counterexample for as long as one of the
+// shrink candidates still fails, trying at most limit candidates in total.
+// It is greedy: the first failing candidate is taken and shrunk in turn.
+func (p Pair[In, Out]) shrink(c *Counterexample[In, Out], limit int) {
+	if p.Shrink == nil {
+		return
+	}
+
+	tried := 0
+	for progress := true; progress && tried < limit; {
+		progress = false
+		for candidate := range p.Shrink(c.Input) {
+			if tried++; tried > limit {
+				return
+			}
+			if smaller := p.compare(candidate); smaller != nil {
+				c.Input, c.Reference, c.Candidate, c.Panic = smaller.Input, smaller.Reference, smaller.Candidate, smaller.Panic
+				c.Shrinks++
+				progress = true
+				break
+			}
+		}
+	}
+}
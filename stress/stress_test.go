@@ -0,0 +1,130 @@
+package stress
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// abs is a reference for the buggy candidates below.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// TestRun checks that Run finds, shrinks and reports disagreements.
+func TestRun(t *testing.T) {
+	t.Setenv("STRESS_SEED", "")
+	t.Setenv("STRESS_ITERATIONS", "")
+
+	testCases := []struct {
+		name        string
+		candidate   func(int) int
+		wantFailure bool
+		wantInput   int
+		wantPanic   string
+	}{
+		{"agreeing candidate", func(n int) int { return max(n, -n) }, false, 0, ""},
+		{"wrong above threshold", func(n int) int {
+			if n >= 1234 {
+				return n + 1
+			}
+			return abs(n)
+		}, true, 1234, ""},
+		{"wrong for negatives", func(n int) int { return n }, true, -1, ""},
+		{"panicking candidate", func(n int) int {
+			if n > 500 {
+				panic("too big")
+			}
+			return abs(n)
+		}, true, 501, "candidate panicked: too big"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pair := Pair[int, int]{
+				Name:      tc.name,
+				Reference: abs,
+				Candidate: tc.candidate,
+				Gen:       Ints(-10_000, 10_000),
+				Shrink:    ShrinkInt(0),
+			}
+			c, err := Run(pair, Options{Seed: 42})
+			require.NoError(t, err)
+			if !tc.wantFailure {
+				assert.Nil(t, c, "Expected: %v, Got: %v", nil, c)
+				return
+			}
+
+			require.NotNil(t, c)
+			assert.Equal(t, tc.wantInput, c.Input, "Expected: %v, Got: %v", tc.wantInput, c.Input)
+			assert.Equal(t, tc.wantPanic, c.Panic)
+			assert.Equal(t, uint64(42), c.Seed)
+			assert.Contains(t, c.String(), "replay with STRESS_SEED=42")
+		})
+	}
+}
+
+// TestRunReproducible checks that a seed determines the counterexample, and
+// that STRESS_SEED overrides the seed in Options.
+func TestRunReproducible(t *testing.T) {
+	t.Setenv("STRESS_ITERATIONS", "")
+	pair := Pair[[]int, int]{
+		Name:      "compact",
+		Reference: func(s []int) int { return len(s) },
+		Candidate: func(s []int) int { return len(slices.Compact(slices.Clone(s))) },
+		Gen:       Slices(Ints(0, 3)),
+		Shrink:    ShrinkSlice(ShrinkInt(0)),
+		Equal:     func(a, b int) bool { return a == b },
+	}
+
+	t.Setenv("STRESS_SEED", "")
+	first, err := Run(pair, Options{Seed: 7})
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	// shrinking is greedy: [1 1] is a local minimum, since [0 1] passes
+	require.Len(t, first.Input, 2)
+	assert.Equal(t, first.Input[0], first.Input[1])
+
+	t.Setenv("STRESS_SEED", "7")
+	replay, err := Run(pair, Options{Seed: 99})
+	require.NoError(t, err)
+	assert.Equal(t, first, replay)
+
+	t.Setenv("STRESS_SEED", "seven")
+	_, err = Run(pair, Options{})
+	assert.Error(t, err)
+}
+
+// TestRunWithoutShrink checks that the generated input is reported as it is
+// when no shrinker is given, and that STRESS_ITERATIONS bounds the run.
+func TestRunWithoutShrink(t *testing.T) {
+	t.Setenv("STRESS_SEED", "")
+	pair := Pair[int, bool]{
+		Name:      "even",
+		Reference: func(n int) bool { return n%2 == 0 },
+		Candidate: func(n int) bool { return n < 1_000_000 },
+		Gen:       Ints(1_000_000, 2_000_000),
+	}
+
+	t.Setenv("STRESS_ITERATIONS", "")
+	c, err := Run(pair, Options{Seed: 3})
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, c.Original, c.Input)
+	assert.Equal(t, 0, c.Shrinks)
+	assert.Equal(t, 0, c.Input%2)
+
+	// odd numbers pass, so a single iteration may or may not fail, but the
+	// iteration index must stay below the limit
+	t.Setenv("STRESS_ITERATIONS", "1")
+	c, err = Run(pair, Options{Seed: 3})
+	require.NoError(t, err)
+	if c != nil {
+		assert.Equal(t, 0, c.Iteration)
+	}
+}
@@ -0,0 +1,140 @@
+package trace
+
+import (
+	"html/template"
+	"io"
+)
+
+// cellSize is the side of a grid cell in the SVG, in pixels.
+const cellSize = 40
+
+// htmlCell is a grid cell placed in the SVG.
+type htmlCell struct {
+	N, X, Y int
+}
+
+// htmlEvent is an event as the replay script sees it.
+type htmlEvent struct {
+	Kind   string `json:"kind"`
+	A      int    `json:"a"`
+	B      int    `json:"b"`
+	Result int    `json:"result"`
+	Text   string `json:"text"`
+}
+
+// htmlPage is the data of htmlTemplate.
+type htmlPage struct {
+	Title          string
+	Cells          []htmlCell
+	Width, Height  int
+	Events         []htmlEvent
+	CellSize, Half int
+}
+
+// WriteHTML writes t as a self-contained HTML page that replays it: an SVG
+// grid of the cells, the list of events, and controls to step through them or
+// play them. The page loads nothing from the network.
+func WriteHTML(w io.Writer, t Trace) error {
+	page := htmlPage{Title: t.Title, CellSize: cellSize, Half: cellSize / 2, Events: []htmlEvent{}}
+	for i, c := range t.Cells {
+		page.Cells = append(page.Cells, htmlCell{N: c, X: i % gridColumns * cellSize, Y: i / gridColumns * cellSize})
+	}
+	if len(t.Cells) > 0 {
+		page.Width = min(len(t.Cells), gridColumns) * cellSize
+		page.Height = (len(t.Cells) + gridColumns - 1) / gridColumns * cellSize
+	}
+	for _, e := range t.Events {
+		page.Events = append(page.Events, htmlEvent{Kind: e.Kind.String(), A: e.A, B: e.B, Result: e.Result, Text: e.String()})
+	}
+	return htmlTemplate.Execute(w, page)
+}
+
+var htmlTemplate = template.Must(template.New("trace").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.controls { margin: 1em 0; }
+.controls button { min-width: 3em; }
+#step { width: 20em; vertical-align: middle; }
+svg rect { fill: #fff; stroke: #999; }
+svg text { font: 14px monospace; text-anchor: middle; dominant-baseline: central; }
+svg .composite rect { fill: #ddd; }
+svg .composite text { fill: #aaa; }
+svg .current rect { fill: #fc6; stroke: #c60; }
+#log { font-family: monospace; max-height: 20em; overflow-y: auto; }
+#log .current { background: #fc6; }
+#log .future { color: #aaa; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="controls">
+<button id="first" title="first step">|&lt;</button>
+<button id="back" title="previous step">&lt;</button>
+<button id="play" title="play or pause">play</button>
+<button id="next" title="next step">&gt;</button>
+<button id="last" title="last step">&gt;|</button>
+<input id="step" type="range" min="0" max="{{len .Events}}" value="0">
+<span id="counter"></span>
+</div>
+{{if .Cells}}<svg width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+{{range .Cells}}<g id="cell-{{.N}}"><rect x="{{.X}}" y="{{.Y}}" width="{{$.CellSize}}" height="{{$.CellSize}}"/><text x="{{.X}}" y="{{.Y}}" dx="{{$.Half}}" dy="{{$.Half}}">{{.N}}</text></g>
+{{end}}</svg>
+{{end}}<ol id="log">{{range .Events}}<li>{{.Text}}</li>{{end}}</ol>
+<script>
+const events = {{.Events}};
+const slider = document.getElementById("step");
+const counter = document.getElementById("counter");
+const play = document.getElementById("play");
+const items = document.querySelectorAll("#log li");
+let step = 0, timer = null;
+
+function show(n) {
+	step = Math.max(0, Math.min(events.length, n));
+	slider.value = step;
+	counter.textContent = "step " + step + "/" + events.length;
+	document.querySelectorAll("svg g").forEach(g => g.setAttribute("class", ""));
+	for (let i = 0; i < step; i++) {
+		const e = events[i];
+		if (e.kind === "mark composite") {
+			const g = document.getElementById("cell-" + e.a);
+			if (g) g.classList.add("composite");
+		}
+	}
+	if (step > 0) {
+		const e = events[step - 1];
+		for (const n of [e.a, e.b]) {
+			const g = document.getElementById("cell-" + n);
+			if (g) g.classList.add("current");
+		}
+	}
+	items.forEach((li, i) => li.className = i === step - 1 ? "current" : i >= step ? "future" : "");
+	if (step > 0) items[step - 1].scrollIntoView({block: "nearest"});
+	if (step === events.length) pause();
+}
+
+function pause() {
+	clearInterval(timer);
+	timer = null;
+	play.textContent = "play";
+}
+
+play.onclick = () => {
+	if (timer) { pause(); return; }
+	if (step === events.length) show(0);
+	play.textContent = "pause";
+	timer = setInterval(() => show(step + 1), 300);
+};
+document.getElementById("first").onclick = () => show(0);
+document.getElementById("back").onclick = () => show(step - 1);
+document.getElementById("next").onclick = () => show(step + 1);
+document.getElementById("last").onclick = () => show(events.length);
+slider.oninput = () => show(Number(slider.value));
+show(0);
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,39 @@
+package trace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteHTML tests that the page embeds the grid, the events and the
+// replay script, and loads nothing from elsewhere.
+func TestWriteHTML(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, WriteHTML(&out, sieveTrace))
+	page := out.String()
+
+	assert.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	assert.Contains(t, page, "<title>sieve up to 12</title>")
+	assert.Contains(t, page, `<svg width="400" height="80"`)
+	assert.Contains(t, page, `<g id="cell-12"><rect x="0" y="40"`)
+	assert.Contains(t, page, "<li>mark 9 composite, a multiple of 3</li>")
+	assert.Contains(t, page, `{"kind":"mark composite","a":9,"b":3,"result":0,"text":"mark 9 composite, a multiple of 3"}`)
+	assert.Contains(t, page, `max="6"`)
+	assert.NotContains(t, page, "http")
+	assert.NotContains(t, page, "src=")
+}
+
+// TestWriteHTMLEscapes tests that titles are escaped and that a trace without
+// cells has no grid.
+func TestWriteHTMLEscapes(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, WriteHTML(&out, Trace{Title: "a < b"}))
+	page := out.String()
+
+	assert.Contains(t, page, "<title>a &lt; b</title>")
+	assert.NotContains(t, page, "<svg")
+	assert.Contains(t, page, "const events = [];")
+}
@@ -0,0 +1,126 @@
+package trace_test
+
+import (
+	"testing"
+
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/gcd"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/prime_factors"
+	"github.com/ignoreAnt/go-dsa/algorithms/mathematics/sieve_of_eratosthenes"
+	"github.com/stretchr/testify/assert"
+)
+
+// The baselines below are the instrumented algorithms as they were before
+// tracing was added, to measure what an untraced run costs against them.
+
+// sieveBaseline returns the primes up to n by the sieve of Eratosthenes.
+func sieveBaseline(n int) []int {
+	if n < 2 {
+		return []int{}
+	}
+	isPrime := make([]bool, n+1)
+	for i := 2; i <= n; i++ {
+		isPrime[i] = true
+	}
+	for p := 2; p*p <= n; p++ {
+		if isPrime[p] {
+			for i := p * p; i <= n; i += p {
+				isPrime[i] = false
+			}
+		}
+	}
+	var primes []int
+	for i := 2; i <= n; i++ {
+		if isPrime[i] {
+			primes = append(primes, i)
+		}
+	}
+	return primes
+}
+
+// primeFactorsBaseline returns the prime factors of n by trial division.
+func primeFactorsBaseline(n int) []int {
+	var factors []int
+	for n%2 == 0 {
+		factors = append(factors, 2)
+		n = n / 2
+	}
+	for i := 3; i*i <= n; i = i + 2 {
+		for n%i == 0 {
+			factors = append(factors, i)
+			n = n / i
+		}
+	}
+	if n > 2 {
+		factors = append(factors, n)
+	}
+	return factors
+}
+
+// gcdBaseline returns the greatest common divisor of a and b by the
+// Euclidean algorithm.
+func gcdBaseline(a, b int) int {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// overheadCases pairs each instrumented algorithm, run with a nil Tracer,
+// with its baseline on the same input.
+var overheadCases = []struct {
+	name             string
+	untraced, before func()
+}{
+	{
+		"sieve",
+		func() { sieve_of_eratosthenes.SieveOfEratosthenesTraced(10_000, nil) },
+		func() { sieveBaseline(10_000) },
+	},
+	{
+		"prime factors",
+		func() { prime_factors.PrimeFactorsTraced(2*3*3*7*999_983, nil) },
+		func() { primeFactorsBaseline(2 * 3 * 3 * 7 * 999_983) },
+	},
+	{
+		"gcd",
+		func() { gcd.GCDTraced(832_040, 514_229, nil) },
+		func() { gcdBaseline(832_040, 514_229) },
+	},
+}
+
+// TestUntracedAllocations tests that a run with a nil Tracer allocates no
+// more than the algorithm did before it was instrumented.
+func TestUntracedAllocations(t *testing.T) {
+	for _, tc := range overheadCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := testing.AllocsPerRun(100, tc.before)
+			actual := testing.AllocsPerRun(100, tc.untraced)
+			assert.Equal(t, expected, actual, "Expected: %v, Got: %v", expected, actual)
+		})
+	}
+}
+
+// BenchmarkUntraced compares each instrumented algorithm run with a nil
+// Tracer with its baseline, e.g. go test -bench . -run ^$ ./trace.
+func BenchmarkUntraced(b *testing.B) {
+	for _, tc := range overheadCases {
+		b.Run(tc.name+"/baseline", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				tc.before()
+			}
+		})
+		b.Run(tc.name+"/nil tracer", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				tc.untraced()
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// gridColumns is the number of cells per row of the text grid.
+	gridColumns = 10
+	// logLines is the number of recent events shown under the grid.
+	logLines = 8
+	// clearScreen moves the cursor home and clears an ANSI terminal.
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// state is the playback state of a trace after some number of events.
+type state struct {
+	composite map[int]bool
+}
+
+// apply advances s past e.
+func (s *state) apply(e Event) {
+	if e.Kind == MarkComposite {
+		s.composite[e.A] = true
+	}
+}
+
+// Frames returns the frames of an animation of t as plain text: the initial
+// state, then the state after each event. A frame shows the title, the step
+// number, the grid of cells, if any, and the most recent events with the
+// current one marked by ">".
+func Frames(t Trace) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		s := state{composite: make(map[int]bool)}
+		if !yield(frame(t, &s, 0)) {
+			return
+		}
+		for i, e := range t.Events {
+			s.apply(e)
+			if !yield(frame(t, &s, i+1)) {
+				return
+			}
+		}
+	}
+}
+
+// frame renders t after its first step events, with s the state at that
+// point.
+func frame(t Trace, s *state, step int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\nstep %d/%d\n", t.Title, step, len(t.Events))
+
+	var current *Event
+	if step > 0 {
+		current = &t.Events[step-1]
+	}
+
+	if len(t.Cells) > 0 {
+		width := 1
+		for _, c := range t.Cells {
+			width = max(width, len(strconv.Itoa(c)))
+		}
+		b.WriteString("\n")
+		for i, c := range t.Cells {
+			switch {
+			case current != nil && (c == current.A || c == current.B):
+				fmt.Fprintf(&b, "[%*d]", width, c)
+			case s.composite[c]:
+				fmt.Fprintf(&b, " %*s ", width, ".")
+			default:
+				fmt.Fprintf(&b, " %*d ", width, c)
+			}
+			if i%gridColumns == gridColumns-1 || i == len(t.Cells)-1 {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	for i := max(0, step-logLines); i < step; i++ {
+		marker := "  "
+		if i == step-1 {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%s\n", marker, t.Events[i])
+	}
+	return b.String()
+}
+
+// Animate plays t on an ANSI terminal, clearing the screen before each frame
+// and pausing delay after it.
+func Animate(w io.Writer, t Trace, delay time.Duration) error {
+	for f := range Frames(t) {
+		if _, err := io.WriteString(w, clearScreen+f); err != nil {
+			return err
+		}
+		time.Sleep(delay)
+	}
+	return nil
+}
+
+// WriteLog writes t as a numbered list of events, one per line, for output
+// that is not a terminal.
+func WriteLog(w io.Writer, t Trace) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", t.Title)
+	width := len(strconv.Itoa(len(t.Events)))
+	for i, e := range t.Events {
+		fmt.Fprintf(&b, "%*d  %s\n", width, i+1, e)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,82 @@
+package trace
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sieveTrace is the trace of the sieve of Eratosthenes up to 12.
+var sieveTrace = Trace{
+	Title: "sieve up to 12",
+	Cells: Range(2, 12),
+	Events: []Event{
+		{Kind: MarkComposite, A: 4, B: 2},
+		{Kind: MarkComposite, A: 6, B: 2},
+		{Kind: MarkComposite, A: 8, B: 2},
+		{Kind: MarkComposite, A: 10, B: 2},
+		{Kind: MarkComposite, A: 12, B: 2},
+		{Kind: MarkComposite, A: 9, B: 3},
+	},
+}
+
+// TestFrames tests the initial and final frames of a trace with a grid.
+func TestFrames(t *testing.T) {
+	frames := slices.Collect(Frames(sieveTrace))
+	require.Len(t, frames, len(sieveTrace.Events)+1)
+
+	expectedFirst := "sieve up to 12\nstep 0/6\n\n" +
+		"  2   3   4   5   6   7   8   9  10  11 \n" +
+		" 12 \n" +
+		"\n"
+	assert.Equal(t, expectedFirst, frames[0])
+
+	expectedLast := "sieve up to 12\nstep 6/6\n\n" +
+		"  2 [ 3]  .   5   .   7   . [ 9]  .  11 \n" +
+		"  . \n" +
+		"\n" +
+		"  mark 4 composite, a multiple of 2\n" +
+		"  mark 6 composite, a multiple of 2\n" +
+		"  mark 8 composite, a multiple of 2\n" +
+		"  mark 10 composite, a multiple of 2\n" +
+		"  mark 12 composite, a multiple of 2\n" +
+		"> mark 9 composite, a multiple of 3\n"
+	assert.Equal(t, expectedLast, frames[len(frames)-1])
+}
+
+// TestFramesLogWindow tests that only the most recent events are listed.
+func TestFramesLogWindow(t *testing.T) {
+	var tr Trace
+	for i := range 20 {
+		tr.Events = append(tr.Events, Comparison(i, 10))
+	}
+	var last string
+	for f := range Frames(tr) {
+		last = f
+	}
+	assert.Equal(t, logLines, strings.Count(last, "compare"))
+	assert.Contains(t, last, "> compare 19 with 10: greater than\n")
+	assert.NotContains(t, last, "compare 11 with")
+}
+
+// TestAnimate tests that every frame is drawn on a cleared screen.
+func TestAnimate(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, Animate(&out, sieveTrace, 0))
+	assert.Equal(t, len(sieveTrace.Events)+1, strings.Count(out.String(), clearScreen))
+	assert.True(t, strings.HasSuffix(out.String(), "> mark 9 composite, a multiple of 3\n"))
+}
+
+// TestWriteLog tests the numbered event list.
+func TestWriteLog(t *testing.T) {
+	tr := Trace{Title: "gcd(12, 8)", Events: []Event{
+		{Kind: Divide, A: 12, B: 8, Result: 4},
+		{Kind: Swap, A: 4, B: 8},
+	}}
+	var out strings.Builder
+	require.NoError(t, WriteLog(&out, tr))
+	assert.Equal(t, "gcd(12, 8)\n1  divide 12 by 8: remainder 4\n2  swap 4 and 8\n", out.String())
+}
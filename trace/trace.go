@@ -0,0 +1,131 @@
+// Package trace records the steps of an algorithm as it runs, for teaching
+// and debugging, and plays them back as a terminal animation or a
+// self-contained HTML page.
+//
+// An instrumented algorithm takes a Tracer and reports each basic step to it
+// as an Event. The instrumented algorithms check for a nil Tracer before
+// building an event, so an untraced run costs one predictable branch per
+// step and allocates nothing more than the algorithm did before; see
+// TestUntracedAllocations and BenchmarkUntraced:
+//
+//	var rec trace.Recorder
+//	sieve_of_eratosthenes.SieveOfEratosthenesTraced(30, &rec)
+//	t := trace.Trace{Title: "sieve of Eratosthenes up to 30", Cells: trace.Range(2, 30), Events: rec.Events}
+//	trace.Animate(os.Stdout, t, 100*time.Millisecond)
+package trace
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Kind is the kind of step an Event records.
+type Kind uint8
+
+// The kinds of step. The meaning of the operands A, B and Result depends on
+// the kind.
+const (
+	// Compare compares A with B; Result is -1, 0 or +1 as A is less than,
+	// equal to or greater than B.
+	Compare Kind = iota + 1
+	// Swap exchanges the places of the values A and B.
+	Swap
+	// MarkComposite crosses off A as composite, being a multiple of B.
+	MarkComposite
+	// Divide divides A by B; Result is the remainder.
+	Divide
+)
+
+// kindNames are the names of the kinds, used in text and HTML output.
+var kindNames = [...]string{
+	Compare:       "compare",
+	Swap:          "swap",
+	MarkComposite: "mark composite",
+	Divide:        "divide",
+}
+
+// String returns the name of k, such as "mark composite".
+func (k Kind) String() string {
+	if int(k) < len(kindNames) && kindNames[k] != "" {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", uint8(k))
+}
+
+// Event is one step of an algorithm.
+type Event struct {
+	Kind   Kind
+	A, B   int
+	Result int
+}
+
+// Comparison returns the Compare event of a with b.
+func Comparison(a, b int) Event {
+	return Event{Kind: Compare, A: a, B: b, Result: cmp.Compare(a, b)}
+}
+
+// String describes e in words, such as "divide 360 by 7: remainder 3".
+func (e Event) String() string {
+	switch e.Kind {
+	case Compare:
+		relation := "equal to"
+		if e.Result < 0 {
+			relation = "less than"
+		} else if e.Result > 0 {
+			relation = "greater than"
+		}
+		return fmt.Sprintf("compare %d with %d: %s", e.A, e.B, relation)
+	case Swap:
+		return fmt.Sprintf("swap %d and %d", e.A, e.B)
+	case MarkComposite:
+		return fmt.Sprintf("mark %d composite, a multiple of %d", e.A, e.B)
+	case Divide:
+		return fmt.Sprintf("divide %d by %d: remainder %d", e.A, e.B, e.Result)
+	}
+	return fmt.Sprintf("%v %d %d %d", e.Kind, e.A, e.B, e.Result)
+}
+
+// Tracer receives the steps of an instrumented algorithm. Algorithms accept a
+// nil Tracer, which disables tracing.
+type Tracer interface {
+	Event(e Event)
+}
+
+// Recorder is a Tracer that keeps every event in order.
+type Recorder struct {
+	Events []Event
+}
+
+// Event appends e to r.Events.
+func (r *Recorder) Event(e Event) {
+	r.Events = append(r.Events, e)
+}
+
+// Func adapts a function to the Tracer interface.
+type Func func(e Event)
+
+// Event calls f(e).
+func (f Func) Event(e Event) {
+	f(e)
+}
+
+// Trace is a recorded run prepared for playback.
+type Trace struct {
+	// Title names the run, such as "gcd(48, 18)".
+	Title string
+	// Cells are the numbers drawn as a grid, such as the candidates of a
+	// sieve. Cells are highlighted when an event names them and greyed out
+	// once marked composite. The grid is omitted when Cells is empty.
+	Cells []int
+	// Events are the steps of the run, in order.
+	Events []Event
+}
+
+// Range returns the numbers from lo to hi inclusive, for Trace.Cells.
+func Range(lo, hi int) []int {
+	var cells []int
+	for i := lo; i <= hi; i++ {
+		cells = append(cells, i)
+	}
+	return cells
+}
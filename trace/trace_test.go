@@ -0,0 +1,53 @@
+package trace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEventString tests the description of each kind of event.
+func TestEventString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{"less", Comparison(3, 5), "compare 3 with 5: less than"},
+		{"equal", Comparison(4, 4), "compare 4 with 4: equal to"},
+		{"greater", Comparison(9, 0), "compare 9 with 0: greater than"},
+		{"swap", Event{Kind: Swap, A: 6, B: 12}, "swap 6 and 12"},
+		{"mark composite", Event{Kind: MarkComposite, A: 9, B: 3}, "mark 9 composite, a multiple of 3"},
+		{"divide", Event{Kind: Divide, A: 360, B: 7, Result: 3}, "divide 360 by 7: remainder 3"},
+		{"unknown kind", Event{Kind: 42, A: 1, B: 2, Result: 3}, "Kind(42) 1 2 3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.event.String()
+			assert.Equal(t, tc.expected, actual, "Expected: %v, Got: %v", tc.expected, actual)
+		})
+	}
+}
+
+// TestRecorderAndFunc tests the two Tracer implementations.
+func TestRecorderAndFunc(t *testing.T) {
+	events := []Event{Comparison(1, 2), {Kind: Swap, A: 1, B: 2}}
+
+	var rec Recorder
+	var seen []Event
+	tracers := []Tracer{&rec, Func(func(e Event) { seen = append(seen, e) })}
+	for _, tracer := range tracers {
+		for _, e := range events {
+			tracer.Event(e)
+		}
+	}
+	assert.Equal(t, events, rec.Events)
+	assert.Equal(t, events, seen)
+}
+
+// TestRange tests the cells helper.
+func TestRange(t *testing.T) {
+	assert.Equal(t, []int{2, 3, 4, 5}, Range(2, 5))
+	assert.Empty(t, Range(2, 1))
+}